@@ -21,106 +21,577 @@ import (
 	"beam.apache.org/playground/backend/internal/code_processing"
 	"beam.apache.org/playground/backend/internal/environment"
 	"beam.apache.org/playground/backend/internal/errors"
+	"beam.apache.org/playground/backend/internal/fs_tool"
 	"beam.apache.org/playground/backend/internal/logger"
+	"beam.apache.org/playground/backend/internal/result_storage"
 	"beam.apache.org/playground/backend/internal/setup_tools/life_cycle"
+	"beam.apache.org/playground/backend/internal/streaming"
 	"beam.apache.org/playground/backend/internal/utils"
 	"context"
 	"fmt"
 	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"math"
+	"regexp"
+	"time"
 )
 
+// artifactChunkSize is a maximum size in bytes of the chunk of the compiled file sent by GetArtifact
+const artifactChunkSize = 64 * 1024
+
+// traceIdHeader is the header of the request which contains the trace id to correlate logs of code processing with the request
+const traceIdHeader = "x-trace-id"
+
+// streamPollInterval is an interval of checking the run output sent by StreamRunOutput in case the stream isn't notified,
+// e.g. the pipeline is processed by another instance of the server
+const streamPollInterval = 200 * time.Millisecond
+
 // playgroundController processes `gRPC' requests from clients.
 // Contains methods to process receiving code, monitor current status of code processing and receive compile/run output.
 type playgroundController struct {
-	env          *environment.Environment
-	cacheService cache.Cache
+	env                 *environment.Environment
+	cacheService        cache.Cache
+	submissionLimiter   *submissionLimiter
+	pipelinePool        *pipelinePool
+	resultStorage       result_storage.Storage
+	shutdownCoordinator *shutdownCoordinator
+	sdkDisabled         bool
 
 	pb.UnimplementedPlaygroundServiceServer
 }
 
 // RunCode is running code from requests using a particular SDK
 // - In case of incorrect sdk returns codes.InvalidArgument
+// - In case of the toolchain of the sdk is broken returns codes.Unavailable
+// - In case of the server is shutting down returns codes.Unavailable
 // - In case of error during preparing files/folders returns codes.Internal
+// - In case of request contains files with tests which aren't supported or couldn't be created returns codes.InvalidArgument
+// - In case of request contains additional source files which couldn't be created, e.g. with incorrect names, returns codes.InvalidArgument
+// - In case of request contains classpath libraries which aren't allowed by the server returns codes.InvalidArgument
+// - In case of request contains the output charset which isn't supported returns codes.InvalidArgument
+// - In case of request contains the output format which isn't supported returns codes.InvalidArgument
+// - In case of request contains the version of Apache Beam which isn't available on the server returns codes.InvalidArgument
+// - In case of request contains environment variables with incorrect names or denied by the server returns codes.InvalidArgument
+// - In case of request contains both the scripted stdin and the data for stdin returns codes.InvalidArgument
+// - In case of identical code has been submitted within the submission window saves playground.Status_STATUS_VALIDATION_ERROR as cache.Status and the advice to wait as cache.RunError into cache without processing the code.
+// - In case of request has the trace id in the x-trace-id header adds it to logs of code processing and passes it to commands of code processing.
+// - In case of request contains the pipeline id which isn't a correct uuid returns codes.InvalidArgument
+// - In case of request contains the pipeline id which is already submitted returns the same id without processing the code again,
+//   so the request could be retried safely. The id is released if the request is failed, so it could be submitted again.
 // - In case of no errors saves playground.Status_STATUS_EXECUTING as cache.Status into cache and sets expiration time
 //   for all cache values which will be saved into cache during processing received code.
 //   Returns id of code processing (pipelineId)
 func (controller *playgroundController) RunCode(ctx context.Context, info *pb.RunCodeRequest) (*pb.RunCodeResponse, error) {
+	// submitted is true once the code is submitted for processing, so the pipeline id of the client isn't released
+	submitted := false
 	// check for correct sdk
 	if info.Sdk != controller.env.BeamSdkEnvs.ApacheBeamSdk {
 		logger.Errorf("RunCode(): request contains incorrect sdk: %s\n", info.Sdk)
 		return nil, errors.InvalidArgumentError("Run code()", fmt.Sprintf("incorrect sdk: %s", info.Sdk.String()))
 	}
 	switch info.Sdk {
-	case pb.Sdk_SDK_UNSPECIFIED, pb.Sdk_SDK_SCIO:
+	case pb.Sdk_SDK_UNSPECIFIED:
 		logger.Errorf("RunCode(): unimplemented sdk: %s\n", info.Sdk)
 		return nil, errors.InvalidArgumentError("Run code()", fmt.Sprintf("unimplemented sdk: %s", info.Sdk.String()))
 	}
+	if controller.sdkDisabled {
+		logger.Errorf("RunCode(): sdk is disabled because its toolchain is broken: %s\n", info.Sdk)
+		return nil, errors.UnavailableError("Run code()", fmt.Sprintf("sdk is disabled because its toolchain is broken: %s", info.Sdk.String()))
+	}
 
 	cacheExpirationTime := controller.env.ApplicationEnvs.CacheEnvs().KeyExpirationTime()
 	pipelineId := uuid.New()
+	ctx = logger.ContextWithTraceId(ctx, traceIdFromRequest(ctx))
+	if info.PipelineUuid != "" {
+		clientPipelineId, err := uuid.Parse(info.PipelineUuid)
+		if err != nil {
+			logger.Errorf("RunCode(): incorrect pipeline id: %s\n", err.Error())
+			return nil, errors.InvalidArgumentError("Run code()", fmt.Sprintf("incorrect pipeline id: %s", err.Error()))
+		}
+		pipelineId = clientPipelineId
+		// the status is saved only if the pipeline is new, so the retried request doesn't process the code again
+		claimed, err := controller.cacheService.SetValueIfAbsent(ctx, pipelineId, cache.Status, pb.Status_STATUS_VALIDATING)
+		if err != nil {
+			logger.WithPipelineId(pipelineId).WithContext(ctx).Errorf("RunCode(): error during claiming the pipeline: %s\n", err.Error())
+			return nil, errors.InternalError("Run code()", fmt.Sprintf("Error during set value to cache: %s", err.Error()))
+		}
+		if !claimed {
+			logger.WithPipelineId(pipelineId).WithContext(ctx).Warnf("RunCode(): pipeline is already submitted, the retried request is skipped\n")
+			return &pb.RunCodeResponse{PipelineUuid: pipelineId.String()}, nil
+		}
+		defer func() {
+			if !submitted {
+				// the request is failed, so the pipeline could be submitted again
+				controller.cacheService.DeleteValue(ctx, pipelineId, cache.Status)
+			}
+		}()
+	}
 
-	lc, err := life_cycle.Setup(info.Sdk, info.Code, pipelineId, controller.env.ApplicationEnvs.WorkingDir(), controller.env.BeamSdkEnvs.PreparedModDir())
+	if !streaming.IsSupportedCharset(info.OutputCharset) {
+		logger.Errorf("RunCode(): unsupported output charset: %s\n", info.OutputCharset)
+		return nil, errors.InvalidArgumentError("Run code()", fmt.Sprintf("unsupported output charset: %s", info.OutputCharset))
+	}
+	if !streaming.IsSupportedOutputFormat(info.OutputFormat) {
+		logger.Errorf("RunCode(): unsupported output format: %s\n", info.OutputFormat)
+		return nil, errors.InvalidArgumentError("Run code()", fmt.Sprintf("unsupported output format: %s", info.OutputFormat))
+	}
+	if info.ClientTimeoutMs < 0 {
+		logger.Errorf("RunCode(): negative client timeout: %d\n", info.ClientTimeoutMs)
+		return nil, errors.InvalidArgumentError("Run code()", fmt.Sprintf("client timeout should be positive: %d", info.ClientTimeoutMs))
+	}
+	if _, err := regexp.Compile(info.LogLevelFilter); err != nil {
+		logger.Errorf("RunCode(): incorrect log level filter: %s\n", err.Error())
+		return nil, errors.InvalidArgumentError("Run code()", fmt.Sprintf("incorrect log level filter: %s", err.Error()))
+	}
+	if info.ExecuteTimeoutMs < 0 {
+		logger.Errorf("RunCode(): negative execute timeout: %d\n", info.ExecuteTimeoutMs)
+		return nil, errors.InvalidArgumentError("Run code()", fmt.Sprintf("execute timeout should be positive: %d", info.ExecuteTimeoutMs))
+	}
+	if len(info.Stdin) > 0 && info.StdInput != "" {
+		logger.Errorf("RunCode(): both scripted stdin and stdin data are set\n")
+		return nil, errors.InvalidArgumentError("Run code()", "scripted stdin couldn't be used together with stdin data")
+	}
+	stdin := make([]streaming.StdinChunk, 0, len(info.Stdin))
+	for _, chunk := range info.Stdin {
+		if chunk.DelayMs < 0 {
+			logger.Errorf("RunCode(): negative stdin delay: %d\n", chunk.DelayMs)
+			return nil, errors.InvalidArgumentError("Run code()", fmt.Sprintf("stdin delay should be positive: %d", chunk.DelayMs))
+		}
+		stdin = append(stdin, streaming.StdinChunk{Data: chunk.Data, Delay: time.Duration(chunk.DelayMs) * time.Millisecond})
+	}
+
+	lc, err := life_cycle.Setup(info.Sdk, info.Code, pipelineId, controller.env.ApplicationEnvs.SdkWorkingDir(controller.env.BeamSdkEnvs.ApacheBeamSdk), controller.env.BeamSdkEnvs.PreparedModDir(), controller.env.BeamSdkEnvs.LifeCycleConfig)
 	if err != nil {
 		logger.Errorf("RunCode(): error during setup file system: %s\n", err.Error())
 		return nil, errors.InternalError("Run code", fmt.Sprintf("Error during setup file system: %s", err.Error()))
 	}
+	if len(info.TestFiles) > 0 {
+		if controller.env.BeamSdkEnvs.ExecutorConfig.Test == nil {
+			logger.Errorf("RunCode(): tests aren't supported for sdk: %s\n", info.Sdk)
+			code_processing.DeleteFolders(pipelineId, lc, &controller.env.ApplicationEnvs)
+			return nil, errors.InvalidArgumentError("Run code()", fmt.Sprintf("tests aren't supported for sdk: %s", info.Sdk.String()))
+		}
+		if err = lc.CreateTestFiles(info.TestFiles); err != nil {
+			logger.Errorf("RunCode(): error during creating files with tests: %s\n", err.Error())
+			code_processing.DeleteFolders(pipelineId, lc, &controller.env.ApplicationEnvs)
+			return nil, errors.InvalidArgumentError("Run code()", fmt.Sprintf("Error during creating files with tests: %s", err.Error()))
+		}
+	}
+	if len(info.AdditionalFiles) > 0 {
+		if err = lc.CreateAdditionalSourceFiles(info.AdditionalFiles); err != nil {
+			logger.Errorf("RunCode(): error during creating additional source files: %s\n", err.Error())
+			code_processing.DeleteFolders(pipelineId, lc, &controller.env.ApplicationEnvs)
+			return nil, errors.InvalidArgumentError("Run code()", fmt.Sprintf("Error during creating additional source files: %s", err.Error()))
+		}
+	}
+	if len(info.ClasspathLibraries) > 0 {
+		libraryPaths := make([]string, 0, len(info.ClasspathLibraries))
+		for _, name := range info.ClasspathLibraries {
+			libraryPath, ok := controller.env.BeamSdkEnvs.ClasspathLibraryPath(name)
+			if !ok {
+				logger.Errorf("RunCode(): classpath library isn't allowed: %s\n", name)
+				code_processing.DeleteFolders(pipelineId, lc, &controller.env.ApplicationEnvs)
+				return nil, errors.InvalidArgumentError("Run code()", fmt.Sprintf("classpath library isn't allowed: %s", name))
+			}
+			libraryPaths = append(libraryPaths, libraryPath)
+		}
+		lc.SetClasspathLibraries(libraryPaths)
+	}
+	if info.Dependency != nil {
+		if err = lc.CreateDependencyFile(controller.env.BeamSdkEnvs.ApacheBeamSdk, info.Dependency.Name, info.Dependency.Content); err != nil {
+			logger.Errorf("RunCode(): error during creating the dependency: %s\n", err.Error())
+			code_processing.DeleteFolders(pipelineId, lc, &controller.env.ApplicationEnvs)
+			return nil, errors.InvalidArgumentError("Run code()", fmt.Sprintf("Error during creating the dependency: %s", err.Error()))
+		}
+	}
+	if info.SampleDataset != "" {
+		datasetPath, ok := controller.env.BeamSdkEnvs.SampleDatasetPath(info.SampleDataset)
+		if !ok {
+			logger.Errorf("RunCode(): sample dataset isn't in the catalog: %s\n", info.SampleDataset)
+			code_processing.DeleteFolders(pipelineId, lc, &controller.env.ApplicationEnvs)
+			return nil, errors.InvalidArgumentError("Run code()", fmt.Sprintf("sample dataset isn't in the catalog: %s", info.SampleDataset))
+		}
+		lc.SetSampleDataset(info.SampleDataset, datasetPath)
+	}
+	if err = controller.env.BeamSdkEnvs.ValidatePipelineArgs(info.PipelineArgs); err != nil {
+		logger.Errorf("RunCode(): %s\n", err.Error())
+		code_processing.DeleteFolders(pipelineId, lc, &controller.env.ApplicationEnvs)
+		return nil, errors.InvalidArgumentError("Run code()", err.Error())
+	}
+	lc.SetPipelineArgs(info.PipelineArgs)
+	if _, err = controller.env.BeamSdkEnvs.WithBeamVersion(info.BeamVersion); err != nil {
+		logger.Errorf("RunCode(): %s\n", err.Error())
+		code_processing.DeleteFolders(pipelineId, lc, &controller.env.ApplicationEnvs)
+		return nil, errors.InvalidArgumentError("Run code()", err.Error())
+	}
+	lc.SetBeamVersion(info.BeamVersion)
+	if err = controller.env.ApplicationEnvs.ValidateRunEnv(info.Env); err != nil {
+		logger.Errorf("RunCode(): %s\n", err.Error())
+		code_processing.DeleteFolders(pipelineId, lc, &controller.env.ApplicationEnvs)
+		return nil, errors.InvalidArgumentError("Run code()", err.Error())
+	}
+	lc.SetRunEnv(info.Env)
+	lc.SetOutputCharset(info.OutputCharset)
+	lc.SetClientTimeout(millisecondsToDuration(info.ClientTimeoutMs, 0))
+	lc.SetExecuteTimeout(millisecondsToDuration(info.ExecuteTimeoutMs, controller.env.ApplicationEnvs.MaxPipelineExecuteTimeout()))
+	lc.SetCompileOnly(info.CompileOnly)
+	if info.Wasm && controller.env.BeamSdkEnvs.ApacheBeamSdk != pb.Sdk_SDK_GO {
+		code_processing.DeleteFolders(pipelineId, lc, &controller.env.ApplicationEnvs)
+		return nil, errors.InvalidArgumentError("Run code()", fmt.Sprintf("WebAssembly isn't supported by %s", controller.env.BeamSdkEnvs.ApacheBeamSdk))
+	}
+	lc.SetWasm(info.Wasm)
+	lc.SetStdin(stdin)
+
+	// the submission is recorded only after the request is validated, so the corrected resubmission of the rejected request isn't a duplicate
+	if !controller.submissionLimiter.allow(info, time.Now()) {
+		logger.WithPipelineId(pipelineId).WithContext(ctx).Warnf("RunCode(): identical code has been submitted within the submission window\n")
+		code_processing.DeleteFolders(pipelineId, lc, &controller.env.ApplicationEnvs)
+		submitted = true
+		return controller.rejectSubmission(ctx, pipelineId, cacheExpirationTime)
+	}
 
 	if err = utils.SetToCache(ctx, controller.cacheService, pipelineId, cache.Status, pb.Status_STATUS_VALIDATING); err != nil {
-		code_processing.DeleteFolders(pipelineId, lc)
+		code_processing.DeleteFolders(pipelineId, lc, &controller.env.ApplicationEnvs)
+		return nil, errors.InternalError("Run code()", fmt.Sprintf("Error during set value to cache: %s", err.Error()))
+	}
+	if err = utils.SetToCache(ctx, controller.cacheService, pipelineId, cache.Source, info.Code); err != nil {
+		return nil, errors.InternalError("Run code()", fmt.Sprintf("Error during set value to cache: %s", err.Error()))
+	}
+	if info.OutputFormat != "" {
+		if err = utils.SetToCache(ctx, controller.cacheService, pipelineId, cache.OutputFormat, info.OutputFormat); err != nil {
+			return nil, errors.InternalError("Run code()", fmt.Sprintf("Error during set value to cache: %s", err.Error()))
+		}
+	}
+	if info.LogLevelFilter != "" {
+		if err = utils.SetToCache(ctx, controller.cacheService, pipelineId, cache.LogLevelFilter, info.LogLevelFilter); err != nil {
+			return nil, errors.InternalError("Run code()", fmt.Sprintf("Error during set value to cache: %s", err.Error()))
+		}
+	}
+	if info.StdInput != "" {
+		if err = utils.SetToCache(ctx, controller.cacheService, pipelineId, cache.StdInput, info.StdInput); err != nil {
+			return nil, errors.InternalError("Run code()", fmt.Sprintf("Error during set value to cache: %s", err.Error()))
+		}
+	}
+	if err = utils.SetToCache(ctx, controller.cacheService, pipelineId, cache.CanonicalSource, canonicalSource(pipelineId, info)); err != nil {
 		return nil, errors.InternalError("Run code()", fmt.Sprintf("Error during set value to cache: %s", err.Error()))
 	}
 	if err = utils.SetToCache(ctx, controller.cacheService, pipelineId, cache.RunOutputIndex, 0); err != nil {
 		return nil, errors.InternalError("Run code()", fmt.Sprintf("Error during set value to cache: %s", err.Error()))
 	}
+	if err = utils.SetToCache(ctx, controller.cacheService, pipelineId, cache.CompileOutputIndex, 0); err != nil {
+		return nil, errors.InternalError("Run code()", fmt.Sprintf("Error during set value to cache: %s", err.Error()))
+	}
 	if err = utils.SetToCache(ctx, controller.cacheService, pipelineId, cache.LogsIndex, 0); err != nil {
 		return nil, errors.InternalError("Run code()", fmt.Sprintf("Error during set value to cache: %s", err.Error()))
 	}
+	if err = utils.SetToCache(ctx, controller.cacheService, pipelineId, cache.MetricsIndex, 0); err != nil {
+		return nil, errors.InternalError("Run code()", fmt.Sprintf("Error during set value to cache: %s", err.Error()))
+	}
 	if err = controller.cacheService.SetExpTime(ctx, pipelineId, cacheExpirationTime); err != nil {
-		logger.Errorf("%s: RunCode(): cache.SetExpTime(): %s\n", pipelineId, err.Error())
-		code_processing.DeleteFolders(pipelineId, lc)
+		logger.WithPipelineId(pipelineId).WithContext(ctx).Errorf("RunCode(): cache.SetExpTime(): %s\n", err.Error())
+		code_processing.DeleteFolders(pipelineId, lc, &controller.env.ApplicationEnvs)
 		return nil, errors.InternalError("Run code()", fmt.Sprintf("Error during set expiration to cache: %s", err.Error()))
 	}
 
-	// TODO change using of context.TODO() to context.Background()
-	go code_processing.Process(context.TODO(), controller.cacheService, lc, pipelineId, &controller.env.ApplicationEnvs, &controller.env.BeamSdkEnvs)
+	processCtx, ok := controller.shutdownCoordinator.track(pipelineId, lc)
+	if !ok {
+		logger.WithPipelineId(pipelineId).WithContext(ctx).Warnf("RunCode(): server is shutting down\n")
+		code_processing.DeleteFolders(pipelineId, lc, &controller.env.ApplicationEnvs)
+		return nil, errors.UnavailableError("Run code()", "server is shutting down")
+	}
+	acquired := controller.pipelinePool.tryAcquire()
+	if !acquired {
+		controller.cacheService.SetValue(ctx, pipelineId, cache.Status, pb.Status_STATUS_QUEUED)
+	}
+	go controller.process(processCtx, lc, pipelineId, acquired, logger.TraceIdFromContext(ctx))
+	submitted = true
 
 	pipelineInfo := pb.RunCodeResponse{PipelineUuid: pipelineId.String()}
 	return &pipelineInfo, nil
 }
 
-// CheckStatus is checking status for the specific pipeline by PipelineUuid
+// process processes the code by pipelineId when there is a free slot in the pipeline pool.
+// In case the slot isn't acquired yet waits for it in the queue. If the pipeline waits longer than
+// ApplicationEnvs.MaxQueueWait() saves playground.Status_STATUS_ERROR as cache.Status and the "server busy" message
+// as cache.RunError into cache and deletes its folders. If the server is shutting down while the pipeline waits
+// stops waiting and cancels the pipeline by cancelOnShutdown, so it isn't processed once a slot is freed.
+// traceId of the request is carried by the context of code processing (empty if the request has no trace id).
+// ctx is the context of code processing which is canceled on shutdown of the server, the pipeline is untracked once it is processed.
+func (controller *playgroundController) process(ctx context.Context, lc *fs_tool.LifeCycle, pipelineId uuid.UUID, acquired bool, traceId string) {
+	defer controller.shutdownCoordinator.untrack(pipelineId)
+	ctx = logger.ContextWithTraceId(ctx, traceId)
+	if !acquired {
+		if !controller.pipelinePool.acquire(ctx) {
+			if ctx.Err() != nil {
+				// the server is shutting down, so the pipeline isn't processed and its status isn't changed to validating
+				logger.WithPipelineId(pipelineId).Warnf("RunCode(): pipeline has been waiting in the queue until shutdown\n")
+				cancelOnShutdown(logger.ContextWithTraceId(context.Background(), traceId), controller.cacheService, pipelineId, lc, &controller.env.ApplicationEnvs)
+				return
+			}
+			logger.WithPipelineId(pipelineId).Warnf("RunCode(): pipeline has been waiting in the queue longer than %s\n", controller.env.ApplicationEnvs.MaxQueueWait())
+			busyMessage := fmt.Sprintf("server busy: all %d slots for code processing have been taken longer than %s, please try again later", controller.env.ApplicationEnvs.MaxConcurrentPipelines(), controller.env.ApplicationEnvs.MaxQueueWait())
+			controller.cacheService.SetValue(ctx, pipelineId, cache.RunError, busyMessage)
+			code_processing.SetStatus(ctx, controller.cacheService, pipelineId, pb.Status_STATUS_ERROR)
+			code_processing.DeleteFolders(pipelineId, lc, &controller.env.ApplicationEnvs)
+			return
+		}
+		code_processing.SetStatus(ctx, controller.cacheService, pipelineId, pb.Status_STATUS_VALIDATING)
+	}
+	defer controller.pipelinePool.release()
+	code_processing.Process(ctx, controller.cacheService, lc, pipelineId, &controller.env.ApplicationEnvs, &controller.env.BeamSdkEnvs)
+	if controller.resultStorage != nil {
+		if err := code_processing.PersistResult(ctx, controller.cacheService, controller.resultStorage, pipelineId); err != nil {
+			logger.WithPipelineId(pipelineId).Errorf("RunCode(): error during persisting the result: %s\n", err.Error())
+		}
+	}
+}
+
+// restorePersistedResult restores the result of the pipeline from the result storage into cache
+// in case the result storage is configured and cache doesn't keep the pipeline anymore.
+func (controller *playgroundController) restorePersistedResult(ctx context.Context, pipelineId uuid.UUID) {
+	if controller.resultStorage == nil {
+		return
+	}
+	if _, err := controller.cacheService.GetValue(ctx, pipelineId, cache.Status); err == nil {
+		return
+	}
+	expirationTime := controller.env.ApplicationEnvs.CacheEnvs().KeyExpirationTime()
+	if err := code_processing.RestoreResult(ctx, controller.cacheService, controller.resultStorage, pipelineId, expirationTime); err != nil && err != result_storage.ErrNotFound {
+		logger.WithPipelineId(pipelineId).Errorf("error during restoring the persisted result: %s\n", err.Error())
+	}
+}
+
+// millisecondsToDuration converts the timeout requested in milliseconds to time.Duration.
+// The timeout is clamped to maxTimeout (to the maximum duration in case maxTimeout isn't positive)
+// before the conversion, so the converted timeout doesn't overflow.
+func millisecondsToDuration(timeoutMs int64, maxTimeout time.Duration) time.Duration {
+	if maxTimeout <= 0 {
+		maxTimeout = math.MaxInt64
+	}
+	if timeoutMs > maxTimeout.Milliseconds() {
+		return maxTimeout
+	}
+	return time.Duration(timeoutMs) * time.Millisecond
+}
+
+// traceIdFromRequest returns the trace id from traceIdHeader of the request (empty if the request has no trace id)
+func traceIdFromRequest(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	if values := md.Get(traceIdHeader); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// canonicalSource returns the canonical form of the code.
+// In case the code couldn't be canonicalized returns the code as is.
+func canonicalSource(pipelineId uuid.UUID, info *pb.RunCodeRequest) string {
+	source, err := utils.CanonicalizeSource(info.Sdk, info.Code)
+	if err != nil {
+		logger.WithPipelineId(pipelineId).Warnf("RunCode(): error during canonicalization of the code: %s\n", err.Error())
+		return info.Code
+	}
+	return source
+}
+
+// rejectSubmission saves playground.Status_STATUS_VALIDATION_ERROR and the advice to wait for the pipeline without processing the code
+func (controller *playgroundController) rejectSubmission(ctx context.Context, pipelineId uuid.UUID, cacheExpirationTime time.Duration) (*pb.RunCodeResponse, error) {
+	if err := utils.SetToCache(ctx, controller.cacheService, pipelineId, cache.Status, pb.Status_STATUS_VALIDATION_ERROR); err != nil {
+		return nil, errors.InternalError("Run code()", fmt.Sprintf("Error during set value to cache: %s", err.Error()))
+	}
+	advice := fmt.Sprintf("identical code has been submitted recently, please wait %s before submitting it again", controller.env.ApplicationEnvs.SubmissionWindow())
+	if err := utils.SetToCache(ctx, controller.cacheService, pipelineId, cache.RunError, advice); err != nil {
+		return nil, errors.InternalError("Run code()", fmt.Sprintf("Error during set value to cache: %s", err.Error()))
+	}
+	if err := controller.cacheService.SetExpTime(ctx, pipelineId, cacheExpirationTime); err != nil {
+		logger.WithPipelineId(pipelineId).Errorf("RunCode(): cache.SetExpTime(): %s\n", err.Error())
+		return nil, errors.InternalError("Run code()", fmt.Sprintf("Error during set expiration to cache: %s", err.Error()))
+	}
+	return &pb.RunCodeResponse{PipelineUuid: pipelineId.String()}, nil
+}
+
+// RunCodeBatch is running all submissions from the request the same way as RunCode and waits until all of them are processed.
+// The number of submissions which are processed at the same time is limited by the pipeline pool.
+// Rejected submissions don't abort the batch, the reason of the rejection is returned in the result of the submission
+// with playground.Status_STATUS_VALIDATION_ERROR for invalid submissions and playground.Status_STATUS_ERROR for other failures.
+// In case ctx is done before all submissions are processed returns the last known statuses of the remaining submissions.
+func (controller *playgroundController) RunCodeBatch(ctx context.Context, info *pb.RunCodeBatchRequest) (*pb.RunCodeBatchResponse, error) {
+	results := code_processing.ProcessBatch(ctx, controller.cacheService, controller.batchSubmissions(info.Submissions))
+	return &pb.RunCodeBatchResponse{Results: toBatchResults(results)}, nil
+}
+
+// RunCodeGroup is running all variants of the same example from the request the same way as RunCodeBatch
+// and keeps ids of their pipelines as cache.Group of each pipeline, so cancellation of one variant cancels others.
+// Each variant is processed by its own LifeCycle and the number of variants which are processed at the same time
+// is limited by the pipeline pool.
+// Ids of pipelines are assigned to variants which don't have them and the group is saved before any variant is submitted,
+// so the variant which is canceled right after its submission cancels all others. Rejected variants are removed from the group.
+// In case the request doesn't contain the group id generates it.
+// In case any variant has sdk which isn't processed by the server or incorrect pipeline id returns codes.InvalidArgument,
+// variants of other sdks should be submitted to servers of their sdks.
+// In case of error during saving the group to cache returns codes.Internal.
+// Returns playground.Status_STATUS_FINISHED as the status of the group if all variants are finished successfully,
+// otherwise the status of the first variant which isn't.
+func (controller *playgroundController) RunCodeGroup(ctx context.Context, info *pb.RunCodeGroupRequest) (*pb.RunCodeGroupResponse, error) {
+	groupId := info.GroupId
+	if groupId == "" {
+		groupId = uuid.New().String()
+	}
+	group := cache.PipelineGroup{Id: groupId, PipelineIds: make([]string, 0, len(info.Variants))}
+	for _, variant := range info.Variants {
+		if variant.Sdk != controller.env.BeamSdkEnvs.ApacheBeamSdk {
+			logger.Errorf("RunCodeGroup(): variant of the group contains incorrect sdk: %s\n", variant.Sdk)
+			return nil, errors.InvalidArgumentError("RunCodeGroup", fmt.Sprintf("incorrect sdk: %s, the variant should be submitted to the server of its sdk", variant.Sdk.String()))
+		}
+		if variant.PipelineUuid == "" {
+			variant.PipelineUuid = uuid.New().String()
+		}
+		if _, err := uuid.Parse(variant.PipelineUuid); err != nil {
+			logger.Errorf("RunCodeGroup(): incorrect pipeline id: %s\n", err.Error())
+			return nil, errors.InvalidArgumentError("RunCodeGroup", fmt.Sprintf("incorrect pipeline id: %s", err.Error()))
+		}
+		group.PipelineIds = append(group.PipelineIds, variant.PipelineUuid)
+	}
+	if err := controller.saveGroup(ctx, group); err != nil {
+		return nil, err
+	}
+	results := code_processing.SubmitBatch(ctx, controller.batchSubmissions(info.Variants))
+	submitted := cache.PipelineGroup{Id: groupId, PipelineIds: make([]string, 0, len(results))}
+	for i, result := range results {
+		if result.Err == nil {
+			submitted.PipelineIds = append(submitted.PipelineIds, result.PipelineId.String())
+			continue
+		}
+		controller.cacheService.DeleteValue(ctx, uuid.MustParse(info.Variants[i].PipelineUuid), cache.Group)
+	}
+	if len(submitted.PipelineIds) != len(group.PipelineIds) {
+		if err := controller.saveGroup(ctx, submitted); err != nil {
+			return nil, err
+		}
+	}
+	code_processing.WaitForBatch(ctx, controller.cacheService, results)
+
+	groupStatus := pb.Status_STATUS_FINISHED
+	for _, result := range results {
+		if result.Status != pb.Status_STATUS_FINISHED {
+			groupStatus = result.Status
+			break
+		}
+	}
+	return &pb.RunCodeGroupResponse{GroupId: groupId, Status: groupStatus, Results: toBatchResults(results)}, nil
+}
+
+// saveGroup saves group as cache.Group of each its pipeline.
+// In case of error during saving the group to cache returns codes.Internal.
+func (controller *playgroundController) saveGroup(ctx context.Context, group cache.PipelineGroup) error {
+	for _, pipelineUuid := range group.PipelineIds {
+		if err := utils.SetToCache(ctx, controller.cacheService, uuid.MustParse(pipelineUuid), cache.Group, group); err != nil {
+			return errors.InternalError("RunCodeGroup", fmt.Sprintf("Error during set value to cache: %s", err.Error()))
+		}
+	}
+	return nil
+}
+
+// batchSubmissions returns submissions of code processing of the batch which run requests the same way as RunCode
+func (controller *playgroundController) batchSubmissions(requests []*pb.RunCodeRequest) []code_processing.BatchSubmission {
+	submissions := make([]code_processing.BatchSubmission, len(requests))
+	for i, request := range requests {
+		request := request
+		submissions[i] = func(ctx context.Context) (uuid.UUID, error) {
+			response, err := controller.RunCode(ctx, request)
+			if err != nil {
+				return uuid.Nil, err
+			}
+			return uuid.Parse(response.PipelineUuid)
+		}
+	}
+	return submissions
+}
+
+// toBatchResults converts results of code processing of the batch to results of the response
+func toBatchResults(results []code_processing.BatchResult) []*pb.RunCodeBatchResult {
+	batchResults := make([]*pb.RunCodeBatchResult, len(results))
+	for i, result := range results {
+		if result.Err != nil {
+			batchResults[i] = &pb.RunCodeBatchResult{Status: result.Status, Error: status.Convert(result.Err).Message()}
+			continue
+		}
+		batchResults[i] = &pb.RunCodeBatchResult{PipelineUuid: result.PipelineId.String(), Status: result.Status}
+	}
+	return batchResults
+}
+
+// CheckStatus is checking status, progress and durations of completed steps for the specific pipeline by PipelineUuid
 func (controller *playgroundController) CheckStatus(ctx context.Context, info *pb.CheckStatusRequest) (*pb.CheckStatusResponse, error) {
 	pipelineId, err := uuid.Parse(info.PipelineUuid)
 	if err != nil {
 		logger.Errorf("%s: CheckStatus(): pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid, err.Error())
 		return nil, errors.InvalidArgumentError("CheckStatus", fmt.Sprintf("pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid))
 	}
+	controller.restorePersistedResult(ctx, pipelineId)
 	status, err := code_processing.GetProcessingStatus(ctx, controller.cacheService, pipelineId, "CheckStatus")
 	if err != nil {
 		return nil, err
 	}
-	return &pb.CheckStatusResponse{Status: status}, nil
+	progress := code_processing.GetProgress(ctx, controller.cacheService, pipelineId)
+	response := pb.CheckStatusResponse{Status: status, Progress: int32(progress)}
+	if compileDuration, err := code_processing.GetDuration(ctx, controller.cacheService, pipelineId, cache.CompileDuration, "CheckStatus"); err == nil {
+		response.CompileDurationMs = int64(compileDuration)
+	}
+	if runDuration, err := code_processing.GetDuration(ctx, controller.cacheService, pipelineId, cache.RunDuration, "CheckStatus"); err == nil {
+		response.RunDurationMs = int64(runDuration)
+	}
+	if executeTimeout, err := code_processing.GetDuration(ctx, controller.cacheService, pipelineId, cache.ExecuteTimeout, "CheckStatus"); err == nil {
+		response.ExecuteTimeoutMs = int64(executeTimeout)
+	}
+	// the cancel reason is reported only when the cancellation is observed by code processing
+	if status == pb.Status_STATUS_CANCELED {
+		if reason, err := code_processing.GetCancelReason(ctx, controller.cacheService, pipelineId, "CheckStatus"); err == nil {
+			response.CancelReason = reason
+		}
+	}
+	return &response, nil
 }
 
-// GetRunOutput is returning output of execution for specific pipeline by PipelineUuid
+// GetRunOutput is returning output of execution for specific pipeline by PipelineUuid.
+// In case of preview is requested returns the start and the end of the whole output without changing the index of the returned output.
+// In case of raw is requested returns the whole output without filtering of log lines without changing the index of the returned output.
 func (controller *playgroundController) GetRunOutput(ctx context.Context, info *pb.GetRunOutputRequest) (*pb.GetRunOutputResponse, error) {
 	pipelineId, err := uuid.Parse(info.PipelineUuid)
 	if err != nil {
 		logger.Errorf("%s: GetRunOutput(): pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid, err.Error())
 		return nil, errors.InvalidArgumentError("GetRunOutput", fmt.Sprintf("pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid))
 	}
+	controller.restorePersistedResult(ctx, pipelineId)
+	if info.Raw {
+		rawOutput, err := code_processing.GetProcessingOutput(ctx, controller.cacheService, pipelineId, cache.RawRunOutput, "GetRunOutput")
+		if err != nil {
+			return nil, err
+		}
+		return &pb.GetRunOutputResponse{Output: rawOutput}, nil
+	}
+	if info.Preview {
+		preview, err := code_processing.GetProcessingOutputPreview(ctx, controller.cacheService, pipelineId, cache.RunOutput, controller.env.ApplicationEnvs.OutputPreviewSize(), "GetRunOutput")
+		if err != nil {
+			return nil, err
+		}
+		return &pb.GetRunOutputResponse{Output: preview}, nil
+	}
 	lastIndex, err := code_processing.GetLastIndex(ctx, controller.cacheService, pipelineId, cache.RunOutputIndex, "GetRunOutput")
 	if err != nil {
 		return nil, err
 	}
-	runOutput, err := code_processing.GetProcessingOutput(ctx, controller.cacheService, pipelineId, cache.RunOutput, "GetRunOutput")
+	newRunOutput, newIndex, err := code_processing.GetProcessingOutputSince(ctx, controller.cacheService, pipelineId, cache.RunOutput, lastIndex, "GetRunOutput")
 	if err != nil {
 		return nil, err
 	}
-	newRunOutput := ""
-	if len(runOutput) > lastIndex {
-		newRunOutput = runOutput[lastIndex:]
-		if err := utils.SetToCache(ctx, controller.cacheService, pipelineId, cache.RunOutputIndex, lastIndex+len(newRunOutput)); err != nil {
+	if newIndex != lastIndex {
+		if err := utils.SetToCache(ctx, controller.cacheService, pipelineId, cache.RunOutputIndex, newIndex); err != nil {
 			return nil, errors.InternalError("GetRunOutput", fmt.Sprintf("Error during set value to cache: %s", err.Error()))
 		}
 	}
@@ -130,6 +601,52 @@ func (controller *playgroundController) GetRunOutput(ctx context.Context, info *
 	return &pipelineResult, nil
 }
 
+// StreamRunOutput is sending the run output for specific pipeline by PipelineUuid as it is written until the status of the pipeline is final.
+// The stream starts from FromIndex, so the stream resumed from the index of the last received response doesn't miss or duplicate the output.
+// The offset is kept by the stream itself, so cache.RunOutputIndex used by GetRunOutput isn't changed.
+// The output is sent once the run output writer or the final status notifies the stream. The output of pipelines
+// processed by other instances of the server with the remote cache is checked every streamPollInterval.
+func (controller *playgroundController) StreamRunOutput(info *pb.StreamRunOutputRequest, stream pb.PlaygroundService_StreamRunOutputServer) error {
+	ctx := stream.Context()
+	pipelineId, err := uuid.Parse(info.PipelineUuid)
+	if err != nil {
+		logger.Errorf("%s: StreamRunOutput(): pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid, err.Error())
+		return errors.InvalidArgumentError("StreamRunOutput", fmt.Sprintf("pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid))
+	}
+	controller.restorePersistedResult(ctx, pipelineId)
+	// the stream is subscribed before the output is read, so the output written in between isn't missed
+	notifications, unsubscribe := streaming.SubscribeRunOutput(pipelineId)
+	defer unsubscribe()
+	ticker := time.NewTicker(streamPollInterval)
+	defer ticker.Stop()
+	lastIndex := int(info.FromIndex)
+	for {
+		// the status is received before the output, so the output written before the final status is sent
+		pipelineStatus, err := code_processing.GetProcessingStatus(ctx, controller.cacheService, pipelineId, "StreamRunOutput")
+		if err != nil {
+			return err
+		}
+		// the run output doesn't exist until the run step is started
+		newRunOutput, newIndex, err := code_processing.GetProcessingOutputSince(ctx, controller.cacheService, pipelineId, cache.RunOutput, lastIndex, "StreamRunOutput")
+		if err == nil && newIndex != lastIndex {
+			if err = stream.Send(&pb.StreamRunOutputResponse{Output: newRunOutput, Index: int64(newIndex)}); err != nil {
+				logger.WithPipelineId(pipelineId).Errorf("StreamRunOutput(): error during sending the run output: %s", err.Error())
+				return err
+			}
+			lastIndex = newIndex
+		}
+		if code_processing.IsFinished(pipelineStatus) {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-notifications:
+		case <-ticker.C:
+		}
+	}
+}
+
 // GetLogs is returning logs of execution for specific pipeline by PipelineUuid
 func (controller *playgroundController) GetLogs(ctx context.Context, info *pb.GetLogsRequest) (*pb.GetLogsResponse, error) {
 	errorTitle := utils.GetFuncName(controller.GetRunOutput)
@@ -159,47 +676,430 @@ func (controller *playgroundController) GetLogs(ctx context.Context, info *pb.Ge
 	return &pipelineResult, nil
 }
 
-// GetRunError is returning error output of execution for specific pipeline by PipelineUuid
+// GetRunError is returning error output of execution for specific pipeline by PipelineUuid.
+// In case of preview is requested returns the start and the end of the error output.
 func (controller *playgroundController) GetRunError(ctx context.Context, info *pb.GetRunErrorRequest) (*pb.GetRunErrorResponse, error) {
 	pipelineId, err := uuid.Parse(info.PipelineUuid)
 	if err != nil {
 		logger.Errorf("%s: GetRunError(): pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid, err.Error())
 		return nil, errors.InvalidArgumentError("GetRunError", fmt.Sprintf("pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid))
 	}
-	runError, err := code_processing.GetProcessingOutput(ctx, controller.cacheService, pipelineId, cache.RunError, "GetRunError")
+	controller.restorePersistedResult(ctx, pipelineId)
+	previewSize := 0
+	if info.Preview {
+		previewSize = controller.env.ApplicationEnvs.OutputPreviewSize()
+	}
+	runError, err := code_processing.GetProcessingOutputPreview(ctx, controller.cacheService, pipelineId, cache.RunError, previewSize, "GetRunError")
 	if err != nil {
 		return nil, err
 	}
 	return &pb.GetRunErrorResponse{Output: runError}, nil
 }
 
-//GetCompileOutput is returning output of compilation for specific pipeline by PipelineUuid
+// GetSource is returning the code for specific pipeline by PipelineUuid as it has been submitted.
+// In case the pipeline has expired from cache the code is restored from the result storage, so it could be opened by a shared link.
+func (controller *playgroundController) GetSource(ctx context.Context, info *pb.GetSourceRequest) (*pb.GetSourceResponse, error) {
+	pipelineId, err := uuid.Parse(info.PipelineUuid)
+	if err != nil {
+		logger.Errorf("%s: GetSource(): pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid, err.Error())
+		return nil, errors.InvalidArgumentError("GetSource", fmt.Sprintf("pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid))
+	}
+	controller.restorePersistedResult(ctx, pipelineId)
+	source, err := code_processing.GetProcessingOutput(ctx, controller.cacheService, pipelineId, cache.Source, "GetSource")
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetSourceResponse{Code: source}, nil
+}
+
+// GetDependencies is returning imports of the code detected during the preparation for specific pipeline by PipelineUuid
+func (controller *playgroundController) GetDependencies(ctx context.Context, info *pb.GetDependenciesRequest) (*pb.GetDependenciesResponse, error) {
+	pipelineId, err := uuid.Parse(info.PipelineUuid)
+	if err != nil {
+		logger.Errorf("%s: GetDependencies(): pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid, err.Error())
+		return nil, errors.InvalidArgumentError("GetDependencies", fmt.Sprintf("pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid))
+	}
+	dependencies, err := code_processing.GetDependencies(ctx, controller.cacheService, pipelineId, "GetDependencies")
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetDependenciesResponse{Dependencies: dependencies}, nil
+}
+
+//GetCompileOutput is returning output of compilation, its diagnostics and warnings and the url of the build scan (if it was published) for specific pipeline by PipelineUuid
 func (controller *playgroundController) GetCompileOutput(ctx context.Context, info *pb.GetCompileOutputRequest) (*pb.GetCompileOutputResponse, error) {
 	pipelineId, err := uuid.Parse(info.PipelineUuid)
 	if err != nil {
 		logger.Errorf("%s: GetCompileOutput(): pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid, err.Error())
 		return nil, errors.InvalidArgumentError("GetCompileOutput", fmt.Sprintf("pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid))
 	}
+	controller.restorePersistedResult(ctx, pipelineId)
 	compileOutput, err := code_processing.GetProcessingOutput(ctx, controller.cacheService, pipelineId, cache.CompileOutput, "GetCompileOutput")
 	if err != nil {
 		return nil, err
 	}
-	return &pb.GetCompileOutputResponse{Output: compileOutput}, nil
+	if info.Incremental {
+		lastIndex, err := code_processing.GetLastIndex(ctx, controller.cacheService, pipelineId, cache.CompileOutputIndex, "GetCompileOutput")
+		if err != nil {
+			return nil, err
+		}
+		if len(compileOutput) < lastIndex {
+			lastIndex = len(compileOutput)
+		}
+		compileOutput = compileOutput[lastIndex:]
+		if err := utils.SetToCache(ctx, controller.cacheService, pipelineId, cache.CompileOutputIndex, lastIndex+len(compileOutput)); err != nil {
+			return nil, errors.InternalError("GetCompileOutput", fmt.Sprintf("Error during set value to cache: %s", err.Error()))
+		}
+	}
+	buildScanUrl := code_processing.GetBuildScanUrl(ctx, controller.cacheService, pipelineId)
+	response := pb.GetCompileOutputResponse{Output: compileOutput, BuildScanUrl: buildScanUrl}
+	response.Diagnostics = toFileDiagnostics(code_processing.GetCompileDiagnostics(ctx, controller.cacheService, pipelineId))
+	for _, warning := range code_processing.GetCompileWarnings(ctx, controller.cacheService, pipelineId) {
+		response.Warnings = append(response.Warnings, &pb.CompileWarning{FileName: warning.FileName, Line: int32(warning.Line), Category: warning.Category, Message: warning.Message})
+	}
+	return &response, nil
+}
+
+// GetValidationDiagnostics is returning errors of the validation step with their positions for specific pipeline by PipelineUuid.
+// For the Go SDK they also include messages of go build and go vet.
+// In case the validation step isn't failed or its errors have no positions returns no diagnostics.
+func (controller *playgroundController) GetValidationDiagnostics(ctx context.Context, info *pb.GetValidationDiagnosticsRequest) (*pb.GetValidationDiagnosticsResponse, error) {
+	pipelineId, err := uuid.Parse(info.PipelineUuid)
+	if err != nil {
+		logger.Errorf("%s: GetValidationDiagnostics(): pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid, err.Error())
+		return nil, errors.InvalidArgumentError("GetValidationDiagnostics", fmt.Sprintf("pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid))
+	}
+	if _, err = code_processing.GetProcessingStatus(ctx, controller.cacheService, pipelineId, "GetValidationDiagnostics"); err != nil {
+		return nil, err
+	}
+	return &pb.GetValidationDiagnosticsResponse{Diagnostics: toFileDiagnostics(code_processing.GetValidationDiagnostics(ctx, controller.cacheService, pipelineId))}, nil
+}
+
+// toFileDiagnostics converts diagnostics grouped by source files from cache to their representation in responses
+func toFileDiagnostics(fileDiagnostics []cache.FileDiagnostics) []*pb.FileDiagnostics {
+	var result []*pb.FileDiagnostics
+	for _, fileDiagnostic := range fileDiagnostics {
+		file := &pb.FileDiagnostics{FileName: fileDiagnostic.FileName, Diagnostics: make([]*pb.Diagnostic, 0, len(fileDiagnostic.Diagnostics))}
+		for _, diagnostic := range fileDiagnostic.Diagnostics {
+			file.Diagnostics = append(file.Diagnostics, &pb.Diagnostic{Line: int32(diagnostic.Line), Column: int32(diagnostic.Column), Message: diagnostic.Message, Severity: diagnostic.Severity})
+		}
+		result = append(result, file)
+	}
+	return result
+}
+
+// GetTestResults is returning results of tests run against the code for specific pipeline by PipelineUuid
+func (controller *playgroundController) GetTestResults(ctx context.Context, info *pb.GetTestResultsRequest) (*pb.GetTestResultsResponse, error) {
+	pipelineId, err := uuid.Parse(info.PipelineUuid)
+	if err != nil {
+		logger.Errorf("%s: GetTestResults(): pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid, err.Error())
+		return nil, errors.InvalidArgumentError("GetTestResults", fmt.Sprintf("pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid))
+	}
+	results, err := code_processing.GetTestResults(ctx, controller.cacheService, pipelineId, "GetTestResults")
+	if err != nil {
+		return nil, err
+	}
+	response := pb.GetTestResultsResponse{Results: make([]*pb.TestResult, 0, len(results))}
+	for _, result := range results {
+		response.Results = append(response.Results, &pb.TestResult{Name: result.Name, Passed: result.Passed})
+	}
+	return &response, nil
+}
+
+// GetOutputFiles is returning files written by execution for specific pipeline by PipelineUuid
+func (controller *playgroundController) GetOutputFiles(ctx context.Context, info *pb.GetOutputFilesRequest) (*pb.GetOutputFilesResponse, error) {
+	pipelineId, err := uuid.Parse(info.PipelineUuid)
+	if err != nil {
+		logger.Errorf("%s: GetOutputFiles(): pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid, err.Error())
+		return nil, errors.InvalidArgumentError("GetOutputFiles", fmt.Sprintf("pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid))
+	}
+	outputFiles, err := code_processing.GetOutputFiles(ctx, controller.cacheService, pipelineId, "GetOutputFiles")
+	if err != nil {
+		return nil, err
+	}
+	response := pb.GetOutputFilesResponse{Files: make([]*pb.OutputFile, 0, len(outputFiles))}
+	for _, outputFile := range outputFiles {
+		response.Files = append(response.Files, &pb.OutputFile{Name: outputFile.Name, Content: outputFile.Content, Error: outputFile.Error})
+	}
+	return &response, nil
+}
+
+// GetRunRecords is returning records parsed from the run output in the JSON lines format for specific pipeline by PipelineUuid.
+// Records exist only if the pipeline is run with the "jsonl" output format.
+func (controller *playgroundController) GetRunRecords(ctx context.Context, info *pb.GetRunRecordsRequest) (*pb.GetRunRecordsResponse, error) {
+	pipelineId, err := uuid.Parse(info.PipelineUuid)
+	if err != nil {
+		logger.Errorf("%s: GetRunRecords(): pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid, err.Error())
+		return nil, errors.InvalidArgumentError("GetRunRecords", fmt.Sprintf("pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid))
+	}
+	records, err := code_processing.GetRunRecords(ctx, controller.cacheService, pipelineId, "GetRunRecords")
+	if err != nil {
+		return nil, err
+	}
+	response := pb.GetRunRecordsResponse{Records: make([]*pb.RunRecord, 0, len(records))}
+	for _, record := range records {
+		response.Records = append(response.Records, &pb.RunRecord{Line: int32(record.Line), Json: record.Json, Malformed: record.Malformed})
+	}
+	return &response, nil
+}
+
+// GetArtifact is sending the file compiled from the code for specific pipeline by PipelineUuid by chunks of artifactChunkSize bytes.
+// In case the file couldn't be provided (e.g. it is too large) returns codes.NotFound with the reason.
+func (controller *playgroundController) GetArtifact(info *pb.GetArtifactRequest, stream pb.PlaygroundService_GetArtifactServer) error {
+	pipelineId, err := uuid.Parse(info.PipelineUuid)
+	if err != nil {
+		logger.Errorf("%s: GetArtifact(): pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid, err.Error())
+		return errors.InvalidArgumentError("GetArtifact", fmt.Sprintf("pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid))
+	}
+	artifact, err := code_processing.GetArtifact(stream.Context(), controller.cacheService, pipelineId, "GetArtifact")
+	if err != nil {
+		return err
+	}
+	if artifact.Error != "" {
+		return errors.NotFoundError("GetArtifact", artifact.Error)
+	}
+	response := pb.GetArtifactResponse{Name: artifact.Name}
+	for start := 0; start == 0 || start < len(artifact.Content); start += artifactChunkSize {
+		end := start + artifactChunkSize
+		if end > len(artifact.Content) {
+			end = len(artifact.Content)
+		}
+		response.Content = artifact.Content[start:end]
+		if err = stream.Send(&response); err != nil {
+			logger.WithPipelineId(pipelineId).Errorf("GetArtifact(): error during sending the chunk: %s", err.Error())
+			return err
+		}
+		response.Name = ""
+	}
+	return nil
+}
+
+// GetFormattedOutput is returning the output of execution formatted to be displayed for specific pipeline by PipelineUuid.
+// The raw output is still returned by GetRunOutput.
+func (controller *playgroundController) GetFormattedOutput(ctx context.Context, info *pb.GetFormattedOutputRequest) (*pb.GetFormattedOutputResponse, error) {
+	pipelineId, err := uuid.Parse(info.PipelineUuid)
+	if err != nil {
+		logger.Errorf("%s: GetFormattedOutput(): pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid, err.Error())
+		return nil, errors.InvalidArgumentError("GetFormattedOutput", fmt.Sprintf("pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid))
+	}
+	output, err := code_processing.GetProcessingOutput(ctx, controller.cacheService, pipelineId, cache.FormattedOutput, "GetFormattedOutput")
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetFormattedOutputResponse{Output: output}, nil
+}
+
+// GetCoverage is returning the coverage summary of execution for specific pipeline by PipelineUuid
+func (controller *playgroundController) GetCoverage(ctx context.Context, info *pb.GetCoverageRequest) (*pb.GetCoverageResponse, error) {
+	pipelineId, err := uuid.Parse(info.PipelineUuid)
+	if err != nil {
+		logger.Errorf("%s: GetCoverage(): pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid, err.Error())
+		return nil, errors.InvalidArgumentError("GetCoverage", fmt.Sprintf("pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid))
+	}
+	coverage, err := code_processing.GetProcessingOutput(ctx, controller.cacheService, pipelineId, cache.Coverage, "GetCoverage")
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetCoverageResponse{Coverage: coverage}, nil
+}
+
+// GetCrossSdkDiff is returning the diff of normalized outputs of two finished pipelines by their PipelineUuids
+func (controller *playgroundController) GetCrossSdkDiff(ctx context.Context, info *pb.GetCrossSdkDiffRequest) (*pb.GetCrossSdkDiffResponse, error) {
+	pipelineIds := make([]uuid.UUID, 0, 2)
+	for _, pipelineUuid := range []string{info.FirstPipelineUuid, info.SecondPipelineUuid} {
+		pipelineId, err := uuid.Parse(pipelineUuid)
+		if err != nil {
+			logger.Errorf("%s: GetCrossSdkDiff(): pipelineId has incorrect value and couldn't be parsed as uuid value: %s", pipelineUuid, err.Error())
+			return nil, errors.InvalidArgumentError("GetCrossSdkDiff", fmt.Sprintf("pipelineId has incorrect value and couldn't be parsed as uuid value: %s", pipelineUuid))
+		}
+		pipelineIds = append(pipelineIds, pipelineId)
+	}
+	diff, err := code_processing.CompareOutputs(ctx, controller.cacheService, pipelineIds[0], pipelineIds[1], "GetCrossSdkDiff")
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetCrossSdkDiffResponse{Diff: diff}, nil
+}
+
+// GetMetrics is returning element count metrics of execution for specific pipeline by PipelineUuid.
+// Returns only the metric points which haven't been returned yet.
+func (controller *playgroundController) GetMetrics(ctx context.Context, info *pb.GetMetricsRequest) (*pb.GetMetricsResponse, error) {
+	pipelineId, err := uuid.Parse(info.PipelineUuid)
+	if err != nil {
+		logger.Errorf("%s: GetMetrics(): pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid, err.Error())
+		return nil, errors.InvalidArgumentError("GetMetrics", fmt.Sprintf("pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid))
+	}
+	lastIndex, err := code_processing.GetLastIndex(ctx, controller.cacheService, pipelineId, cache.MetricsIndex, "GetMetrics")
+	if err != nil {
+		return nil, err
+	}
+	points, err := code_processing.GetMetrics(ctx, controller.cacheService, pipelineId, "GetMetrics")
+	if err != nil {
+		return nil, err
+	}
+	response := pb.GetMetricsResponse{Points: make([]*pb.MetricPoint, 0)}
+	if len(points) > lastIndex {
+		for _, point := range points[lastIndex:] {
+			response.Points = append(response.Points, &pb.MetricPoint{Timestamp: point.Timestamp.UnixNano() / int64(time.Millisecond), ElementCount: point.ElementCount})
+		}
+		if err := utils.SetToCache(ctx, controller.cacheService, pipelineId, cache.MetricsIndex, len(points)); err != nil {
+			return nil, errors.InternalError("GetMetrics", fmt.Sprintf("Error during set value to cache: %s", err.Error()))
+		}
+	}
+	return &response, nil
+}
+
+// GetIntermediateSamples is returning sample elements of PCollections of the code processing for specific pipeline by PipelineUuid
+func (controller *playgroundController) GetIntermediateSamples(ctx context.Context, info *pb.GetIntermediateSamplesRequest) (*pb.GetIntermediateSamplesResponse, error) {
+	pipelineId, err := uuid.Parse(info.PipelineUuid)
+	if err != nil {
+		logger.Errorf("%s: GetIntermediateSamples(): pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid, err.Error())
+		return nil, errors.InvalidArgumentError("GetIntermediateSamples", fmt.Sprintf("pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid))
+	}
+	samples, err := code_processing.GetIntermediateSamples(ctx, controller.cacheService, pipelineId, "GetIntermediateSamples")
+	if err != nil {
+		return nil, err
+	}
+	response := pb.GetIntermediateSamplesResponse{Samples: make([]*pb.PCollectionSamples, 0, len(samples))}
+	for _, sample := range samples {
+		response.Samples = append(response.Samples, &pb.PCollectionSamples{Pcollection: sample.PCollection, Elements: sample.Elements})
+	}
+	return &response, nil
+}
+
+// GetTimestamps is returning wall-clock times when code processing is started, finished, observed the cancel request
+// and reached statuses after completed steps for specific pipeline by PipelineUuid
+func (controller *playgroundController) GetTimestamps(ctx context.Context, info *pb.GetTimestampsRequest) (*pb.GetTimestampsResponse, error) {
+	pipelineId, err := uuid.Parse(info.PipelineUuid)
+	if err != nil {
+		logger.Errorf("%s: GetTimestamps(): pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid, err.Error())
+		return nil, errors.InvalidArgumentError("GetTimestamps", fmt.Sprintf("pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid))
+	}
+	startedAt, err := code_processing.GetTimestamp(ctx, controller.cacheService, pipelineId, cache.StartedAt, "GetTimestamps")
+	if err != nil {
+		return nil, err
+	}
+	response := pb.GetTimestampsResponse{StartedAt: startedAt.UnixNano() / int64(time.Millisecond)}
+	// code processing isn't finished yet if there is no finish time
+	if finishedAt, err := code_processing.GetTimestamp(ctx, controller.cacheService, pipelineId, cache.FinishedAt, "GetTimestamps"); err == nil {
+		response.FinishedAt = finishedAt.UnixNano() / int64(time.Millisecond)
+	}
+	// the cancel request isn't observed by code processing yet if there is no acknowledgement time
+	if cancelAcknowledgedAt, err := code_processing.GetTimestamp(ctx, controller.cacheService, pipelineId, cache.CancelAcknowledged, "GetTimestamps"); err == nil {
+		response.CancelAcknowledgedAt = cancelAcknowledgedAt.UnixNano() / int64(time.Millisecond)
+	}
+	// no step is completed yet if there are no status transitions
+	if transitions, err := code_processing.GetStatusTransitions(ctx, controller.cacheService, pipelineId, "GetTimestamps"); err == nil {
+		for _, transition := range transitions {
+			response.Transitions = append(response.Transitions, &pb.StatusTransition{Status: transition.Status, Timestamp: transition.Timestamp.UnixNano() / int64(time.Millisecond)})
+		}
+	}
+	return &response, nil
+}
+
+// GetInvocation is returning the working directory and arguments of commands which are used to compile and run the code
+// for specific pipeline by PipelineUuid. Paths are relative to the working directory of the server.
+// Invocations of steps which aren't started (e.g. the compile step of python code) aren't set.
+func (controller *playgroundController) GetInvocation(ctx context.Context, info *pb.GetInvocationRequest) (*pb.GetInvocationResponse, error) {
+	pipelineId, err := uuid.Parse(info.PipelineUuid)
+	if err != nil {
+		logger.Errorf("%s: GetInvocation(): pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid, err.Error())
+		return nil, errors.InvalidArgumentError("GetInvocation", fmt.Sprintf("pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid))
+	}
+	if _, err = code_processing.GetProcessingStatus(ctx, controller.cacheService, pipelineId, "GetInvocation"); err != nil {
+		return nil, err
+	}
+	compileInvocation, err := controller.getInvocation(ctx, pipelineId, cache.CompileInvocation)
+	if err != nil {
+		return nil, err
+	}
+	runInvocation, err := controller.getInvocation(ctx, pipelineId, cache.RunInvocation)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetInvocationResponse{CompileInvocation: compileInvocation, RunInvocation: runInvocation}, nil
+}
+
+// getInvocation returns the invocation of the step which is saved into cache by subKey or nil if the step isn't started.
+// In case the invocation couldn't be converted returns codes.Internal.
+func (controller *playgroundController) getInvocation(ctx context.Context, pipelineId uuid.UUID, subKey cache.SubKey) (*pb.Invocation, error) {
+	invocation, err := code_processing.GetInvocation(ctx, controller.cacheService, pipelineId, subKey, "GetInvocation")
+	if status.Code(err) == codes.NotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &pb.Invocation{WorkingDir: invocation.WorkingDir, Args: invocation.Args}, nil
 }
 
-// Cancel is setting cancel flag to stop code processing
+// Cancel is setting cancel flag to stop code processing.
+// In case the pipeline is a variant of the group submitted by RunCodeGroup also sets cancel flag for other pipelines of the group.
 func (controller *playgroundController) Cancel(ctx context.Context, info *pb.CancelRequest) (*pb.CancelResponse, error) {
 	pipelineId, err := uuid.Parse(info.PipelineUuid)
 	if err != nil {
 		logger.Errorf("%s: Cancel(): pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid, err.Error())
 		return nil, errors.InvalidArgumentError("Cancel", fmt.Sprintf("pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid))
 	}
+	// the reason is saved before the cancel flag, so code processing always observes it together with the flag
+	if err := utils.SetToCache(ctx, controller.cacheService, pipelineId, cache.CancelReason, pb.CancelReason_CANCEL_REASON_USER); err != nil {
+		return nil, errors.InternalError("Cancel", "error during set cancel reason to cache")
+	}
 	if err := utils.SetToCache(ctx, controller.cacheService, pipelineId, cache.Canceled, true); err != nil {
 		return nil, errors.InternalError("Cancel", "error during set cancel flag to cache")
 	}
+	// the pipeline isn't a part of any group if there is no group in cache
+	group, err := code_processing.GetPipelineGroup(ctx, controller.cacheService, pipelineId, "Cancel")
+	if err != nil {
+		return &pb.CancelResponse{}, nil
+	}
+	for _, pipelineUuid := range group.PipelineIds {
+		siblingId, err := uuid.Parse(pipelineUuid)
+		if err != nil || siblingId == pipelineId {
+			continue
+		}
+		if err := utils.SetToCache(ctx, controller.cacheService, siblingId, cache.CancelReason, pb.CancelReason_CANCEL_REASON_GROUP); err != nil {
+			return nil, errors.InternalError("Cancel", "error during set cancel reason to cache")
+		}
+		if err := utils.SetToCache(ctx, controller.cacheService, siblingId, cache.Canceled, true); err != nil {
+			return nil, errors.InternalError("Cancel", "error during set cancel flag to cache")
+		}
+	}
 	return &pb.CancelResponse{}, nil
 }
 
+// ExtendTimeout is setting extend timeout flag to push the deadline of code processing forward
+func (controller *playgroundController) ExtendTimeout(ctx context.Context, info *pb.ExtendTimeoutRequest) (*pb.ExtendTimeoutResponse, error) {
+	pipelineId, err := uuid.Parse(info.PipelineUuid)
+	if err != nil {
+		logger.Errorf("%s: ExtendTimeout(): pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid, err.Error())
+		return nil, errors.InvalidArgumentError("ExtendTimeout", fmt.Sprintf("pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid))
+	}
+	if err := utils.SetToCache(ctx, controller.cacheService, pipelineId, cache.ExtendTimeout, true); err != nil {
+		return nil, errors.InternalError("ExtendTimeout", "error during set extend timeout flag to cache")
+	}
+	return &pb.ExtendTimeoutResponse{}, nil
+}
+
+// CheckSdkAvailability returns whether the SDK with versions of its language and Apache Beam could be processed.
+// In case it couldn't be processed also returns the nearest available alternatives.
+// In case the toolchain of the SDK is broken it isn't available.
+func (controller *playgroundController) CheckSdkAvailability(ctx context.Context, info *pb.CheckSdkAvailabilityRequest) (*pb.CheckSdkAvailabilityResponse, error) {
+	if controller.sdkDisabled {
+		return &pb.CheckSdkAvailabilityResponse{Available: false}, nil
+	}
+	available, alternatives := controller.env.BeamSdkEnvs.CheckAvailability(info.Sdk, info.SdkVersion, info.BeamVersion)
+	response := pb.CheckSdkAvailabilityResponse{Available: available}
+	for _, alternative := range alternatives {
+		response.Alternatives = append(response.Alternatives, &pb.SdkAvailability{
+			Sdk:         alternative.Sdk,
+			SdkVersion:  alternative.SdkVersion,
+			BeamVersion: alternative.Version,
+		})
+	}
+	return &response, nil
+}
+
 // GetPrecompiledObjects returns the list of examples
 func (controller *playgroundController) GetPrecompiledObjects(ctx context.Context, info *pb.GetPrecompiledObjectsRequest) (*pb.GetPrecompiledObjectsResponse, error) {
 	bucket := cloud_bucket.New()
@@ -18,21 +18,31 @@ import (
 	pb "beam.apache.org/playground/backend/internal/api/v1"
 	"beam.apache.org/playground/backend/internal/cache"
 	"beam.apache.org/playground/backend/internal/cache/local"
+	"beam.apache.org/playground/backend/internal/code_processing"
 	"beam.apache.org/playground/backend/internal/environment"
+	"beam.apache.org/playground/backend/internal/fs_tool"
+	"beam.apache.org/playground/backend/internal/streaming"
 	"context"
 	"fmt"
 	"github.com/google/uuid"
 	"go.uber.org/goleak"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+	"io"
 	"io/fs"
 	"log"
+	"math"
 	"net"
 	"os"
 	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 )
 
 const (
@@ -128,6 +138,8 @@ func TestPlaygroundController_RunCode(t *testing.T) {
 		name    string
 		args    args
 		wantErr bool
+		// wantStdInput is the data for stdin which should be saved into cache, empty if it isn't checked
+		wantStdInput string
 	}{
 		{
 			// Test case with calling RunCode method with incorrect SDK.
@@ -142,6 +154,218 @@ func TestPlaygroundController_RunCode(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			// Test case with calling RunCode method with the classpath library which isn't allowed.
+			// As a result, want to receive an error.
+			name: "RunCode with not allowed classpath library",
+			args: args{
+				ctx: context.Background(),
+				request: &pb.RunCodeRequest{
+					Code:               "MOCK_CODE",
+					Sdk:                pb.Sdk_SDK_JAVA,
+					ClasspathLibraries: []string{"MOCK_LIBRARY"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			// Test case with calling RunCode method with the pipeline option which isn't allowed.
+			// As a result, want to receive an error.
+			name: "RunCode with not allowed pipeline args",
+			args: args{
+				ctx: context.Background(),
+				request: &pb.RunCodeRequest{
+					Code:         "MOCK_CODE",
+					Sdk:          pb.Sdk_SDK_JAVA,
+					PipelineArgs: []string{"--runner=DataflowRunner"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			// Test case with calling RunCode method with the version of Apache Beam which isn't available.
+			// As a result, want to receive an error.
+			name: "RunCode with unavailable beam version",
+			args: args{
+				ctx: context.Background(),
+				request: &pb.RunCodeRequest{
+					Code:        "MOCK_CODE",
+					Sdk:         pb.Sdk_SDK_JAVA,
+					BeamVersion: "0.0.1",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			// Test case with calling RunCode method with the environment variable which overrides HOME.
+			// As a result, want to receive an error.
+			name: "RunCode with denied env",
+			args: args{
+				ctx: context.Background(),
+				request: &pb.RunCodeRequest{
+					Code: "MOCK_CODE",
+					Sdk:  pb.Sdk_SDK_JAVA,
+					Env:  map[string]string{"HOME": "/tmp"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			// Test case with calling RunCode method with WebAssembly compilation on the server of the Java SDK.
+			// As a result, want to receive an error.
+			name: "RunCode with wasm for not go sdk",
+			args: args{
+				ctx: context.Background(),
+				request: &pb.RunCodeRequest{
+					Code: "MOCK_CODE",
+					Sdk:  pb.Sdk_SDK_JAVA,
+					Wasm: true,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			// Test case with calling RunCode method with the output format which isn't supported.
+			// As a result, want to receive an error.
+			name: "RunCode with unsupported output format",
+			args: args{
+				ctx: context.Background(),
+				request: &pb.RunCodeRequest{
+					Code:         "MOCK_CODE",
+					Sdk:          pb.Sdk_SDK_JAVA,
+					OutputFormat: "MOCK_FORMAT",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			// Test case with calling RunCode method with the output charset which isn't supported.
+			// As a result, want to receive an error.
+			name: "RunCode with unsupported output charset",
+			args: args{
+				ctx: context.Background(),
+				request: &pb.RunCodeRequest{
+					Code:          "MOCK_CODE",
+					Sdk:           pb.Sdk_SDK_JAVA,
+					OutputCharset: "MOCK_CHARSET",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			// Test case with calling RunCode method with negative client timeout.
+			// As a result, want to receive an error.
+			name: "RunCode with negative client timeout",
+			args: args{
+				ctx: context.Background(),
+				request: &pb.RunCodeRequest{
+					Code:            "MOCK_CODE",
+					Sdk:             pb.Sdk_SDK_JAVA,
+					ClientTimeoutMs: -1,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			// Test case with calling RunCode method with the dependency which has the extension of the other SDK.
+			// As a result, want to receive an error.
+			name: "RunCode with incorrect dependency extension",
+			args: args{
+				ctx: context.Background(),
+				request: &pb.RunCodeRequest{
+					Code:       "MOCK_CODE",
+					Sdk:        pb.Sdk_SDK_JAVA,
+					Dependency: &pb.Dependency{Name: "transforms.whl", Content: []byte("MOCK_DEPENDENCY")},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			// Test case with calling RunCode method with the sample dataset which isn't in the catalog of the server.
+			// As a result, want to receive an error.
+			name: "RunCode with unknown sample dataset",
+			args: args{
+				ctx: context.Background(),
+				request: &pb.RunCodeRequest{
+					Code:          "MOCK_CODE",
+					Sdk:           pb.Sdk_SDK_JAVA,
+					SampleDataset: "MOCK_DATASET",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			// Test case with calling RunCode method with log level filter which isn't a regular expression.
+			// As a result, want to receive an error.
+			name: "RunCode with incorrect log level filter",
+			args: args{
+				ctx: context.Background(),
+				request: &pb.RunCodeRequest{
+					Code:           "MOCK_CODE",
+					Sdk:            pb.Sdk_SDK_JAVA,
+					LogLevelFilter: "(INFO",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			// Test case with calling RunCode method with negative execute timeout.
+			// As a result, want to receive an error.
+			name: "RunCode with negative execute timeout",
+			args: args{
+				ctx: context.Background(),
+				request: &pb.RunCodeRequest{
+					Code:             "MOCK_CODE",
+					Sdk:              pb.Sdk_SDK_JAVA,
+					ExecuteTimeoutMs: -1,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			// Test case with calling RunCode method with negative delay of the stdin chunk.
+			// As a result, want to receive an error.
+			name: "RunCode with negative stdin delay",
+			args: args{
+				ctx: context.Background(),
+				request: &pb.RunCodeRequest{
+					Code:  "MOCK_CODE",
+					Sdk:   pb.Sdk_SDK_JAVA,
+					Stdin: []*pb.StdinChunk{{Data: []byte("MOCK_INPUT"), DelayMs: -1}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			// Test case with calling RunCode method with both the scripted stdin and the data for stdin.
+			// As a result, want to receive an error.
+			name: "RunCode with scripted stdin and stdin data",
+			args: args{
+				ctx: context.Background(),
+				request: &pb.RunCodeRequest{
+					Code:     "MOCK_CODE",
+					Sdk:      pb.Sdk_SDK_JAVA,
+					Stdin:    []*pb.StdinChunk{{Data: []byte("MOCK_INPUT")}},
+					StdInput: "MOCK_INPUT",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			// Test case with calling RunCode method with the data for stdin.
+			// As a result, want to receive response with pipelineId and the data should be saved into cache as cache.StdInput.
+			name: "RunCode with stdin data",
+			args: args{
+				ctx: context.Background(),
+				request: &pb.RunCodeRequest{
+					Code:     "MOCK_STDIN_CODE",
+					Sdk:      pb.Sdk_SDK_JAVA,
+					StdInput: "MOCK_INPUT",
+				},
+			},
+			wantErr:      false,
+			wantStdInput: "MOCK_INPUT",
+		},
 		{
 			// Test case with calling RunCode method with correct SDK.
 			// As a result, want to receive response with pipelineId and status into cache should be set as Status_STATUS_COMPILING.
@@ -153,45 +377,1744 @@ func TestPlaygroundController_RunCode(t *testing.T) {
 					Sdk:  pb.Sdk_SDK_JAVA,
 				},
 			},
-			wantErr: false,
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn, err := grpc.DialContext(tt.args.ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
+			if err != nil {
+				t.Fatalf("Failed to dial bufnet: %v", err)
+			}
+			defer conn.Close()
+			client := pb.NewPlaygroundServiceClient(conn)
+			response, err := client.RunCode(tt.args.ctx, tt.args.request)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PlaygroundController_RunCode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil {
+				if response == nil {
+					t.Errorf("PlaygroundController_RunCode() response shoudn't be nil")
+				} else {
+					if response.PipelineUuid == "" {
+						t.Errorf("PlaygroundController_RunCode() response.pipelineId shoudn't be nil")
+					}
+					status, _ := cacheService.GetValue(tt.args.ctx, uuid.MustParse(response.PipelineUuid), cache.Status)
+					path := os.Getenv("APP_WORK_DIR") + "/executable_files"
+					os.RemoveAll(path)
+					if status == nil {
+						t.Errorf("PlaygroundController_RunCode() status shoudn't be nil")
+					}
+					if tt.wantStdInput != "" {
+						stdInput, _ := cacheService.GetValue(tt.args.ctx, uuid.MustParse(response.PipelineUuid), cache.StdInput)
+						if stdInput != tt.wantStdInput {
+							t.Errorf("PlaygroundController_RunCode() stdin data = %v, want %v", stdInput, tt.wantStdInput)
+						}
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestPlaygroundController_RunCode_submissionWindow(t *testing.T) {
+	networkEnv, _ := environment.GetNetworkEnvsFromOsEnvs()
+	appEnv, err := environment.GetApplicationEnvsFromOsEnvs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sdkEnv, err := environment.ConfigureBeamEnvs(appEnv.WorkingDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name      string
+		request   *pb.RunCodeRequest
+		wantCode  codes.Code
+		wantAllow bool
+	}{
+		{
+			// Test case with calling RunCode method with the request which is rejected by validation within the submission window.
+			// As a result, want to receive InvalidArgument error and the identical submission should still be allowed.
+			name:      "invalid request isn't recorded",
+			request:   &pb.RunCodeRequest{Code: "MOCK_CODE", Sdk: pb.Sdk_SDK_JAVA, OutputCharset: "MOCK_CHARSET"},
+			wantCode:  codes.InvalidArgument,
+			wantAllow: true,
+		},
+		{
+			// Test case with calling RunCode method with the valid request within the submission window while the server is shutting down.
+			// As a result, want to receive Unavailable error and the identical submission should be rejected as a duplicate.
+			name:      "valid request is recorded",
+			request:   &pb.RunCodeRequest{Code: "MOCK_CODE", Sdk: pb.Sdk_SDK_JAVA},
+			wantCode:  codes.Unavailable,
+			wantAllow: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			coordinator := newShutdownCoordinator(0)
+			coordinator.stopped = true
+			controller := &playgroundController{
+				env:                 environment.NewEnvironment(*networkEnv, *sdkEnv, *appEnv),
+				cacheService:        cacheService,
+				submissionLimiter:   newSubmissionLimiter(time.Minute),
+				shutdownCoordinator: coordinator,
+			}
+			_, err := controller.RunCode(context.Background(), tt.request)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Errorf("RunCode() error code = %s, want %s", code, tt.wantCode)
+			}
+			resubmission := &pb.RunCodeRequest{Code: tt.request.Code, Sdk: tt.request.Sdk}
+			if allowed := controller.submissionLimiter.allow(resubmission, time.Now()); allowed != tt.wantAllow {
+				t.Errorf("allow() = %v after RunCode(), want %v", allowed, tt.wantAllow)
+			}
+		})
+	}
+}
+
+func TestPlaygroundController_RunCode_pipelineUuid(t *testing.T) {
+	networkEnv, _ := environment.GetNetworkEnvsFromOsEnvs()
+	appEnv, err := environment.GetApplicationEnvsFromOsEnvs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sdkEnv, err := environment.ConfigureBeamEnvs(appEnv.WorkingDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name          string
+		pipelineUuid  func() string
+		outputCharset string
+		// status is saved into cache before the call in case it isn't unspecified
+		status     pb.Status
+		wantCode   codes.Code
+		wantStatus pb.Status
+	}{
+		{
+			// Test case with calling RunCode method with the pipeline id which isn't a correct uuid.
+			// As a result, want to receive InvalidArgument error.
+			name:         "incorrect pipeline id",
+			pipelineUuid: func() string { return "MOCK_PIPELINE_ID" },
+			wantCode:     codes.InvalidArgument,
+		},
+		{
+			// Test case with calling RunCode method with the pipeline id which is already submitted.
+			// As a result, want to receive the same id without changing the status of the pipeline.
+			name:         "pipeline is already submitted",
+			pipelineUuid: func() string { return uuid.NewString() },
+			status:       pb.Status_STATUS_RUN_ERROR,
+			wantCode:     codes.OK,
+			wantStatus:   pb.Status_STATUS_RUN_ERROR,
+		},
+		{
+			// Test case with calling RunCode method with the new pipeline id and the request which is rejected by validation.
+			// As a result, want to receive InvalidArgument error and the pipeline id to be released.
+			name:          "failed request releases the pipeline id",
+			pipelineUuid:  func() string { return uuid.NewString() },
+			outputCharset: "MOCK_CHARSET",
+			wantCode:      codes.InvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := &playgroundController{
+				env:                 environment.NewEnvironment(*networkEnv, *sdkEnv, *appEnv),
+				cacheService:        cacheService,
+				submissionLimiter:   newSubmissionLimiter(0),
+				shutdownCoordinator: newShutdownCoordinator(0),
+			}
+			pipelineUuid := tt.pipelineUuid()
+			pipelineId, _ := uuid.Parse(pipelineUuid)
+			if tt.status != pb.Status_STATUS_UNSPECIFIED {
+				if err := cacheService.SetValue(context.Background(), pipelineId, cache.Status, tt.status); err != nil {
+					t.Fatal(err)
+				}
+			}
+			response, err := controller.RunCode(context.Background(), &pb.RunCodeRequest{Code: "MOCK_CODE", Sdk: pb.Sdk_SDK_JAVA, OutputCharset: tt.outputCharset, PipelineUuid: pipelineUuid})
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("RunCode() error code = %s, want %s", code, tt.wantCode)
+			}
+			if err == nil && response.PipelineUuid != pipelineUuid {
+				t.Errorf("RunCode() pipeline id = %s, want %s", response.PipelineUuid, pipelineUuid)
+			}
+			value, err := cacheService.GetValue(context.Background(), pipelineId, cache.Status)
+			if tt.wantStatus == pb.Status_STATUS_UNSPECIFIED && err == nil {
+				t.Errorf("RunCode() status = %v, want the pipeline id to be released", value)
+			}
+			if tt.wantStatus != pb.Status_STATUS_UNSPECIFIED && value != tt.wantStatus {
+				t.Errorf("RunCode() status = %v, want %s", value, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestPlaygroundController_RunCodeBatch(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	type args struct {
+		ctx     context.Context
+		request *pb.RunCodeBatchRequest
+	}
+	tests := []struct {
+		name        string
+		args        args
+		wantResults int
+		wantErrors  []bool
+		// wantStatuses are statuses of rejected submissions by their indexes
+		wantStatuses map[int]pb.Status
+	}{
+		{
+			// Test case with calling RunCodeBatch method without submissions.
+			// As a result, want to receive an empty response.
+			name: "empty batch",
+			args: args{
+				ctx:     ctx,
+				request: &pb.RunCodeBatchRequest{},
+			},
+			wantResults: 0,
+			wantErrors:  []bool{},
+		},
+		{
+			// Test case with calling RunCodeBatch method with rejected and correct submissions.
+			// As a result, want to receive the error with the validation error status for the rejected submission and the finished status for the correct submission.
+			name: "batch with rejected submission",
+			args: args{
+				ctx: ctx,
+				request: &pb.RunCodeBatchRequest{Submissions: []*pb.RunCodeRequest{
+					{Code: "MOCK_CODE", Sdk: pb.Sdk_SDK_UNSPECIFIED},
+					{Code: "MOCK_BATCH_CODE", Sdk: pb.Sdk_SDK_JAVA},
+				}},
+			},
+			wantResults:  2,
+			wantErrors:   []bool{true, false},
+			wantStatuses: map[int]pb.Status{0: pb.Status_STATUS_VALIDATION_ERROR},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn, err := grpc.DialContext(tt.args.ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
+			if err != nil {
+				t.Fatalf("Failed to dial bufnet: %v", err)
+			}
+			defer conn.Close()
+			client := pb.NewPlaygroundServiceClient(conn)
+			response, err := client.RunCodeBatch(tt.args.ctx, tt.args.request)
+			os.RemoveAll(filepath.Join(os.Getenv("APP_WORK_DIR"), "executable_files"))
+			if err != nil {
+				t.Fatalf("PlaygroundController_RunCodeBatch() error = %v", err)
+			}
+			if len(response.Results) != tt.wantResults {
+				t.Fatalf("PlaygroundController_RunCodeBatch() got %d results, want %d", len(response.Results), tt.wantResults)
+			}
+			for i, result := range response.Results {
+				if (result.Error != "") != tt.wantErrors[i] {
+					t.Errorf("PlaygroundController_RunCodeBatch() result %d error = %s, wantError %v", i, result.Error, tt.wantErrors[i])
+				}
+				if wantStatus, found := tt.wantStatuses[i]; found && result.Status != wantStatus {
+					t.Errorf("PlaygroundController_RunCodeBatch() result %d status = %s, want %s", i, result.Status, wantStatus)
+				}
+				if !tt.wantErrors[i] && !code_processing.IsFinished(result.Status) {
+					t.Errorf("PlaygroundController_RunCodeBatch() result %d status = %s, want finished status", i, result.Status)
+				}
+			}
+		})
+	}
+}
+
+func TestPlaygroundController_RunCodeGroup(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	rejectedStatus := pb.Status_STATUS_VALIDATION_ERROR
+	type args struct {
+		ctx     context.Context
+		request *pb.RunCodeGroupRequest
+	}
+	tests := []struct {
+		name        string
+		args        args
+		wantGroupId string
+		wantStatus  *pb.Status
+		wantErrors  []bool
+		wantErr     bool
+	}{
+		{
+			// Test case with calling RunCodeGroup method without the group id.
+			// As a result, want to receive the generated group id and the finished status of the group.
+			name: "group without id",
+			args: args{
+				ctx: ctx,
+				request: &pb.RunCodeGroupRequest{Variants: []*pb.RunCodeRequest{
+					{Code: "MOCK_GROUP_CODE", Sdk: pb.Sdk_SDK_JAVA},
+				}},
+			},
+			wantGroupId: "",
+			wantStatus:  nil,
+			wantErrors:  []bool{false},
+		},
+		{
+			// Test case with calling RunCodeGroup method with the variant which is rejected by the server.
+			// As a result, want to receive the error for the rejected variant, its status as the status of the group
+			// and the group of the accepted variant without the rejected one.
+			name: "group with rejected variant",
+			args: args{
+				ctx: ctx,
+				request: &pb.RunCodeGroupRequest{GroupId: "MOCK_GROUP_ID", Variants: []*pb.RunCodeRequest{
+					{Code: "MOCK_GROUP_CODE", Sdk: pb.Sdk_SDK_JAVA, OutputCharset: "MOCK_CHARSET"},
+					{Code: "MOCK_ANOTHER_GROUP_CODE", Sdk: pb.Sdk_SDK_JAVA},
+				}},
+			},
+			wantGroupId: "MOCK_GROUP_ID",
+			wantStatus:  &rejectedStatus,
+			wantErrors:  []bool{true, false},
+		},
+		{
+			// Test case with calling RunCodeGroup method with the variant of sdk which isn't processed by the server.
+			// As a result, want to receive codes.InvalidArgument without submitting any variant.
+			name: "group with variant of another sdk",
+			args: args{
+				ctx: ctx,
+				request: &pb.RunCodeGroupRequest{Variants: []*pb.RunCodeRequest{
+					{Code: "MOCK_GROUP_CODE", Sdk: pb.Sdk_SDK_PYTHON},
+					{Code: "MOCK_ANOTHER_GROUP_CODE", Sdk: pb.Sdk_SDK_JAVA},
+				}},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn, err := grpc.DialContext(tt.args.ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
+			if err != nil {
+				t.Fatalf("Failed to dial bufnet: %v", err)
+			}
+			defer conn.Close()
+			client := pb.NewPlaygroundServiceClient(conn)
+			response, err := client.RunCodeGroup(tt.args.ctx, tt.args.request)
+			os.RemoveAll(filepath.Join(os.Getenv("APP_WORK_DIR"), "executable_files"))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("PlaygroundController_RunCodeGroup() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if tt.wantGroupId != "" && response.GroupId != tt.wantGroupId {
+				t.Errorf("PlaygroundController_RunCodeGroup() group id = %s, want %s", response.GroupId, tt.wantGroupId)
+			}
+			if response.GroupId == "" {
+				t.Error("PlaygroundController_RunCodeGroup() group id is empty")
+			}
+			if tt.wantStatus != nil && response.Status != *tt.wantStatus {
+				t.Errorf("PlaygroundController_RunCodeGroup() status = %s, want %s", response.Status, tt.wantStatus)
+			}
+			if tt.wantStatus == nil && !code_processing.IsFinished(response.Status) {
+				t.Errorf("PlaygroundController_RunCodeGroup() status = %s, want finished status", response.Status)
+			}
+			if len(response.Results) != len(tt.wantErrors) {
+				t.Fatalf("PlaygroundController_RunCodeGroup() got %d results, want %d", len(response.Results), len(tt.wantErrors))
+			}
+			for i, result := range response.Results {
+				if (result.Error != "") != tt.wantErrors[i] {
+					t.Errorf("PlaygroundController_RunCodeGroup() result %d error = %s, wantError %v", i, result.Error, tt.wantErrors[i])
+				}
+				if tt.wantErrors[i] {
+					continue
+				}
+				group, err := code_processing.GetPipelineGroup(context.Background(), cacheService, uuid.MustParse(result.PipelineUuid), "")
+				if err != nil {
+					t.Fatalf("PlaygroundController_RunCodeGroup() result %d group isn't saved: %v", i, err)
+				}
+				if group.Id != response.GroupId || !reflect.DeepEqual(group.PipelineIds, []string{result.PipelineUuid}) {
+					t.Errorf("PlaygroundController_RunCodeGroup() result %d group = %v, want group %s of the pipeline", i, group, response.GroupId)
+				}
+			}
+		})
+	}
+}
+
+func TestPlaygroundController_CheckStatus(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	ctx := context.Background()
+	pipelineId := uuid.New()
+	canceledPipelineId := uuid.New()
+	wantStatus := pb.Status_STATUS_FINISHED
+	canceledStatus := pb.Status_STATUS_CANCELED
+	timeoutPipelineId := uuid.New()
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+	client := pb.NewPlaygroundServiceClient(conn)
+
+	type args struct {
+		ctx     context.Context
+		request *pb.CheckStatusRequest
+	}
+	tests := []struct {
+		name                string
+		prepare             func()
+		args                args
+		wantStatus          *pb.Status
+		wantProgress        int32
+		wantCompileDuration int64
+		wantRunDuration     int64
+		wantCancelReason    pb.CancelReason
+		wantExecuteTimeout  int64
+		wantErr             bool
+	}{
+		{
+			// Test case with calling CheckStatus method with incorrect pipelineId.
+			// As a result, want to receive an error
+			name:    "incorrect pipelineId",
+			prepare: func() {},
+			args: args{
+				ctx:     ctx,
+				request: &pb.CheckStatusRequest{PipelineUuid: "NO_UUID_STRING"},
+			},
+			wantStatus: nil,
+			wantErr:    true,
+		},
+		{
+			// Test case with calling CheckStatus method with pipelineId which doesn't exist.
+			// As a result, want to receive an error.
+			name:    "status is not set",
+			prepare: func() {},
+			args: args{
+				ctx:     ctx,
+				request: &pb.CheckStatusRequest{PipelineUuid: pipelineId.String()},
+			},
+			wantStatus: nil,
+			wantErr:    true,
+		},
+		{
+			// Test case with calling CheckStatus method with pipelineId which contains status without progress.
+			// As a result, want to receive an expected status and unknown progress.
+			name: "status exists",
+			prepare: func() {
+				_ = cacheService.SetValue(ctx, pipelineId, cache.Status, wantStatus)
+			},
+			args: args{
+				ctx:     ctx,
+				request: &pb.CheckStatusRequest{PipelineUuid: pipelineId.String()},
+			},
+			wantStatus:   &wantStatus,
+			wantProgress: -1,
+			wantErr:      false,
+		},
+		{
+			// Test case with calling CheckStatus method with pipelineId which contains status and progress.
+			// As a result, want to receive an expected status and progress.
+			name: "status and progress exist",
+			prepare: func() {
+				_ = cacheService.SetValue(ctx, pipelineId, cache.Progress, 100)
+			},
+			args: args{
+				ctx:     ctx,
+				request: &pb.CheckStatusRequest{PipelineUuid: pipelineId.String()},
+			},
+			wantStatus:   &wantStatus,
+			wantProgress: 100,
+			wantErr:      false,
+		},
+		{
+			// Test case with calling CheckStatus method with pipelineId which contains durations of compile and run steps.
+			// As a result, want to receive an expected status, progress and durations.
+			name: "durations exist",
+			prepare: func() {
+				_ = cacheService.SetValue(ctx, pipelineId, cache.CompileDuration, 3000)
+				_ = cacheService.SetValue(ctx, pipelineId, cache.RunDuration, 5000)
+			},
+			args: args{
+				ctx:     ctx,
+				request: &pb.CheckStatusRequest{PipelineUuid: pipelineId.String()},
+			},
+			wantStatus:          &wantStatus,
+			wantProgress:        100,
+			wantCompileDuration: 3000,
+			wantRunDuration:     5000,
+			wantErr:             false,
+		},
+		{
+			// Test case with calling CheckStatus method with pipelineId which is canceled by server shutdown.
+			// As a result, want to receive canceled status and the reason of the cancellation.
+			name: "cancel reason exists",
+			prepare: func() {
+				_ = cacheService.SetValue(ctx, canceledPipelineId, cache.Status, canceledStatus)
+				_ = cacheService.SetValue(ctx, canceledPipelineId, cache.CancelReason, pb.CancelReason_CANCEL_REASON_SHUTDOWN)
+			},
+			args: args{
+				ctx:     ctx,
+				request: &pb.CheckStatusRequest{PipelineUuid: canceledPipelineId.String()},
+			},
+			wantStatus:       &canceledStatus,
+			wantProgress:     -1,
+			wantCancelReason: pb.CancelReason_CANCEL_REASON_SHUTDOWN,
+			wantErr:          false,
+		},
+		{
+			// Test case with calling CheckStatus method with pipelineId which contains the effective timeout of code processing.
+			// As a result, want to receive an expected status and timeout.
+			name: "execute timeout exists",
+			prepare: func() {
+				_ = cacheService.SetValue(ctx, timeoutPipelineId, cache.Status, wantStatus)
+				_ = cacheService.SetValue(ctx, timeoutPipelineId, cache.ExecuteTimeout, 60000)
+			},
+			args: args{
+				ctx:     ctx,
+				request: &pb.CheckStatusRequest{PipelineUuid: timeoutPipelineId.String()},
+			},
+			wantStatus:         &wantStatus,
+			wantProgress:       -1,
+			wantExecuteTimeout: 60000,
+			wantErr:            false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.prepare()
+			got, err := client.CheckStatus(ctx, tt.args.request)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PlaygroundController_CheckStatus() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got == nil && tt.wantStatus != nil {
+				t.Errorf("PlaygroundController_CheckStatus() return = %v, want response with status %v", got, tt.wantStatus)
+			}
+			if got != nil && !reflect.DeepEqual(got.Status, *tt.wantStatus) {
+				t.Errorf("PlaygroundController_CheckStatus() return status = %v, want status %v", got.Status, tt.wantStatus)
+			}
+			if got != nil && got.Progress != tt.wantProgress {
+				t.Errorf("PlaygroundController_CheckStatus() return progress = %v, want progress %v", got.Progress, tt.wantProgress)
+			}
+			if got != nil && (got.CompileDurationMs != tt.wantCompileDuration || got.RunDurationMs != tt.wantRunDuration) {
+				t.Errorf("PlaygroundController_CheckStatus() return durations = %v, %v, want durations %v, %v", got.CompileDurationMs, got.RunDurationMs, tt.wantCompileDuration, tt.wantRunDuration)
+			}
+			if got != nil && got.CancelReason != tt.wantCancelReason {
+				t.Errorf("PlaygroundController_CheckStatus() return cancel reason = %v, want cancel reason %v", got.CancelReason, tt.wantCancelReason)
+			}
+			if got != nil && got.ExecuteTimeoutMs != tt.wantExecuteTimeout {
+				t.Errorf("PlaygroundController_CheckStatus() return execute timeout = %v, want execute timeout %v", got.ExecuteTimeoutMs, tt.wantExecuteTimeout)
+			}
+		})
+	}
+}
+
+func TestPlaygroundController_GetCompileOutput(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	ctx := context.Background()
+	pipelineId := uuid.New()
+	diagnosticsPipelineId := uuid.New()
+	compileOutput := "MOCK_COMPILE_OUTPUT"
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+	client := pb.NewPlaygroundServiceClient(conn)
+
+	type args struct {
+		ctx  context.Context
+		info *pb.GetCompileOutputRequest
+	}
+	tests := []struct {
+		name    string
+		prepare func()
+		args    args
+		want    *pb.GetCompileOutputResponse
+		wantErr bool
+	}{
+		{
+			// Test case with calling GetCompileOutput method with incorrect pipelineId.
+			// As a result, want to receive an error
+			name:    "incorrect pipelineId",
+			prepare: func() {},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetCompileOutputRequest{PipelineUuid: "NO_UUID_STRING"},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			// Test case with calling GetCompileOutput method with pipelineId which doesn't exist.
+			// As a result, want to receive an error.
+			name:    "pipelineId doesn't exist",
+			prepare: func() {},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetCompileOutputRequest{PipelineUuid: pipelineId.String()},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			// Test case with calling GetCompileOutput method with pipelineId which contains compile output.
+			// As a result, want to receive an expected compile output.
+			name: "compile output exist",
+			prepare: func() {
+				_ = cacheService.SetValue(ctx, pipelineId, cache.CompileOutput, compileOutput)
+			},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetCompileOutputRequest{PipelineUuid: pipelineId.String()},
+			},
+			want:    &pb.GetCompileOutputResponse{Output: compileOutput},
+			wantErr: false,
+		},
+		{
+			// Test case with calling GetCompileOutput method for the incremental output with pipelineId which index of compile output is 5.
+			// As a result, want to receive the compile output after the index.
+			name: "incremental compile output",
+			prepare: func() {
+				_ = cacheService.SetValue(ctx, pipelineId, cache.CompileOutputIndex, 5)
+			},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetCompileOutputRequest{PipelineUuid: pipelineId.String(), Incremental: true},
+			},
+			want:    &pb.GetCompileOutputResponse{Output: compileOutput[5:]},
+			wantErr: false,
+		},
+		{
+			// Test case with calling GetCompileOutput method for the incremental output which has been already received.
+			// As a result, want to receive an empty compile output.
+			name:    "no new compile output",
+			prepare: func() {},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetCompileOutputRequest{PipelineUuid: pipelineId.String(), Incremental: true},
+			},
+			want:    &pb.GetCompileOutputResponse{Output: ""},
+			wantErr: false,
+		},
+		{
+			// Test case with calling GetCompileOutput method with pipelineId which contains compile output, diagnostics and warnings.
+			// As a result, want to receive an expected compile output with diagnostics grouped by source files and warnings.
+			name: "compile diagnostics exist",
+			prepare: func() {
+				_ = cacheService.SetValue(ctx, diagnosticsPipelineId, cache.CompileOutput, compileOutput)
+				_ = cacheService.SetValue(ctx, diagnosticsPipelineId, cache.CompileDiagnostics, []cache.FileDiagnostics{
+					{FileName: "Main.java", Diagnostics: []cache.Diagnostic{{Line: 3, Message: "error: ';' expected"}}},
+				})
+				_ = cacheService.SetValue(ctx, diagnosticsPipelineId, cache.CompileWarnings, []cache.CompileWarning{
+					{FileName: "Main.java", Line: 7, Category: "deprecated API", Message: "MOCK_WARNING"},
+				})
+			},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetCompileOutputRequest{PipelineUuid: diagnosticsPipelineId.String()},
+			},
+			want: &pb.GetCompileOutputResponse{
+				Output: compileOutput,
+				Diagnostics: []*pb.FileDiagnostics{
+					{FileName: "Main.java", Diagnostics: []*pb.Diagnostic{{Line: 3, Message: "error: ';' expected"}}},
+				},
+				Warnings: []*pb.CompileWarning{
+					{FileName: "Main.java", Line: 7, Category: "deprecated API", Message: "MOCK_WARNING"},
+				},
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.prepare()
+			got, err := client.GetCompileOutput(tt.args.ctx, tt.args.info)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetCompileOutput() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr {
+				if !strings.EqualFold(got.Output, tt.want.Output) {
+					t.Errorf("GetCompileOutput() got = %v, want %v", got.Output, tt.want.Output)
+				}
+				if !proto.Equal(&pb.GetCompileOutputResponse{Diagnostics: got.Diagnostics}, &pb.GetCompileOutputResponse{Diagnostics: tt.want.Diagnostics}) {
+					t.Errorf("GetCompileOutput() diagnostics got = %v, want %v", got.Diagnostics, tt.want.Diagnostics)
+				}
+				if !proto.Equal(&pb.GetCompileOutputResponse{Warnings: got.Warnings}, &pb.GetCompileOutputResponse{Warnings: tt.want.Warnings}) {
+					t.Errorf("GetCompileOutput() warnings got = %v, want %v", got.Warnings, tt.want.Warnings)
+				}
+			}
+		})
+	}
+}
+
+func TestPlaygroundController_GetRunOutput(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	ctx := context.Background()
+	pipelineId := uuid.New()
+	runOutput := "MOCK_RUN_OUTPUT"
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+	client := pb.NewPlaygroundServiceClient(conn)
+
+	type args struct {
+		ctx  context.Context
+		info *pb.GetRunOutputRequest
+	}
+	tests := []struct {
+		name    string
+		prepare func()
+		args    args
+		want    *pb.GetRunOutputResponse
+		wantErr bool
+	}{
+		{
+			// Test case with calling GetRunOutput method with incorrect pipelineId.
+			// As a result, want to receive an error
+			name:    "incorrect pipelineId",
+			prepare: func() {},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetRunOutputRequest{PipelineUuid: "NO_UUID_STRING"},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			// Test case with calling GetRunOutput method with pipelineId which doesn't exist.
+			// As a result, want to receive an error.
+			name:    "pipelineId doesn't exist",
+			prepare: func() {},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetRunOutputRequest{PipelineUuid: pipelineId.String()},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			// Test case with calling GetRunOutput method with pipelineId which doesn't contain run output.
+			// As a result, want to receive an error.
+			name: "run output doesn't exist",
+			prepare: func() {
+				_ = cacheService.SetValue(ctx, pipelineId, cache.Status, pb.Status_STATUS_EXECUTING)
+			},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetRunOutputRequest{PipelineUuid: pipelineId.String()},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			// Test case with calling GetRunOutput method with pipelineId which contains run output.
+			// As a result want to receive response with an expected run output.
+			name: "run output exist",
+			prepare: func() {
+				_ = cacheService.SetValue(ctx, pipelineId, cache.RunOutputIndex, 0)
+				_ = cacheService.SetValue(ctx, pipelineId, cache.RunOutput, runOutput)
+			},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetRunOutputRequest{PipelineUuid: pipelineId.String()},
+			},
+			want:    &pb.GetRunOutputResponse{Output: runOutput},
+			wantErr: false,
+		},
+		{
+			// Test case with calling RunOutput method with pipelineId which contain run output and index of run output is 1.
+			// As a result want to receive response with correct output (output[1:]).
+			name: "get the second part",
+			prepare: func() {
+				_ = cacheService.SetValue(ctx, pipelineId, cache.RunOutputIndex, 1)
+				_ = cacheService.SetValue(ctx, pipelineId, cache.RunOutput, runOutput)
+			},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetRunOutputRequest{PipelineUuid: pipelineId.String()},
+			},
+			want:    &pb.GetRunOutputResponse{Output: runOutput[1:]},
+			wantErr: false,
+		},
+		{
+			// Test case with calling RunOutput method with preview and pipelineId which contains long run output and index of run output is 1.
+			// As a result want to receive response with the start and the end of the whole output.
+			name: "get the preview",
+			prepare: func() {
+				_ = cacheService.SetValue(ctx, pipelineId, cache.RunOutputIndex, 1)
+				_ = cacheService.SetValue(ctx, pipelineId, cache.RunOutput, strings.Repeat("1", 1024)+strings.Repeat("2", 1000)+strings.Repeat("3", 1024))
+			},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetRunOutputRequest{PipelineUuid: pipelineId.String(), Preview: true},
+			},
+			want:    &pb.GetRunOutputResponse{Output: strings.Repeat("1", 1024) + fmt.Sprintf(code_processing.OutputPreviewMarkerFormat, 1000) + strings.Repeat("3", 1024)},
+			wantErr: false,
+		},
+		{
+			// Test case with calling RunOutput method with raw and pipelineId which contains the run output without log lines and index of run output is 1.
+			// As a result want to receive response with the whole output with log lines.
+			name: "get the raw output",
+			prepare: func() {
+				_ = cacheService.SetValue(ctx, pipelineId, cache.RunOutputIndex, 1)
+				_ = cacheService.SetValue(ctx, pipelineId, cache.RunOutput, runOutput)
+				_ = cacheService.SetValue(ctx, pipelineId, cache.RawRunOutput, "INFO: MOCK_LOG\n"+runOutput)
+			},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetRunOutputRequest{PipelineUuid: pipelineId.String(), Raw: true},
+			},
+			want:    &pb.GetRunOutputResponse{Output: "INFO: MOCK_LOG\n" + runOutput},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.prepare()
+			got, err := client.GetRunOutput(tt.args.ctx, tt.args.info)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetRunOutput() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr {
+				if !strings.EqualFold(got.Output, tt.want.Output) {
+					t.Errorf("GetRunOutput() got = %v, want %v", got.Output, tt.want.Output)
+				}
+			}
+		})
+	}
+}
+
+func TestPlaygroundController_GetTestResults(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	ctx := context.Background()
+	pipelineId := uuid.New()
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+	client := pb.NewPlaygroundServiceClient(conn)
+
+	type args struct {
+		ctx  context.Context
+		info *pb.GetTestResultsRequest
+	}
+	tests := []struct {
+		name    string
+		prepare func()
+		args    args
+		want    map[string]bool
+		wantErr bool
+	}{
+		{
+			// Test case with calling GetTestResults method with incorrect pipelineId.
+			// As a result, want to receive an error
+			name:    "incorrect pipelineId",
+			prepare: func() {},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetTestResultsRequest{PipelineUuid: "NO_UUID_STRING"},
+			},
+			wantErr: true,
+		},
+		{
+			// Test case with calling GetTestResults method with pipelineId which doesn't contain test results.
+			// As a result, want to receive an error.
+			name:    "test results don't exist",
+			prepare: func() {},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetTestResultsRequest{PipelineUuid: pipelineId.String()},
+			},
+			wantErr: true,
+		},
+		{
+			// Test case with calling GetTestResults method with pipelineId which contains test results.
+			// As a result want to receive response with all test results.
+			name: "test results exist",
+			prepare: func() {
+				_ = cacheService.SetValue(ctx, pipelineId, cache.TestResults, []cache.TestResult{{Name: "TestSum", Passed: true}, {Name: "TestDiv", Passed: false}})
+			},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetTestResultsRequest{PipelineUuid: pipelineId.String()},
+			},
+			want:    map[string]bool{"TestSum": true, "TestDiv": false},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.prepare()
+			got, err := client.GetTestResults(tt.args.ctx, tt.args.info)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetTestResults() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr {
+				results := make(map[string]bool)
+				for _, result := range got.Results {
+					results[result.Name] = result.Passed
+				}
+				if !reflect.DeepEqual(results, tt.want) {
+					t.Errorf("GetTestResults() got = %v, want %v", results, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestPlaygroundController_GetOutputFiles(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	ctx := context.Background()
+	pipelineId := uuid.New()
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+	client := pb.NewPlaygroundServiceClient(conn)
+
+	outputFiles := []cache.OutputFile{
+		{Name: "output.txt", Content: "MOCK_CONTENT"},
+		{Name: "output.bin", Error: "MOCK_ERROR"},
+	}
+
+	type args struct {
+		ctx  context.Context
+		info *pb.GetOutputFilesRequest
+	}
+	tests := []struct {
+		name    string
+		prepare func()
+		args    args
+		want    []cache.OutputFile
+		wantErr bool
+	}{
+		{
+			// Test case with calling GetOutputFiles method with incorrect pipelineId.
+			// As a result, want to receive an error
+			name:    "incorrect pipelineId",
+			prepare: func() {},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetOutputFilesRequest{PipelineUuid: "NO_UUID_STRING"},
+			},
+			wantErr: true,
+		},
+		{
+			// Test case with calling GetOutputFiles method with pipelineId which doesn't contain output files.
+			// As a result, want to receive an error.
+			name:    "output files don't exist",
+			prepare: func() {},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetOutputFilesRequest{PipelineUuid: pipelineId.String()},
+			},
+			wantErr: true,
+		},
+		{
+			// Test case with calling GetOutputFiles method with pipelineId which contains output files.
+			// As a result want to receive response with all output files.
+			name: "output files exist",
+			prepare: func() {
+				_ = cacheService.SetValue(ctx, pipelineId, cache.OutputFiles, outputFiles)
+			},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetOutputFilesRequest{PipelineUuid: pipelineId.String()},
+			},
+			want:    outputFiles,
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.prepare()
+			got, err := client.GetOutputFiles(tt.args.ctx, tt.args.info)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetOutputFiles() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr {
+				files := make([]cache.OutputFile, 0, len(got.Files))
+				for _, file := range got.Files {
+					files = append(files, cache.OutputFile{Name: file.Name, Content: file.Content, Error: file.Error})
+				}
+				if !reflect.DeepEqual(files, tt.want) {
+					t.Errorf("GetOutputFiles() got = %v, want %v", files, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestPlaygroundController_GetRunRecords(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	ctx := context.Background()
+	pipelineId := uuid.New()
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+	client := pb.NewPlaygroundServiceClient(conn)
+
+	records := []cache.RunRecord{
+		{Line: 1, Json: `{"word":"MOCK_WORD","count":1}`},
+		{Line: 3, Json: "MOCK_LINE", Malformed: true},
+	}
+
+	type args struct {
+		ctx  context.Context
+		info *pb.GetRunRecordsRequest
+	}
+	tests := []struct {
+		name    string
+		prepare func()
+		args    args
+		want    []cache.RunRecord
+		wantErr bool
+	}{
+		{
+			// Test case with calling GetRunRecords method with incorrect pipelineId.
+			// As a result, want to receive an error
+			name:    "incorrect pipelineId",
+			prepare: func() {},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetRunRecordsRequest{PipelineUuid: "NO_UUID_STRING"},
+			},
+			wantErr: true,
+		},
+		{
+			// Test case with calling GetRunRecords method with pipelineId which doesn't contain run records.
+			// As a result, want to receive an error.
+			name:    "run records don't exist",
+			prepare: func() {},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetRunRecordsRequest{PipelineUuid: pipelineId.String()},
+			},
+			wantErr: true,
+		},
+		{
+			// Test case with calling GetRunRecords method with pipelineId which contains run records.
+			// As a result want to receive response with all records including malformed ones.
+			name: "run records exist",
+			prepare: func() {
+				_ = cacheService.SetValue(ctx, pipelineId, cache.RunRecords, records)
+			},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetRunRecordsRequest{PipelineUuid: pipelineId.String()},
+			},
+			want:    records,
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.prepare()
+			got, err := client.GetRunRecords(tt.args.ctx, tt.args.info)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetRunRecords() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr {
+				records := make([]cache.RunRecord, 0, len(got.Records))
+				for _, record := range got.Records {
+					records = append(records, cache.RunRecord{Line: int(record.Line), Json: record.Json, Malformed: record.Malformed})
+				}
+				if !reflect.DeepEqual(records, tt.want) {
+					t.Errorf("GetRunRecords() got = %v, want %v", records, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestPlaygroundController_StreamRunOutput(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+	client := pb.NewPlaygroundServiceClient(conn)
+
+	runOutput := "MOCK_RUN_OUTPUT"
+	newRunOutput := "MOCK_NEW_RUN_OUTPUT"
+
+	type args struct {
+		ctx  context.Context
+		info *pb.StreamRunOutputRequest
+	}
+	tests := []struct {
+		name string
+		// prepare saves values into cache for the pipeline
+		prepare func(pipelineId uuid.UUID)
+		// onResponse is called after each received response
+		onResponse func(pipelineId uuid.UUID)
+		fromIndex  int64
+		want       string
+		wantIndex  int64
+		wantErr    bool
+	}{
+		{
+			// Test case with calling StreamRunOutput method with pipelineId which doesn't exist.
+			// As a result, want to receive an error.
+			name:       "pipelineId doesn't exist",
+			prepare:    func(pipelineId uuid.UUID) {},
+			onResponse: func(pipelineId uuid.UUID) {},
+			wantErr:    true,
+		},
+		{
+			// Test case with calling StreamRunOutput method with pipelineId which is finished and index of GetRunOutput is 1.
+			// As a result, want to receive the whole output and the end of the stream.
+			name: "pipeline is finished",
+			prepare: func(pipelineId uuid.UUID) {
+				_ = cacheService.SetValue(ctx, pipelineId, cache.Status, pb.Status_STATUS_FINISHED)
+				_ = cacheService.SetValue(ctx, pipelineId, cache.RunOutputIndex, 1)
+				_ = cacheService.SetValue(ctx, pipelineId, cache.RunOutput, runOutput)
+			},
+			onResponse: func(pipelineId uuid.UUID) {},
+			want:       runOutput,
+			wantIndex:  int64(len(runOutput)),
+		},
+		{
+			// Test case with calling StreamRunOutput method with the index to resume the stream from.
+			// As a result, want to receive the output since the requested index.
+			name: "stream is resumed from the index",
+			prepare: func(pipelineId uuid.UUID) {
+				_ = cacheService.SetValue(ctx, pipelineId, cache.Status, pb.Status_STATUS_FINISHED)
+				_ = cacheService.SetValue(ctx, pipelineId, cache.RunOutputIndex, 1)
+				_ = cacheService.SetValue(ctx, pipelineId, cache.RunOutput, runOutput)
+			},
+			onResponse: func(pipelineId uuid.UUID) {},
+			fromIndex:  5,
+			want:       runOutput[5:],
+			wantIndex:  int64(len(runOutput)),
+		},
+		{
+			// Test case with calling StreamRunOutput method with pipelineId which is executing and writes the output after the first response.
+			// As a result, want to receive the whole output and the end of the stream after the pipeline is finished.
+			name: "output is written during the stream",
+			prepare: func(pipelineId uuid.UUID) {
+				_ = cacheService.SetValue(ctx, pipelineId, cache.Status, pb.Status_STATUS_EXECUTING)
+				_ = cacheService.SetValue(ctx, pipelineId, cache.RunOutputIndex, 1)
+				_ = cacheService.SetValue(ctx, pipelineId, cache.RunOutput, runOutput)
+			},
+			onResponse: func(pipelineId uuid.UUID) {
+				writer := &streaming.RunOutputWriter{Ctx: ctx, CacheService: cacheService, PipelineId: pipelineId}
+				_, _ = writer.Write([]byte(newRunOutput))
+				code_processing.SetStatus(ctx, cacheService, pipelineId, pb.Status_STATUS_FINISHED)
+			},
+			want:      runOutput + newRunOutput,
+			wantIndex: int64(len(runOutput + newRunOutput)),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pipelineId := uuid.New()
+			tt.prepare(pipelineId)
+			stream, err := client.StreamRunOutput(ctx, &pb.StreamRunOutputRequest{PipelineUuid: pipelineId.String(), FromIndex: tt.fromIndex})
+			if err != nil {
+				t.Fatalf("StreamRunOutput() error = %v", err)
+			}
+			var got string
+			var gotIndex int64
+			for {
+				response, err := stream.Recv()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					if !tt.wantErr {
+						t.Errorf("StreamRunOutput() error = %v, wantErr %v", err, tt.wantErr)
+					}
+					return
+				}
+				got += response.Output
+				gotIndex = response.Index
+				tt.onResponse(pipelineId)
+			}
+			if tt.wantErr {
+				t.Errorf("StreamRunOutput() error = nil, wantErr %v", tt.wantErr)
+				return
+			}
+			if got != tt.want || gotIndex != tt.wantIndex {
+				t.Errorf("StreamRunOutput() got = %s with index %d, want %s with index %d", got, gotIndex, tt.want, tt.wantIndex)
+			}
+			lastIndex, err := cacheService.GetValue(ctx, pipelineId, cache.RunOutputIndex)
+			if err != nil || lastIndex != 1 {
+				t.Errorf("StreamRunOutput() index of GetRunOutput = %v, want %d", lastIndex, 1)
+			}
+		})
+	}
+}
+
+func TestPlaygroundController_GetArtifact(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	ctx := context.Background()
+	pipelineId := uuid.New()
+	tooLargePipelineId := uuid.New()
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+	client := pb.NewPlaygroundServiceClient(conn)
+
+	content := []byte(strings.Repeat("MOCK_CONTENT", artifactChunkSize/4))
+
+	type args struct {
+		ctx  context.Context
+		info *pb.GetArtifactRequest
+	}
+	tests := []struct {
+		name       string
+		prepare    func()
+		args       args
+		wantName   string
+		want       []byte
+		wantChunks int
+		wantErr    bool
+	}{
+		{
+			// Test case with calling GetArtifact method with incorrect pipelineId.
+			// As a result, want to receive an error.
+			name:    "incorrect pipelineId",
+			prepare: func() {},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetArtifactRequest{PipelineUuid: "NO_UUID_STRING"},
+			},
+			wantErr: true,
+		},
+		{
+			// Test case with calling GetArtifact method with pipelineId which doesn't contain the artifact.
+			// As a result, want to receive an error.
+			name:    "artifact doesn't exist",
+			prepare: func() {},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetArtifactRequest{PipelineUuid: pipelineId.String()},
+			},
+			wantErr: true,
+		},
+		{
+			// Test case with calling GetArtifact method with pipelineId which artifact is too large to be downloaded.
+			// As a result, want to receive an error.
+			name: "artifact is too large",
+			prepare: func() {
+				_ = cacheService.SetValue(ctx, tooLargePipelineId, cache.CompiledArtifact, cache.Artifact{Name: "MOCK_NAME", Error: "MOCK_ERROR"})
+			},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetArtifactRequest{PipelineUuid: tooLargePipelineId.String()},
+			},
+			wantErr: true,
+		},
+		{
+			// Test case with calling GetArtifact method with pipelineId which contains the artifact.
+			// As a result, want to receive the name and content of the artifact by several chunks.
+			name: "artifact exists",
+			prepare: func() {
+				_ = cacheService.SetValue(ctx, pipelineId, cache.CompiledArtifact, cache.Artifact{Name: "MOCK_NAME", Content: content})
+			},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetArtifactRequest{PipelineUuid: pipelineId.String()},
+			},
+			wantName:   "MOCK_NAME",
+			want:       content,
+			wantChunks: 3,
+			wantErr:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.prepare()
+			stream, err := client.GetArtifact(tt.args.ctx, tt.args.info)
+			if err != nil {
+				t.Fatalf("GetArtifact() error = %v", err)
+			}
+			var name string
+			var got []byte
+			chunks := 0
+			for {
+				chunk, err := stream.Recv()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					if !tt.wantErr {
+						t.Errorf("GetArtifact() error = %v, wantErr %v", err, tt.wantErr)
+					}
+					return
+				}
+				if chunks == 0 {
+					name = chunk.Name
+				}
+				got = append(got, chunk.Content...)
+				chunks++
+			}
+			if tt.wantErr {
+				t.Errorf("GetArtifact() error = nil, wantErr %v", tt.wantErr)
+				return
+			}
+			if name != tt.wantName || !reflect.DeepEqual(got, tt.want) || chunks != tt.wantChunks {
+				t.Errorf("GetArtifact() got name = %s, %d bytes by %d chunks, want name = %s, %d bytes by %d chunks", name, len(got), chunks, tt.wantName, len(tt.want), tt.wantChunks)
+			}
+		})
+	}
+}
+
+func TestPlaygroundController_GetMetrics(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	ctx := context.Background()
+	pipelineId := uuid.New()
+	points := []cache.MetricPoint{{Timestamp: time.Unix(1, 0), ElementCount: 10}, {Timestamp: time.Unix(2, 0), ElementCount: 20}}
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+	client := pb.NewPlaygroundServiceClient(conn)
+
+	type args struct {
+		ctx  context.Context
+		info *pb.GetMetricsRequest
+	}
+	tests := []struct {
+		name    string
+		prepare func()
+		args    args
+		want    []int64
+		wantErr bool
+	}{
+		{
+			// Test case with calling GetMetrics method with incorrect pipelineId.
+			// As a result, want to receive an error
+			name:    "incorrect pipelineId",
+			prepare: func() {},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetMetricsRequest{PipelineUuid: "NO_UUID_STRING"},
+			},
+			wantErr: true,
+		},
+		{
+			// Test case with calling GetMetrics method with pipelineId which doesn't contain metrics.
+			// As a result, want to receive an error.
+			name: "metrics don't exist",
+			prepare: func() {
+				_ = cacheService.SetValue(ctx, pipelineId, cache.MetricsIndex, 0)
+			},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetMetricsRequest{PipelineUuid: pipelineId.String()},
+			},
+			wantErr: true,
+		},
+		{
+			// Test case with calling GetMetrics method with pipelineId which contains metrics and index of metrics is 1.
+			// As a result want to receive response with the second metric point.
+			name: "get the second point",
+			prepare: func() {
+				_ = cacheService.SetValue(ctx, pipelineId, cache.MetricsIndex, 1)
+				_ = cacheService.SetValue(ctx, pipelineId, cache.Metrics, points)
+			},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetMetricsRequest{PipelineUuid: pipelineId.String()},
+			},
+			want:    []int64{20},
+			wantErr: false,
+		},
+		{
+			// Test case with calling GetMetrics method with pipelineId which metrics have been already received.
+			// As a result want to receive response without metric points.
+			name:    "no new points",
+			prepare: func() {},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetMetricsRequest{PipelineUuid: pipelineId.String()},
+			},
+			want:    []int64{},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.prepare()
+			got, err := client.GetMetrics(tt.args.ctx, tt.args.info)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetMetrics() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr {
+				counts := make([]int64, 0)
+				for _, point := range got.Points {
+					counts = append(counts, point.ElementCount)
+				}
+				if !reflect.DeepEqual(counts, tt.want) {
+					t.Errorf("GetMetrics() got = %v, want %v", counts, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestPlaygroundController_GetIntermediateSamples(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	ctx := context.Background()
+	pipelineId := uuid.New()
+	samples := []cache.PCollectionSamples{{PCollection: "Create", Elements: []string{"1", "2"}}, {PCollection: "Square", Elements: []string{"1", "4"}}}
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+	client := pb.NewPlaygroundServiceClient(conn)
+
+	tests := []struct {
+		name    string
+		prepare func()
+		info    *pb.GetIntermediateSamplesRequest
+		want    map[string][]string
+		wantErr bool
+	}{
+		{
+			// Test case with calling GetIntermediateSamples method with incorrect pipelineId.
+			// As a result, want to receive an error.
+			name:    "incorrect pipelineId",
+			prepare: func() {},
+			info:    &pb.GetIntermediateSamplesRequest{PipelineUuid: "NO_UUID_STRING"},
+			wantErr: true,
+		},
+		{
+			// Test case with calling GetIntermediateSamples method with pipelineId which isn't instrumented.
+			// As a result, want to receive an error.
+			name:    "samples don't exist",
+			prepare: func() {},
+			info:    &pb.GetIntermediateSamplesRequest{PipelineUuid: pipelineId.String()},
+			wantErr: true,
+		},
+		{
+			// Test case with calling GetIntermediateSamples method with pipelineId which contains samples.
+			// As a result, want to receive elements of both PCollections.
+			name: "get samples",
+			prepare: func() {
+				_ = cacheService.SetValue(ctx, pipelineId, cache.IntermediateSamples, samples)
+			},
+			info:    &pb.GetIntermediateSamplesRequest{PipelineUuid: pipelineId.String()},
+			want:    map[string][]string{"Create": {"1", "2"}, "Square": {"1", "4"}},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.prepare()
+			got, err := client.GetIntermediateSamples(ctx, tt.info)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetIntermediateSamples() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr {
+				elements := make(map[string][]string)
+				for _, sample := range got.Samples {
+					elements[sample.Pcollection] = sample.Elements
+				}
+				if !reflect.DeepEqual(elements, tt.want) {
+					t.Errorf("GetIntermediateSamples() got = %v, want %v", elements, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestPlaygroundController_GetLogs(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	ctx := context.Background()
+	pipelineId := uuid.New()
+	logs := "MOCK_LOGS"
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+	client := pb.NewPlaygroundServiceClient(conn)
+
+	type args struct {
+		ctx  context.Context
+		info *pb.GetLogsRequest
+	}
+	tests := []struct {
+		name    string
+		prepare func()
+		args    args
+		want    *pb.GetLogsResponse
+		wantErr bool
+	}{
+		{
+			// Test case with calling GetLogs method with incorrect pipelineId.
+			// As a result, want to receive an error
+			name:    "incorrect pipelineId",
+			prepare: func() {},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetLogsRequest{PipelineUuid: "NO_UUID_STRING"},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			// Test case with calling GetRLogs method with pipelineId which doesn't exist.
+			// As a result, want to receive an error.
+			name:    "pipelineId doesn't exist",
+			prepare: func() {},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetLogsRequest{PipelineUuid: pipelineId.String()},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			// Test case with calling GetLogs method with pipelineId which doesn't contain logs.
+			// As a result, want to receive an error.
+			name: "logs don't exist",
+			prepare: func() {
+				_ = cacheService.SetValue(ctx, pipelineId, cache.Status, pb.Status_STATUS_EXECUTING)
+			},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetLogsRequest{PipelineUuid: pipelineId.String()},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			// Test case with calling GetLogs method with pipelineId which contains logs.
+			// As a result want to receive response with an expected logs.
+			name: "logs exist",
+			prepare: func() {
+				_ = cacheService.SetValue(ctx, pipelineId, cache.LogsIndex, 0)
+				_ = cacheService.SetValue(ctx, pipelineId, cache.Logs, logs)
+			},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetLogsRequest{PipelineUuid: pipelineId.String()},
+			},
+			want:    &pb.GetLogsResponse{Output: logs},
+			wantErr: false,
+		},
+		{
+			// Test case with calling GetLogs method with pipelineId which contain logs and index of logs is 1.
+			// As a result want to receive response with correct logs (logs[1:]).
+			name: "get the second part",
+			prepare: func() {
+				_ = cacheService.SetValue(ctx, pipelineId, cache.LogsIndex, 1)
+				_ = cacheService.SetValue(ctx, pipelineId, cache.Logs, logs)
+			},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetLogsRequest{PipelineUuid: pipelineId.String()},
+			},
+			want:    &pb.GetLogsResponse{Output: logs[1:]},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.prepare()
+			got, err := client.GetLogs(tt.args.ctx, tt.args.info)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetLogs() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr {
+				if !strings.EqualFold(got.Output, tt.want.Output) {
+					t.Errorf("GetLogs() got = %v, want %v", got.Output, tt.want.Output)
+				}
+			}
+		})
+	}
+}
+
+func TestPlaygroundController_GetRunError(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	ctx := context.Background()
+	pipelineId := uuid.New()
+	runError := "MOCK_RUN_ERROR"
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+	client := pb.NewPlaygroundServiceClient(conn)
+
+	type args struct {
+		ctx  context.Context
+		info *pb.GetRunErrorRequest
+	}
+	tests := []struct {
+		name    string
+		prepare func()
+		args    args
+		want    *pb.GetRunErrorResponse
+		wantErr bool
+	}{
+		{
+			// Test case with calling GetRunError method with incorrect pipelineId.
+			// As a result, want to receive an error
+			name:    "incorrect pipelineId",
+			prepare: func() {},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetRunErrorRequest{PipelineUuid: "NO_UUID_STRING"},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			// Test case with calling GetRunError method with pipelineId which doesn't exist.
+			// As a result, want to receive an error.
+			name:    "pipelineId doesn't exist",
+			prepare: func() {},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetRunErrorRequest{PipelineUuid: pipelineId.String()},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			// Test case with calling GetRunError method with pipelineId which doesn't contain run error.
+			// As a result, want to receive an error.
+			name: "run error output doesn't exist",
+			prepare: func() {
+				_ = cacheService.SetValue(ctx, pipelineId, cache.Status, pb.Status_STATUS_VALIDATING)
+			},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetRunErrorRequest{PipelineUuid: pipelineId.String()},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			// Test case with calling GetRunError method with pipelineId which contains run error.
+			// As a result want to receive response with an expected run error .
+			name: "run error output exist",
+			prepare: func() {
+				_ = cacheService.SetValue(ctx, pipelineId, cache.RunError, runError)
+			},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetRunErrorRequest{PipelineUuid: pipelineId.String()},
+			},
+			want:    &pb.GetRunErrorResponse{Output: runError},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.prepare()
+			got, err := client.GetRunError(tt.args.ctx, tt.args.info)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetRunError() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr {
+				if !strings.EqualFold(got.Output, tt.want.Output) {
+					t.Errorf("GetRunError() got = %v, want %v", got.Output, tt.want.Output)
+				}
+			}
+		})
+	}
+}
+
+func TestPlaygroundController_GetValidationDiagnostics(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	ctx := context.Background()
+	pipelineId := uuid.New()
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+	client := pb.NewPlaygroundServiceClient(conn)
+
+	tests := []struct {
+		name         string
+		prepare      func()
+		pipelineUuid string
+		want         []*pb.FileDiagnostics
+		wantErr      bool
+	}{
+		{
+			// Test case with calling GetValidationDiagnostics method with incorrect pipelineId.
+			// As a result, want to receive an error.
+			name:         "incorrect pipelineId",
+			prepare:      func() {},
+			pipelineUuid: "NO_UUID_STRING",
+			wantErr:      true,
+		},
+		{
+			// Test case with calling GetValidationDiagnostics method with pipelineId which doesn't exist.
+			// As a result, want to receive an error.
+			name:         "pipelineId doesn't exist",
+			prepare:      func() {},
+			pipelineUuid: pipelineId.String(),
+			wantErr:      true,
+		},
+		{
+			// Test case with calling GetValidationDiagnostics method with pipelineId which validation step isn't failed.
+			// As a result, want to receive no diagnostics.
+			name: "validation isn't failed",
+			prepare: func() {
+				_ = cacheService.SetValue(ctx, pipelineId, cache.Status, pb.Status_STATUS_EXECUTING)
+			},
+			pipelineUuid: pipelineId.String(),
+			want:         nil,
+		},
+		{
+			// Test case with calling GetValidationDiagnostics method with pipelineId which validation step is failed with syntax errors.
+			// As a result, want to receive the diagnostics grouped by source files.
+			name: "validation is failed",
+			prepare: func() {
+				_ = cacheService.SetValue(ctx, pipelineId, cache.Status, pb.Status_STATUS_VALIDATION_ERROR)
+				_ = cacheService.SetValue(ctx, pipelineId, cache.ValidationDiagnostics, []cache.FileDiagnostics{
+					{FileName: "main.py", Diagnostics: []cache.Diagnostic{{Line: 3, Column: 7, Message: "invalid syntax", Severity: cache.DiagnosticSeverityError}}},
+				})
+			},
+			pipelineUuid: pipelineId.String(),
+			want: []*pb.FileDiagnostics{
+				{FileName: "main.py", Diagnostics: []*pb.Diagnostic{{Line: 3, Column: 7, Message: "invalid syntax", Severity: cache.DiagnosticSeverityError}}},
+			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			conn, err := grpc.DialContext(tt.args.ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
-			if err != nil {
-				t.Fatalf("Failed to dial bufnet: %v", err)
-			}
-			defer conn.Close()
-			client := pb.NewPlaygroundServiceClient(conn)
-			response, err := client.RunCode(tt.args.ctx, tt.args.request)
+			tt.prepare()
+			got, err := client.GetValidationDiagnostics(ctx, &pb.GetValidationDiagnosticsRequest{PipelineUuid: tt.pipelineUuid})
 			if (err != nil) != tt.wantErr {
-				t.Errorf("PlaygroundController_RunCode() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("GetValidationDiagnostics() error = %v, wantErr %v", err, tt.wantErr)
+				return
 			}
-			if err == nil {
-				if response == nil {
-					t.Errorf("PlaygroundController_RunCode() response shoudn't be nil")
-				} else {
-					if response.PipelineUuid == "" {
-						t.Errorf("PlaygroundController_RunCode() response.pipelineId shoudn't be nil")
-					}
-					status, _ := cacheService.GetValue(tt.args.ctx, uuid.MustParse(response.PipelineUuid), cache.Status)
-					path := os.Getenv("APP_WORK_DIR") + "/executable_files"
-					os.RemoveAll(path)
-					if status == nil {
-						t.Errorf("PlaygroundController_RunCode() status shoudn't be nil")
-					}
-				}
+			if !tt.wantErr && !proto.Equal(got, &pb.GetValidationDiagnosticsResponse{Diagnostics: tt.want}) {
+				t.Errorf("GetValidationDiagnostics() got = %v, want %v", got.Diagnostics, tt.want)
 			}
 		})
 	}
 }
 
-func TestPlaygroundController_CheckStatus(t *testing.T) {
+func TestPlaygroundController_GetSource(t *testing.T) {
 	defer goleak.VerifyNone(t, opt)
 	ctx := context.Background()
 	pipelineId := uuid.New()
-	wantStatus := pb.Status_STATUS_FINISHED
+	source := "MOCK_SOURCE"
 	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
 	if err != nil {
 		t.Fatalf("Failed to dial bufnet: %v", err)
@@ -200,77 +2123,75 @@ func TestPlaygroundController_CheckStatus(t *testing.T) {
 	client := pb.NewPlaygroundServiceClient(conn)
 
 	type args struct {
-		ctx     context.Context
-		request *pb.CheckStatusRequest
+		ctx  context.Context
+		info *pb.GetSourceRequest
 	}
 	tests := []struct {
-		name       string
-		prepare    func()
-		args       args
-		wantStatus *pb.Status
-		wantErr    bool
+		name    string
+		prepare func()
+		args    args
+		want    *pb.GetSourceResponse
+		wantErr bool
 	}{
 		{
-			// Test case with calling CheckStatus method with incorrect pipelineId.
-			// As a result, want to receive an error
+			// Test case with calling GetSource method with incorrect pipelineId.
+			// As a result, want to receive an error.
 			name:    "incorrect pipelineId",
 			prepare: func() {},
 			args: args{
-				ctx:     ctx,
-				request: &pb.CheckStatusRequest{PipelineUuid: "NO_UUID_STRING"},
+				ctx:  ctx,
+				info: &pb.GetSourceRequest{PipelineUuid: "NO_UUID_STRING"},
 			},
-			wantStatus: nil,
-			wantErr:    true,
+			want:    nil,
+			wantErr: true,
 		},
 		{
-			// Test case with calling CheckStatus method with pipelineId which doesn't exist.
+			// Test case with calling GetSource method with pipelineId which doesn't contain the source.
 			// As a result, want to receive an error.
-			name:    "status is not set",
+			name:    "source doesn't exist",
 			prepare: func() {},
 			args: args{
-				ctx:     ctx,
-				request: &pb.CheckStatusRequest{PipelineUuid: pipelineId.String()},
+				ctx:  ctx,
+				info: &pb.GetSourceRequest{PipelineUuid: pipelineId.String()},
 			},
-			wantStatus: nil,
-			wantErr:    true,
+			want:    nil,
+			wantErr: true,
 		},
 		{
-			// Test case with calling CheckStatus method with pipelineId which contains status.
-			// As a result, want to receive an expected status.
-			name: "status exists",
+			// Test case with calling GetSource method with pipelineId which contains the source.
+			// As a result, want to receive response with the source.
+			name: "source exists",
 			prepare: func() {
-				_ = cacheService.SetValue(ctx, pipelineId, cache.Status, wantStatus)
+				_ = cacheService.SetValue(ctx, pipelineId, cache.Source, source)
 			},
 			args: args{
-				ctx:     ctx,
-				request: &pb.CheckStatusRequest{PipelineUuid: pipelineId.String()},
+				ctx:  ctx,
+				info: &pb.GetSourceRequest{PipelineUuid: pipelineId.String()},
 			},
-			wantStatus: &wantStatus,
-			wantErr:    false,
+			want:    &pb.GetSourceResponse{Code: source},
+			wantErr: false,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.prepare()
-			got, err := client.CheckStatus(ctx, tt.args.request)
+			got, err := client.GetSource(tt.args.ctx, tt.args.info)
 			if (err != nil) != tt.wantErr {
-				t.Errorf("PlaygroundController_CheckStatus() error = %v, wantErr %v", err, tt.wantErr)
-			}
-			if got == nil && tt.wantStatus != nil {
-				t.Errorf("PlaygroundController_CheckStatus() return = %v, want response with status %v", got, tt.wantStatus)
+				t.Errorf("GetSource() error = %v, wantErr %v", err, tt.wantErr)
+				return
 			}
-			if got != nil && !reflect.DeepEqual(got.Status, *tt.wantStatus) {
-				t.Errorf("PlaygroundController_CheckStatus() return status = %v, want status %v", got.Status, tt.wantStatus)
+			if !tt.wantErr && got.Code != tt.want.Code {
+				t.Errorf("GetSource() got = %v, want %v", got.Code, tt.want.Code)
 			}
 		})
 	}
 }
 
-func TestPlaygroundController_GetCompileOutput(t *testing.T) {
+func TestPlaygroundController_GetDependencies(t *testing.T) {
 	defer goleak.VerifyNone(t, opt)
 	ctx := context.Background()
 	pipelineId := uuid.New()
-	compileOutput := "MOCK_COMPILE_OUTPUT"
+	dependencies := []string{"MOCK_DEPENDENCY"}
 	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
 	if err != nil {
 		t.Fatalf("Failed to dial bufnet: %v", err)
@@ -280,76 +2201,76 @@ func TestPlaygroundController_GetCompileOutput(t *testing.T) {
 
 	type args struct {
 		ctx  context.Context
-		info *pb.GetCompileOutputRequest
+		info *pb.GetDependenciesRequest
 	}
 	tests := []struct {
 		name    string
 		prepare func()
 		args    args
-		want    *pb.GetCompileOutputResponse
+		want    *pb.GetDependenciesResponse
 		wantErr bool
 	}{
 		{
-			// Test case with calling GetCompileOutput method with incorrect pipelineId.
-			// As a result, want to receive an error
+			// Test case with calling GetDependencies method with incorrect pipelineId.
+			// As a result, want to receive an error.
 			name:    "incorrect pipelineId",
 			prepare: func() {},
 			args: args{
 				ctx:  ctx,
-				info: &pb.GetCompileOutputRequest{PipelineUuid: "NO_UUID_STRING"},
+				info: &pb.GetDependenciesRequest{PipelineUuid: "NO_UUID_STRING"},
 			},
 			want:    nil,
 			wantErr: true,
 		},
 		{
-			// Test case with calling GetCompileOutput method with pipelineId which doesn't exist.
+			// Test case with calling GetDependencies method with pipelineId which doesn't contain dependencies.
 			// As a result, want to receive an error.
-			name:    "pipelineId doesn't exist",
-			prepare: func() {},
+			name: "dependencies don't exist",
+			prepare: func() {
+				_ = cacheService.SetValue(ctx, pipelineId, cache.Status, pb.Status_STATUS_FINISHED)
+			},
 			args: args{
 				ctx:  ctx,
-				info: &pb.GetCompileOutputRequest{PipelineUuid: pipelineId.String()},
+				info: &pb.GetDependenciesRequest{PipelineUuid: pipelineId.String()},
 			},
 			want:    nil,
 			wantErr: true,
 		},
 		{
-			// Test case with calling GetCompileOutput method with pipelineId which contains compile output.
-			// As a result, want to receive an expected compile output.
-			name: "compile output exist",
+			// Test case with calling GetDependencies method with pipelineId which contains dependencies.
+			// As a result, want to receive response with expected dependencies.
+			name: "dependencies exist",
 			prepare: func() {
-				_ = cacheService.SetValue(ctx, pipelineId, cache.CompileOutput, compileOutput)
+				_ = cacheService.SetValue(ctx, pipelineId, cache.Dependencies, dependencies)
 			},
 			args: args{
 				ctx:  ctx,
-				info: &pb.GetCompileOutputRequest{PipelineUuid: pipelineId.String()},
+				info: &pb.GetDependenciesRequest{PipelineUuid: pipelineId.String()},
 			},
-			want:    &pb.GetCompileOutputResponse{Output: compileOutput},
+			want:    &pb.GetDependenciesResponse{Dependencies: dependencies},
 			wantErr: false,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.prepare()
-			got, err := client.GetCompileOutput(tt.args.ctx, tt.args.info)
+			got, err := client.GetDependencies(tt.args.ctx, tt.args.info)
 			if (err != nil) != tt.wantErr {
-				t.Errorf("GetCompileOutput() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("GetDependencies() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
-			if !tt.wantErr {
-				if !strings.EqualFold(got.Output, tt.want.Output) {
-					t.Errorf("GetCompileOutput() got = %v, want %v", got.Output, tt.want.Output)
-				}
+			if !tt.wantErr && !reflect.DeepEqual(got.Dependencies, tt.want.Dependencies) {
+				t.Errorf("GetDependencies() got = %v, want %v", got.Dependencies, tt.want.Dependencies)
 			}
 		})
 	}
 }
 
-func TestPlaygroundController_GetRunOutput(t *testing.T) {
+func TestPlaygroundController_GetCoverage(t *testing.T) {
 	defer goleak.VerifyNone(t, opt)
 	ctx := context.Background()
 	pipelineId := uuid.New()
-	runOutput := "MOCK_RUN_OUTPUT"
+	coverage := "MOCK_COVERAGE"
 	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
 	if err != nil {
 		t.Fatalf("Failed to dial bufnet: %v", err)
@@ -359,106 +2280,242 @@ func TestPlaygroundController_GetRunOutput(t *testing.T) {
 
 	type args struct {
 		ctx  context.Context
-		info *pb.GetRunOutputRequest
+		info *pb.GetCoverageRequest
 	}
 	tests := []struct {
 		name    string
 		prepare func()
 		args    args
-		want    *pb.GetRunOutputResponse
+		want    *pb.GetCoverageResponse
 		wantErr bool
 	}{
 		{
-			// Test case with calling GetRunOutput method with incorrect pipelineId.
-			// As a result, want to receive an error
+			// Test case with calling GetCoverage method with incorrect pipelineId.
+			// As a result, want to receive an error.
 			name:    "incorrect pipelineId",
 			prepare: func() {},
 			args: args{
 				ctx:  ctx,
-				info: &pb.GetRunOutputRequest{PipelineUuid: "NO_UUID_STRING"},
+				info: &pb.GetCoverageRequest{PipelineUuid: "NO_UUID_STRING"},
 			},
 			want:    nil,
 			wantErr: true,
 		},
 		{
-			// Test case with calling GetRunOutput method with pipelineId which doesn't exist.
+			// Test case with calling GetCoverage method with pipelineId which doesn't contain the coverage summary.
 			// As a result, want to receive an error.
-			name:    "pipelineId doesn't exist",
+			name: "coverage doesn't exist",
+			prepare: func() {
+				_ = cacheService.SetValue(ctx, pipelineId, cache.Status, pb.Status_STATUS_FINISHED)
+			},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetCoverageRequest{PipelineUuid: pipelineId.String()},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			// Test case with calling GetCoverage method with pipelineId which contains the coverage summary.
+			// As a result, want to receive response with the expected coverage summary.
+			name: "coverage exists",
+			prepare: func() {
+				_ = cacheService.SetValue(ctx, pipelineId, cache.Coverage, coverage)
+			},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetCoverageRequest{PipelineUuid: pipelineId.String()},
+			},
+			want:    &pb.GetCoverageResponse{Coverage: coverage},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.prepare()
+			got, err := client.GetCoverage(tt.args.ctx, tt.args.info)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetCoverage() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got.Coverage != tt.want.Coverage {
+				t.Errorf("GetCoverage() got = %v, want %v", got.Coverage, tt.want.Coverage)
+			}
+		})
+	}
+}
+
+func TestPlaygroundController_GetFormattedOutput(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	ctx := context.Background()
+	pipelineId := uuid.New()
+	formattedOutput := "MOCK_FORMATTED_OUTPUT"
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+	client := pb.NewPlaygroundServiceClient(conn)
+
+	type args struct {
+		ctx  context.Context
+		info *pb.GetFormattedOutputRequest
+	}
+	tests := []struct {
+		name    string
+		prepare func()
+		args    args
+		want    *pb.GetFormattedOutputResponse
+		wantErr bool
+	}{
+		{
+			// Test case with calling GetFormattedOutput method with incorrect pipelineId.
+			// As a result, want to receive an error.
+			name:    "incorrect pipelineId",
 			prepare: func() {},
 			args: args{
 				ctx:  ctx,
-				info: &pb.GetRunOutputRequest{PipelineUuid: pipelineId.String()},
+				info: &pb.GetFormattedOutputRequest{PipelineUuid: "NO_UUID_STRING"},
 			},
 			want:    nil,
 			wantErr: true,
 		},
 		{
-			// Test case with calling GetRunOutput method with pipelineId which doesn't contain run output.
+			// Test case with calling GetFormattedOutput method with pipelineId which doesn't contain the formatted output.
 			// As a result, want to receive an error.
-			name: "run output doesn't exist",
+			name: "formatted output doesn't exist",
 			prepare: func() {
 				_ = cacheService.SetValue(ctx, pipelineId, cache.Status, pb.Status_STATUS_EXECUTING)
 			},
 			args: args{
 				ctx:  ctx,
-				info: &pb.GetRunOutputRequest{PipelineUuid: pipelineId.String()},
+				info: &pb.GetFormattedOutputRequest{PipelineUuid: pipelineId.String()},
 			},
 			want:    nil,
 			wantErr: true,
 		},
 		{
-			// Test case with calling GetRunOutput method with pipelineId which contains run output.
-			// As a result want to receive response with an expected run output.
-			name: "run output exist",
+			// Test case with calling GetFormattedOutput method with pipelineId which contains the formatted output.
+			// As a result, want to receive response with the expected formatted output.
+			name: "formatted output exists",
 			prepare: func() {
-				_ = cacheService.SetValue(ctx, pipelineId, cache.RunOutputIndex, 0)
-				_ = cacheService.SetValue(ctx, pipelineId, cache.RunOutput, runOutput)
+				_ = cacheService.SetValue(ctx, pipelineId, cache.FormattedOutput, formattedOutput)
 			},
 			args: args{
 				ctx:  ctx,
-				info: &pb.GetRunOutputRequest{PipelineUuid: pipelineId.String()},
+				info: &pb.GetFormattedOutputRequest{PipelineUuid: pipelineId.String()},
 			},
-			want:    &pb.GetRunOutputResponse{Output: runOutput},
+			want:    &pb.GetFormattedOutputResponse{Output: formattedOutput},
 			wantErr: false,
 		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.prepare()
+			got, err := client.GetFormattedOutput(tt.args.ctx, tt.args.info)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetFormattedOutput() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got.Output != tt.want.Output {
+				t.Errorf("GetFormattedOutput() got = %v, want %v", got.Output, tt.want.Output)
+			}
+		})
+	}
+}
+
+func TestPlaygroundController_GetCrossSdkDiff(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	ctx := context.Background()
+	firstPipelineId := uuid.New()
+	secondPipelineId := uuid.New()
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+	client := pb.NewPlaygroundServiceClient(conn)
+
+	type args struct {
+		ctx  context.Context
+		info *pb.GetCrossSdkDiffRequest
+	}
+	tests := []struct {
+		name    string
+		prepare func()
+		args    args
+		want    *pb.GetCrossSdkDiffResponse
+		wantErr bool
+	}{
+		{
+			// Test case with calling GetCrossSdkDiff method with incorrect second pipelineId.
+			// As a result, want to receive an error.
+			name:    "incorrect pipelineId",
+			prepare: func() {},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetCrossSdkDiffRequest{FirstPipelineUuid: firstPipelineId.String(), SecondPipelineUuid: "NO_UUID_STRING"},
+			},
+			want:    nil,
+			wantErr: true,
+		},
 		{
-			// Test case with calling RunOutput method with pipelineId which contain run output and index of run output is 1.
-			// As a result want to receive response with correct output (output[1:]).
-			name: "get the second part",
+			// Test case with calling GetCrossSdkDiff method with the second pipeline which isn't finished.
+			// As a result, want to receive an error.
+			name: "pipeline isn't finished",
 			prepare: func() {
-				_ = cacheService.SetValue(ctx, pipelineId, cache.RunOutputIndex, 1)
-				_ = cacheService.SetValue(ctx, pipelineId, cache.RunOutput, runOutput)
+				_ = cacheService.SetValue(ctx, firstPipelineId, cache.Status, pb.Status_STATUS_FINISHED)
+				_ = cacheService.SetValue(ctx, firstPipelineId, cache.RunOutput, "a: 1\nb: 2\n")
+				_ = cacheService.SetValue(ctx, secondPipelineId, cache.Status, pb.Status_STATUS_EXECUTING)
 			},
 			args: args{
 				ctx:  ctx,
-				info: &pb.GetRunOutputRequest{PipelineUuid: pipelineId.String()},
+				info: &pb.GetCrossSdkDiffRequest{FirstPipelineUuid: firstPipelineId.String(), SecondPipelineUuid: secondPipelineId.String()},
 			},
-			want:    &pb.GetRunOutputResponse{Output: runOutput[1:]},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			// Test case with calling GetCrossSdkDiff method with finished pipelines.
+			// As a result, want to receive the diff of their normalized outputs.
+			name: "pipelines are finished",
+			prepare: func() {
+				_ = cacheService.SetValue(ctx, secondPipelineId, cache.Status, pb.Status_STATUS_FINISHED)
+				_ = cacheService.SetValue(ctx, secondPipelineId, cache.RunOutput, "c: 3\na: 1\n")
+			},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetCrossSdkDiffRequest{FirstPipelineUuid: firstPipelineId.String(), SecondPipelineUuid: secondPipelineId.String()},
+			},
+			want:    &pb.GetCrossSdkDiffResponse{Diff: "- b: 2\n+ c: 3\n"},
 			wantErr: false,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.prepare()
-			got, err := client.GetRunOutput(tt.args.ctx, tt.args.info)
+			got, err := client.GetCrossSdkDiff(tt.args.ctx, tt.args.info)
 			if (err != nil) != tt.wantErr {
-				t.Errorf("GetRunOutput() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("GetCrossSdkDiff() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
-			if !tt.wantErr {
-				if !strings.EqualFold(got.Output, tt.want.Output) {
-					t.Errorf("GetRunOutput() got = %v, want %v", got.Output, tt.want.Output)
-				}
+			if !tt.wantErr && got.Diff != tt.want.Diff {
+				t.Errorf("GetCrossSdkDiff() got = %v, want %v", got.Diff, tt.want.Diff)
 			}
 		})
 	}
 }
 
-func TestPlaygroundController_GetLogs(t *testing.T) {
+func TestPlaygroundController_GetTimestamps(t *testing.T) {
 	defer goleak.VerifyNone(t, opt)
 	ctx := context.Background()
 	pipelineId := uuid.New()
-	logs := "MOCK_LOGS"
+	finishedPipelineId := uuid.New()
+	canceledPipelineId := uuid.New()
+	transitionsPipelineId := uuid.New()
+	startedAt := time.Unix(1600000000, 0)
+	finishedAt := startedAt.Add(8 * time.Second)
+	cancelAcknowledgedAt := startedAt.Add(3 * time.Second)
 	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
 	if err != nil {
 		t.Fatalf("Failed to dial bufnet: %v", err)
@@ -468,106 +2525,137 @@ func TestPlaygroundController_GetLogs(t *testing.T) {
 
 	type args struct {
 		ctx  context.Context
-		info *pb.GetLogsRequest
+		info *pb.GetTimestampsRequest
 	}
 	tests := []struct {
 		name    string
 		prepare func()
 		args    args
-		want    *pb.GetLogsResponse
+		want    *pb.GetTimestampsResponse
 		wantErr bool
 	}{
 		{
-			// Test case with calling GetLogs method with incorrect pipelineId.
+			// Test case with calling GetTimestamps method with incorrect pipelineId.
 			// As a result, want to receive an error
 			name:    "incorrect pipelineId",
 			prepare: func() {},
 			args: args{
 				ctx:  ctx,
-				info: &pb.GetLogsRequest{PipelineUuid: "NO_UUID_STRING"},
+				info: &pb.GetTimestampsRequest{PipelineUuid: "NO_UUID_STRING"},
 			},
-			want:    nil,
 			wantErr: true,
 		},
 		{
-			// Test case with calling GetRLogs method with pipelineId which doesn't exist.
+			// Test case with calling GetTimestamps method with pipelineId which isn't started.
 			// As a result, want to receive an error.
-			name:    "pipelineId doesn't exist",
+			name:    "code processing isn't started",
 			prepare: func() {},
 			args: args{
 				ctx:  ctx,
-				info: &pb.GetLogsRequest{PipelineUuid: pipelineId.String()},
+				info: &pb.GetTimestampsRequest{PipelineUuid: pipelineId.String()},
 			},
-			want:    nil,
 			wantErr: true,
 		},
 		{
-			// Test case with calling GetLogs method with pipelineId which doesn't contain logs.
-			// As a result, want to receive an error.
-			name: "logs don't exist",
+			// Test case with calling GetTimestamps method with pipelineId which is started but isn't finished.
+			// As a result, want to receive the start time without the finish time.
+			name: "code processing isn't finished",
 			prepare: func() {
-				_ = cacheService.SetValue(ctx, pipelineId, cache.Status, pb.Status_STATUS_EXECUTING)
+				_ = cacheService.SetValue(ctx, pipelineId, cache.StartedAt, startedAt)
 			},
 			args: args{
 				ctx:  ctx,
-				info: &pb.GetLogsRequest{PipelineUuid: pipelineId.String()},
+				info: &pb.GetTimestampsRequest{PipelineUuid: pipelineId.String()},
 			},
-			want:    nil,
-			wantErr: true,
+			want:    &pb.GetTimestampsResponse{StartedAt: 1600000000000},
+			wantErr: false,
 		},
 		{
-			// Test case with calling GetLogs method with pipelineId which contains logs.
-			// As a result want to receive response with an expected logs.
-			name: "logs exist",
+			// Test case with calling GetTimestamps method with pipelineId which is finished.
+			// As a result, want to receive the start and the finish times.
+			name: "code processing is finished",
 			prepare: func() {
-				_ = cacheService.SetValue(ctx, pipelineId, cache.LogsIndex, 0)
-				_ = cacheService.SetValue(ctx, pipelineId, cache.Logs, logs)
+				_ = cacheService.SetValue(ctx, finishedPipelineId, cache.StartedAt, startedAt)
+				_ = cacheService.SetValue(ctx, finishedPipelineId, cache.FinishedAt, finishedAt)
 			},
 			args: args{
 				ctx:  ctx,
-				info: &pb.GetLogsRequest{PipelineUuid: pipelineId.String()},
+				info: &pb.GetTimestampsRequest{PipelineUuid: finishedPipelineId.String()},
 			},
-			want:    &pb.GetLogsResponse{Output: logs},
+			want:    &pb.GetTimestampsResponse{StartedAt: 1600000000000, FinishedAt: 1600000008000},
 			wantErr: false,
 		},
 		{
-			// Test case with calling GetLogs method with pipelineId which contain logs and index of logs is 1.
-			// As a result want to receive response with correct logs (logs[1:]).
-			name: "get the second part",
+			// Test case with calling GetTimestamps method with pipelineId which observed the cancel request.
+			// As a result, want to receive the start, the finish and the cancel acknowledgement times.
+			name: "cancel is acknowledged",
 			prepare: func() {
-				_ = cacheService.SetValue(ctx, pipelineId, cache.LogsIndex, 1)
-				_ = cacheService.SetValue(ctx, pipelineId, cache.Logs, logs)
+				_ = cacheService.SetValue(ctx, canceledPipelineId, cache.StartedAt, startedAt)
+				_ = cacheService.SetValue(ctx, canceledPipelineId, cache.CancelAcknowledged, cancelAcknowledgedAt)
+				_ = cacheService.SetValue(ctx, canceledPipelineId, cache.FinishedAt, finishedAt)
 			},
 			args: args{
 				ctx:  ctx,
-				info: &pb.GetLogsRequest{PipelineUuid: pipelineId.String()},
+				info: &pb.GetTimestampsRequest{PipelineUuid: canceledPipelineId.String()},
 			},
-			want:    &pb.GetLogsResponse{Output: logs[1:]},
+			want:    &pb.GetTimestampsResponse{StartedAt: 1600000000000, FinishedAt: 1600000008000, CancelAcknowledgedAt: 1600000003000},
+			wantErr: false,
+		},
+		{
+			// Test case with calling GetTimestamps method with pipelineId which reached statuses after completed steps.
+			// As a result, want to receive the start time and status transitions ordered by time.
+			name: "status transitions",
+			prepare: func() {
+				_ = cacheService.SetValue(ctx, transitionsPipelineId, cache.StartedAt, startedAt)
+				_ = cacheService.SetValue(ctx, transitionsPipelineId, cache.Timestamps, map[pb.Status]time.Time{
+					pb.Status_STATUS_EXECUTING: finishedAt,
+					pb.Status_STATUS_COMPILING: cancelAcknowledgedAt,
+				})
+			},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetTimestampsRequest{PipelineUuid: transitionsPipelineId.String()},
+			},
+			want: &pb.GetTimestampsResponse{StartedAt: 1600000000000, Transitions: []*pb.StatusTransition{
+				{Status: pb.Status_STATUS_COMPILING, Timestamp: 1600000003000},
+				{Status: pb.Status_STATUS_EXECUTING, Timestamp: 1600000008000},
+			}},
 			wantErr: false,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.prepare()
-			got, err := client.GetLogs(tt.args.ctx, tt.args.info)
+			got, err := client.GetTimestamps(tt.args.ctx, tt.args.info)
 			if (err != nil) != tt.wantErr {
-				t.Errorf("GetLogs() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("GetTimestamps() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
 			if !tt.wantErr {
-				if !strings.EqualFold(got.Output, tt.want.Output) {
-					t.Errorf("GetLogs() got = %v, want %v", got.Output, tt.want.Output)
+				if got.StartedAt != tt.want.StartedAt || got.FinishedAt != tt.want.FinishedAt || got.CancelAcknowledgedAt != tt.want.CancelAcknowledgedAt {
+					t.Errorf("GetTimestamps() got = %v, want %v", got, tt.want)
+				}
+				if len(got.Transitions) != len(tt.want.Transitions) {
+					t.Fatalf("GetTimestamps() got transitions = %v, want %v", got.Transitions, tt.want.Transitions)
+				}
+				for i, transition := range got.Transitions {
+					if transition.Status != tt.want.Transitions[i].Status || transition.Timestamp != tt.want.Transitions[i].Timestamp {
+						t.Errorf("GetTimestamps() got transition = %v, want %v", transition, tt.want.Transitions[i])
+					}
 				}
 			}
 		})
 	}
 }
 
-func TestPlaygroundController_GetRunError(t *testing.T) {
+func TestPlaygroundController_GetInvocation(t *testing.T) {
 	defer goleak.VerifyNone(t, opt)
 	ctx := context.Background()
 	pipelineId := uuid.New()
-	runError := "MOCK_RUN_ERROR"
+	runPipelineId := uuid.New()
+	compiledPipelineId := uuid.New()
+	compileInvocation := cache.Invocation{WorkingDir: "executable_files/" + compiledPipelineId.String(), Args: []string{"javac", "-d", "bin", "src/main.java"}}
+	runInvocation := cache.Invocation{WorkingDir: "executable_files/" + runPipelineId.String(), Args: []string{"python3", "main.py"}}
 	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
 	if err != nil {
 		t.Fatalf("Failed to dial bufnet: %v", err)
@@ -577,89 +2665,98 @@ func TestPlaygroundController_GetRunError(t *testing.T) {
 
 	type args struct {
 		ctx  context.Context
-		info *pb.GetRunErrorRequest
+		info *pb.GetInvocationRequest
 	}
 	tests := []struct {
 		name    string
 		prepare func()
 		args    args
-		want    *pb.GetRunErrorResponse
+		want    *pb.GetInvocationResponse
 		wantErr bool
 	}{
 		{
-			// Test case with calling GetRunError method with incorrect pipelineId.
-			// As a result, want to receive an error
+			// Test case with calling GetInvocation method with incorrect pipelineId.
+			// As a result, want to receive an error.
 			name:    "incorrect pipelineId",
 			prepare: func() {},
 			args: args{
 				ctx:  ctx,
-				info: &pb.GetRunErrorRequest{PipelineUuid: "NO_UUID_STRING"},
+				info: &pb.GetInvocationRequest{PipelineUuid: "NO_UUID_STRING"},
 			},
-			want:    nil,
 			wantErr: true,
 		},
 		{
-			// Test case with calling GetRunError method with pipelineId which doesn't exist.
+			// Test case with calling GetInvocation method with pipelineId which doesn't exist.
 			// As a result, want to receive an error.
 			name:    "pipelineId doesn't exist",
 			prepare: func() {},
 			args: args{
 				ctx:  ctx,
-				info: &pb.GetRunErrorRequest{PipelineUuid: pipelineId.String()},
+				info: &pb.GetInvocationRequest{PipelineUuid: pipelineId.String()},
 			},
-			want:    nil,
 			wantErr: true,
 		},
 		{
-			// Test case with calling GetRunError method with pipelineId which doesn't contain run error.
-			// As a result, want to receive an error.
-			name: "run error output doesn't exist",
+			// Test case with calling GetInvocation method with pipelineId which has only the run invocation.
+			// As a result, want to receive only the run invocation.
+			name: "only run invocation",
 			prepare: func() {
-				_ = cacheService.SetValue(ctx, pipelineId, cache.Status, pb.Status_STATUS_VALIDATING)
+				_ = cacheService.SetValue(ctx, runPipelineId, cache.Status, pb.Status_STATUS_FINISHED)
+				_ = cacheService.SetValue(ctx, runPipelineId, cache.RunInvocation, runInvocation)
 			},
 			args: args{
 				ctx:  ctx,
-				info: &pb.GetRunErrorRequest{PipelineUuid: pipelineId.String()},
+				info: &pb.GetInvocationRequest{PipelineUuid: runPipelineId.String()},
 			},
-			want:    nil,
-			wantErr: true,
+			want: &pb.GetInvocationResponse{
+				RunInvocation: &pb.Invocation{WorkingDir: runInvocation.WorkingDir, Args: runInvocation.Args},
+			},
+			wantErr: false,
 		},
 		{
-			// Test case with calling GetRunError method with pipelineId which contains run error.
-			// As a result want to receive response with an expected run error .
-			name: "run error output exist",
+			// Test case with calling GetInvocation method with pipelineId which has the compile and run invocations.
+			// As a result, want to receive both invocations.
+			name: "compile and run invocations",
 			prepare: func() {
-				_ = cacheService.SetValue(ctx, pipelineId, cache.RunError, runError)
+				_ = cacheService.SetValue(ctx, compiledPipelineId, cache.Status, pb.Status_STATUS_FINISHED)
+				_ = cacheService.SetValue(ctx, compiledPipelineId, cache.CompileInvocation, compileInvocation)
+				_ = cacheService.SetValue(ctx, compiledPipelineId, cache.RunInvocation, runInvocation)
 			},
 			args: args{
 				ctx:  ctx,
-				info: &pb.GetRunErrorRequest{PipelineUuid: pipelineId.String()},
+				info: &pb.GetInvocationRequest{PipelineUuid: compiledPipelineId.String()},
+			},
+			want: &pb.GetInvocationResponse{
+				CompileInvocation: &pb.Invocation{WorkingDir: compileInvocation.WorkingDir, Args: compileInvocation.Args},
+				RunInvocation:     &pb.Invocation{WorkingDir: runInvocation.WorkingDir, Args: runInvocation.Args},
 			},
-			want:    &pb.GetRunErrorResponse{Output: runError},
 			wantErr: false,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.prepare()
-			got, err := client.GetRunError(tt.args.ctx, tt.args.info)
+			got, err := client.GetInvocation(tt.args.ctx, tt.args.info)
 			if (err != nil) != tt.wantErr {
-				t.Errorf("GetRunError() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("GetInvocation() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
-			if !tt.wantErr {
-				if !strings.EqualFold(got.Output, tt.want.Output) {
-					t.Errorf("GetRunError() got = %v, want %v", got.Output, tt.want.Output)
-				}
+			if !tt.wantErr && !proto.Equal(got, tt.want) {
+				t.Errorf("GetInvocation() got = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
-
 func TestPlaygroundController_Cancel(t *testing.T) {
 	defer goleak.VerifyNone(t, opt)
 	ctx := context.Background()
 	pipelineId := uuid.New()
+	groupPipelineId := uuid.New()
+	siblingPipelineId := uuid.New()
+	group := cache.PipelineGroup{Id: "MOCK_GROUP_ID", PipelineIds: []string{groupPipelineId.String(), siblingPipelineId.String()}}
+	if err := cacheService.SetValue(ctx, groupPipelineId, cache.Group, group); err != nil {
+		t.Fatalf("error during prepare group: %s", err.Error())
+	}
 	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
 	if err != nil {
 		t.Fatalf("Failed to dial bufnet: %v", err)
@@ -694,7 +2791,7 @@ func TestPlaygroundController_Cancel(t *testing.T) {
 		},
 		{
 			// Test case with calling Cancel method.
-			// As a result, want to find value in cache for cache.Canceled subKey.
+			// As a result, want to find value in cache for cache.Canceled subKey and the user as cache.CancelReason.
 			name: "set cancel without error",
 			args: args{
 				ctx:  ctx,
@@ -705,7 +2802,33 @@ func TestPlaygroundController_Cancel(t *testing.T) {
 				if err != nil {
 					return false
 				}
-				return value.(bool)
+				reason, err := cacheService.GetValue(context.Background(), pipelineId, cache.CancelReason)
+				if err != nil {
+					return false
+				}
+				return value.(bool) && reason == pb.CancelReason_CANCEL_REASON_USER
+			},
+			want:    &pb.CancelResponse{},
+			wantErr: false,
+		},
+		{
+			// Test case with calling Cancel method for the pipeline of the group.
+			// As a result, want to find value in cache for cache.Canceled subKey and the group as cache.CancelReason of the other pipeline of the group.
+			name: "cancel siblings of the group",
+			args: args{
+				ctx:  ctx,
+				info: &pb.CancelRequest{PipelineUuid: groupPipelineId.String()},
+			},
+			checkFunc: func() bool {
+				value, err := cacheService.GetValue(context.Background(), siblingPipelineId, cache.Canceled)
+				if err != nil {
+					return false
+				}
+				reason, err := cacheService.GetValue(context.Background(), siblingPipelineId, cache.CancelReason)
+				if err != nil {
+					return false
+				}
+				return value.(bool) && reason == pb.CancelReason_CANCEL_REASON_GROUP
 			},
 			want:    &pb.CancelResponse{},
 			wantErr: false,
@@ -722,3 +2845,298 @@ func TestPlaygroundController_Cancel(t *testing.T) {
 		})
 	}
 }
+
+func TestPlaygroundController_ExtendTimeout(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	ctx := context.Background()
+	pipelineId := uuid.New()
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+	client := pb.NewPlaygroundServiceClient(conn)
+
+	type args struct {
+		ctx  context.Context
+		info *pb.ExtendTimeoutRequest
+	}
+	tests := []struct {
+		name      string
+		args      args
+		checkFunc func() bool
+		wantErr   bool
+	}{
+		{
+			// Test case with calling ExtendTimeout method with incorrect pipelineId.
+			// As a result, want to receive an error
+			name: "incorrect pipelineId",
+			args: args{
+				ctx:  ctx,
+				info: &pb.ExtendTimeoutRequest{PipelineUuid: "NO_UUID_STRING"},
+			},
+			checkFunc: func() bool {
+				return true
+			},
+			wantErr: true,
+		},
+		{
+			// Test case with calling ExtendTimeout method.
+			// As a result, want to find value in cache for cache.ExtendTimeout subKey.
+			name: "set extend timeout without error",
+			args: args{
+				ctx:  ctx,
+				info: &pb.ExtendTimeoutRequest{PipelineUuid: pipelineId.String()},
+			},
+			checkFunc: func() bool {
+				value, err := cacheService.GetValue(context.Background(), pipelineId, cache.ExtendTimeout)
+				if err != nil {
+					return false
+				}
+				return value.(bool)
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := client.ExtendTimeout(tt.args.ctx, tt.args.info); (err != nil) != tt.wantErr {
+				t.Errorf("ExtendTimeout() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.checkFunc() {
+				t.Error("ExtendTimeout() doesn't set extend timeout flag")
+			}
+		})
+	}
+}
+
+func TestPlaygroundController_CheckSdkAvailability(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+	client := pb.NewPlaygroundServiceClient(conn)
+
+	tests := []struct {
+		name             string
+		info             *pb.CheckSdkAvailabilityRequest
+		want             bool
+		wantAlternatives []pb.Sdk
+	}{
+		{
+			// Test case with calling CheckSdkAvailability method with the server's SDK and any versions.
+			// As a result, want to receive that the SDK is available.
+			name: "available sdk",
+			info: &pb.CheckSdkAvailabilityRequest{Sdk: pb.Sdk_SDK_JAVA},
+			want: true,
+		},
+		{
+			// Test case with calling CheckSdkAvailability method with the SDK which isn't processed by the server.
+			// As a result, want to receive that the SDK isn't available and the server's SDK as an alternative.
+			name:             "unavailable sdk",
+			info:             &pb.CheckSdkAvailabilityRequest{Sdk: pb.Sdk_SDK_GO, BeamVersion: "2.50.0"},
+			want:             false,
+			wantAlternatives: []pb.Sdk{pb.Sdk_SDK_JAVA},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := client.CheckSdkAvailability(ctx, tt.info)
+			if err != nil {
+				t.Fatalf("CheckSdkAvailability() error = %v", err)
+			}
+			if got.Available != tt.want {
+				t.Errorf("CheckSdkAvailability() available = %v, want %v", got.Available, tt.want)
+			}
+			var gotAlternatives []pb.Sdk
+			for _, alternative := range got.Alternatives {
+				gotAlternatives = append(gotAlternatives, alternative.Sdk)
+			}
+			if !reflect.DeepEqual(gotAlternatives, tt.wantAlternatives) {
+				t.Errorf("CheckSdkAvailability() alternatives = %v, want %v", gotAlternatives, tt.wantAlternatives)
+			}
+		})
+	}
+}
+
+func Test_millisecondsToDuration(t *testing.T) {
+	tests := []struct {
+		name       string
+		timeoutMs  int64
+		maxTimeout time.Duration
+		want       time.Duration
+	}{
+		{
+			// Test case with calling millisecondsToDuration method with the timeout which is less than the maximum one.
+			// As a result, want to receive the converted timeout.
+			name:       "timeout is less than max",
+			timeoutMs:  1500,
+			maxTimeout: time.Minute,
+			want:       1500 * time.Millisecond,
+		},
+		{
+			// Test case with calling millisecondsToDuration method with the timeout which overflows time.Duration.
+			// As a result, want to receive the maximum timeout instead of the overflowed one.
+			name:       "timeout overflows duration",
+			timeoutMs:  math.MaxInt64,
+			maxTimeout: time.Minute,
+			want:       time.Minute,
+		},
+		{
+			// Test case with calling millisecondsToDuration method with the timeout which overflows time.Duration and without the maximum timeout.
+			// As a result, want to receive the maximum duration.
+			name:       "timeout overflows duration without max",
+			timeoutMs:  math.MaxInt64,
+			maxTimeout: 0,
+			want:       math.MaxInt64,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := millisecondsToDuration(tt.timeoutMs, tt.maxTimeout); got != tt.want {
+				t.Errorf("millisecondsToDuration() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_traceIdFromRequest(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want string
+	}{
+		{
+			// Test case with calling traceIdFromRequest method with the request without metadata.
+			// As a result, want to receive an empty trace id.
+			name: "no metadata",
+			ctx:  context.Background(),
+			want: "",
+		},
+		{
+			// Test case with calling traceIdFromRequest method with the request without the trace id header.
+			// As a result, want to receive an empty trace id.
+			name: "no trace id header",
+			ctx:  metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-mock-header", "MOCK_VALUE")),
+			want: "",
+		},
+		{
+			// Test case with calling traceIdFromRequest method with the request with the trace id header.
+			// As a result, want to receive the trace id from the header.
+			name: "trace id header",
+			ctx:  metadata.NewIncomingContext(context.Background(), metadata.Pairs("X-Trace-Id", "MOCK_TRACE")),
+			want: "MOCK_TRACE",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := traceIdFromRequest(tt.ctx); got != tt.want {
+				t.Errorf("traceIdFromRequest() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_playgroundController_process(t *testing.T) {
+	os.Setenv("MAX_CONCURRENT_PIPELINES", "1")
+	os.Setenv("MAX_QUEUE_WAIT", "50ms")
+	defer os.Unsetenv("MAX_CONCURRENT_PIPELINES")
+	defer os.Unsetenv("MAX_QUEUE_WAIT")
+	networkEnv, _ := environment.GetNetworkEnvsFromOsEnvs()
+	appEnv, err := environment.GetApplicationEnvsFromOsEnvs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sdkEnv, err := environment.ConfigureBeamEnvs(appEnv.WorkingDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		// maxQueueWait overrides the max queue wait of the environment in case it is positive
+		maxQueueWait  time.Duration
+		shutdownAfter time.Duration
+		running       int
+		wantStatus    pb.Status
+		wantBusy      bool
+	}{
+		{
+			// Test case with calling process method for the pipeline over the limit of concurrent pipelines
+			// when the running pipeline doesn't free its slot within the max queue wait.
+			// As a result, want the pipeline to be throttled with the error status and the "server busy" message.
+			name:       "pipeline over the limit",
+			running:    1,
+			wantStatus: pb.Status_STATUS_ERROR,
+			wantBusy:   true,
+		},
+		{
+			// Test case with calling process method for the pipeline over the limit of concurrent pipelines
+			// when the server is shutting down while the pipeline waits in the queue.
+			// As a result, want the pipeline to stop waiting and to be canceled with the shutdown reason.
+			name:          "shutdown while waiting in the queue",
+			maxQueueWait:  time.Minute,
+			shutdownAfter: 50 * time.Millisecond,
+			running:       1,
+			wantStatus:    pb.Status_STATUS_CANCELED,
+			wantBusy:      false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := &playgroundController{
+				env:          environment.NewEnvironment(*networkEnv, *sdkEnv, *appEnv),
+				cacheService: cacheService,
+				pipelinePool: newPipelinePool(appEnv.MaxConcurrentPipelines(), appEnv.MaxQueueWait()),
+			}
+			if tt.maxQueueWait > 0 {
+				controller.pipelinePool = newPipelinePool(appEnv.MaxConcurrentPipelines(), tt.maxQueueWait)
+			}
+			for i := 0; i < tt.running; i++ {
+				if !controller.pipelinePool.tryAcquire() {
+					t.Fatalf("running pipeline %d should acquire a slot", i)
+				}
+			}
+			pipelineId := uuid.New()
+			acquired := controller.pipelinePool.tryAcquire()
+			if acquired {
+				t.Fatalf("pipeline over the limit shouldn't acquire a slot")
+			}
+			lc, err := fs_tool.NewLifeCycle(pb.Sdk_SDK_JAVA, pipelineId, appEnv.WorkingDir())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err = lc.CreateFolders(); err != nil {
+				t.Fatal(err)
+			}
+			ctx, shutdown := context.WithCancel(context.Background())
+			defer shutdown()
+			if tt.shutdownAfter > 0 {
+				time.AfterFunc(tt.shutdownAfter, shutdown)
+			}
+			startedAt := time.Now()
+			controller.process(ctx, lc, pipelineId, acquired, "")
+			if tt.maxQueueWait > 0 && time.Since(startedAt) >= tt.maxQueueWait {
+				t.Errorf("process() waited in the queue %s", time.Since(startedAt))
+			}
+
+			status, err := cacheService.GetValue(context.Background(), pipelineId, cache.Status)
+			if err != nil || status != tt.wantStatus {
+				t.Errorf("process() status = %v, want %s", status, tt.wantStatus)
+			}
+			timestamps, _ := cacheService.GetValue(context.Background(), pipelineId, cache.Timestamps)
+			if saved, _ := timestamps.(map[pb.Status]time.Time); saved[tt.wantStatus].IsZero() {
+				t.Errorf("process() timestamps = %v, want timestamp of %s", timestamps, tt.wantStatus)
+			}
+			runError, err := cacheService.GetValue(context.Background(), pipelineId, cache.RunError)
+			if gotBusy := err == nil && strings.HasPrefix(fmt.Sprint(runError), "server busy"); gotBusy != tt.wantBusy {
+				t.Errorf("process() run error = %v, want the \"server busy\" message: %v", runError, tt.wantBusy)
+			}
+			if _, err = os.Stat(lc.GetAbsoluteBaseFolderPath()); !os.IsNotExist(err) {
+				t.Errorf("process() folders of the pipeline which isn't processed should be deleted")
+			}
+		})
+	}
+}
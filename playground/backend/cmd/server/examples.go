@@ -0,0 +1,123 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"beam.apache.org/playground/backend/internal/cloud_bucket"
+	"beam.apache.org/playground/backend/internal/environment"
+	"beam.apache.org/playground/backend/internal/fs_tool"
+	"beam.apache.org/playground/backend/internal/logger"
+	"beam.apache.org/playground/backend/internal/utils"
+	"context"
+	"github.com/google/uuid"
+	"sort"
+	"sync"
+)
+
+// validateExamples validates examples of the server's SDK which are stored at the cloud storage.
+// Examples are validated concurrently, no more than env.ApplicationEnvs.ExamplesValidationParallelism() at the same time.
+// Failures are logged per example and don't abort startup.
+func validateExamples(ctx context.Context, env *environment.Environment) {
+	bucket := cloud_bucket.New()
+	sdkToCategories, err := bucket.GetPrecompiledObjects(ctx, env.BeamSdkEnvs.ApacheBeamSdk, "")
+	if err != nil {
+		logger.Errorf("validateExamples(): cloud storage error: %s", err.Error())
+		return
+	}
+	cloudPaths := getExampleCloudPaths(sdkToCategories)
+	failed := runWithParallelism(cloudPaths, env.ApplicationEnvs.ExamplesValidationParallelism(), func(cloudPath string) error {
+		return validateExample(ctx, bucket, env, cloudPath)
+	})
+	logger.Infof("validateExamples(): %d of %d examples are valid\n", len(cloudPaths)-failed, len(cloudPaths))
+}
+
+// validateExample gets the code of the example from the cloud storage and checks it by validators of the server's SDK
+func validateExample(ctx context.Context, bucket *cloud_bucket.CloudStorage, env *environment.Environment, cloudPath string) error {
+	code, err := bucket.GetPrecompiledObject(ctx, cloudPath)
+	if err != nil {
+		return err
+	}
+	lc, err := fs_tool.NewLifeCycle(env.BeamSdkEnvs.ApacheBeamSdk, uuid.New(), env.ApplicationEnvs.SdkWorkingDir(env.BeamSdkEnvs.ApacheBeamSdk))
+	if err != nil {
+		return err
+	}
+	if err = lc.CreateFolders(); err != nil {
+		return err
+	}
+	defer lc.DeleteFolders()
+	if _, err = lc.CreateSourceCodeFile(*code); err != nil {
+		return err
+	}
+	validators, err := utils.GetValidators(env.BeamSdkEnvs.ApacheBeamSdk, lc.GetAbsoluteSourceFilePath())
+	if err != nil {
+		return err
+	}
+	for _, validator := range *validators {
+		if err = validator.Validator(validator.Args...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getExampleCloudPaths returns sorted cloud paths of all examples without duplicates
+// (the same example could be in several categories)
+func getExampleCloudPaths(sdkToCategories *cloud_bucket.SdkToCategories) []string {
+	unique := make(map[string]bool)
+	for _, categories := range *sdkToCategories {
+		for _, examples := range categories {
+			for _, example := range examples {
+				unique[example.CloudPath] = true
+			}
+		}
+	}
+	cloudPaths := make([]string, 0, len(unique))
+	for cloudPath := range unique {
+		cloudPaths = append(cloudPaths, cloudPath)
+	}
+	sort.Strings(cloudPaths)
+	return cloudPaths
+}
+
+// runWithParallelism calls validate for each cloud path, no more than parallelism calls at the same time.
+// Errors are logged per cloud path. Returns the number of failed calls.
+func runWithParallelism(cloudPaths []string, parallelism int, validate func(cloudPath string) error) int {
+	if parallelism < 1 {
+		parallelism = 1
+	}
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	failed := 0
+	semaphore := make(chan struct{}, parallelism)
+	for _, cloudPath := range cloudPaths {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(cloudPath string) {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+			if err := validate(cloudPath); err != nil {
+				logger.Errorf("%s: example validation failed: %s\n", cloudPath, err.Error())
+				mu.Lock()
+				failed++
+				mu.Unlock()
+			}
+		}(cloudPath)
+	}
+	wg.Wait()
+	return failed
+}
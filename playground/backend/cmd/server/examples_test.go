@@ -0,0 +1,135 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"beam.apache.org/playground/backend/internal/cloud_bucket"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func Test_getExampleCloudPaths(t *testing.T) {
+	tests := []struct {
+		name            string
+		sdkToCategories *cloud_bucket.SdkToCategories
+		want            []string
+	}{
+		{
+			// Test case with calling getExampleCloudPaths method with example which is in several categories.
+			// As a result, want to receive sorted cloud paths without duplicates.
+			name: "example in several categories",
+			sdkToCategories: &cloud_bucket.SdkToCategories{
+				"SDK_JAVA": cloud_bucket.CategoryToPrecompiledObjects{
+					"Common": cloud_bucket.PrecompiledObjects{{CloudPath: "SDK_JAVA/WordCount"}, {CloudPath: "SDK_JAVA/JoinExamples"}},
+					"IO":     cloud_bucket.PrecompiledObjects{{CloudPath: "SDK_JAVA/WordCount"}},
+				},
+			},
+			want: []string{"SDK_JAVA/JoinExamples", "SDK_JAVA/WordCount"},
+		},
+		{
+			// Test case with calling getExampleCloudPaths method without examples.
+			// As a result, want to receive an empty slice.
+			name:            "no examples",
+			sdkToCategories: &cloud_bucket.SdkToCategories{},
+			want:            []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getExampleCloudPaths(tt.sdkToCategories); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getExampleCloudPaths() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_runWithParallelism(t *testing.T) {
+	cloudPaths := make([]string, 0)
+	for i := 0; i < 10; i++ {
+		cloudPaths = append(cloudPaths, fmt.Sprintf("SDK_JAVA/Example%d", i))
+	}
+	type args struct {
+		cloudPaths  []string
+		parallelism int
+		failed      map[string]bool
+	}
+	tests := []struct {
+		name       string
+		args       args
+		want       int
+		wantCalled int
+	}{
+		{
+			// Test case with calling runWithParallelism method where some validations fail.
+			// As a result, want all examples to be validated and receive the number of failed validations.
+			name: "some validations fail",
+			args: args{
+				cloudPaths:  cloudPaths,
+				parallelism: 3,
+				failed:      map[string]bool{"SDK_JAVA/Example2": true, "SDK_JAVA/Example7": true},
+			},
+			want:       2,
+			wantCalled: 10,
+		},
+		{
+			// Test case with calling runWithParallelism method with parallelism less than one.
+			// As a result, want all examples to be validated one by one.
+			name: "incorrect parallelism",
+			args: args{
+				cloudPaths:  cloudPaths,
+				parallelism: 0,
+				failed:      map[string]bool{},
+			},
+			want:       0,
+			wantCalled: 10,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			called, running, maxRunning := 0, 0, 0
+			got := runWithParallelism(tt.args.cloudPaths, tt.args.parallelism, func(cloudPath string) error {
+				mu.Lock()
+				called++
+				running++
+				if running > maxRunning {
+					maxRunning = running
+				}
+				mu.Unlock()
+				time.Sleep(10 * time.Millisecond)
+				mu.Lock()
+				running--
+				mu.Unlock()
+				if tt.args.failed[cloudPath] {
+					return fmt.Errorf("MOCK_ERROR")
+				}
+				return nil
+			})
+			if got != tt.want {
+				t.Errorf("runWithParallelism() = %v, want %v", got, tt.want)
+			}
+			if called != tt.wantCalled {
+				t.Errorf("runWithParallelism() validated %v examples, want %v", called, tt.wantCalled)
+			}
+			if tt.args.parallelism > 0 && maxRunning > tt.args.parallelism || tt.args.parallelism < 1 && maxRunning > 1 {
+				t.Errorf("runWithParallelism() validated %v examples at the same time, want no more than %v", maxRunning, tt.args.parallelism)
+			}
+		})
+	}
+}
@@ -17,6 +17,7 @@ package main
 import (
 	"beam.apache.org/playground/backend/internal/environment"
 	"beam.apache.org/playground/backend/internal/logger"
+	"beam.apache.org/playground/backend/internal/metrics"
 	"context"
 	"net/http"
 )
@@ -33,3 +34,14 @@ func listenHttp(ctx context.Context, errChan chan error, envs environment.Networ
 		return
 	}
 }
+
+// listenMetrics serves metrics of the collector at /metrics on the separate TCP network address,
+// so they could be scraped regardless of the protocol of the API
+func listenMetrics(errChan chan error, address string, collector *metrics.Collector) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", collector.Handler())
+	logger.Infof("serving metrics at %s\n", address)
+	if err := http.ListenAndServe(address, mux); err != nil {
+		errChan <- err
+	}
+}
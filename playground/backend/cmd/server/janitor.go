@@ -0,0 +1,82 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"beam.apache.org/playground/backend/internal/cache"
+	"beam.apache.org/playground/backend/internal/code_processing"
+	"beam.apache.org/playground/backend/internal/environment"
+	"beam.apache.org/playground/backend/internal/errors"
+	"beam.apache.org/playground/backend/internal/fs_tool"
+	"beam.apache.org/playground/backend/internal/logger"
+	"context"
+	stderrors "errors"
+	"github.com/google/uuid"
+	"os"
+	"time"
+)
+
+// runJanitor removes stale folders of pipelines every appEnv.JanitorInterval() until ctx is done.
+// Folders could be left in the working dir or working dirs of SDKs if they couldn't be deleted after code processing.
+func runJanitor(ctx context.Context, cacheService cache.Cache, appEnv *environment.ApplicationEnvs) {
+	ticker := time.NewTicker(appEnv.JanitorInterval())
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for _, workingDir := range appEnv.WorkingDirs() {
+				if pipelineIds := removeStaleFolders(ctx, cacheService, workingDir, appEnv.StaleFolderAge()); len(pipelineIds) > 0 {
+					logger.Infof("runJanitor(): stale folders of %d pipelines have been removed from %s\n", len(pipelineIds), workingDir)
+				}
+			}
+		}
+	}
+}
+
+// removeStaleFolders removes folders of pipelines in workingDir which haven't been modified for staleAge
+// in case the pipeline is finished or its status isn't in cache anymore. Folders of pipelines which are still processed are kept.
+// In case the status couldn't be read from cache for another reason than errors.ErrCacheMiss, e.g. cache is unavailable, the folder is kept.
+// In case the folder couldn't be removed saves cache.CleanupFailed into cache.
+// Returns ids of pipelines which folders have been removed.
+func removeStaleFolders(ctx context.Context, cacheService cache.Cache, workingDir string, staleAge time.Duration) []uuid.UUID {
+	folders, err := fs_tool.StalePipelineFolders(workingDir, staleAge)
+	if err != nil {
+		logger.Errorf("removeStaleFolders(): %s\n", err.Error())
+		return nil
+	}
+	var pipelineIds []uuid.UUID
+	for pipelineId, folder := range folders {
+		// the pipeline is unknown to cache if there is no status, e.g. it has expired
+		status, err := cacheService.GetValue(ctx, pipelineId, cache.Status)
+		if err != nil && !stderrors.Is(err, errors.ErrCacheMiss) {
+			logger.WithPipelineId(pipelineId).Errorf("removeStaleFolders(): status isn't read from cache, the folder is kept: %s\n", err.Error())
+			continue
+		}
+		if pipelineStatus, ok := status.(pb.Status); ok && !code_processing.IsFinished(pipelineStatus) {
+			continue
+		}
+		if err := os.RemoveAll(folder); err != nil {
+			logger.WithPipelineId(pipelineId).Errorf("removeStaleFolders(): %s\n", err.Error())
+			cacheService.SetValue(ctx, pipelineId, cache.CleanupFailed, true)
+			continue
+		}
+		pipelineIds = append(pipelineIds, pipelineId)
+	}
+	return pipelineIds
+}
@@ -0,0 +1,129 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"beam.apache.org/playground/backend/internal/cache"
+	"beam.apache.org/playground/backend/internal/cache/local"
+	"beam.apache.org/playground/backend/internal/fs_tool"
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"os"
+	"testing"
+	"time"
+)
+
+func Test_removeStaleFolders(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	tests := []struct {
+		name string
+		// status is the status of the pipeline in cache, nil means that the pipeline is unknown to cache
+		status interface{}
+		// cacheErr is returned by cache on reading the status instead of the status in case it is set
+		cacheErr    error
+		age         time.Duration
+		wantRemoved bool
+	}{
+		{
+			// Test case with calling removeStaleFolders method with the stale folder of the finished pipeline.
+			// As a result, want the folder is removed.
+			name:        "finished pipeline",
+			status:      pb.Status_STATUS_FINISHED,
+			age:         time.Hour,
+			wantRemoved: true,
+		},
+		{
+			// Test case with calling removeStaleFolders method with the stale folder of the pipeline which is unknown to cache.
+			// As a result, want the folder is removed.
+			name:        "unknown pipeline",
+			status:      nil,
+			age:         time.Hour,
+			wantRemoved: true,
+		},
+		{
+			// Test case with calling removeStaleFolders method with the stale folder of the pipeline which is still processed.
+			// As a result, want the folder is kept.
+			name:        "executing pipeline",
+			status:      pb.Status_STATUS_EXECUTING,
+			age:         time.Hour,
+			wantRemoved: false,
+		},
+		{
+			// Test case with calling removeStaleFolders method with the fresh folder of the finished pipeline.
+			// As a result, want the folder is kept.
+			name:        "fresh folder",
+			status:      pb.Status_STATUS_FINISHED,
+			age:         0,
+			wantRemoved: false,
+		},
+		{
+			// Test case with calling removeStaleFolders method with the stale folder of the pipeline which status couldn't be read
+			// because cache is unavailable.
+			// As a result, want the folder is kept.
+			name:        "cache is unavailable",
+			status:      pb.Status_STATUS_EXECUTING,
+			cacheErr:    fmt.Errorf("MOCK_ERROR"),
+			age:         time.Hour,
+			wantRemoved: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workingDir := t.TempDir()
+			cacheService := local.New(ctx)
+			pipelineId := uuid.New()
+			lc, err := fs_tool.NewLifeCycle(pb.Sdk_SDK_JAVA, pipelineId, workingDir)
+			if err != nil {
+				t.Fatalf("NewLifeCycle() error = %v", err)
+			}
+			if err = lc.CreateFolders(); err != nil {
+				t.Fatalf("CreateFolders() error = %v", err)
+			}
+			modTime := time.Now().Add(-tt.age)
+			if err = os.Chtimes(lc.GetAbsoluteBaseFolderPath(), modTime, modTime); err != nil {
+				t.Fatalf("Chtimes() error = %v", err)
+			}
+			if tt.status != nil {
+				_ = cacheService.SetValue(ctx, pipelineId, cache.Status, tt.status)
+			}
+
+			var janitorCache cache.Cache = cacheService
+			if tt.cacheErr != nil {
+				janitorCache = &failingCache{Cache: cacheService, err: tt.cacheErr}
+			}
+			pipelineIds := removeStaleFolders(ctx, janitorCache, workingDir, time.Minute)
+			if removed := len(pipelineIds) == 1 && pipelineIds[0] == pipelineId; removed != tt.wantRemoved {
+				t.Errorf("removeStaleFolders() = %v, want removed %v", pipelineIds, tt.wantRemoved)
+			}
+			if _, err := os.Stat(lc.GetAbsoluteBaseFolderPath()); os.IsNotExist(err) != tt.wantRemoved {
+				t.Errorf("removeStaleFolders() folder exists = %v, want removed %v", !os.IsNotExist(err), tt.wantRemoved)
+			}
+		})
+	}
+}
+
+// failingCache is a cache which fails all reads with err
+type failingCache struct {
+	cache.Cache
+	err error
+}
+
+func (c *failingCache) GetValue(ctx context.Context, pipelineId uuid.UUID, subKey cache.SubKey) (interface{}, error) {
+	return nil, c.err
+}
@@ -0,0 +1,81 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"time"
+)
+
+// pipelinePool limits the number of pipelines which are processed at the same time.
+// Pipelines which exceed the limit wait in the queue for a free slot no longer than maxQueueWait.
+type pipelinePool struct {
+	slots        chan struct{}
+	maxQueueWait time.Duration
+}
+
+// newPipelinePool returns pipelinePool with size slots.
+// In case size isn't positive returns nil, so all pipelines are processed at once.
+func newPipelinePool(size int, maxQueueWait time.Duration) *pipelinePool {
+	if size <= 0 {
+		return nil
+	}
+	return &pipelinePool{slots: make(chan struct{}, size), maxQueueWait: maxQueueWait}
+}
+
+// tryAcquire takes a free slot without waiting.
+// Returns false if there is no free slot.
+func (p *pipelinePool) tryAcquire() bool {
+	if p == nil {
+		return true
+	}
+	select {
+	case p.slots <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+// acquire waits for a free slot and takes it.
+// Returns false if maxQueueWait is positive and there is no free slot during it or if ctx is done while waiting, e.g. on shutdown of the server.
+func (p *pipelinePool) acquire(ctx context.Context) bool {
+	if p == nil {
+		return true
+	}
+	var timeout <-chan time.Time
+	if p.maxQueueWait > 0 {
+		timer := time.NewTimer(p.maxQueueWait)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	select {
+	case p.slots <- struct{}{}:
+		return true
+	case <-timeout:
+		return false
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// release frees the slot taken by tryAcquire or acquire
+func (p *pipelinePool) release() {
+	if p == nil {
+		return
+	}
+	<-p.slots
+}
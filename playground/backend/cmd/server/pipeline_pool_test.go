@@ -0,0 +1,129 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func Test_pipelinePool_tryAcquire(t *testing.T) {
+	tests := []struct {
+		name     string
+		pool     *pipelinePool
+		acquired int
+		want     bool
+	}{
+		{
+			// Test case with calling tryAcquire method of the disabled pool.
+			// As a result, want to receive true.
+			name: "disabled pool",
+			pool: newPipelinePool(0, 0),
+			want: true,
+		},
+		{
+			// Test case with calling tryAcquire method of the pool with a free slot.
+			// As a result, want to receive true.
+			name:     "free slot",
+			pool:     newPipelinePool(2, 0),
+			acquired: 1,
+			want:     true,
+		},
+		{
+			// Test case with calling tryAcquire method of the pool without free slots.
+			// As a result, want to receive false.
+			name:     "no free slots",
+			pool:     newPipelinePool(2, 0),
+			acquired: 2,
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i < tt.acquired; i++ {
+				tt.pool.tryAcquire()
+			}
+			if got := tt.pool.tryAcquire(); got != tt.want {
+				t.Errorf("tryAcquire() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_pipelinePool_acquire(t *testing.T) {
+	tests := []struct {
+		name         string
+		maxQueueWait time.Duration
+		releaseAfter time.Duration
+		cancelAfter  time.Duration
+		want         bool
+	}{
+		{
+			// Test case with calling acquire method when the slot is released within the max queue wait.
+			// As a result, want to receive true.
+			name:         "slot is released within max queue wait",
+			maxQueueWait: time.Second,
+			releaseAfter: 50 * time.Millisecond,
+			want:         true,
+		},
+		{
+			// Test case with calling acquire method without the max queue wait when the slot is released.
+			// As a result, want to receive true.
+			name:         "no max queue wait",
+			maxQueueWait: 0,
+			releaseAfter: 50 * time.Millisecond,
+			want:         true,
+		},
+		{
+			// Test case with calling acquire method when the slot isn't released within the max queue wait.
+			// As a result, want to receive false.
+			name:         "max queue wait is exceeded",
+			maxQueueWait: 50 * time.Millisecond,
+			releaseAfter: time.Second,
+			want:         false,
+		},
+		{
+			// Test case with calling acquire method when the context is canceled, e.g. on shutdown, before the slot is released.
+			// As a result, want to receive false as soon as the context is canceled.
+			name:         "context is canceled while waiting",
+			maxQueueWait: 5 * time.Second,
+			releaseAfter: 5 * time.Second,
+			cancelAfter:  50 * time.Millisecond,
+			want:         false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool := newPipelinePool(1, tt.maxQueueWait)
+			pool.tryAcquire()
+			timer := time.AfterFunc(tt.releaseAfter, pool.release)
+			defer timer.Stop()
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tt.cancelAfter > 0 {
+				time.AfterFunc(tt.cancelAfter, cancel)
+			}
+			startedAt := time.Now()
+			if got := pool.acquire(ctx); got != tt.want {
+				t.Errorf("acquire() = %v, want %v", got, tt.want)
+			}
+			if tt.cancelAfter > 0 && time.Since(startedAt) >= tt.maxQueueWait {
+				t.Errorf("acquire() waited %s after the context is canceled", time.Since(startedAt))
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"beam.apache.org/playground/backend/internal/environment"
+	"fmt"
+	"os/exec"
+)
+
+// checkSdkToolchain checks that commands which compile, run and test the code of the server's SDK could be found.
+// Returns an error with the first command which couldn't be found.
+func checkSdkToolchain(executorConfig *environment.ExecutorConfig) error {
+	commands := []string{executorConfig.CompileCmd, executorConfig.RunCmd}
+	if executorConfig.Test != nil {
+		commands = append(commands, executorConfig.Test.Cmd)
+	}
+	for _, command := range commands {
+		// empty command means that the step isn't needed or the compiled file is run itself
+		if command == "" {
+			continue
+		}
+		if _, err := exec.LookPath(command); err != nil {
+			return fmt.Errorf("command of the SDK couldn't be found: %s", err.Error())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"beam.apache.org/playground/backend/internal/environment"
+	"testing"
+)
+
+func Test_checkSdkToolchain(t *testing.T) {
+	tests := []struct {
+		name           string
+		executorConfig *environment.ExecutorConfig
+		wantErr        bool
+	}{
+		{
+			// Test case with calling checkSdkToolchain method with commands which could be found.
+			// As a result, want to receive no error.
+			name:           "toolchain is found",
+			executorConfig: &environment.ExecutorConfig{CompileCmd: "sh", RunCmd: "", Test: &environment.TestConfig{Cmd: "sh"}},
+			wantErr:        false,
+		},
+		{
+			// Test case with calling checkSdkToolchain method with the run command which couldn't be found.
+			// As a result, want to receive an error.
+			name:           "run command isn't found",
+			executorConfig: &environment.ExecutorConfig{CompileCmd: "sh", RunCmd: "MOCK_RUN_CMD"},
+			wantErr:        true,
+		},
+		{
+			// Test case with calling checkSdkToolchain method with the test command which couldn't be found.
+			// As a result, want to receive an error.
+			name:           "test command isn't found",
+			executorConfig: &environment.ExecutorConfig{CompileCmd: "sh", Test: &environment.TestConfig{Cmd: "MOCK_TEST_CMD"}},
+			wantErr:        true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := checkSdkToolchain(tt.executorConfig); (err != nil) != tt.wantErr {
+				t.Errorf("checkSdkToolchain() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
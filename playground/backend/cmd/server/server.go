@@ -20,31 +20,66 @@ import (
 	"beam.apache.org/playground/backend/internal/cache"
 	"beam.apache.org/playground/backend/internal/cache/local"
 	"beam.apache.org/playground/backend/internal/cache/redis"
+	"beam.apache.org/playground/backend/internal/code_processing"
 	"beam.apache.org/playground/backend/internal/environment"
 	"beam.apache.org/playground/backend/internal/logger"
+	"beam.apache.org/playground/backend/internal/metrics"
+	"beam.apache.org/playground/backend/internal/result_storage"
+	"beam.apache.org/playground/backend/internal/result_storage/gcs"
+	localStorage "beam.apache.org/playground/backend/internal/result_storage/local"
 	"context"
+	"fmt"
 	"github.com/improbable-eng/grpc-web/go/grpcweb"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 )
 
 // runServer is starting http server wrapped on grpc
 func runServer() error {
-	ctx, cancel := context.WithCancel(context.Background())
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer cancel()
 
 	envService, err := setupEnvironment()
 	if err != nil {
 		return err
 	}
+	logger.SetJsonFormat(envService.ApplicationEnvs.JsonLogging())
 	grpcServer := grpc.NewServer()
 
 	cacheService, err := setupCache(ctx, envService.ApplicationEnvs)
 	if err != nil {
 		return err
 	}
+	resultStorage, err := setupResultStorage(ctx, envService.ApplicationEnvs)
+	if err != nil {
+		return err
+	}
+	if envService.ApplicationEnvs.ExamplesValidationParallelism() > 0 {
+		go validateExamples(ctx, envService)
+	}
+	if envService.ApplicationEnvs.JanitorInterval() > 0 {
+		go runJanitor(ctx, cacheService, &envService.ApplicationEnvs)
+	}
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+	shutdownCoordinator := newShutdownCoordinator(envService.ApplicationEnvs.ShutdownGracePeriod())
+	sdkDisabled := !checkSdk(envService, healthServer)
+	if !sdkDisabled && envService.ApplicationEnvs.WarmupEnabled() {
+		go warmupSdk(ctx, envService)
+	}
 	pb.RegisterPlaygroundServiceServer(grpcServer, &playgroundController{
-		env:          envService,
-		cacheService: cacheService,
+		env:                 envService,
+		cacheService:        cacheService,
+		submissionLimiter:   newSubmissionLimiter(envService.ApplicationEnvs.SubmissionWindow()),
+		pipelinePool:        newPipelinePool(envService.ApplicationEnvs.MaxConcurrentPipelines(), envService.ApplicationEnvs.MaxQueueWait()),
+		resultStorage:       resultStorage,
+		shutdownCoordinator: shutdownCoordinator,
+		sdkDisabled:         sdkDisabled,
 	})
 
 	errChan := make(chan error)
@@ -56,6 +91,9 @@ func runServer() error {
 		handler := Wrap(grpcServer, getGrpcWebOptions())
 		go listenHttp(ctx, errChan, envService.NetworkEnvs, handler)
 	}
+	if address := envService.ApplicationEnvs.MetricsAddress(); address != "" {
+		go listenMetrics(errChan, address, metrics.Default)
+	}
 
 	for {
 		select {
@@ -63,11 +101,42 @@ func runServer() error {
 			return err
 		case <-ctx.Done():
 			logger.Info("interrupt signal received; stopping...")
+			healthServer.Shutdown()
+			if pipelineIds := shutdownCoordinator.shutdown(cacheService, &envService.ApplicationEnvs); len(pipelineIds) > 0 {
+				logger.Warnf("%d pipelines haven't been finished during the grace period and have been canceled\n", len(pipelineIds))
+			}
 			return nil
 		}
 	}
 }
 
+// checkSdk checks the toolchain of the server's SDK in case it is enabled by the environment.
+// In case the toolchain is broken and the SDK is critical marks the server as not serving via healthServer,
+// so the server doesn't receive traffic. Returns false if the toolchain is broken.
+func checkSdk(env *environment.Environment, healthServer *health.Server) bool {
+	if !env.ApplicationEnvs.SdkCheckEnabled() {
+		return true
+	}
+	if err := checkSdkToolchain(env.BeamSdkEnvs.ExecutorConfig); err != nil {
+		if env.ApplicationEnvs.SdkCritical() {
+			logger.Errorf("checkSdk(): toolchain of the critical sdk %s is broken, the server isn't ready: %s\n", env.BeamSdkEnvs.ApacheBeamSdk, err.Error())
+			healthServer.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+		} else {
+			logger.Warnf("checkSdk(): toolchain of the sdk %s is broken, the sdk is disabled: %s\n", env.BeamSdkEnvs.ApacheBeamSdk, err.Error())
+		}
+		return false
+	}
+	return true
+}
+
+// warmupSdk warms up the toolchain of the server's SDK no longer than env.ApplicationEnvs.WarmupTimeout().
+// Failures are logged by code_processing.Warmup and don't abort startup.
+func warmupSdk(ctx context.Context, env *environment.Environment) {
+	ctx, cancel := context.WithTimeout(ctx, env.ApplicationEnvs.WarmupTimeout())
+	defer cancel()
+	_ = code_processing.Warmup(ctx, &env.ApplicationEnvs, &env.BeamSdkEnvs)
+}
+
 func setupEnvironment() (*environment.Environment, error) {
 	networkEnvs, err := environment.GetNetworkEnvsFromOsEnvs()
 	if err != nil {
@@ -77,6 +146,9 @@ func setupEnvironment() (*environment.Environment, error) {
 	if err != nil {
 		return nil, err
 	}
+	if err = appEnvs.ValidateSdkWorkingDirs(); err != nil {
+		return nil, err
+	}
 	beamEnvs, err := environment.ConfigureBeamEnvs(appEnvs.WorkingDir())
 	if err != nil {
 		return nil, err
@@ -96,13 +168,50 @@ func getGrpcWebOptions() []grpcweb.Option {
 
 }
 
-// setupCache constructs required cache by application environment
+// setupCache constructs required cache by application environment.
+// The cache is wrapped to apply expiration times of statuses, so the pipeline expires after it reaches a final status.
 func setupCache(ctx context.Context, appEnv environment.ApplicationEnvs) (cache.Cache, error) {
+	var cacheService cache.Cache
 	switch appEnv.CacheEnvs().CacheType() {
 	case "remote":
-		return redis.New(ctx, appEnv.CacheEnvs().Address())
+		redisCache, err := redis.New(ctx, appEnv.CacheEnvs().Address())
+		if err != nil {
+			return nil, err
+		}
+		cacheService = redisCache
+	default:
+		cacheService = local.New(ctx)
+	}
+	return cache.NewStatusExpTimeCache(cacheService, statusExpirationTimes(appEnv)), nil
+}
+
+// statusExpirationTimes returns expiration times of statuses which are provided by application environment.
+// Final statuses which aren't provided expire after the expiration time of cache keys counted from reaching the status.
+func statusExpirationTimes(appEnv environment.ApplicationEnvs) map[pb.Status]time.Duration {
+	expTimes := make(map[pb.Status]time.Duration)
+	for value := range pb.Status_name {
+		if status := pb.Status(value); code_processing.IsFinished(status) {
+			expTimes[status] = appEnv.CacheEnvs().KeyExpirationTime()
+		}
+	}
+	for status, expTime := range appEnv.StatusExpirationTimes() {
+		expTimes[status] = expTime
+	}
+	return expTimes
+}
+
+// setupResultStorage constructs the object storage where results of finished pipelines are persisted by application environment.
+// In case the type of the storage isn't provided returns nil, so results aren't persisted.
+func setupResultStorage(ctx context.Context, appEnv environment.ApplicationEnvs) (result_storage.Storage, error) {
+	switch appEnv.ResultStorageType() {
+	case "":
+		return nil, nil
+	case "local":
+		return localStorage.New(appEnv.ResultStorageAddress())
+	case "gcs":
+		return gcs.New(ctx, appEnv.ResultStorageAddress(), appEnv.ResultStorageCredentials())
 	default:
-		return local.New(ctx), nil
+		return nil, fmt.Errorf("unsupported result storage type: %s", appEnv.ResultStorageType())
 	}
 }
 
@@ -0,0 +1,78 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"beam.apache.org/playground/backend/internal/environment"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func Test_statusExpirationTimes(t *testing.T) {
+	tests := []struct {
+		name     string
+		expTimes string
+		status   pb.Status
+		want     time.Duration
+		wantSet  bool
+	}{
+		{
+			// Test case with calling statusExpirationTimes method with a final status which isn't provided.
+			// As a result, want to receive the expiration time of cache keys for the status.
+			name:    "final status by default",
+			status:  pb.Status_STATUS_FINISHED,
+			want:    time.Minute,
+			wantSet: true,
+		},
+		{
+			// Test case with calling statusExpirationTimes method with a final status which is provided.
+			// As a result, want to receive the provided expiration time for the status.
+			name:     "provided final status",
+			expTimes: "STATUS_RUN_ERROR=1h",
+			status:   pb.Status_STATUS_RUN_ERROR,
+			want:     time.Hour,
+			wantSet:  true,
+		},
+		{
+			// Test case with calling statusExpirationTimes method with a status which isn't final.
+			// As a result, want the status not to change expiration time.
+			name:    "status in progress",
+			status:  pb.Status_STATUS_EXECUTING,
+			wantSet: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Setenv("WORKING_DIR", "/app")
+			os.Setenv("KEY_EXPIRATION_TIME", "1m")
+			os.Setenv("STATUS_KEY_EXPIRATION_TIMES", tt.expTimes)
+			defer os.Unsetenv("WORKING_DIR")
+			defer os.Unsetenv("KEY_EXPIRATION_TIME")
+			defer os.Unsetenv("STATUS_KEY_EXPIRATION_TIMES")
+			appEnv, err := environment.GetApplicationEnvsFromOsEnvs()
+			if err != nil {
+				t.Fatalf("GetApplicationEnvsFromOsEnvs() error = %v", err)
+			}
+			got, found := statusExpirationTimes(*appEnv)[tt.status]
+			if found != tt.wantSet || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("statusExpirationTimes() got = %v, %v, want %v, %v", got, found, tt.want, tt.wantSet)
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"beam.apache.org/playground/backend/internal/cache"
+	"beam.apache.org/playground/backend/internal/code_processing"
+	"beam.apache.org/playground/backend/internal/environment"
+	"beam.apache.org/playground/backend/internal/fs_tool"
+	"beam.apache.org/playground/backend/internal/logger"
+	"context"
+	"github.com/google/uuid"
+	"sync"
+	"time"
+)
+
+// shutdownCoordinator tracks pipelines which are processed by the server, so they are drained on shutdown.
+// Code processing of tracked pipelines is canceled via the context of the coordinator.
+type shutdownCoordinator struct {
+	ctx         context.Context
+	cancel      context.CancelFunc
+	gracePeriod time.Duration
+
+	mu        sync.Mutex
+	stopped   bool
+	pipelines map[uuid.UUID]*fs_tool.LifeCycle
+	wg        sync.WaitGroup
+}
+
+// newShutdownCoordinator returns shutdownCoordinator which waits for running pipelines no longer than gracePeriod on shutdown
+func newShutdownCoordinator(gracePeriod time.Duration) *shutdownCoordinator {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &shutdownCoordinator{
+		ctx:         ctx,
+		cancel:      cancel,
+		gracePeriod: gracePeriod,
+		pipelines:   make(map[uuid.UUID]*fs_tool.LifeCycle),
+	}
+}
+
+// track registers the pipeline by pipelineId until untrack is called for it.
+// Returns the context which code processing of the pipeline should use.
+// Returns false if the server is shutting down, so the pipeline shouldn't be processed.
+func (sc *shutdownCoordinator) track(pipelineId uuid.UUID, lc *fs_tool.LifeCycle) (context.Context, bool) {
+	if sc == nil {
+		return context.Background(), true
+	}
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	if sc.stopped {
+		return nil, false
+	}
+	sc.pipelines[pipelineId] = lc
+	sc.wg.Add(1)
+	return sc.ctx, true
+}
+
+// untrack removes the pipeline registered by track once its code processing is finished
+func (sc *shutdownCoordinator) untrack(pipelineId uuid.UUID) {
+	if sc == nil {
+		return
+	}
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	if _, ok := sc.pipelines[pipelineId]; !ok {
+		return
+	}
+	delete(sc.pipelines, pipelineId)
+	sc.wg.Done()
+}
+
+// shutdown stops tracking new pipelines, cancels code processing of tracked pipelines and waits until they are finished no longer than gracePeriod.
+// Pipelines which aren't finished during gracePeriod are saved with playground.Status_STATUS_CANCELED as cache.Status
+// and playground.CancelReason_CANCEL_REASON_SHUTDOWN as cache.CancelReason into cache and their folders are deleted.
+// In case folders couldn't be deleted saves cache.CleanupFailed into cache, so the janitor removes them later.
+// Returns ids of pipelines which aren't finished during gracePeriod.
+func (sc *shutdownCoordinator) shutdown(cacheService cache.Cache, appEnv *environment.ApplicationEnvs) []uuid.UUID {
+	if sc == nil {
+		return nil
+	}
+	sc.mu.Lock()
+	sc.stopped = true
+	sc.mu.Unlock()
+	sc.cancel()
+
+	drained := make(chan struct{})
+	go func() {
+		sc.wg.Wait()
+		close(drained)
+	}()
+	timer := time.NewTimer(sc.gracePeriod)
+	defer timer.Stop()
+	select {
+	case <-drained:
+		return nil
+	case <-timer.C:
+	}
+
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	// the context of the coordinator is canceled, so the status is saved with a new context
+	ctx := context.Background()
+	var pipelineIds []uuid.UUID
+	for pipelineId, lc := range sc.pipelines {
+		logger.WithPipelineId(pipelineId).Warnf("shutdown(): pipeline hasn't been finished during the grace period %s\n", sc.gracePeriod)
+		cancelOnShutdown(ctx, cacheService, pipelineId, lc, appEnv)
+		pipelineIds = append(pipelineIds, pipelineId)
+	}
+	return pipelineIds
+}
+
+// cancelOnShutdown saves playground.Status_STATUS_CANCELED as cache.Status and playground.CancelReason_CANCEL_REASON_SHUTDOWN
+// as cache.CancelReason into cache for the pipeline which isn't processed because of shutdown and deletes its folders.
+// ctx shouldn't be the context of the coordinator which is already canceled.
+// In case folders couldn't be deleted saves cache.CleanupFailed into cache, so the janitor removes them later.
+func cancelOnShutdown(ctx context.Context, cacheService cache.Cache, pipelineId uuid.UUID, lc *fs_tool.LifeCycle, appEnv *environment.ApplicationEnvs) {
+	cacheService.SetValue(ctx, pipelineId, cache.CancelReason, pb.CancelReason_CANCEL_REASON_SHUTDOWN)
+	code_processing.SetStatus(ctx, cacheService, pipelineId, pb.Status_STATUS_CANCELED)
+	if err := code_processing.DeleteFolders(pipelineId, lc, appEnv); err != nil {
+		cacheService.SetValue(ctx, pipelineId, cache.CleanupFailed, true)
+	}
+}
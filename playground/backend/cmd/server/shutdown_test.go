@@ -0,0 +1,114 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"beam.apache.org/playground/backend/internal/cache"
+	"beam.apache.org/playground/backend/internal/cache/local"
+	"beam.apache.org/playground/backend/internal/environment"
+	"beam.apache.org/playground/backend/internal/fs_tool"
+	"context"
+	"github.com/google/uuid"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func Test_shutdownCoordinator_shutdown(t *testing.T) {
+	ctx := context.Background()
+	appEnv := environment.NewApplicationEnvs(t.TempDir(), environment.NewCacheEnvs("", "", 0), 0)
+	tests := []struct {
+		name string
+		// finishAfter is a duration after which the pipeline is untracked once the code processing context is canceled
+		finishAfter time.Duration
+		gracePeriod time.Duration
+		wantStatus  interface{}
+		wantDrained bool
+	}{
+		{
+			// Test case with calling shutdown method when the pipeline is finished within the grace period.
+			// As a result, want to receive no pipelines and the status of the pipeline shouldn't be changed.
+			name:        "pipeline is finished within grace period",
+			finishAfter: 50 * time.Millisecond,
+			gracePeriod: time.Second,
+			wantStatus:  pb.Status_STATUS_EXECUTING,
+			wantDrained: true,
+		},
+		{
+			// Test case with calling shutdown method when the pipeline isn't finished within the grace period.
+			// As a result, want to receive the pipeline, its status should be set as Status_STATUS_CANCELED with its timestamp and its folders should be deleted.
+			name:        "pipeline isn't finished within grace period",
+			finishAfter: time.Second,
+			gracePeriod: 50 * time.Millisecond,
+			wantStatus:  pb.Status_STATUS_CANCELED,
+			wantDrained: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cacheCtx, cancelCache := context.WithCancel(ctx)
+			defer cancelCache()
+			cacheService := local.New(cacheCtx)
+			pipelineId := uuid.New()
+			lc, err := fs_tool.NewLifeCycle(pb.Sdk_SDK_JAVA, pipelineId, appEnv.WorkingDir())
+			if err != nil {
+				t.Fatalf("NewLifeCycle() error = %v", err)
+			}
+			if err = lc.CreateFolders(); err != nil {
+				t.Fatalf("CreateFolders() error = %v", err)
+			}
+			_ = cacheService.SetValue(ctx, pipelineId, cache.Status, pb.Status_STATUS_EXECUTING)
+
+			coordinator := newShutdownCoordinator(tt.gracePeriod)
+			processCtx, ok := coordinator.track(pipelineId, lc)
+			if !ok {
+				t.Fatalf("track() = false, want true")
+			}
+			finished := make(chan struct{})
+			go func() {
+				defer close(finished)
+				<-processCtx.Done()
+				time.Sleep(tt.finishAfter)
+				coordinator.untrack(pipelineId)
+			}()
+
+			got := coordinator.shutdown(cacheService, appEnv)
+			<-finished
+			if drained := len(got) == 0; drained != tt.wantDrained {
+				t.Errorf("shutdown() = %v, want drained %v", got, tt.wantDrained)
+			}
+			if !tt.wantDrained && !reflect.DeepEqual(got, []uuid.UUID{pipelineId}) {
+				t.Errorf("shutdown() = %v, want %v", got, []uuid.UUID{pipelineId})
+			}
+			status, _ := cacheService.GetValue(ctx, pipelineId, cache.Status)
+			if !reflect.DeepEqual(status, tt.wantStatus) {
+				t.Errorf("shutdown() set status: %s, but expects: %s", status, tt.wantStatus)
+			}
+			timestamps, _ := cacheService.GetValue(ctx, pipelineId, cache.Timestamps)
+			if saved, _ := timestamps.(map[pb.Status]time.Time); !tt.wantDrained && saved[pb.Status_STATUS_CANCELED].IsZero() {
+				t.Errorf("shutdown() timestamps = %v, want timestamp of %s", timestamps, pb.Status_STATUS_CANCELED)
+			}
+			if _, err = os.Stat(lc.GetAbsoluteBaseFolderPath()); !tt.wantDrained && !os.IsNotExist(err) {
+				t.Errorf("shutdown() doesn't delete folders of the pipeline, error = %v", err)
+			}
+			if _, ok = coordinator.track(uuid.New(), lc); ok {
+				t.Errorf("track() after shutdown() = true, want false")
+			}
+		})
+	}
+}
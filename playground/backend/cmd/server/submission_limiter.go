@@ -0,0 +1,137 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"beam.apache.org/playground/backend/internal/utils"
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// submissionLimiter rejects identical submissions of the code within the window.
+// Submissions are identical if they have the same hash by submissionHash.
+type submissionLimiter struct {
+	mu              sync.Mutex
+	window          time.Duration
+	lastSubmissions map[string]time.Time
+}
+
+// newSubmissionLimiter returns submissionLimiter with the window.
+// In case the window isn't positive returns nil, so all submissions are allowed.
+func newSubmissionLimiter(window time.Duration) *submissionLimiter {
+	if window <= 0 {
+		return nil
+	}
+	return &submissionLimiter{window: window, lastSubmissions: make(map[string]time.Time)}
+}
+
+// allow returns true if an identical submission hasn't been allowed within the window before now.
+// Allowed submissions are remembered, expired submissions are forgotten.
+func (l *submissionLimiter) allow(info *pb.RunCodeRequest, now time.Time) bool {
+	if l == nil {
+		return true
+	}
+	hash := submissionHash(info)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for submissionHash, submittedAt := range l.lastSubmissions {
+		if now.Sub(submittedAt) >= l.window {
+			delete(l.lastSubmissions, submissionHash)
+		}
+	}
+	if _, found := l.lastSubmissions[hash]; found {
+		return false
+	}
+	l.lastSubmissions[hash] = now
+	return true
+}
+
+// submissionHash returns the hash of the submission.
+// It covers the same fields as the deduplication key of code processing: the SDK, the canonical form of the code,
+// additional source files and options which change the result, e.g. the output charset, pipeline arguments or environment variables,
+// and in addition files with tests and stdin, which results aren't deduplicated.
+// In case the canonical form of the code couldn't be received the code is hashed as is.
+func submissionHash(info *pb.RunCodeRequest) string {
+	code, err := utils.CanonicalizeSource(info.Sdk, info.Code)
+	if err != nil {
+		code = info.Code
+	}
+	h := sha256.New()
+	writeHashField(h, info.Sdk.String())
+	writeHashField(h, code)
+	writeHashField(h, strconv.FormatBool(info.CompileOnly))
+	writeHashField(h, strconv.FormatBool(info.Wasm))
+	writeHashField(h, info.OutputCharset)
+	writeHashField(h, info.OutputFormat)
+	writeHashField(h, info.LogLevelFilter)
+	writeHashField(h, info.BeamVersion)
+	for _, arg := range info.PipelineArgs {
+		writeHashField(h, arg)
+	}
+	writeHashField(h, "")
+	for _, library := range info.ClasspathLibraries {
+		writeHashField(h, library)
+	}
+	writeHashField(h, "")
+	if info.Dependency != nil {
+		writeHashField(h, info.Dependency.Name)
+		writeHashField(h, string(info.Dependency.Content))
+	}
+	writeHashField(h, "")
+	writeHashField(h, info.SampleDataset)
+	for _, name := range sortedKeys(info.Env) {
+		writeHashField(h, name+"="+info.Env[name])
+	}
+	writeHashField(h, "")
+	for _, name := range sortedKeys(info.AdditionalFiles) {
+		writeHashField(h, name)
+		writeHashField(h, info.AdditionalFiles[name])
+	}
+	writeHashField(h, "")
+	for _, name := range sortedKeys(info.TestFiles) {
+		writeHashField(h, name)
+		writeHashField(h, info.TestFiles[name])
+	}
+	writeHashField(h, "")
+	for _, chunk := range info.Stdin {
+		writeHashField(h, string(chunk.Data))
+		writeHashField(h, strconv.FormatInt(chunk.DelayMs, 10))
+	}
+	writeHashField(h, info.StdInput)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// writeHashField writes value to h followed by the separator, so values couldn't be mixed up
+func writeHashField(h hash.Hash, value string) {
+	h.Write([]byte(value))
+	h.Write([]byte{0})
+}
+
+// sortedKeys returns keys of the map in the sorted order, so the hash doesn't depend on the order of the map
+func sortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
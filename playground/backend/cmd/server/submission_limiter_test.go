@@ -0,0 +1,133 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"testing"
+	"time"
+)
+
+func Test_submissionLimiter_allow(t *testing.T) {
+	now := time.Now()
+	submission := &pb.RunCodeRequest{Code: "MOCK_CODE", Sdk: pb.Sdk_SDK_JAVA}
+	type args struct {
+		info *pb.RunCodeRequest
+		now  time.Time
+	}
+	tests := []struct {
+		name    string
+		limiter *submissionLimiter
+		args    args
+		want    bool
+	}{
+		{
+			// Test case with calling allow method of the disabled limiter.
+			// As a result, want to receive true.
+			name:    "disabled limiter",
+			limiter: newSubmissionLimiter(0),
+			args:    args{info: submission, now: now},
+			want:    true,
+		},
+		{
+			// Test case with calling allow method for the first submission of the code.
+			// As a result, want to receive true.
+			name:    "first submission",
+			limiter: &submissionLimiter{window: time.Minute, lastSubmissions: map[string]time.Time{}},
+			args:    args{info: submission, now: now},
+			want:    true,
+		},
+		{
+			// Test case with calling allow method for the identical submission within the window.
+			// As a result, want to receive false.
+			name:    "identical submission within window",
+			limiter: &submissionLimiter{window: time.Minute, lastSubmissions: map[string]time.Time{submissionHash(submission): now.Add(-time.Second)}},
+			args:    args{info: &pb.RunCodeRequest{Code: "MOCK_CODE", Sdk: pb.Sdk_SDK_JAVA}, now: now},
+			want:    false,
+		},
+		{
+			// Test case with calling allow method for the identical submission after the window.
+			// As a result, want to receive true.
+			name:    "identical submission after window",
+			limiter: &submissionLimiter{window: time.Minute, lastSubmissions: map[string]time.Time{submissionHash(submission): now.Add(-time.Minute)}},
+			args:    args{info: submission, now: now},
+			want:    true,
+		},
+		{
+			// Test case with calling allow method for the submission which differs only by comments and indentation within the window.
+			// As a result, want to receive false.
+			name:    "submission with other comments within window",
+			limiter: &submissionLimiter{window: time.Minute, lastSubmissions: map[string]time.Time{submissionHash(submission): now.Add(-time.Second)}},
+			args:    args{info: &pb.RunCodeRequest{Code: "// comment\n    MOCK_CODE\n", Sdk: pb.Sdk_SDK_JAVA}, now: now},
+			want:    false,
+		},
+		{
+			// Test case with calling allow method for the submission with other files with tests within the window.
+			// As a result, want to receive true.
+			name:    "submission with other tests within window",
+			limiter: &submissionLimiter{window: time.Minute, lastSubmissions: map[string]time.Time{submissionHash(submission): now.Add(-time.Second)}},
+			args:    args{info: &pb.RunCodeRequest{Code: "MOCK_CODE", Sdk: pb.Sdk_SDK_JAVA, TestFiles: map[string]string{"MOCK_TEST": "MOCK_TEST_CODE"}}, now: now},
+			want:    true,
+		},
+		{
+			// Test case with calling allow method for the submission with other pipeline arguments within the window.
+			// As a result, want to receive true, since the result of the code could differ.
+			name:    "submission with other pipeline arguments within window",
+			limiter: &submissionLimiter{window: time.Minute, lastSubmissions: map[string]time.Time{submissionHash(submission): now.Add(-time.Second)}},
+			args:    args{info: &pb.RunCodeRequest{Code: "MOCK_CODE", Sdk: pb.Sdk_SDK_JAVA, PipelineArgs: []string{"--streaming"}}, now: now},
+			want:    true,
+		},
+		{
+			// Test case with calling allow method for the submission with other environment variables within the window.
+			// As a result, want to receive true, since the result of the code could differ.
+			name:    "submission with other environment variables within window",
+			limiter: &submissionLimiter{window: time.Minute, lastSubmissions: map[string]time.Time{submissionHash(submission): now.Add(-time.Second)}},
+			args:    args{info: &pb.RunCodeRequest{Code: "MOCK_CODE", Sdk: pb.Sdk_SDK_JAVA, Env: map[string]string{"MOCK_NAME": "MOCK_VALUE"}}, now: now},
+			want:    true,
+		},
+		{
+			// Test case with calling allow method for the submission with other additional source files within the window.
+			// As a result, want to receive true, since the result of the code could differ.
+			name:    "submission with other additional files within window",
+			limiter: &submissionLimiter{window: time.Minute, lastSubmissions: map[string]time.Time{submissionHash(submission): now.Add(-time.Second)}},
+			args:    args{info: &pb.RunCodeRequest{Code: "MOCK_CODE", Sdk: pb.Sdk_SDK_JAVA, AdditionalFiles: map[string]string{"Helper.java": "MOCK_HELPER"}}, now: now},
+			want:    true,
+		},
+		{
+			// Test case with calling allow method for the submission with other stdin within the window.
+			// As a result, want to receive true, since the result of the code could differ.
+			name:    "submission with other stdin within window",
+			limiter: &submissionLimiter{window: time.Minute, lastSubmissions: map[string]time.Time{submissionHash(submission): now.Add(-time.Second)}},
+			args:    args{info: &pb.RunCodeRequest{Code: "MOCK_CODE", Sdk: pb.Sdk_SDK_JAVA, Stdin: []*pb.StdinChunk{{Data: []byte("MOCK_INPUT")}}}, now: now},
+			want:    true,
+		},
+		{
+			// Test case with calling allow method for the submission with other stdin data within the window.
+			// As a result, want to receive true, since the result of the code could differ.
+			name:    "submission with other stdin data within window",
+			limiter: &submissionLimiter{window: time.Minute, lastSubmissions: map[string]time.Time{submissionHash(submission): now.Add(-time.Second)}},
+			args:    args{info: &pb.RunCodeRequest{Code: "MOCK_CODE", Sdk: pb.Sdk_SDK_JAVA, StdInput: "MOCK_INPUT"}, now: now},
+			want:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.limiter.allow(tt.args.info, tt.args.now); got != tt.want {
+				t.Errorf("allow() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -37,18 +37,56 @@ const _ = grpc.SupportPackageIsVersion7
 type PlaygroundServiceClient interface {
 	// Submit the job for an execution and get the pipeline uuid.
 	RunCode(ctx context.Context, in *RunCodeRequest, opts ...grpc.CallOption) (*RunCodeResponse, error)
+	// Submit several codes and wait until all of them are processed.
+	RunCodeBatch(ctx context.Context, in *RunCodeBatchRequest, opts ...grpc.CallOption) (*RunCodeBatchResponse, error)
+	// Submit variants of the same example in the SDK of the backend and wait until all of them are processed.
+	RunCodeGroup(ctx context.Context, in *RunCodeGroupRequest, opts ...grpc.CallOption) (*RunCodeGroupResponse, error)
 	// Get the status of pipeline execution.
 	CheckStatus(ctx context.Context, in *CheckStatusRequest, opts ...grpc.CallOption) (*CheckStatusResponse, error)
 	// Get the result of pipeline execution.
 	GetRunOutput(ctx context.Context, in *GetRunOutputRequest, opts ...grpc.CallOption) (*GetRunOutputResponse, error)
+	// Get the run output of pipeline execution as it is written until the execution is finished.
+	StreamRunOutput(ctx context.Context, in *StreamRunOutputRequest, opts ...grpc.CallOption) (PlaygroundService_StreamRunOutputClient, error)
 	// Get the logs of pipeline execution.
 	GetLogs(ctx context.Context, in *GetLogsRequest, opts ...grpc.CallOption) (*GetLogsResponse, error)
 	// Get the error of pipeline execution.
 	GetRunError(ctx context.Context, in *GetRunErrorRequest, opts ...grpc.CallOption) (*GetRunErrorResponse, error)
+	// Get the code of the pipeline, e.g. to open the pipeline by a shared link.
+	GetSource(ctx context.Context, in *GetSourceRequest, opts ...grpc.CallOption) (*GetSourceResponse, error)
+	// Get the imports of the code detected during the preparation.
+	GetDependencies(ctx context.Context, in *GetDependenciesRequest, opts ...grpc.CallOption) (*GetDependenciesResponse, error)
 	// Get the result of pipeline compilation.
 	GetCompileOutput(ctx context.Context, in *GetCompileOutputRequest, opts ...grpc.CallOption) (*GetCompileOutputResponse, error)
+	// Get errors of the validation step with their positions, e.g. to underline them in the editor.
+	GetValidationDiagnostics(ctx context.Context, in *GetValidationDiagnosticsRequest, opts ...grpc.CallOption) (*GetValidationDiagnosticsResponse, error)
+	// Get the results of tests run against the code.
+	GetTestResults(ctx context.Context, in *GetTestResultsRequest, opts ...grpc.CallOption) (*GetTestResultsResponse, error)
+	// Get the files written by pipeline execution.
+	GetOutputFiles(ctx context.Context, in *GetOutputFilesRequest, opts ...grpc.CallOption) (*GetOutputFilesResponse, error)
+	// Get records parsed from the output of pipeline execution in the JSON lines format.
+	GetRunRecords(ctx context.Context, in *GetRunRecordsRequest, opts ...grpc.CallOption) (*GetRunRecordsResponse, error)
+	// Get the file compiled from the code by chunks.
+	GetArtifact(ctx context.Context, in *GetArtifactRequest, opts ...grpc.CallOption) (PlaygroundService_GetArtifactClient, error)
+	// Get the output of pipeline execution formatted to be displayed.
+	GetFormattedOutput(ctx context.Context, in *GetFormattedOutputRequest, opts ...grpc.CallOption) (*GetFormattedOutputResponse, error)
+	// Get the coverage summary of pipeline execution.
+	GetCoverage(ctx context.Context, in *GetCoverageRequest, opts ...grpc.CallOption) (*GetCoverageResponse, error)
+	// Get the diff of outputs of two pipelines, e.g. the same code run on different SDKs.
+	GetCrossSdkDiff(ctx context.Context, in *GetCrossSdkDiffRequest, opts ...grpc.CallOption) (*GetCrossSdkDiffResponse, error)
+	// Get the element count metrics of pipeline execution.
+	GetMetrics(ctx context.Context, in *GetMetricsRequest, opts ...grpc.CallOption) (*GetMetricsResponse, error)
+	// Get the sample elements of PCollections of pipeline execution.
+	GetIntermediateSamples(ctx context.Context, in *GetIntermediateSamplesRequest, opts ...grpc.CallOption) (*GetIntermediateSamplesResponse, error)
+	// Get the wall-clock times when code processing is started and finished.
+	GetTimestamps(ctx context.Context, in *GetTimestampsRequest, opts ...grpc.CallOption) (*GetTimestampsResponse, error)
+	// Get the working directory and arguments of commands which are used to compile and run the code.
+	GetInvocation(ctx context.Context, in *GetInvocationRequest, opts ...grpc.CallOption) (*GetInvocationResponse, error)
 	// Cancel code processing
 	Cancel(ctx context.Context, in *CancelRequest, opts ...grpc.CallOption) (*CancelResponse, error)
+	// Extend the timeout of code processing
+	ExtendTimeout(ctx context.Context, in *ExtendTimeoutRequest, opts ...grpc.CallOption) (*ExtendTimeoutResponse, error)
+	// Check whether the SDK with versions of its language and Apache Beam is available.
+	CheckSdkAvailability(ctx context.Context, in *CheckSdkAvailabilityRequest, opts ...grpc.CallOption) (*CheckSdkAvailabilityResponse, error)
 	// Get all precompiled objects from the cloud storage.
 	GetPrecompiledObjects(ctx context.Context, in *GetPrecompiledObjectsRequest, opts ...grpc.CallOption) (*GetPrecompiledObjectsResponse, error)
 	// Get the code of an PrecompiledObject.
@@ -74,6 +112,24 @@ func (c *playgroundServiceClient) RunCode(ctx context.Context, in *RunCodeReques
 	return out, nil
 }
 
+func (c *playgroundServiceClient) RunCodeBatch(ctx context.Context, in *RunCodeBatchRequest, opts ...grpc.CallOption) (*RunCodeBatchResponse, error) {
+	out := new(RunCodeBatchResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.PlaygroundService/RunCodeBatch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playgroundServiceClient) RunCodeGroup(ctx context.Context, in *RunCodeGroupRequest, opts ...grpc.CallOption) (*RunCodeGroupResponse, error) {
+	out := new(RunCodeGroupResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.PlaygroundService/RunCodeGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *playgroundServiceClient) CheckStatus(ctx context.Context, in *CheckStatusRequest, opts ...grpc.CallOption) (*CheckStatusResponse, error) {
 	out := new(CheckStatusResponse)
 	err := c.cc.Invoke(ctx, "/api.v1.PlaygroundService/CheckStatus", in, out, opts...)
@@ -92,6 +148,38 @@ func (c *playgroundServiceClient) GetRunOutput(ctx context.Context, in *GetRunOu
 	return out, nil
 }
 
+func (c *playgroundServiceClient) StreamRunOutput(ctx context.Context, in *StreamRunOutputRequest, opts ...grpc.CallOption) (PlaygroundService_StreamRunOutputClient, error) {
+	stream, err := c.cc.NewStream(ctx, &PlaygroundService_ServiceDesc.Streams[0], "/api.v1.PlaygroundService/StreamRunOutput", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &playgroundServiceStreamRunOutputClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type PlaygroundService_StreamRunOutputClient interface {
+	Recv() (*StreamRunOutputResponse, error)
+	grpc.ClientStream
+}
+
+type playgroundServiceStreamRunOutputClient struct {
+	grpc.ClientStream
+}
+
+func (x *playgroundServiceStreamRunOutputClient) Recv() (*StreamRunOutputResponse, error) {
+	m := new(StreamRunOutputResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *playgroundServiceClient) GetLogs(ctx context.Context, in *GetLogsRequest, opts ...grpc.CallOption) (*GetLogsResponse, error) {
 	out := new(GetLogsResponse)
 	err := c.cc.Invoke(ctx, "/api.v1.PlaygroundService/GetLogs", in, out, opts...)
@@ -110,6 +198,24 @@ func (c *playgroundServiceClient) GetRunError(ctx context.Context, in *GetRunErr
 	return out, nil
 }
 
+func (c *playgroundServiceClient) GetSource(ctx context.Context, in *GetSourceRequest, opts ...grpc.CallOption) (*GetSourceResponse, error) {
+	out := new(GetSourceResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.PlaygroundService/GetSource", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playgroundServiceClient) GetDependencies(ctx context.Context, in *GetDependenciesRequest, opts ...grpc.CallOption) (*GetDependenciesResponse, error) {
+	out := new(GetDependenciesResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.PlaygroundService/GetDependencies", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *playgroundServiceClient) GetCompileOutput(ctx context.Context, in *GetCompileOutputRequest, opts ...grpc.CallOption) (*GetCompileOutputResponse, error) {
 	out := new(GetCompileOutputResponse)
 	err := c.cc.Invoke(ctx, "/api.v1.PlaygroundService/GetCompileOutput", in, out, opts...)
@@ -119,6 +225,137 @@ func (c *playgroundServiceClient) GetCompileOutput(ctx context.Context, in *GetC
 	return out, nil
 }
 
+func (c *playgroundServiceClient) GetValidationDiagnostics(ctx context.Context, in *GetValidationDiagnosticsRequest, opts ...grpc.CallOption) (*GetValidationDiagnosticsResponse, error) {
+	out := new(GetValidationDiagnosticsResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.PlaygroundService/GetValidationDiagnostics", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playgroundServiceClient) GetTestResults(ctx context.Context, in *GetTestResultsRequest, opts ...grpc.CallOption) (*GetTestResultsResponse, error) {
+	out := new(GetTestResultsResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.PlaygroundService/GetTestResults", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playgroundServiceClient) GetOutputFiles(ctx context.Context, in *GetOutputFilesRequest, opts ...grpc.CallOption) (*GetOutputFilesResponse, error) {
+	out := new(GetOutputFilesResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.PlaygroundService/GetOutputFiles", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playgroundServiceClient) GetRunRecords(ctx context.Context, in *GetRunRecordsRequest, opts ...grpc.CallOption) (*GetRunRecordsResponse, error) {
+	out := new(GetRunRecordsResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.PlaygroundService/GetRunRecords", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playgroundServiceClient) GetArtifact(ctx context.Context, in *GetArtifactRequest, opts ...grpc.CallOption) (PlaygroundService_GetArtifactClient, error) {
+	stream, err := c.cc.NewStream(ctx, &PlaygroundService_ServiceDesc.Streams[1], "/api.v1.PlaygroundService/GetArtifact", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &playgroundServiceGetArtifactClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type PlaygroundService_GetArtifactClient interface {
+	Recv() (*GetArtifactResponse, error)
+	grpc.ClientStream
+}
+
+type playgroundServiceGetArtifactClient struct {
+	grpc.ClientStream
+}
+
+func (x *playgroundServiceGetArtifactClient) Recv() (*GetArtifactResponse, error) {
+	m := new(GetArtifactResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *playgroundServiceClient) GetFormattedOutput(ctx context.Context, in *GetFormattedOutputRequest, opts ...grpc.CallOption) (*GetFormattedOutputResponse, error) {
+	out := new(GetFormattedOutputResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.PlaygroundService/GetFormattedOutput", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playgroundServiceClient) GetCoverage(ctx context.Context, in *GetCoverageRequest, opts ...grpc.CallOption) (*GetCoverageResponse, error) {
+	out := new(GetCoverageResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.PlaygroundService/GetCoverage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playgroundServiceClient) GetCrossSdkDiff(ctx context.Context, in *GetCrossSdkDiffRequest, opts ...grpc.CallOption) (*GetCrossSdkDiffResponse, error) {
+	out := new(GetCrossSdkDiffResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.PlaygroundService/GetCrossSdkDiff", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playgroundServiceClient) GetMetrics(ctx context.Context, in *GetMetricsRequest, opts ...grpc.CallOption) (*GetMetricsResponse, error) {
+	out := new(GetMetricsResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.PlaygroundService/GetMetrics", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playgroundServiceClient) GetIntermediateSamples(ctx context.Context, in *GetIntermediateSamplesRequest, opts ...grpc.CallOption) (*GetIntermediateSamplesResponse, error) {
+	out := new(GetIntermediateSamplesResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.PlaygroundService/GetIntermediateSamples", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playgroundServiceClient) GetTimestamps(ctx context.Context, in *GetTimestampsRequest, opts ...grpc.CallOption) (*GetTimestampsResponse, error) {
+	out := new(GetTimestampsResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.PlaygroundService/GetTimestamps", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playgroundServiceClient) GetInvocation(ctx context.Context, in *GetInvocationRequest, opts ...grpc.CallOption) (*GetInvocationResponse, error) {
+	out := new(GetInvocationResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.PlaygroundService/GetInvocation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *playgroundServiceClient) Cancel(ctx context.Context, in *CancelRequest, opts ...grpc.CallOption) (*CancelResponse, error) {
 	out := new(CancelResponse)
 	err := c.cc.Invoke(ctx, "/api.v1.PlaygroundService/Cancel", in, out, opts...)
@@ -128,6 +365,24 @@ func (c *playgroundServiceClient) Cancel(ctx context.Context, in *CancelRequest,
 	return out, nil
 }
 
+func (c *playgroundServiceClient) ExtendTimeout(ctx context.Context, in *ExtendTimeoutRequest, opts ...grpc.CallOption) (*ExtendTimeoutResponse, error) {
+	out := new(ExtendTimeoutResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.PlaygroundService/ExtendTimeout", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playgroundServiceClient) CheckSdkAvailability(ctx context.Context, in *CheckSdkAvailabilityRequest, opts ...grpc.CallOption) (*CheckSdkAvailabilityResponse, error) {
+	out := new(CheckSdkAvailabilityResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.PlaygroundService/CheckSdkAvailability", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *playgroundServiceClient) GetPrecompiledObjects(ctx context.Context, in *GetPrecompiledObjectsRequest, opts ...grpc.CallOption) (*GetPrecompiledObjectsResponse, error) {
 	out := new(GetPrecompiledObjectsResponse)
 	err := c.cc.Invoke(ctx, "/api.v1.PlaygroundService/GetPrecompiledObjects", in, out, opts...)
@@ -161,18 +416,56 @@ func (c *playgroundServiceClient) GetPrecompiledObjectOutput(ctx context.Context
 type PlaygroundServiceServer interface {
 	// Submit the job for an execution and get the pipeline uuid.
 	RunCode(context.Context, *RunCodeRequest) (*RunCodeResponse, error)
+	// Submit several codes and wait until all of them are processed.
+	RunCodeBatch(context.Context, *RunCodeBatchRequest) (*RunCodeBatchResponse, error)
+	// Submit variants of the same example in the SDK of the backend and wait until all of them are processed.
+	RunCodeGroup(context.Context, *RunCodeGroupRequest) (*RunCodeGroupResponse, error)
 	// Get the status of pipeline execution.
 	CheckStatus(context.Context, *CheckStatusRequest) (*CheckStatusResponse, error)
 	// Get the result of pipeline execution.
 	GetRunOutput(context.Context, *GetRunOutputRequest) (*GetRunOutputResponse, error)
+	// Get the run output of pipeline execution as it is written until the execution is finished.
+	StreamRunOutput(*StreamRunOutputRequest, PlaygroundService_StreamRunOutputServer) error
 	// Get the logs of pipeline execution.
 	GetLogs(context.Context, *GetLogsRequest) (*GetLogsResponse, error)
 	// Get the error of pipeline execution.
 	GetRunError(context.Context, *GetRunErrorRequest) (*GetRunErrorResponse, error)
+	// Get the code of the pipeline, e.g. to open the pipeline by a shared link.
+	GetSource(context.Context, *GetSourceRequest) (*GetSourceResponse, error)
+	// Get the imports of the code detected during the preparation.
+	GetDependencies(context.Context, *GetDependenciesRequest) (*GetDependenciesResponse, error)
 	// Get the result of pipeline compilation.
 	GetCompileOutput(context.Context, *GetCompileOutputRequest) (*GetCompileOutputResponse, error)
+	// Get errors of the validation step with their positions, e.g. to underline them in the editor.
+	GetValidationDiagnostics(context.Context, *GetValidationDiagnosticsRequest) (*GetValidationDiagnosticsResponse, error)
+	// Get the results of tests run against the code.
+	GetTestResults(context.Context, *GetTestResultsRequest) (*GetTestResultsResponse, error)
+	// Get the files written by pipeline execution.
+	GetOutputFiles(context.Context, *GetOutputFilesRequest) (*GetOutputFilesResponse, error)
+	// Get records parsed from the output of pipeline execution in the JSON lines format.
+	GetRunRecords(context.Context, *GetRunRecordsRequest) (*GetRunRecordsResponse, error)
+	// Get the file compiled from the code by chunks.
+	GetArtifact(*GetArtifactRequest, PlaygroundService_GetArtifactServer) error
+	// Get the output of pipeline execution formatted to be displayed.
+	GetFormattedOutput(context.Context, *GetFormattedOutputRequest) (*GetFormattedOutputResponse, error)
+	// Get the coverage summary of pipeline execution.
+	GetCoverage(context.Context, *GetCoverageRequest) (*GetCoverageResponse, error)
+	// Get the diff of outputs of two pipelines, e.g. the same code run on different SDKs.
+	GetCrossSdkDiff(context.Context, *GetCrossSdkDiffRequest) (*GetCrossSdkDiffResponse, error)
+	// Get the element count metrics of pipeline execution.
+	GetMetrics(context.Context, *GetMetricsRequest) (*GetMetricsResponse, error)
+	// Get the sample elements of PCollections of pipeline execution.
+	GetIntermediateSamples(context.Context, *GetIntermediateSamplesRequest) (*GetIntermediateSamplesResponse, error)
+	// Get the wall-clock times when code processing is started and finished.
+	GetTimestamps(context.Context, *GetTimestampsRequest) (*GetTimestampsResponse, error)
+	// Get the working directory and arguments of commands which are used to compile and run the code.
+	GetInvocation(context.Context, *GetInvocationRequest) (*GetInvocationResponse, error)
 	// Cancel code processing
 	Cancel(context.Context, *CancelRequest) (*CancelResponse, error)
+	// Extend the timeout of code processing
+	ExtendTimeout(context.Context, *ExtendTimeoutRequest) (*ExtendTimeoutResponse, error)
+	// Check whether the SDK with versions of its language and Apache Beam is available.
+	CheckSdkAvailability(context.Context, *CheckSdkAvailabilityRequest) (*CheckSdkAvailabilityResponse, error)
 	// Get all precompiled objects from the cloud storage.
 	GetPrecompiledObjects(context.Context, *GetPrecompiledObjectsRequest) (*GetPrecompiledObjectsResponse, error)
 	// Get the code of an PrecompiledObject.
@@ -188,24 +481,81 @@ type UnimplementedPlaygroundServiceServer struct {
 func (UnimplementedPlaygroundServiceServer) RunCode(context.Context, *RunCodeRequest) (*RunCodeResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RunCode not implemented")
 }
+func (UnimplementedPlaygroundServiceServer) RunCodeBatch(context.Context, *RunCodeBatchRequest) (*RunCodeBatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RunCodeBatch not implemented")
+}
+func (UnimplementedPlaygroundServiceServer) RunCodeGroup(context.Context, *RunCodeGroupRequest) (*RunCodeGroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RunCodeGroup not implemented")
+}
 func (UnimplementedPlaygroundServiceServer) CheckStatus(context.Context, *CheckStatusRequest) (*CheckStatusResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CheckStatus not implemented")
 }
 func (UnimplementedPlaygroundServiceServer) GetRunOutput(context.Context, *GetRunOutputRequest) (*GetRunOutputResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetRunOutput not implemented")
 }
+func (UnimplementedPlaygroundServiceServer) StreamRunOutput(*StreamRunOutputRequest, PlaygroundService_StreamRunOutputServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamRunOutput not implemented")
+}
 func (UnimplementedPlaygroundServiceServer) GetLogs(context.Context, *GetLogsRequest) (*GetLogsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetLogs not implemented")
 }
 func (UnimplementedPlaygroundServiceServer) GetRunError(context.Context, *GetRunErrorRequest) (*GetRunErrorResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetRunError not implemented")
 }
+func (UnimplementedPlaygroundServiceServer) GetSource(context.Context, *GetSourceRequest) (*GetSourceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSource not implemented")
+}
+func (UnimplementedPlaygroundServiceServer) GetDependencies(context.Context, *GetDependenciesRequest) (*GetDependenciesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDependencies not implemented")
+}
 func (UnimplementedPlaygroundServiceServer) GetCompileOutput(context.Context, *GetCompileOutputRequest) (*GetCompileOutputResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetCompileOutput not implemented")
 }
+func (UnimplementedPlaygroundServiceServer) GetValidationDiagnostics(context.Context, *GetValidationDiagnosticsRequest) (*GetValidationDiagnosticsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetValidationDiagnostics not implemented")
+}
+func (UnimplementedPlaygroundServiceServer) GetTestResults(context.Context, *GetTestResultsRequest) (*GetTestResultsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTestResults not implemented")
+}
+func (UnimplementedPlaygroundServiceServer) GetOutputFiles(context.Context, *GetOutputFilesRequest) (*GetOutputFilesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOutputFiles not implemented")
+}
+func (UnimplementedPlaygroundServiceServer) GetRunRecords(context.Context, *GetRunRecordsRequest) (*GetRunRecordsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRunRecords not implemented")
+}
+func (UnimplementedPlaygroundServiceServer) GetArtifact(*GetArtifactRequest, PlaygroundService_GetArtifactServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetArtifact not implemented")
+}
+func (UnimplementedPlaygroundServiceServer) GetFormattedOutput(context.Context, *GetFormattedOutputRequest) (*GetFormattedOutputResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFormattedOutput not implemented")
+}
+func (UnimplementedPlaygroundServiceServer) GetCoverage(context.Context, *GetCoverageRequest) (*GetCoverageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCoverage not implemented")
+}
+func (UnimplementedPlaygroundServiceServer) GetCrossSdkDiff(context.Context, *GetCrossSdkDiffRequest) (*GetCrossSdkDiffResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCrossSdkDiff not implemented")
+}
+func (UnimplementedPlaygroundServiceServer) GetMetrics(context.Context, *GetMetricsRequest) (*GetMetricsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMetrics not implemented")
+}
+func (UnimplementedPlaygroundServiceServer) GetIntermediateSamples(context.Context, *GetIntermediateSamplesRequest) (*GetIntermediateSamplesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetIntermediateSamples not implemented")
+}
+func (UnimplementedPlaygroundServiceServer) GetTimestamps(context.Context, *GetTimestampsRequest) (*GetTimestampsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTimestamps not implemented")
+}
+func (UnimplementedPlaygroundServiceServer) GetInvocation(context.Context, *GetInvocationRequest) (*GetInvocationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInvocation not implemented")
+}
 func (UnimplementedPlaygroundServiceServer) Cancel(context.Context, *CancelRequest) (*CancelResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Cancel not implemented")
 }
+func (UnimplementedPlaygroundServiceServer) ExtendTimeout(context.Context, *ExtendTimeoutRequest) (*ExtendTimeoutResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExtendTimeout not implemented")
+}
+func (UnimplementedPlaygroundServiceServer) CheckSdkAvailability(context.Context, *CheckSdkAvailabilityRequest) (*CheckSdkAvailabilityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckSdkAvailability not implemented")
+}
 func (UnimplementedPlaygroundServiceServer) GetPrecompiledObjects(context.Context, *GetPrecompiledObjectsRequest) (*GetPrecompiledObjectsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetPrecompiledObjects not implemented")
 }
@@ -245,6 +595,42 @@ func _PlaygroundService_RunCode_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PlaygroundService_RunCodeBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RunCodeBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlaygroundServiceServer).RunCodeBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.PlaygroundService/RunCodeBatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlaygroundServiceServer).RunCodeBatch(ctx, req.(*RunCodeBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlaygroundService_RunCodeGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RunCodeGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlaygroundServiceServer).RunCodeGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.PlaygroundService/RunCodeGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlaygroundServiceServer).RunCodeGroup(ctx, req.(*RunCodeGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _PlaygroundService_CheckStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CheckStatusRequest)
 	if err := dec(in); err != nil {
@@ -281,6 +667,27 @@ func _PlaygroundService_GetRunOutput_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PlaygroundService_StreamRunOutput_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamRunOutputRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PlaygroundServiceServer).StreamRunOutput(m, &playgroundServiceStreamRunOutputServer{stream})
+}
+
+type PlaygroundService_StreamRunOutputServer interface {
+	Send(*StreamRunOutputResponse) error
+	grpc.ServerStream
+}
+
+type playgroundServiceStreamRunOutputServer struct {
+	grpc.ServerStream
+}
+
+func (x *playgroundServiceStreamRunOutputServer) Send(m *StreamRunOutputResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _PlaygroundService_GetLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetLogsRequest)
 	if err := dec(in); err != nil {
@@ -317,6 +724,42 @@ func _PlaygroundService_GetRunError_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PlaygroundService_GetSource_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSourceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlaygroundServiceServer).GetSource(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.PlaygroundService/GetSource",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlaygroundServiceServer).GetSource(ctx, req.(*GetSourceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlaygroundService_GetDependencies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDependenciesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlaygroundServiceServer).GetDependencies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.PlaygroundService/GetDependencies",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlaygroundServiceServer).GetDependencies(ctx, req.(*GetDependenciesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _PlaygroundService_GetCompileOutput_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetCompileOutputRequest)
 	if err := dec(in); err != nil {
@@ -335,6 +778,225 @@ func _PlaygroundService_GetCompileOutput_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PlaygroundService_GetValidationDiagnostics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetValidationDiagnosticsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlaygroundServiceServer).GetValidationDiagnostics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.PlaygroundService/GetValidationDiagnostics",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlaygroundServiceServer).GetValidationDiagnostics(ctx, req.(*GetValidationDiagnosticsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlaygroundService_GetTestResults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTestResultsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlaygroundServiceServer).GetTestResults(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.PlaygroundService/GetTestResults",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlaygroundServiceServer).GetTestResults(ctx, req.(*GetTestResultsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlaygroundService_GetOutputFiles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOutputFilesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlaygroundServiceServer).GetOutputFiles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.PlaygroundService/GetOutputFiles",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlaygroundServiceServer).GetOutputFiles(ctx, req.(*GetOutputFilesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlaygroundService_GetRunRecords_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRunRecordsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlaygroundServiceServer).GetRunRecords(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.PlaygroundService/GetRunRecords",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlaygroundServiceServer).GetRunRecords(ctx, req.(*GetRunRecordsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlaygroundService_GetArtifact_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetArtifactRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PlaygroundServiceServer).GetArtifact(m, &playgroundServiceGetArtifactServer{stream})
+}
+
+type PlaygroundService_GetArtifactServer interface {
+	Send(*GetArtifactResponse) error
+	grpc.ServerStream
+}
+
+type playgroundServiceGetArtifactServer struct {
+	grpc.ServerStream
+}
+
+func (x *playgroundServiceGetArtifactServer) Send(m *GetArtifactResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _PlaygroundService_GetFormattedOutput_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFormattedOutputRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlaygroundServiceServer).GetFormattedOutput(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.PlaygroundService/GetFormattedOutput",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlaygroundServiceServer).GetFormattedOutput(ctx, req.(*GetFormattedOutputRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlaygroundService_GetCoverage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCoverageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlaygroundServiceServer).GetCoverage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.PlaygroundService/GetCoverage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlaygroundServiceServer).GetCoverage(ctx, req.(*GetCoverageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlaygroundService_GetCrossSdkDiff_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCrossSdkDiffRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlaygroundServiceServer).GetCrossSdkDiff(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.PlaygroundService/GetCrossSdkDiff",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlaygroundServiceServer).GetCrossSdkDiff(ctx, req.(*GetCrossSdkDiffRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlaygroundService_GetMetrics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMetricsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlaygroundServiceServer).GetMetrics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.PlaygroundService/GetMetrics",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlaygroundServiceServer).GetMetrics(ctx, req.(*GetMetricsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlaygroundService_GetIntermediateSamples_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetIntermediateSamplesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlaygroundServiceServer).GetIntermediateSamples(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.PlaygroundService/GetIntermediateSamples",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlaygroundServiceServer).GetIntermediateSamples(ctx, req.(*GetIntermediateSamplesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlaygroundService_GetTimestamps_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTimestampsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlaygroundServiceServer).GetTimestamps(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.PlaygroundService/GetTimestamps",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlaygroundServiceServer).GetTimestamps(ctx, req.(*GetTimestampsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlaygroundService_GetInvocation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetInvocationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlaygroundServiceServer).GetInvocation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.PlaygroundService/GetInvocation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlaygroundServiceServer).GetInvocation(ctx, req.(*GetInvocationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _PlaygroundService_Cancel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CancelRequest)
 	if err := dec(in); err != nil {
@@ -353,6 +1015,42 @@ func _PlaygroundService_Cancel_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PlaygroundService_ExtendTimeout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExtendTimeoutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlaygroundServiceServer).ExtendTimeout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.PlaygroundService/ExtendTimeout",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlaygroundServiceServer).ExtendTimeout(ctx, req.(*ExtendTimeoutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlaygroundService_CheckSdkAvailability_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckSdkAvailabilityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlaygroundServiceServer).CheckSdkAvailability(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.PlaygroundService/CheckSdkAvailability",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlaygroundServiceServer).CheckSdkAvailability(ctx, req.(*CheckSdkAvailabilityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _PlaygroundService_GetPrecompiledObjects_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetPrecompiledObjectsRequest)
 	if err := dec(in); err != nil {
@@ -418,6 +1116,14 @@ var PlaygroundService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RunCode",
 			Handler:    _PlaygroundService_RunCode_Handler,
 		},
+		{
+			MethodName: "RunCodeBatch",
+			Handler:    _PlaygroundService_RunCodeBatch_Handler,
+		},
+		{
+			MethodName: "RunCodeGroup",
+			Handler:    _PlaygroundService_RunCodeGroup_Handler,
+		},
 		{
 			MethodName: "CheckStatus",
 			Handler:    _PlaygroundService_CheckStatus_Handler,
@@ -434,14 +1140,74 @@ var PlaygroundService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetRunError",
 			Handler:    _PlaygroundService_GetRunError_Handler,
 		},
+		{
+			MethodName: "GetSource",
+			Handler:    _PlaygroundService_GetSource_Handler,
+		},
+		{
+			MethodName: "GetDependencies",
+			Handler:    _PlaygroundService_GetDependencies_Handler,
+		},
 		{
 			MethodName: "GetCompileOutput",
 			Handler:    _PlaygroundService_GetCompileOutput_Handler,
 		},
+		{
+			MethodName: "GetValidationDiagnostics",
+			Handler:    _PlaygroundService_GetValidationDiagnostics_Handler,
+		},
+		{
+			MethodName: "GetTestResults",
+			Handler:    _PlaygroundService_GetTestResults_Handler,
+		},
+		{
+			MethodName: "GetOutputFiles",
+			Handler:    _PlaygroundService_GetOutputFiles_Handler,
+		},
+		{
+			MethodName: "GetRunRecords",
+			Handler:    _PlaygroundService_GetRunRecords_Handler,
+		},
+		{
+			MethodName: "GetFormattedOutput",
+			Handler:    _PlaygroundService_GetFormattedOutput_Handler,
+		},
+		{
+			MethodName: "GetCoverage",
+			Handler:    _PlaygroundService_GetCoverage_Handler,
+		},
+		{
+			MethodName: "GetCrossSdkDiff",
+			Handler:    _PlaygroundService_GetCrossSdkDiff_Handler,
+		},
+		{
+			MethodName: "GetMetrics",
+			Handler:    _PlaygroundService_GetMetrics_Handler,
+		},
+		{
+			MethodName: "GetIntermediateSamples",
+			Handler:    _PlaygroundService_GetIntermediateSamples_Handler,
+		},
+		{
+			MethodName: "GetTimestamps",
+			Handler:    _PlaygroundService_GetTimestamps_Handler,
+		},
+		{
+			MethodName: "GetInvocation",
+			Handler:    _PlaygroundService_GetInvocation_Handler,
+		},
 		{
 			MethodName: "Cancel",
 			Handler:    _PlaygroundService_Cancel_Handler,
 		},
+		{
+			MethodName: "ExtendTimeout",
+			Handler:    _PlaygroundService_ExtendTimeout_Handler,
+		},
+		{
+			MethodName: "CheckSdkAvailability",
+			Handler:    _PlaygroundService_CheckSdkAvailability_Handler,
+		},
 		{
 			MethodName: "GetPrecompiledObjects",
 			Handler:    _PlaygroundService_GetPrecompiledObjects_Handler,
@@ -455,6 +1221,17 @@ var PlaygroundService_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _PlaygroundService_GetPrecompiledObjectOutput_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamRunOutput",
+			Handler:       _PlaygroundService_StreamRunOutput_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "GetArtifact",
+			Handler:       _PlaygroundService_GetArtifact_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "api/v1/api.proto",
 }
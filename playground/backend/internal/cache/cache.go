@@ -16,6 +16,7 @@
 package cache
 
 import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
 	"context"
 	"github.com/google/uuid"
 	"time"
@@ -32,15 +33,126 @@ const (
 	// RunOutput is used to keep run code output value
 	RunOutput SubKey = "RUN_OUTPUT"
 
+	// FormattedOutput is used to keep run code output formatted by the output formatter of the SDK
+	FormattedOutput SubKey = "FORMATTED_OUTPUT"
+
 	// RunError is used to keep run code error value
 	RunError SubKey = "RUN_ERROR"
 
 	// CompileOutput is used to keep compilation output value
 	CompileOutput SubKey = "COMPILE_OUTPUT"
 
+	// CompileOutputIndex is the index of the start of the compile output which hasn't been sent to the client yet
+	CompileOutputIndex SubKey = "COMPILE_OUTPUT_INDEX"
+
+	// BuildScanUrl is used to keep the url of the build scan published during compilation
+	BuildScanUrl SubKey = "BUILD_SCAN_URL"
+
+	// Source is used to keep the code as it has been submitted
+	Source SubKey = "SOURCE"
+
+	// CanonicalSource is used to keep the code without comments and formatting differences
+	CanonicalSource SubKey = "CANONICAL_SOURCE"
+
+	// Dependencies is used to keep imports of the code as []string value
+	Dependencies SubKey = "DEPENDENCIES"
+
+	// CompileDiagnostics is used to keep messages of the compiler grouped by source files as []FileDiagnostics value
+	CompileDiagnostics SubKey = "COMPILE_DIAGNOSTICS"
+
+	// ValidationDiagnostics is used to keep errors of the validation step grouped by source files as []FileDiagnostics value.
+	// For the Go SDK it also keeps messages of go build and go vet.
+	ValidationDiagnostics SubKey = "VALIDATION_DIAGNOSTICS"
+
+	// CompileWarnings is used to keep warnings of the compiler which don't fail the compilation as []CompileWarning value
+	CompileWarnings SubKey = "COMPILE_WARNINGS"
+
+	// TestResults is used to keep results of tests run against the code as []TestResult value
+	TestResults SubKey = "TEST_RESULTS"
+
+	// OutputFiles is used to keep files written by the run step as []OutputFile value
+	OutputFiles SubKey = "OUTPUT_FILES"
+
+	// OutputFormat is used to keep the format of the run output requested by the client, e.g. "jsonl"
+	OutputFormat SubKey = "OUTPUT_FORMAT"
+
+	// RunRecords is used to keep lines of the run output in the JSON lines format as []RunRecord value
+	RunRecords SubKey = "RUN_RECORDS"
+
+	// LogLevelFilter is used to keep the pattern of log lines which are dropped from the run output, e.g. `^\S+ \S+ INFO`
+	LogLevelFilter SubKey = "LOG_LEVEL_FILTER"
+
+	// RawRunOutput is used to keep the run output without filtering of log lines by LogLevelFilter
+	RawRunOutput SubKey = "RAW_RUN_OUTPUT"
+
+	// CompiledArtifact is used to keep the file compiled from the code as Artifact value
+	CompiledArtifact SubKey = "COMPILED_ARTIFACT"
+
+	// WasmArtifact is used to keep the WebAssembly file compiled from the Go code as Artifact value
+	WasmArtifact SubKey = "WASM_ARTIFACT"
+
+	// CompileInvocation is used to keep the working directory and argv of the compile command as Invocation value
+	CompileInvocation SubKey = "COMPILE_INVOCATION"
+
+	// RunInvocation is used to keep the working directory and argv of the run command as Invocation value
+	RunInvocation SubKey = "RUN_INVOCATION"
+
+	// PersistedResult is used to keep the reference to the result of the finished pipeline persisted to the object storage
+	PersistedResult SubKey = "PERSISTED_RESULT"
+
+	// CrossSdkDiff is used to keep the diff of normalized run outputs of the pipeline and another pipeline
+	CrossSdkDiff SubKey = "CROSS_SDK_DIFF"
+
+	// Group is used to keep the group of pipelines with variants of the same example in different SDKs as PipelineGroup value
+	Group SubKey = "GROUP"
+
+	// Coverage is used to keep the coverage summary of the code run under a coverage tool
+	Coverage SubKey = "COVERAGE"
+
+	// StartedAt is used to keep the wall-clock time.Time when code processing is started
+	StartedAt SubKey = "STARTED_AT"
+
+	// FinishedAt is used to keep the wall-clock time.Time when code processing is finished
+	FinishedAt SubKey = "FINISHED_AT"
+
+	// Timestamps is used to keep wall-clock times when code processing reaches statuses as map[playground.Status]time.Time value
+	Timestamps SubKey = "TIMESTAMPS"
+
+	// Progress is used to keep the percentage (0-100) of completed steps of code processing as int value
+	Progress SubKey = "PROGRESS"
+
 	// Canceled is used to keep the canceled status
 	Canceled SubKey = "CANCELED"
 
+	// CancelReason is used to keep the playground.CancelReason of the canceled pipeline
+	CancelReason SubKey = "CANCEL_REASON"
+
+	// CancelAcknowledged is used to keep the wall-clock time.Time when the cancellation is observed by code processing
+	CancelAcknowledged SubKey = "CANCEL_ACKNOWLEDGED"
+
+	// CleanupFailed is used to keep the flag whether folders of the pipeline couldn't be deleted after code processing
+	CleanupFailed SubKey = "CLEANUP_FAILED"
+
+	// ExtendTimeout is used to keep the flag of the request to extend the timeout of code processing
+	ExtendTimeout SubKey = "EXTEND_TIMEOUT"
+
+	// CompileDuration is the duration of the compile step in milliseconds
+	CompileDuration SubKey = "COMPILE_DURATION"
+
+	// ExecuteTimeout is the effective timeout of code processing in milliseconds
+	ExecuteTimeout SubKey = "EXECUTE_TIMEOUT"
+
+	// RunDuration is the duration of the run step (or tests run instead of it) in milliseconds
+	RunDuration SubKey = "RUN_DURATION"
+
+	// ExitCode is the exit code of the last finished command of the compile or run step.
+	// A command killed by a signal has the exit code 128+signal like in shells, e.g. 137 for SIGKILL.
+	ExitCode SubKey = "EXIT_CODE"
+
+	// StdInput is used to keep the data which is fed to the stdin of the run step as a string value.
+	// It is used only if the request doesn't contain the scripted stdin.
+	StdInput SubKey = "STD_INPUT"
+
 	// RunOutputIndex is the index of the start of the run step's output
 	RunOutputIndex SubKey = "RUN_OUTPUT_INDEX"
 
@@ -49,8 +161,124 @@ const (
 
 	// LogsIndex is the index of the start of the log
 	LogsIndex SubKey = "LOGS_INDEX"
+
+	// Metrics is used to keep element count metrics of the run step as []MetricPoint value
+	Metrics SubKey = "METRICS"
+
+	// MetricsIndex is the index of the first metric point which hasn't been sent to the client yet
+	MetricsIndex SubKey = "METRICS_INDEX"
+
+	// IntermediateSamples is used to keep sample elements of the pipeline's PCollections as []PCollectionSamples value
+	IntermediateSamples SubKey = "INTERMEDIATE_SAMPLES"
+
+	// OutputTruncated is used to keep the flag whether the run step's output exceeded the limit of size and was truncated
+	OutputTruncated SubKey = "OUTPUT_TRUNCATED"
+
+	// SdkVersion is used to keep the version of Apache Beam SDK which is used to process code as a string value
+	SdkVersion SubKey = "SDK_VERSION"
+
+	// PeakDiskUsage is used to keep the peak size in bytes of the folder of the pipeline observed during the run step as an int value
+	PeakDiskUsage SubKey = "PEAK_DISK_USAGE"
+
+	// ProcessingClaim is used to keep the flag whether code processing of the pipeline is claimed by some instance of the server.
+	// It is set by SetValueIfAbsent, so only one invocation processes the pipeline, and is deleted once code processing is finished.
+	ProcessingClaim SubKey = "PROCESSING_CLAIM"
+)
+
+// DiagnosticSeverityError and DiagnosticSeverityWarning are severities of Diagnostic
+const (
+	DiagnosticSeverityError   = "error"
+	DiagnosticSeverityWarning = "warning"
 )
 
+// Diagnostic is one message of the compiler about the source file.
+// Column is 0 if the compiler doesn't report it.
+type Diagnostic struct {
+	Line     int    `json:"line"`
+	Column   int    `json:"column"`
+	Message  string `json:"message"`
+	Severity string `json:"severity"`
+}
+
+// FileDiagnostics is messages of the compiler about one source file.
+// FileName is the name of the file relative to the folder with source files.
+type FileDiagnostics struct {
+	FileName    string       `json:"file_name"`
+	Diagnostics []Diagnostic `json:"diagnostics"`
+}
+
+// TestResult is the result of one test run against the code
+type TestResult struct {
+	Name   string `json:"name"`
+	Passed bool   `json:"passed"`
+}
+
+// CompileWarning is a warning of the compiler about the source file which doesn't fail the compilation.
+// Line is zero if the warning is about the whole file.
+type CompileWarning struct {
+	FileName string `json:"file_name"`
+	Line     int    `json:"line"`
+	Category string `json:"category"`
+	Message  string `json:"message"`
+}
+
+// RunRecord is a line of the run output in the JSON lines format with its number starting from 1.
+// In case the line isn't a JSON object Malformed is true and Json contains the line as is.
+type RunRecord struct {
+	Line      int    `json:"line"`
+	Json      string `json:"json"`
+	Malformed bool   `json:"malformed"`
+}
+
+// OutputFile is a file written by the run step.
+// In case the file couldn't be read back Error contains the reason and Content is empty.
+type OutputFile struct {
+	Name    string `json:"name"`
+	Content string `json:"content"`
+	Error   string `json:"error"`
+}
+
+// Artifact is the file compiled from the code which could be downloaded.
+// In case the file couldn't be provided Error contains the reason and Content is empty.
+type Artifact struct {
+	Name    string `json:"name"`
+	Content []byte `json:"content"`
+	Error   string `json:"error"`
+}
+
+// Invocation is the command which is used to process the code.
+// WorkingDir and paths in Args are relative to the working directory of the application.
+type Invocation struct {
+	WorkingDir string   `json:"working_dir"`
+	Args       []string `json:"args"`
+}
+
+// PipelineGroup is pipelines which process variants of the same example in different SDKs.
+// PipelineIds contains ids of all pipelines of the group including the pipeline which keeps the value.
+type PipelineGroup struct {
+	Id          string   `json:"id"`
+	PipelineIds []string `json:"pipeline_ids"`
+}
+
+// StatusTransition is the wall-clock time when code processing reaches the status
+type StatusTransition struct {
+	Status    pb.Status `json:"status"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// MetricPoint is the number of elements processed by the pipeline at the moment of time
+type MetricPoint struct {
+	Timestamp    time.Time `json:"timestamp"`
+	ElementCount int64     `json:"element_count"`
+}
+
+// PCollectionSamples is sample elements of one PCollection of the pipeline in the order they were reported.
+// Elements are string representations produced by the SDK.
+type PCollectionSamples struct {
+	PCollection string   `json:"pcollection"`
+	Elements    []string `json:"elements"`
+}
+
 // Cache is used to store states and outputs for Apache Beam pipelines that running in Playground
 // Cache allows keep and read any value by pipelineId and subKey:
 // pipelineId_1:
@@ -62,10 +290,16 @@ const (
 // pipelineId is uuid that calculates in the controller when the server takes new request to run code
 type Cache interface {
 	// GetValue returns value from cache by pipelineId and subKey.
+	// In case the value doesn't exist in cache returns an error which wraps errors.ErrCacheMiss.
 	GetValue(ctx context.Context, pipelineId uuid.UUID, subKey SubKey) (interface{}, error)
 
 	// SetValue adds value to cache by pipelineId and subKey.
 	SetValue(ctx context.Context, pipelineId uuid.UUID, subKey SubKey, value interface{}) error
+	// SetValueIfAbsent atomically adds value to cache by pipelineId and subKey only if there is no value by them yet.
+	// Returns true if the value is added and false if the value by pipelineId and subKey already exists.
+	SetValueIfAbsent(ctx context.Context, pipelineId uuid.UUID, subKey SubKey, value interface{}) (bool, error)
+	// DeleteValue removes the value by pipelineId and subKey from cache. In case the value doesn't exist does nothing.
+	DeleteValue(ctx context.Context, pipelineId uuid.UUID, subKey SubKey) error
 
 	// SetExpTime adds expiration time of the pipeline to cache by pipelineId.
 	SetExpTime(ctx context.Context, pipelineId uuid.UUID, expTime time.Duration) error
@@ -17,6 +17,7 @@ package local
 
 import (
 	"beam.apache.org/playground/backend/internal/cache"
+	"beam.apache.org/playground/backend/internal/errors"
 	"context"
 	"fmt"
 	"github.com/google/uuid"
@@ -49,13 +50,13 @@ func New(ctx context.Context) *Cache {
 
 }
 
-// GetValue returns value from cache. If not found or key is expired, GetValue returns an error.
+// GetValue returns value from cache. If not found or key is expired, GetValue returns an error which wraps errors.ErrCacheMiss.
 func (lc *Cache) GetValue(ctx context.Context, pipelineId uuid.UUID, subKey cache.SubKey) (interface{}, error) {
 	lc.RLock()
 	value, found := lc.items[pipelineId][subKey]
 	if !found {
 		lc.RUnlock()
-		return nil, fmt.Errorf("value with pipelineId: %s and subKey: %s not found", pipelineId, subKey)
+		return nil, errors.CacheMissError(fmt.Errorf("value with pipelineId: %s and subKey: %s not found", pipelineId, subKey))
 	}
 	expTime, found := lc.pipelinesExpiration[pipelineId]
 	lc.RUnlock()
@@ -65,7 +66,7 @@ func (lc *Cache) GetValue(ctx context.Context, pipelineId uuid.UUID, subKey cach
 		delete(lc.items[pipelineId], subKey)
 		delete(lc.pipelinesExpiration, pipelineId)
 		lc.Unlock()
-		return nil, fmt.Errorf("value with pipelineId: %s and subKey: %s is expired", pipelineId, subKey)
+		return nil, errors.CacheMissError(fmt.Errorf("value with pipelineId: %s and subKey: %s is expired", pipelineId, subKey))
 	}
 
 	return value, nil
@@ -87,6 +88,33 @@ func (lc *Cache) SetValue(ctx context.Context, pipelineId uuid.UUID, subKey cach
 	return nil
 }
 
+// SetValueIfAbsent puts element to cache only if there is no value for pipelineId and subKey or the pipeline is expired.
+// The check and the write are done under the lock of the cache, so only one of concurrent calls adds the value.
+func (lc *Cache) SetValueIfAbsent(ctx context.Context, pipelineId uuid.UUID, subKey cache.SubKey, value interface{}) (bool, error) {
+	lc.Lock()
+	defer lc.Unlock()
+
+	if _, found := lc.items[pipelineId][subKey]; found {
+		if expTime, found := lc.pipelinesExpiration[pipelineId]; !found || !expTime.Before(time.Now()) {
+			return false, nil
+		}
+	}
+	if _, ok := lc.items[pipelineId]; !ok {
+		lc.items[pipelineId] = make(map[cache.SubKey]interface{})
+	}
+	lc.items[pipelineId][subKey] = value
+	return true, nil
+}
+
+// DeleteValue removes the value for pipelineId and subKey from cache.
+func (lc *Cache) DeleteValue(ctx context.Context, pipelineId uuid.UUID, subKey cache.SubKey) error {
+	lc.Lock()
+	defer lc.Unlock()
+
+	delete(lc.items[pipelineId], subKey)
+	return nil
+}
+
 // SetExpTime sets expiration time to particular pipelineId in cache.
 // If pipelineId doesn't present in the cache, SetExpTime returns an error.
 func (lc *Cache) SetExpTime(ctx context.Context, pipelineId uuid.UUID, expTime time.Duration) error {
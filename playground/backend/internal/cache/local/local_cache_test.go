@@ -17,10 +17,14 @@ package local
 
 import (
 	"beam.apache.org/playground/backend/internal/cache"
+	"beam.apache.org/playground/backend/internal/errors"
 	"context"
+	stderrors "errors"
 	"github.com/google/uuid"
 	"go.uber.org/goleak"
 	"reflect"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -73,6 +77,7 @@ func TestLocalCache_GetValue(t *testing.T) {
 		args    args
 		want    interface{}
 		wantErr bool
+		errorIs error
 	}{
 		{
 			name: "Get exist value",
@@ -101,6 +106,7 @@ func TestLocalCache_GetValue(t *testing.T) {
 			},
 			want:    nil,
 			wantErr: true,
+			errorIs: errors.ErrCacheMiss,
 		},
 	}
 	for _, tt := range tests {
@@ -115,6 +121,9 @@ func TestLocalCache_GetValue(t *testing.T) {
 				t.Errorf("GetValue() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
+			if tt.errorIs != nil && !stderrors.Is(err, tt.errorIs) {
+				t.Errorf("GetValue() error = %v, want it to wrap %v", err, tt.errorIs)
+			}
 			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("GetValue() got = %v, want %v", got, tt.want)
 			}
@@ -177,6 +186,132 @@ func TestLocalCache_SetValue(t *testing.T) {
 	}
 }
 
+func TestLocalCache_SetValueIfAbsent(t *testing.T) {
+	existingId := uuid.New()
+	expiredId := uuid.New()
+	tests := []struct {
+		name                string
+		items               map[uuid.UUID]map[cache.SubKey]interface{}
+		pipelinesExpiration map[uuid.UUID]time.Time
+		pipelineId          uuid.UUID
+		want                bool
+		wantValue           interface{}
+	}{
+		{
+			// Test case with calling SetValueIfAbsent method with pipelineId which doesn't have the value.
+			// As a result, want the value to be added.
+			name:                "value doesn't exist",
+			items:               map[uuid.UUID]map[cache.SubKey]interface{}{},
+			pipelinesExpiration: map[uuid.UUID]time.Time{},
+			pipelineId:          uuid.New(),
+			want:                true,
+			wantValue:           "MOCK_VALUE",
+		},
+		{
+			// Test case with calling SetValueIfAbsent method with pipelineId which already has the value.
+			// As a result, want the existing value to be kept.
+			name:                "value exists",
+			items:               map[uuid.UUID]map[cache.SubKey]interface{}{existingId: {cache.ProcessingClaim: "MOCK_EXISTING_VALUE"}},
+			pipelinesExpiration: map[uuid.UUID]time.Time{},
+			pipelineId:          existingId,
+			want:                false,
+			wantValue:           "MOCK_EXISTING_VALUE",
+		},
+		{
+			// Test case with calling SetValueIfAbsent method with pipelineId which has the value, but it is expired.
+			// As a result, want the value to be replaced.
+			name:                "value is expired",
+			items:               map[uuid.UUID]map[cache.SubKey]interface{}{expiredId: {cache.ProcessingClaim: "MOCK_EXISTING_VALUE"}},
+			pipelinesExpiration: map[uuid.UUID]time.Time{expiredId: time.Now().Add(-time.Minute)},
+			pipelineId:          expiredId,
+			want:                true,
+			wantValue:           "MOCK_VALUE",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lc := &Cache{
+				cleanupInterval:     cleanupInterval,
+				items:               tt.items,
+				pipelinesExpiration: tt.pipelinesExpiration,
+			}
+			got, err := lc.SetValueIfAbsent(context.Background(), tt.pipelineId, cache.ProcessingClaim, "MOCK_VALUE")
+			if err != nil {
+				t.Fatalf("SetValueIfAbsent() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("SetValueIfAbsent() got = %v, want %v", got, tt.want)
+			}
+			if value := lc.items[tt.pipelineId][cache.ProcessingClaim]; value != tt.wantValue {
+				t.Errorf("SetValueIfAbsent() value = %v, want %v", value, tt.wantValue)
+			}
+		})
+	}
+}
+
+func TestLocalCache_SetValueIfAbsent_concurrent(t *testing.T) {
+	lc := &Cache{
+		cleanupInterval:     cleanupInterval,
+		items:               make(map[uuid.UUID]map[cache.SubKey]interface{}),
+		pipelinesExpiration: make(map[uuid.UUID]time.Time),
+	}
+	pipelineId := uuid.New()
+	var added int64
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if ok, _ := lc.SetValueIfAbsent(context.Background(), pipelineId, cache.ProcessingClaim, true); ok {
+				atomic.AddInt64(&added, 1)
+			}
+		}()
+	}
+	wg.Wait()
+	if added != 1 {
+		t.Errorf("SetValueIfAbsent() added the value %d times, want 1", added)
+	}
+}
+
+func TestLocalCache_DeleteValue(t *testing.T) {
+	pipelineId := uuid.New()
+	tests := []struct {
+		name  string
+		items map[uuid.UUID]map[cache.SubKey]interface{}
+	}{
+		{
+			// Test case with calling DeleteValue method with pipelineId which has the value.
+			// As a result, want the value to be removed.
+			name:  "value exists",
+			items: map[uuid.UUID]map[cache.SubKey]interface{}{pipelineId: {cache.ProcessingClaim: true, cache.Status: "MOCK_STATUS"}},
+		},
+		{
+			// Test case with calling DeleteValue method with pipelineId which doesn't exist in cache.
+			// As a result, want no error.
+			name:  "pipeline doesn't exist",
+			items: map[uuid.UUID]map[cache.SubKey]interface{}{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lc := &Cache{
+				cleanupInterval:     cleanupInterval,
+				items:               tt.items,
+				pipelinesExpiration: make(map[uuid.UUID]time.Time),
+			}
+			if err := lc.DeleteValue(context.Background(), pipelineId, cache.ProcessingClaim); err != nil {
+				t.Fatalf("DeleteValue() error = %v", err)
+			}
+			if _, err := lc.GetValue(context.Background(), pipelineId, cache.ProcessingClaim); err == nil {
+				t.Errorf("DeleteValue() value isn't removed")
+			}
+			if status, found := tt.items[pipelineId][cache.Status]; len(tt.items) > 0 && (!found || status != "MOCK_STATUS") {
+				t.Errorf("DeleteValue() other values of the pipeline should be kept")
+			}
+		})
+	}
+}
+
 func TestLocalCache_SetExpTime(t *testing.T) {
 	preparedId, _ := uuid.NewUUID()
 	type fields struct {
@@ -18,6 +18,7 @@ package redis
 import (
 	pb "beam.apache.org/playground/backend/internal/api/v1"
 	"beam.apache.org/playground/backend/internal/cache"
+	"beam.apache.org/playground/backend/internal/errors"
 	"beam.apache.org/playground/backend/internal/logger"
 	"context"
 	"encoding/json"
@@ -50,6 +51,9 @@ func (rc *Cache) GetValue(ctx context.Context, pipelineId uuid.UUID, subKey cach
 		return nil, err
 	}
 	value, err := rc.HGet(ctx, pipelineId.String(), string(subKeyMarsh)).Result()
+	if err == redis.Nil {
+		return nil, errors.CacheMissError(fmt.Errorf("value with key: %s and subKey: %s not found", pipelineId.String(), subKey))
+	}
 	if err != nil {
 		logger.Errorf("Redis Cache: get value: error during HGet operation for key: %s, subKey: %s, err: %s\n", pipelineId.String(), subKey, err.Error())
 		return nil, err
@@ -77,6 +81,40 @@ func (rc *Cache) SetValue(ctx context.Context, pipelineId uuid.UUID, subKey cach
 	return nil
 }
 
+// SetValueIfAbsent adds value to cache by HSETNX, so the check of the existing value and the write are atomic
+// for all instances of the server which use the same Redis.
+func (rc *Cache) SetValueIfAbsent(ctx context.Context, pipelineId uuid.UUID, subKey cache.SubKey, value interface{}) (bool, error) {
+	subKeyMarsh, err := json.Marshal(subKey)
+	if err != nil {
+		logger.Errorf("Redis Cache: set value if absent: error during marshal subKey: %s, err: %s\n", subKey, err.Error())
+		return false, err
+	}
+	valueMarsh, err := json.Marshal(value)
+	if err != nil {
+		logger.Errorf("Redis Cache: set value if absent: error during marshal value: %s, err: %s\n", value, err.Error())
+		return false, err
+	}
+	added, err := rc.HSetNX(ctx, pipelineId.String(), string(subKeyMarsh), valueMarsh).Result()
+	if err != nil {
+		logger.Errorf("Redis Cache: set value if absent: error during HSetNX operation, err: %s\n", err.Error())
+		return false, err
+	}
+	return added, nil
+}
+
+func (rc *Cache) DeleteValue(ctx context.Context, pipelineId uuid.UUID, subKey cache.SubKey) error {
+	subKeyMarsh, err := json.Marshal(subKey)
+	if err != nil {
+		logger.Errorf("Redis Cache: delete value: error during marshal subKey: %s, err: %s\n", subKey, err.Error())
+		return err
+	}
+	if _, err = rc.HDel(ctx, pipelineId.String(), string(subKeyMarsh)).Result(); err != nil {
+		logger.Errorf("Redis Cache: delete value: error during HDel operation, err: %s\n", err.Error())
+		return err
+	}
+	return nil
+}
+
 func (rc *Cache) SetExpTime(ctx context.Context, pipelineId uuid.UUID, expTime time.Duration) error {
 	exists, err := rc.Exists(ctx, pipelineId.String()).Result()
 	if err != nil {
@@ -101,12 +139,40 @@ func unmarshalBySubKey(subKey cache.SubKey, value string) (result interface{}, e
 	switch subKey {
 	case cache.Status:
 		result = new(pb.Status)
-	case cache.RunOutput, cache.RunError, cache.CompileOutput, cache.Logs:
+	case cache.CancelReason:
+		result = new(pb.CancelReason)
+	case cache.RunOutput, cache.RunError, cache.CompileOutput, cache.Logs, cache.BuildScanUrl, cache.Coverage, cache.CrossSdkDiff, cache.Source, cache.CanonicalSource, cache.FormattedOutput, cache.PersistedResult, cache.StdInput, cache.SdkVersion, cache.OutputFormat, cache.LogLevelFilter, cache.RawRunOutput:
 		result = ""
-	case cache.Canceled:
+	case cache.Canceled, cache.CleanupFailed, cache.ExtendTimeout, cache.OutputTruncated, cache.ProcessingClaim:
 		result = false
-	case cache.RunOutputIndex, cache.LogsIndex:
-		result = 0
+	case cache.RunOutputIndex, cache.CompileOutputIndex, cache.LogsIndex, cache.MetricsIndex, cache.Progress, cache.CompileDuration, cache.RunDuration, cache.ExecuteTimeout, cache.ExitCode, cache.PeakDiskUsage:
+		result = new(int)
+	case cache.StartedAt, cache.FinishedAt, cache.CancelAcknowledged:
+		result = new(time.Time)
+	case cache.Metrics:
+		result = new([]cache.MetricPoint)
+	case cache.IntermediateSamples:
+		result = new([]cache.PCollectionSamples)
+	case cache.TestResults:
+		result = new([]cache.TestResult)
+	case cache.Dependencies:
+		result = new([]string)
+	case cache.CompileDiagnostics, cache.ValidationDiagnostics:
+		result = new([]cache.FileDiagnostics)
+	case cache.CompileWarnings:
+		result = new([]cache.CompileWarning)
+	case cache.OutputFiles:
+		result = new([]cache.OutputFile)
+	case cache.RunRecords:
+		result = new([]cache.RunRecord)
+	case cache.CompiledArtifact, cache.WasmArtifact:
+		result = new(cache.Artifact)
+	case cache.CompileInvocation, cache.RunInvocation:
+		result = new(cache.Invocation)
+	case cache.Group:
+		result = new(cache.PipelineGroup)
+	case cache.Timestamps:
+		result = new(map[pb.Status]time.Time)
 	}
 	err = json.Unmarshal([]byte(value), &result)
 	if err != nil {
@@ -116,6 +182,36 @@ func unmarshalBySubKey(subKey cache.SubKey, value string) (result interface{}, e
 	switch subKey {
 	case cache.Status:
 		result = *result.(*pb.Status)
+	case cache.CancelReason:
+		result = *result.(*pb.CancelReason)
+	case cache.RunOutputIndex, cache.CompileOutputIndex, cache.LogsIndex, cache.MetricsIndex, cache.Progress, cache.CompileDuration, cache.RunDuration, cache.ExecuteTimeout, cache.ExitCode, cache.PeakDiskUsage:
+		result = *result.(*int)
+	case cache.StartedAt, cache.FinishedAt, cache.CancelAcknowledged:
+		result = *result.(*time.Time)
+	case cache.Metrics:
+		result = *result.(*[]cache.MetricPoint)
+	case cache.IntermediateSamples:
+		result = *result.(*[]cache.PCollectionSamples)
+	case cache.TestResults:
+		result = *result.(*[]cache.TestResult)
+	case cache.Dependencies:
+		result = *result.(*[]string)
+	case cache.CompileDiagnostics, cache.ValidationDiagnostics:
+		result = *result.(*[]cache.FileDiagnostics)
+	case cache.CompileWarnings:
+		result = *result.(*[]cache.CompileWarning)
+	case cache.OutputFiles:
+		result = *result.(*[]cache.OutputFile)
+	case cache.RunRecords:
+		result = *result.(*[]cache.RunRecord)
+	case cache.CompiledArtifact, cache.WasmArtifact:
+		result = *result.(*cache.Artifact)
+	case cache.CompileInvocation, cache.RunInvocation:
+		result = *result.(*cache.Invocation)
+	case cache.Group:
+		result = *result.(*cache.PipelineGroup)
+	case cache.Timestamps:
+		result = *result.(*map[pb.Status]time.Time)
 	}
 
 	return
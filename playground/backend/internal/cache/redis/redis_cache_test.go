@@ -18,8 +18,10 @@ package redis
 import (
 	pb "beam.apache.org/playground/backend/internal/api/v1"
 	"beam.apache.org/playground/backend/internal/cache"
+	"beam.apache.org/playground/backend/internal/errors"
 	"context"
 	"encoding/json"
+	stderrors "errors"
 	"fmt"
 	"github.com/go-redis/redis/v8"
 	"github.com/go-redis/redismock/v8"
@@ -52,6 +54,7 @@ func TestRedisCache_GetValue(t *testing.T) {
 		args    args
 		want    interface{}
 		wantErr bool
+		errorIs error
 	}{
 		{
 			name: "error during HGet operation",
@@ -67,6 +70,21 @@ func TestRedisCache_GetValue(t *testing.T) {
 			want:    nil,
 			wantErr: true,
 		},
+		{
+			name: "value doesn't exist",
+			mocks: func() {
+				mock.ExpectHGet(pipelineId.String(), string(marshSubKey)).RedisNil()
+			},
+			fields: fields{client},
+			args: args{
+				ctx:        context.TODO(),
+				pipelineId: pipelineId,
+				subKey:     subKey,
+			},
+			want:    nil,
+			wantErr: true,
+			errorIs: errors.ErrCacheMiss,
+		},
 		{
 			name: "all success",
 			mocks: func() {
@@ -93,6 +111,9 @@ func TestRedisCache_GetValue(t *testing.T) {
 				t.Errorf("GetValue() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
+			if tt.errorIs != nil && !stderrors.Is(err, tt.errorIs) {
+				t.Errorf("GetValue() error = %v, want it to wrap %v", err, tt.errorIs)
+			}
 			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("GetValue() got = %v, want %v", got, tt.want)
 			}
@@ -258,6 +279,107 @@ func TestRedisCache_SetValue(t *testing.T) {
 	}
 }
 
+func TestRedisCache_SetValueIfAbsent(t *testing.T) {
+	pipelineId := uuid.New()
+	client, mock := redismock.NewClientMock()
+	marshSubKey, _ := json.Marshal(cache.ProcessingClaim)
+	marshValue, _ := json.Marshal(true)
+	tests := []struct {
+		name    string
+		mocks   func()
+		want    bool
+		wantErr bool
+	}{
+		{
+			// Test case with calling SetValueIfAbsent method when the value doesn't exist.
+			// As a result, want the value to be added by HSETNX.
+			name: "value is added",
+			mocks: func() {
+				mock.ExpectHSetNX(pipelineId.String(), string(marshSubKey), marshValue).SetVal(true)
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			// Test case with calling SetValueIfAbsent method when the value already exists.
+			// As a result, want to receive false.
+			name: "value exists",
+			mocks: func() {
+				mock.ExpectHSetNX(pipelineId.String(), string(marshSubKey), marshValue).SetVal(false)
+			},
+			want:    false,
+			wantErr: false,
+		},
+		{
+			// Test case with calling SetValueIfAbsent method when HSETNX fails.
+			// As a result, want to receive an error.
+			name: "error during HSetNX operation",
+			mocks: func() {
+				mock.ExpectHSetNX(pipelineId.String(), string(marshSubKey), marshValue).SetErr(fmt.Errorf("MOCK_ERROR"))
+			},
+			want:    false,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mocks()
+			rc := &Cache{client}
+			got, err := rc.SetValueIfAbsent(context.Background(), pipelineId, cache.ProcessingClaim, true)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SetValueIfAbsent() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("SetValueIfAbsent() got = %v, want %v", got, tt.want)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("SetValueIfAbsent() %s", err.Error())
+			}
+			mock.ClearExpect()
+		})
+	}
+}
+
+func TestRedisCache_DeleteValue(t *testing.T) {
+	pipelineId := uuid.New()
+	client, mock := redismock.NewClientMock()
+	marshSubKey, _ := json.Marshal(cache.ProcessingClaim)
+	tests := []struct {
+		name    string
+		mocks   func()
+		wantErr bool
+	}{
+		{
+			// Test case with calling DeleteValue method when HDEL succeeds.
+			// As a result, want no error.
+			name: "value is deleted",
+			mocks: func() {
+				mock.ExpectHDel(pipelineId.String(), string(marshSubKey)).SetVal(1)
+			},
+			wantErr: false,
+		},
+		{
+			// Test case with calling DeleteValue method when HDEL fails.
+			// As a result, want to receive an error.
+			name: "error during HDel operation",
+			mocks: func() {
+				mock.ExpectHDel(pipelineId.String(), string(marshSubKey)).SetErr(fmt.Errorf("MOCK_ERROR"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mocks()
+			rc := &Cache{client}
+			if err := rc.DeleteValue(context.Background(), pipelineId, cache.ProcessingClaim); (err != nil) != tt.wantErr {
+				t.Errorf("DeleteValue() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			mock.ClearExpect()
+		})
+	}
+}
+
 func Test_newRedisCache(t *testing.T) {
 	address := "host:port"
 	type args struct {
@@ -290,8 +412,18 @@ func Test_newRedisCache(t *testing.T) {
 func Test_unmarshalBySubKey(t *testing.T) {
 	status := pb.Status_STATUS_FINISHED
 	statusValue, _ := json.Marshal(status)
+	cancelReason := pb.CancelReason_CANCEL_REASON_SHUTDOWN
+	cancelReasonValue, _ := json.Marshal(cancelReason)
 	output := "MOCK_OUTPUT"
 	outputValue, _ := json.Marshal(output)
+	index := 42
+	indexValue, _ := json.Marshal(index)
+	acknowledgedAt := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+	acknowledgedAtValue, _ := json.Marshal(acknowledgedAt)
+	timestamps := map[pb.Status]time.Time{pb.Status_STATUS_COMPILING: acknowledgedAt}
+	timestampsValue, _ := json.Marshal(timestamps)
+	records := []cache.RunRecord{{Line: 1, Json: `{"word":"MOCK_WORD"}`}, {Line: 2, Json: "MOCK_LINE", Malformed: true}}
+	recordsValue, _ := json.Marshal(records)
 	type args struct {
 		ctx    context.Context
 		subKey cache.SubKey
@@ -313,6 +445,16 @@ func Test_unmarshalBySubKey(t *testing.T) {
 			want:    status,
 			wantErr: false,
 		},
+		{
+			name: "cancelReason subKey",
+			args: args{
+				ctx:    context.Background(),
+				subKey: cache.CancelReason,
+				value:  string(cancelReasonValue),
+			},
+			want:    cancelReason,
+			wantErr: false,
+		},
 		{
 			name: "runOutput subKey",
 			args: args{
@@ -331,6 +473,42 @@ func Test_unmarshalBySubKey(t *testing.T) {
 			want:    output,
 			wantErr: false,
 		},
+		{
+			name: "runOutputIndex subKey",
+			args: args{
+				subKey: cache.RunOutputIndex,
+				value:  string(indexValue),
+			},
+			want:    index,
+			wantErr: false,
+		},
+		{
+			name: "cancelAcknowledged subKey",
+			args: args{
+				subKey: cache.CancelAcknowledged,
+				value:  string(acknowledgedAtValue),
+			},
+			want:    acknowledgedAt,
+			wantErr: false,
+		},
+		{
+			name: "timestamps subKey",
+			args: args{
+				subKey: cache.Timestamps,
+				value:  string(timestampsValue),
+			},
+			want:    timestamps,
+			wantErr: false,
+		},
+		{
+			name: "runRecords subKey",
+			args: args{
+				subKey: cache.RunRecords,
+				value:  string(recordsValue),
+			},
+			want:    records,
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
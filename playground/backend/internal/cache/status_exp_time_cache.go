@@ -0,0 +1,54 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"context"
+	"github.com/google/uuid"
+	"time"
+)
+
+// StatusExpTimeCache is a Cache which sets expiration time of the pipeline depending on its status.
+// Each time Status subKey is set to a status from expTimes, expiration time of the pipeline is set to the corresponding value.
+type StatusExpTimeCache struct {
+	Cache
+	expTimes map[pb.Status]time.Duration
+}
+
+// NewStatusExpTimeCache returns StatusExpTimeCache which wraps cache with expiration times of statuses
+func NewStatusExpTimeCache(cache Cache, expTimes map[pb.Status]time.Duration) *StatusExpTimeCache {
+	return &StatusExpTimeCache{Cache: cache, expTimes: expTimes}
+}
+
+// SetValue adds value to cache by pipelineId and subKey.
+// In case of the value is a status with expiration time sets it as expiration time of the pipeline.
+func (c *StatusExpTimeCache) SetValue(ctx context.Context, pipelineId uuid.UUID, subKey SubKey, value interface{}) error {
+	if err := c.Cache.SetValue(ctx, pipelineId, subKey, value); err != nil {
+		return err
+	}
+	if subKey != Status {
+		return nil
+	}
+	status, converted := value.(pb.Status)
+	if !converted {
+		return nil
+	}
+	if expTime, found := c.expTimes[status]; found {
+		return c.Cache.SetExpTime(ctx, pipelineId, expTime)
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"context"
+	"github.com/google/uuid"
+	"testing"
+	"time"
+)
+
+// mockCache keeps only expiration times which are set to pipelines
+type mockCache struct {
+	expTimes map[uuid.UUID]time.Duration
+}
+
+func (m *mockCache) GetValue(ctx context.Context, pipelineId uuid.UUID, subKey SubKey) (interface{}, error) {
+	return nil, nil
+}
+
+func (m *mockCache) SetValue(ctx context.Context, pipelineId uuid.UUID, subKey SubKey, value interface{}) error {
+	return nil
+}
+
+func (m *mockCache) SetValueIfAbsent(ctx context.Context, pipelineId uuid.UUID, subKey SubKey, value interface{}) (bool, error) {
+	return true, nil
+}
+
+func (m *mockCache) DeleteValue(ctx context.Context, pipelineId uuid.UUID, subKey SubKey) error {
+	return nil
+}
+
+func (m *mockCache) SetExpTime(ctx context.Context, pipelineId uuid.UUID, expTime time.Duration) error {
+	m.expTimes[pipelineId] = expTime
+	return nil
+}
+
+func TestStatusExpTimeCache_SetValue(t *testing.T) {
+	expTimes := map[pb.Status]time.Duration{pb.Status_STATUS_FINISHED: time.Hour}
+	type args struct {
+		subKey SubKey
+		value  interface{}
+	}
+	tests := []struct {
+		name        string
+		args        args
+		wantExpTime time.Duration
+		wantFound   bool
+	}{
+		{
+			// Test case with setting the status which has expiration time.
+			// As a result, want to receive expiration time of the status set to the pipeline.
+			name:        "status with expiration time",
+			args:        args{subKey: Status, value: pb.Status_STATUS_FINISHED},
+			wantExpTime: time.Hour,
+			wantFound:   true,
+		},
+		{
+			// Test case with setting the status which doesn't have expiration time.
+			// As a result, want to receive expiration time of the pipeline unchanged.
+			name:      "status without expiration time",
+			args:      args{subKey: Status, value: pb.Status_STATUS_EXECUTING},
+			wantFound: false,
+		},
+		{
+			// Test case with setting another subKey.
+			// As a result, want to receive expiration time of the pipeline unchanged.
+			name:      "another subKey",
+			args:      args{subKey: RunOutput, value: "MOCK_OUTPUT"},
+			wantFound: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pipelineId := uuid.New()
+			mock := &mockCache{expTimes: make(map[uuid.UUID]time.Duration)}
+			c := NewStatusExpTimeCache(mock, expTimes)
+			if err := c.SetValue(context.Background(), pipelineId, tt.args.subKey, tt.args.value); err != nil {
+				t.Errorf("SetValue() error = %v", err)
+				return
+			}
+			gotExpTime, gotFound := mock.expTimes[pipelineId]
+			if gotFound != tt.wantFound || gotExpTime != tt.wantExpTime {
+				t.Errorf("SetValue() set expiration time = %v (%v), want %v (%v)", gotExpTime, gotFound, tt.wantExpTime, tt.wantFound)
+			}
+		})
+	}
+}
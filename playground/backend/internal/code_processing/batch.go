@@ -0,0 +1,110 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package code_processing
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"beam.apache.org/playground/backend/internal/cache"
+	"context"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"sync"
+	"time"
+)
+
+// batchPollInterval is an interval of checking statuses of pipelines submitted by ProcessBatch
+const batchPollInterval = 500 * time.Millisecond
+
+// BatchSubmission submits the code of one item of the batch for processing and returns the id of its pipeline.
+// The submission is expected to respect the pipeline pool, e.g. the code is submitted the same way as by RunCode.
+type BatchSubmission func(ctx context.Context) (uuid.UUID, error)
+
+// BatchResult is the summary of code processing of one item of the batch.
+// PipelineId is uuid.Nil and Err is the reason of the rejection in case the item is rejected before code processing.
+type BatchResult struct {
+	PipelineId uuid.UUID
+	Status     pb.Status
+	Err        error
+}
+
+// ProcessBatch submits all submissions one after another and waits until all submitted pipelines are processed.
+// Rejected submissions don't abort the batch. Results are returned in the order of submissions.
+// In case ctx is done before all pipelines are processed returns the last known statuses of the remaining pipelines.
+func ProcessBatch(ctx context.Context, cacheService cache.Cache, submissions []BatchSubmission) []BatchResult {
+	results := SubmitBatch(ctx, submissions)
+	WaitForBatch(ctx, cacheService, results)
+	return results
+}
+
+// SubmitBatch submits all submissions one after another and returns their results without waiting for code processing.
+// Results of rejected submissions contain the error and the status by its cause: playground.Status_STATUS_VALIDATION_ERROR
+// in case the submission is invalid (codes.InvalidArgument) and playground.Status_STATUS_ERROR in case of other errors.
+func SubmitBatch(ctx context.Context, submissions []BatchSubmission) []BatchResult {
+	results := make([]BatchResult, len(submissions))
+	for i, submit := range submissions {
+		pipelineId, err := submit(ctx)
+		if err != nil {
+			results[i] = BatchResult{Status: rejectionStatus(err), Err: err}
+			continue
+		}
+		results[i] = BatchResult{PipelineId: pipelineId}
+	}
+	return results
+}
+
+// WaitForBatch waits concurrently until pipelines of all submitted results are processed and sets their statuses to the results.
+// Results of rejected submissions aren't changed.
+func WaitForBatch(ctx context.Context, cacheService cache.Cache, results []BatchResult) {
+	var wg sync.WaitGroup
+	for i := range results {
+		if results[i].PipelineId == uuid.Nil {
+			continue
+		}
+		wg.Add(1)
+		go func(result *BatchResult) {
+			defer wg.Done()
+			result.Status = waitForPipeline(ctx, cacheService, result.PipelineId)
+		}(&results[i])
+	}
+	wg.Wait()
+}
+
+// rejectionStatus returns the status of the submission which is rejected by err
+func rejectionStatus(err error) pb.Status {
+	if status.Code(err) == codes.InvalidArgument {
+		return pb.Status_STATUS_VALIDATION_ERROR
+	}
+	return pb.Status_STATUS_ERROR
+}
+
+// waitForPipeline checks the status of the pipeline by pipelineId every batchPollInterval until it is finished or ctx is done.
+// Returns the last known status of the pipeline or playground.Status_STATUS_UNSPECIFIED if the status couldn't be received from cache.
+func waitForPipeline(ctx context.Context, cacheService cache.Cache, pipelineId uuid.UUID) pb.Status {
+	ticker := time.NewTicker(batchPollInterval)
+	defer ticker.Stop()
+	for {
+		pipelineStatus, err := GetProcessingStatus(ctx, cacheService, pipelineId, "Error during getting status")
+		if err != nil || IsFinished(pipelineStatus) {
+			return pipelineStatus
+		}
+		select {
+		case <-ctx.Done():
+			return pipelineStatus
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,87 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package code_processing
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"beam.apache.org/playground/backend/internal/cache"
+	"beam.apache.org/playground/backend/internal/errors"
+	"context"
+	"github.com/google/uuid"
+	"testing"
+	"time"
+)
+
+func TestProcessBatch(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	finishedId := uuid.New()
+	_ = cacheService.SetValue(ctx, finishedId, cache.Status, pb.Status_STATUS_FINISHED)
+	tests := []struct {
+		name        string
+		submissions []BatchSubmission
+		want        []BatchResult
+		wantErrors  []bool
+	}{
+		{
+			// Test case with calling ProcessBatch method without submissions.
+			// As a result, want to receive no results.
+			name:        "empty batch",
+			submissions: nil,
+			want:        []BatchResult{},
+			wantErrors:  []bool{},
+		},
+		{
+			// Test case with calling ProcessBatch method with the submitted pipeline and submissions rejected by different causes.
+			// As a result, want to receive the final status of the pipeline, the validation error status for the invalid submission
+			// and the error status for the submission rejected by the internal error.
+			name: "batch with rejected submissions",
+			submissions: []BatchSubmission{
+				func(ctx context.Context) (uuid.UUID, error) {
+					return finishedId, nil
+				},
+				func(ctx context.Context) (uuid.UUID, error) {
+					return uuid.Nil, errors.InvalidArgumentError("MOCK_TITLE", "MOCK_INVALID_ARGUMENT")
+				},
+				func(ctx context.Context) (uuid.UUID, error) {
+					return uuid.Nil, errors.InternalError("MOCK_TITLE", "MOCK_INTERNAL_ERROR")
+				},
+			},
+			want: []BatchResult{
+				{PipelineId: finishedId, Status: pb.Status_STATUS_FINISHED},
+				{Status: pb.Status_STATUS_VALIDATION_ERROR},
+				{Status: pb.Status_STATUS_ERROR},
+			},
+			wantErrors: []bool{false, true, true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ProcessBatch(ctx, cacheService, tt.submissions)
+			if len(got) != len(tt.want) {
+				t.Fatalf("ProcessBatch() got %d results, want %d", len(got), len(tt.want))
+			}
+			for i, result := range got {
+				if result.PipelineId != tt.want[i].PipelineId || result.Status != tt.want[i].Status {
+					t.Errorf("ProcessBatch() result %d = %v with status %s, want %v with status %s", i, result.PipelineId, result.Status, tt.want[i].PipelineId, tt.want[i].Status)
+				}
+				if (result.Err != nil) != tt.wantErrors[i] {
+					t.Errorf("ProcessBatch() result %d error = %v, wantError %v", i, result.Err, tt.wantErrors[i])
+				}
+			}
+		})
+	}
+}
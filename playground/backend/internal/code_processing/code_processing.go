@@ -23,170 +23,1875 @@ import (
 	"beam.apache.org/playground/backend/internal/executors"
 	"beam.apache.org/playground/backend/internal/fs_tool"
 	"beam.apache.org/playground/backend/internal/logger"
+	"beam.apache.org/playground/backend/internal/metrics"
+	"beam.apache.org/playground/backend/internal/preparators"
+	"beam.apache.org/playground/backend/internal/result_storage"
 	"beam.apache.org/playground/backend/internal/setup_tools/builder"
 	"beam.apache.org/playground/backend/internal/streaming"
+	"beam.apache.org/playground/backend/internal/utils"
+	"beam.apache.org/playground/backend/internal/validators"
 	"bytes"
 	"context"
+	stderrors "errors"
 	"fmt"
 	"github.com/google/uuid"
 	"io"
+	"io/ioutil"
+	"math"
+	"os"
 	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime/debug"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
 	"time"
+	"unicode/utf8"
 )
 
+// OutputPreviewMarkerFormat is a format of the marker which replaces the middle of the output in its preview
+const OutputPreviewMarkerFormat = "\n[... %d bytes are elided ...]\n"
+
+// buildScanUrlRegexp matches the url which Gradle and Maven print after publishing a build scan
+var buildScanUrlRegexp = regexp.MustCompile(`Publishing build scan\.\.\.\s*(https?://\S+)`)
+
+// diagnosticRegexp matches messages of compilers in the "{file}:{line}:[{column}:] {message}" format,
+// the same format is used by pyflakes and the syntax validator of Python code
+var diagnosticRegexp = regexp.MustCompile(`(?m)^(\S+?\.\w+):(\d+):(?:(\d+):)?[ \t]*(.+)$`)
+
+// goDiagnosticRegexp matches messages of go build and go vet in the "[vet: ]{file}.go:{line}:[{column}:] {message}" format
+var goDiagnosticRegexp = regexp.MustCompile(`^(?:vet: )?(\S+?\.go):(\d+):(?:(\d+):)?[ \t]*(.+)$`)
+
+// deprecatedApiCategory is the category of warnings about usage of deprecated APIs
+const deprecatedApiCategory = "deprecated API"
+
+// warningCategory is the category of compile warnings which have no more specific category
+const warningCategory = "warning"
+
+// lintCategories contains categories of compile warnings by names of lints which are different from the names
+var lintCategories = map[string]string{
+	"deprecation": deprecatedApiCategory,
+	"removal":     deprecatedApiCategory,
+}
+
+// compileWarningPattern is a pattern of the compile warning with the category of warnings it matches.
+// The pattern has the "file" and "message" groups and optionally the "line" and "lint" groups.
+// In case the "lint" group is matched the category is taken from the name of the lint instead.
+type compileWarningPattern struct {
+	regexp   *regexp.Regexp
+	category string
+}
+
+// compileWarningPatterns contains patterns of warnings in the compile output for each SDK
+var compileWarningPatterns = map[pb.Sdk][]compileWarningPattern{
+	pb.Sdk_SDK_JAVA: {
+		{regexp.MustCompile(`(?m)^(?P<file>\S+?\.java):(?P<line>\d+):(?:\d+:)? warning: (?:\[(?P<lint>[\w-]+)\] )?(?P<message>.+)$`), warningCategory},
+		{regexp.MustCompile(`(?m)^Note: (?P<file>\S+?\.java) (?P<message>uses or overrides a deprecated API)\.$`), deprecatedApiCategory},
+		{regexp.MustCompile(`(?m)^Note: (?P<file>\S+?\.java) (?P<message>uses unchecked or unsafe operations)\.$`), "unchecked"},
+	},
+	pb.Sdk_SDK_GO: {
+		// the Go compiler itself has no warnings, only the C compiler of cgo reports them
+		{regexp.MustCompile(`(?m)^(?P<file>\S+?\.go):(?P<line>\d+):(?:\d+:)? warning: (?P<message>.+)$`), warningCategory},
+	},
+}
+
+// stallCpuUsageThreshold is a maximum share of the window which the process could spend on CPU to be considered as stalled
+const stallCpuUsageThreshold = 0.01
+
+// stallHint is saved as cache.RunError when the run step is stalled
+const stallHint = "the pipeline has produced no output and hasn't used CPU for a while, it is likely deadlocked (e.g. waiting for a lock or an input which never comes)"
+
+// outputLimitHint is saved as cache.RunError when the run step is stopped because its output exceeds the limit of size
+const outputLimitHint = "the pipeline has written more output than it is allowed, so it was stopped, try to reduce the amount of output"
+
+// diskQuotaHint is saved as the run error when the run step is stopped because the folder of the pipeline exceeded the quota of size
+const diskQuotaHint = "the pipeline has written more files than it is allowed, so it was stopped, try to reduce the size of temporary files"
+
+// cpuTimeLimitHint is saved as cache.RunError when the run step exceeds the limit of CPU time
+const cpuTimeLimitHint = "the pipeline has used more CPU time than it is allowed, try to reduce the amount of computations"
+
+// jarExtension and jarManifestName are used to pack compiled java classes into the jar
+const (
+	jarExtension    = ".jar"
+	jarManifestName = "META-INF/MANIFEST.MF"
+)
+
+// timeoutStatusKey is the key of the context value with the status which is saved in case code processing is finished by timeout.
+// In case the value isn't set playground.Status_STATUS_RUN_TIMEOUT is saved.
+type timeoutStatusKey struct{}
+
+// sdkKey is the key of the context value with the SDK of the code which labels metrics of code processing
+type sdkKey struct{}
+
+// shutdownKey is the key of the context value with the done channel of the context passed to Process.
+// The channel is closed in case code processing is stopped from outside, e.g. on shutdown of the server, rather than by its timeout.
+type shutdownKey struct{}
+
+// cacheWriteRetryKey is the key of the context value with the cacheWriteRetry policy of writes of statuses into cache.
+// In case the value isn't set failed writes aren't retried.
+type cacheWriteRetryKey struct{}
+
+// cancelGracePeriodKey is the key of the context value with the maximum time to wait for the output of the canceled step to be flushed.
+// In case the value isn't set the canceled step isn't waited for.
+type cancelGracePeriodKey struct{}
+
+// cacheWriteRetry contains the number of retries of the failed write into cache and the delay before the first retry
+type cacheWriteRetry struct {
+	retries int
+	delay   time.Duration
+}
+
+// TraceIdEnv is the environment variable which contains the trace id of the request for commands of code processing
+const TraceIdEnv = "PLAYGROUND_TRACE_ID"
+
+// SampleDatasetEnv is the environment variable which contains the path to the copy of the sample dataset for the run step
+const SampleDatasetEnv = "PLAYGROUND_SAMPLE_DATASET"
+
+// pythonPathEnv is the name of the environment variable with the module search path of Python
+const pythonPathEnv = "PYTHONPATH"
+
+// Names of phases of code processing in metrics and logs
+const (
+	phaseValidate = "validate"
+	phasePrepare  = "prepare"
+	phaseCompile  = "compile"
+	phaseRun      = "run"
+	phaseTest     = "test"
+	phaseCoverage = "coverage"
+	phaseArtifact = "artifact"
+)
+
+// stepPhases contains names of phases of code processing in metrics, by the error status of the step
+var stepPhases = map[pb.Status]string{
+	pb.Status_STATUS_VALIDATION_ERROR:  phaseValidate,
+	pb.Status_STATUS_PREPARATION_ERROR: phasePrepare,
+	pb.Status_STATUS_COMPILE_ERROR:     phaseCompile,
+	pb.Status_STATUS_RUN_ERROR:         phaseRun,
+	pb.Status_STATUS_TEST_FAILED:       phaseTest,
+}
+
+// errTransientStep is returned by processStep in case the step is failed with an error matching one of transient error patterns.
+// Status of the step isn't saved into cache in this case, so the step could be retried.
+var errTransientStep = fmt.Errorf("step is failed with a transient error")
+
+// errArtifactTooLarge is returned by packArtifact in case the compiled file is larger than the maximum size of artifacts
+var errArtifactTooLarge = fmt.Errorf("artifact is too large")
+
+// inFlightCount is a number of Process invocations which are running now
+var inFlightCount int64
+
+// stepTimeoutStatuses contains statuses which are saved in case the step is finished by its own timeout, by the error status of the step
+var stepTimeoutStatuses = map[pb.Status]pb.Status{
+	pb.Status_STATUS_VALIDATION_ERROR:  pb.Status_STATUS_VALIDATION_TIMEOUT,
+	pb.Status_STATUS_PREPARATION_ERROR: pb.Status_STATUS_PREPARATION_TIMEOUT,
+	pb.Status_STATUS_COMPILE_ERROR:     pb.Status_STATUS_COMPILE_TIMEOUT,
+	pb.Status_STATUS_RUN_ERROR:         pb.Status_STATUS_RUN_TIMEOUT,
+	pb.Status_STATUS_TEST_FAILED:       pb.Status_STATUS_RUN_TIMEOUT,
+}
+
+// stopGracePeriod is a time to wait for processes of the canceled step to be stopped gracefully after SIGTERM
+// before they are killed with SIGKILL.
+const stopGracePeriod = 2 * time.Second
+
+// stoppedStepWaitingTime is a maximum time to wait for the command of the step to be stopped after the timeout
+// to keep its partial output.
+const stoppedStepWaitingTime = time.Second
+
+// defaultCancelCheckInterval is an interval between checks of the cancel flag in case the configured interval isn't positive
+const defaultCancelCheckInterval = 500 * time.Millisecond
+
+// maxCacheWriteRetryDelay is a maximum delay between retries of the failed write of the status into cache
+const maxCacheWriteRetryDelay = 5 * time.Second
+
+// terminalWriteTimeout is a timeout of writes of the terminal status (timeout, cancel, error) of code processing into cache
+const terminalWriteTimeout = 15 * time.Second
+
 // Process validates, compiles and runs code by pipelineId.
+// Saves wall-clock times when code processing is started and finished as cache.StartedAt and cache.FinishedAt into cache.
 // During each operation updates status of execution and saves it into cache:
 // - In case of processing works more that timeout duration saves playground.Status_STATUS_RUN_TIMEOUT as cache.Status into cache.
-// - In case of code processing has been canceled saves playground.Status_STATUS_CANCELED as cache.Status into cache.
-// - In case of validation step is failed saves playground.Status_STATUS_VALIDATION_ERROR as cache.Status into cache.
+// - In case of some step works more than its own timeout (appEnv.ValidateTimeout(), appEnv.PrepareTimeout(), appEnv.CompileTimeout() or appEnv.RunTimeout()) saves the timeout status of the step (e.g. playground.Status_STATUS_COMPILE_TIMEOUT) as cache.Status into cache.
+// - In case of lc has the client timeout shorter than the timeout of the server and processing works more than it saves playground.Status_STATUS_CLIENT_TIMEOUT as cache.Status into cache.
+// - In case of code processing has been canceled stops the command of the current step with its child processes and saves playground.Status_STATUS_CANCELED as cache.Status into cache.
+// - In case of ctx is done, e.g. on shutdown of the server, stops the command of the current step the same way as canceled code processing and saves playground.Status_STATUS_CANCELED as cache.Status into cache.
+// - In case of SDK isn't supported, source is empty or whitespace-only, validation step is failed, code matches one of appEnv.BlockedSourcePatterns() or refers to a path matching one of appEnv.DisallowedPathPatterns() saves playground.Status_STATUS_VALIDATION_ERROR as cache.Status into cache.
+// - In case of validation step is failed with errors which have positions, e.g. syntax errors of Go or Python code, saves them grouped by source files as cache.ValidationDiagnostics into cache.
+// - In case of compile step of the Go code is finished saves messages of go build and go vet grouped by source files as cache.ValidationDiagnostics into cache.
+// - In case of prepare step is completed with no errors saves imports of the code as cache.Dependencies into cache.
+// - In case of sdkEnv.AllowedImports() is set and the code imports other packages saves playground.Status_STATUS_PREPARATION_ERROR as cache.Status and the list of disallowed imports as cache.RunError into cache before compiling the code.
 // - In case of compile step is failed saves playground.Status_STATUS_COMPILE_ERROR as cache.Status and compile logs as cache.CompileOutput into cache.
 // - In case of compile step is completed with no errors saves compile output as cache.CompileOutput into cache.
+// - In case of lc is compile-only and compile step (or prepare step for SDKs without compiling) is completed with no errors saves playground.Status_STATUS_COMPILE_FINISHED as cache.Status into cache and doesn't run the code.
+// - In case of lc is wasm compiles the Go code to WebAssembly, saves the .wasm file as cache.WasmArtifact into cache before folders are deleted and doesn't run the code.
+// - In case of compile step is in progress streams its output and logs into cache.CompileOutput, which is replaced by the final value once the step is finished.
+// - In case of compile step is completed with no errors and appEnv.ArtifactDownloadEnabled() is set saves the compiled file as cache.CompiledArtifact into cache.
+// - In case of compile step is completed with no errors saves warnings about usage of deprecated APIs as cache.CompileWarnings into cache.
+// - In case of compile step has published a build scan saves its url as cache.BuildScanUrl into cache.
 // - In case of run step is failed saves playground.Status_STATUS_RUN_ERROR as cache.Status and run logs as cache.RunError into cache.
+// - In case of run step of Python code without tests is failed with an exception appends a tail of the traceback to cache.RunError unless it is already there.
+// - In case of the command of compile or run step is finished saves its exit code as cache.ExitCode into cache.
+// - In case of run step is failed with an error matching one of appEnv.TransientRunErrorPatterns() retries it up to appEnv.RunRetries() times before saving playground.Status_STATUS_RUN_ERROR.
+// - In case of appEnv.MaxOutputBytes() is set saves only the first appEnv.MaxOutputBytes() bytes of the run output with the marker of the truncated output and sets cache.OutputTruncated.
+// - In case of appEnv.KillOnOutputLimit() is set and the run output is truncated stops the run step and saves playground.Status_STATUS_RUN_ERROR as cache.Status and the hint as cache.RunError into cache.
+// - In case of appEnv.StallWindow() is set and run step produces no output and doesn't use CPU during it saves playground.Status_STATUS_RUN_STALLED as cache.Status and the hint as cache.RunError into cache.
+// - In case of appEnv.RunMemoryLimit() is set and run step is killed because it uses more memory saves playground.Status_STATUS_RUN_OOM as cache.Status and the hint as cache.RunError into cache.
+// - In case of the JVM of Java or SCIO code fails with OutOfMemoryError during run step saves playground.Status_STATUS_RUN_OOM as cache.Status and the hint as cache.RunError into cache.
+// - In case of appEnv.RunDiskQuota() is set and the folder of the pipeline exceeds it during run step stops the run step, saves playground.Status_STATUS_RUN_DISK_QUOTA_EXCEEDED as cache.Status, the hint as cache.RunError and the peak size of the folder as cache.PeakDiskUsage into cache.
+// - In case of appEnv.RunCpuTimeLimit() is set and run step uses more CPU time saves playground.Status_STATUS_RESOURCE_LIMIT as cache.Status and the hint as cache.RunError into cache.
+// - In case of compile or run step is completed (successfully or not) saves its duration in milliseconds as cache.CompileDuration or cache.RunDuration into cache.
+// - In case of some step is completed with no errors saves the percentage of completed steps as cache.Progress into cache.
 // - In case of run step is completed with no errors saves playground.Status_STATUS_FINISHED as cache.Status and run output as cache.RunOutput into cache.
-// At the end of this method deletes all created folders.
+// - In case of lc contains additional source files compiles them together with the code, the code remains the entrypoint.
+// - In case of lc contains files with tests runs them instead of run step and saves their results as cache.TestResults into cache.
+// - In case of some tests are failed saves playground.Status_STATUS_TEST_FAILED as cache.Status and test logs as cache.RunError into cache.
+// - In case of ctx carries the trace id of the request adds it to logs of code processing and sets it as TraceIdEnv of compile, run and test commands.
+// - In case of appEnv.ResultDeduplicationTime() is set and identical code with the same SDK and options has been finished with playground.Status_STATUS_FINISHED within it copies its cache.CompileOutput, cache.RunOutput, cache.RunError, cache.SdkVersion and cache.Status into cache without processing the code.
+// - In case of lc has the scripted stdin feeds it to the run step with its delays until the run step is finished or times out.
+// - In case of lc has no scripted stdin but the cache has cache.StdInput feeds it to the run step at once.
+// - In case of lc has the output charset transcodes the run output and run logs to UTF-8 before saving them into cache.
+// - In case of appEnv.OutputRedactionEnabled() is set replaces secrets matching appEnv.SecretPatterns() in the run output and run logs before saving them into cache.
+// - In case of appEnv.OutputSamplingThreshold() is set saves only sampled lines of the run output after the threshold.
+// - In case of run step is completed with no errors saves run output formatted by the output formatter of the SDK as cache.FormattedOutput into cache.
+// - In case of run step is completed with no errors saves files written by the code with extensions from appEnv.AllowedOutputFileExtensions() as cache.OutputFiles into cache, files over appEnv.MaxOutputFiles() are listed without their content.
+// - In case of appEnv.CoverageEnabled() is set runs the code under the coverage tool of the SDK and saves the coverage summary as cache.Coverage into cache.
+// - In case of appEnv.MetricsSamplingInterval() is set saves element counts reported by the run step as cache.Metrics into cache.
+// - In case of some step is completed (successfully or not) adds its duration to metrics.Default by the SDK and the phase.
+// - In case of code processing is finished counts its final status in metrics.Default by the SDK.
+// - In case of lc has the version of Apache Beam uses its jars and saves it as cache.SdkVersion into cache, otherwise saves the default version.
+// - In case of the version of Apache Beam isn't available saves playground.Status_STATUS_ERROR as cache.Status into cache.
+// - In case of run step is started sets only variables of the server allowed by appEnv.RunEnvAllowlist() and environment variables of lc as the environment of the command.
+// - In case of cache.OutputFormat is streaming.JsonLinesFormat saves lines of the run output as cache.RunRecords into cache in addition to the raw output.
+// - In case of appEnv.StripAnsiEscapes() is set removes ANSI escape sequences from the run output before saving it into cache.
+// - In case of lc has the dependency uploaded by the client adds it to the classpath of the Java code or to the module search path of the Python code.
+// - In case of the dependency of lc is larger than appEnv.MaxDependencySize() or it isn't a valid archive saves playground.Status_STATUS_PREPARATION_ERROR as cache.Status and the error as cache.RunError into cache.
+// - In case of lc has the sample dataset copies it to the folder of the pipeline at prepare step and sets SampleDatasetEnv to the path to the copy for the run step.
+// - In case of the sample dataset couldn't be copied saves playground.Status_STATUS_PREPARATION_ERROR as cache.Status into cache.
+// - In case of cache.LogLevelFilter is set drops lines of the run output matching it from cache.RunOutput and saves the unfiltered output as cache.RawRunOutput into cache.
+// - In case of appEnv.IntermediateSamples() is set instruments the code to sample elements of its PCollections and saves them as cache.IntermediateSamples into cache.
+// The timeout of code processing could be extended via cache.ExtendTimeout flag up to appEnv.MaxTimeoutExtension() in total.
+// In case of lc has the execute timeout uses it clamped between appEnv.MinPipelineExecuteTimeout() and appEnv.MaxPipelineExecuteTimeout() instead of appEnv.PipelineExecuteTimeout().
+// In case of the execute timeout of lc isn't positive saves playground.Status_STATUS_VALIDATION_ERROR as cache.Status into cache.
+// The effective timeout of code processing is saved in milliseconds as cache.ExecuteTimeout into cache.
+// In case the pipeline is already processed by another invocation of this method or its status in cache is already final
+// returns at once without changing its cache and folders.
+// The invocation is counted by InFlightCount until this method returns or panics.
+// At the end of this method deletes all created folders. In case they couldn't be deleted saves cache.CleanupFailed into cache.
+// Failed writes of statuses into cache are retried up to appEnv.CacheWriteRetries() times with the delay doubled after each retry.
 func Process(ctx context.Context, cacheService cache.Cache, lc *fs_tool.LifeCycle, pipelineId uuid.UUID, appEnv *environment.ApplicationEnvs, sdkEnv *environment.BeamEnvs) {
-	ctxWithTimeout, finishCtxFunc := context.WithTimeout(ctx, appEnv.PipelineExecuteTimeout())
+	if !claimPipeline(ctx, cacheService, pipelineId) {
+		logger.WithPipelineId(pipelineId).WithContext(ctx).Warnf("Process(): pipeline is already processed or finished, the duplicate invocation is skipped\n")
+		return
+	}
+	atomic.AddInt64(&inFlightCount, 1)
+	defer atomic.AddInt64(&inFlightCount, -1)
+	ctx = context.WithValue(ctx, sdkKey{}, sdkEnv.ApacheBeamSdk)
+	ctx = context.WithValue(ctx, shutdownKey{}, ctx.Done())
+	ctx = context.WithValue(ctx, cacheWriteRetryKey{}, cacheWriteRetry{retries: appEnv.CacheWriteRetries(), delay: appEnv.CacheWriteRetryDelay()})
+	ctx = context.WithValue(ctx, cancelGracePeriodKey{}, appEnv.CancelGracePeriod())
+	workingDir := appEnv.SdkWorkingDir(sdkEnv.ApacheBeamSdk)
+	pipelineTimeout := appEnv.PipelineExecuteTimeout()
+	var executeTimeoutErr error
+	if requested := lc.GetExecuteTimeout(); requested != 0 {
+		if clamped, err := clampExecuteTimeout(requested, appEnv.MinPipelineExecuteTimeout(), appEnv.MaxPipelineExecuteTimeout()); err == nil {
+			pipelineTimeout = clamped
+		} else {
+			executeTimeoutErr = err
+		}
+	}
+	if clientTimeout := lc.GetClientTimeout(); clientTimeout > 0 && clientTimeout < pipelineTimeout {
+		pipelineTimeout = clientTimeout
+		ctx = context.WithValue(ctx, timeoutStatusKey{}, pb.Status_STATUS_CLIENT_TIMEOUT)
+	}
+	ctxWithTimeout, finishCtxFunc, timeout := withExtendableTimeout(ctx, pipelineTimeout, appEnv.MaxTimeoutExtension())
+	cacheService.SetValue(ctx, pipelineId, cache.StartedAt, time.Now())
+	cacheService.SetValue(ctx, pipelineId, cache.Progress, 0)
+	cacheService.SetValue(ctx, pipelineId, cache.ExecuteTimeout, int(pipelineTimeout/time.Millisecond))
 	defer func(lc *fs_tool.LifeCycle) {
+		finishCtx := ctx
+		if isShutdown(ctx) {
+			finishCtx = shutdownContext(ctx)
+		}
+		cacheService.SetValue(finishCtx, pipelineId, cache.FinishedAt, time.Now())
+		countFinalStatus(finishCtx, pipelineId, cacheService)
 		finishCtxFunc()
-		DeleteFolders(pipelineId, lc)
+		if err := DeleteFolders(pipelineId, lc, appEnv); err != nil {
+			cacheService.SetValue(finishCtx, pipelineId, cache.CleanupFailed, true)
+		}
+		releasePipeline(finishCtx, cacheService, pipelineId)
 	}(lc)
 
+	if !environment.IsSupportedSdk(sdkEnv.ApacheBeamSdk) {
+		processError(ctxWithTimeout, fmt.Errorf("unsupported SDK: %s", sdkEnv.ApacheBeamSdk), nil, pipelineId, cacheService, pb.Status_STATUS_VALIDATION_ERROR)
+		return
+	}
+	if executeTimeoutErr != nil {
+		processError(ctxWithTimeout, executeTimeoutErr, nil, pipelineId, cacheService, pb.Status_STATUS_VALIDATION_ERROR)
+		return
+	}
+	if isEmptySource(lc.GetAbsoluteSourceFilePath()) {
+		processError(ctxWithTimeout, fmt.Errorf("source is empty"), nil, pipelineId, cacheService, pb.Status_STATUS_VALIDATION_ERROR)
+		return
+	}
+	testFilePaths := lc.GetAbsoluteTestFilePaths()
+	if len(testFilePaths) > 0 && (sdkEnv.ExecutorConfig == nil || sdkEnv.ExecutorConfig.Test == nil) {
+		processError(ctxWithTimeout, fmt.Errorf("tests aren't supported for SDK: %s", sdkEnv.ApacheBeamSdk), nil, pipelineId, cacheService, pb.Status_STATUS_VALIDATION_ERROR)
+		return
+	}
+	if appEnv.ResultDeduplicationTime() > 0 {
+		if key, ok := deduplicationKey(ctxWithTimeout, cacheService, pipelineId, sdkEnv.ApacheBeamSdk, lc); ok {
+			if reuseResult(ctxWithTimeout, cacheService, pipelineId, key) {
+				return
+			}
+			defer saveResult(ctx, cacheService, pipelineId, key, appEnv.ResultDeduplicationTime())
+		}
+	}
+
 	errorChannel := make(chan error, 1)
 	successChannel := make(chan bool, 1)
 	cancelChannel := make(chan bool, 1)
 
-	go cancelCheck(ctxWithTimeout, pipelineId, cancelChannel, cacheService)
+	go cancelCheck(ctxWithTimeout, pipelineId, cancelChannel, appEnv.CancelCheckInterval(), cacheService)
+	if appEnv.MaxTimeoutExtension() > 0 {
+		go extendTimeoutCheck(ctxWithTimeout, pipelineId, timeout, appEnv.TimeoutExtension(), cacheService)
+	}
 
-	executorBuilder, err := builder.SetupExecutorBuilder(lc.GetAbsoluteSourceFilePath(), lc.GetAbsoluteBaseFolderPath(), lc.GetAbsoluteExecutableFilePath(), sdkEnv)
+	if dependencyPath := lc.GetAbsoluteDependencyFilePath(); dependencyPath != "" {
+		if err := utils.CheckArchive(dependencyPath, appEnv.MaxDependencySize()); err != nil {
+			processError(ctxWithTimeout, err, nil, pipelineId, cacheService, pb.Status_STATUS_PREPARATION_ERROR)
+			return
+		}
+	}
+	sdkEnv = sdkEnv.WithClasspathLibraries(lc.GetClasspathLibraries())
+	executorBuilder, err := builder.SetupExecutorBuilder(lc.GetAbsoluteSourceFilePath(), lc.GetAbsoluteAdditionalSourceFilePaths(), lc.GetAbsoluteBaseFolderPath(), lc.GetAbsoluteExecutableFilePath(), lc.GetPipelineArgs(), lc.GetBeamVersion(), lc.GetAbsoluteDependencyFilePath(), sdkEnv)
 	if err != nil {
 		processSetupError(err, pipelineId, cacheService, ctxWithTimeout)
 		return
 	}
+	// the version is available since the executor builder is set up, so the coverage uses jars of the same version
+	sdkEnv, _ = sdkEnv.WithBeamVersion(lc.GetBeamVersion())
+	cacheService.SetValue(ctxWithTimeout, pipelineId, cache.SdkVersion, sdkEnv.BeamVersion())
+	if patterns := appEnv.BlockedSourcePatterns(); len(patterns) > 0 {
+		filePaths := append(lc.GetAbsoluteSourceFilePaths(), testFilePaths...)
+		blockedPatternsValidator := validators.GetBlockedPatternsValidator(filePaths, patterns)
+		executorBuilder = &executorBuilder.WithValidator().WithExtraValidators(&[]validators.Validator{blockedPatternsValidator}).ExecutorBuilder
+	}
+	if patterns := appEnv.DisallowedPathPatterns(); len(patterns) > 0 {
+		filePaths := append(lc.GetAbsoluteSourceFilePaths(), testFilePaths...)
+		disallowedPathsValidator := validators.GetDisallowedPathsValidator(sdkEnv.ApacheBeamSdk, filePaths, patterns)
+		executorBuilder = &executorBuilder.WithValidator().WithExtraValidators(&[]validators.Validator{disallowedPathsValidator}).ExecutorBuilder
+	}
+	if sdkEnv.AllowedImports() != nil {
+		importsPreparator := preparators.Preparator{Prepare: checkImports, Args: []interface{}{sdkEnv, lc.GetAbsoluteSourceFilePaths()}}
+		executorBuilder = &executorBuilder.WithPreparator().WithExtraPreparators(&[]preparators.Preparator{importsPreparator}).ExecutorBuilder
+	}
+	if lc.GetSampleDatasetName() != "" {
+		datasetPreparator := preparators.Preparator{Prepare: createSampleDataset, Args: []interface{}{lc}}
+		executorBuilder = &executorBuilder.WithPreparator().WithExtraPreparators(&[]preparators.Preparator{datasetPreparator}).ExecutorBuilder
+	}
+	withCoverage := appEnv.CoverageEnabled() && len(testFilePaths) == 0 && sdkEnv.ExecutorConfig.Coverage != nil
+	if withCoverage {
+		executorBuilder = builder.SetupCoverage(executorBuilder, lc.GetAbsoluteBaseFolderPath(), lc.GetAbsoluteExecutableFilePath(), sdkEnv)
+	}
+	withIntermediateSamples := appEnv.IntermediateSamples() > 0 && len(testFilePaths) == 0
+	if withIntermediateSamples {
+		executorBuilder = builder.SetupIntermediateSamples(executorBuilder, lc.GetAbsoluteSourceFilePath(), appEnv.IntermediateSamples(), sdkEnv)
+	}
+	withCrashReport := sdkEnv.ApacheBeamSdk == pb.Sdk_SDK_PYTHON && len(testFilePaths) == 0 && !withCoverage
+	if withCrashReport {
+		executorBuilder = builder.SetupCrashReport(executorBuilder, lc.GetAbsoluteSourceFilePath(), lc.GetAbsoluteCrashReportFilePath(), sdkEnv)
+	}
 	executor := executorBuilder.Build()
 
 	// Validate
-	logger.Infof("%s: Validate() ...\n", pipelineId)
+	logger.WithPipelineId(pipelineId).WithContext(ctx).WithPhase(phaseValidate).Infof("Validate() ...\n")
 	validateFunc := executor.Validate()
-	go validateFunc(successChannel, errorChannel)
+	go runStepFunc(validateFunc, successChannel, errorChannel)
 
-	if err = processStep(ctxWithTimeout, pipelineId, cacheService, cancelChannel, successChannel, nil, nil, errorChannel, pb.Status_STATUS_VALIDATION_ERROR, pb.Status_STATUS_PREPARING); err != nil {
+	if err = processStep(ctxWithTimeout, pipelineId, cacheService, nil, cancelChannel, nil, successChannel, nil, nil, errorChannel, pb.Status_STATUS_VALIDATION_ERROR, pb.Status_STATUS_PREPARING, nil, appEnv.ValidateTimeout(), nil); err != nil {
 		return
 	}
 
 	// Prepare
-	logger.Infof("%s: Prepare() ...\n", pipelineId)
+	logger.WithPipelineId(pipelineId).WithContext(ctx).WithPhase(phasePrepare).Infof("Prepare() ...\n")
 	prepareFunc := executor.Prepare()
-	go prepareFunc(successChannel, errorChannel)
+	go runStepFunc(prepareFunc, successChannel, errorChannel)
 
-	if err = processStep(ctxWithTimeout, pipelineId, cacheService, cancelChannel, successChannel, nil, nil, errorChannel, pb.Status_STATUS_PREPARATION_ERROR, pb.Status_STATUS_COMPILING); err != nil {
+	if err = processStep(ctxWithTimeout, pipelineId, cacheService, nil, cancelChannel, nil, successChannel, nil, nil, errorChannel, pb.Status_STATUS_PREPARATION_ERROR, pb.Status_STATUS_COMPILING, nil, appEnv.PrepareTimeout(), nil); err != nil {
 		return
 	}
+	processDependencies(ctxWithTimeout, sdkEnv.ApacheBeamSdk, lc.GetAbsoluteSourceFilePath(), pipelineId, cacheService)
 
+	compileOnly := lc.IsCompileOnly() || lc.IsWasm()
+	compileSuccessStatus := pb.Status_STATUS_EXECUTING
+	if compileOnly {
+		compileSuccessStatus = pb.Status_STATUS_COMPILE_FINISHED
+	}
 	switch sdkEnv.ApacheBeamSdk {
-	case pb.Sdk_SDK_JAVA, pb.Sdk_SDK_GO:
+	case pb.Sdk_SDK_JAVA, pb.Sdk_SDK_GO, pb.Sdk_SDK_SCIO:
 		// Compile
-		logger.Infof("%s: Compile() ...\n", pipelineId)
-		compileCmd := executor.Compile(ctxWithTimeout)
+		logger.WithPipelineId(pipelineId).WithContext(ctx).WithPhase(phaseCompile).Infof("Compile() ...\n")
+		compileExecutor := executor
+		if len(testFilePaths) > 0 && len(sdkEnv.ExecutorConfig.Test.CompileArgs) > 0 {
+			compileArgs := append(append([]string{}, sdkEnv.ExecutorConfig.Test.CompileArgs...), testFilePaths...)
+			compileExecutor = executorBuilder.WithCompiler().WithArgs(compileArgs).Build()
+		}
+		if lc.IsWasm() {
+			compileExecutor = executorBuilder.WithCompiler().WithArgs([]string{"build", "-o", lc.GetAbsoluteWasmFilePath()}).Build()
+		}
+		compileCmd := compileExecutor.Compile(ctxWithTimeout)
+		setLocale(compileCmd, appEnv.CompileLocale())
+		if lc.IsWasm() {
+			setWasmTarget(compileCmd)
+		}
+		setTraceId(ctxWithTimeout, compileCmd)
+		recordInvocation(ctxWithTimeout, pipelineId, cacheService, cache.CompileInvocation, compileCmd, workingDir)
 		var compileError bytes.Buffer
 		var compileOutput bytes.Buffer
-		runCmdWithOutput(compileCmd, &compileOutput, &compileError, successChannel, errorChannel)
+		var compileOutputMu sync.Mutex
+		cacheService.SetValue(ctxWithTimeout, pipelineId, cache.CompileOutput, "")
+		compileOutputWriter := &streaming.CompileOutputWriter{Ctx: ctxWithTimeout, CacheService: cacheService, PipelineId: pipelineId, Mu: &compileOutputMu}
+		compileErrorWriter := &streaming.CompileOutputWriter{Ctx: ctxWithTimeout, CacheService: cacheService, PipelineId: pipelineId, Mu: &compileOutputMu}
+		compileStartedAt := time.Now()
+		runCmdWithOutput(compileCmd, io.MultiWriter(&compileOutput, compileOutputWriter), io.MultiWriter(&compileError, compileErrorWriter), successChannel, errorChannel, compileOutputWriter, compileErrorWriter)
 
-		if err = processStep(ctxWithTimeout, pipelineId, cacheService, cancelChannel, successChannel, &compileOutput, &compileError, errorChannel, pb.Status_STATUS_COMPILE_ERROR, pb.Status_STATUS_EXECUTING); err != nil {
+		err = processStep(ctxWithTimeout, pipelineId, cacheService, compileCmd, cancelChannel, nil, successChannel, &compileOutput, &compileError, errorChannel, pb.Status_STATUS_COMPILE_ERROR, compileSuccessStatus, nil, appEnv.CompileTimeout(), nil)
+		processDuration(ctxWithTimeout, pipelineId, cacheService, cache.CompileDuration, compileStartedAt)
+		if err != nil {
 			return
 		}
+		if lc.IsWasm() {
+			processWasmArtifact(ctxWithTimeout, pipelineId, cacheService, lc.GetAbsoluteWasmFilePath(), appEnv.MaxArtifactSize())
+		} else if appEnv.ArtifactDownloadEnabled() && len(testFilePaths) == 0 {
+			captureArtifact(ctxWithTimeout, lc, pipelineId, cacheService, workingDir, appEnv.MaxArtifactSize())
+		}
 	case pb.Sdk_SDK_PYTHON:
-		processSuccess(ctx, []byte(""), pipelineId, cacheService, pb.Status_STATUS_EXECUTING)
+		processSuccess(ctx, []byte(""), pipelineId, cacheService, compileSuccessStatus)
+	}
+	if compileOnly {
+		return
+	}
+
+	// Test
+	if len(testFilePaths) > 0 {
+		testsStartedAt := time.Now()
+		runTests(ctxWithTimeout, pipelineId, cacheService, &executor, sdkEnv.ExecutorConfig.Test, cancelChannel, successChannel, errorChannel, appEnv.RunTimeout(), appEnv.MaxOutputBytes(), appEnv.RunEnvAllowlist(), lc.GetRunEnv())
+		processDuration(ctxWithTimeout, pipelineId, cacheService, cache.RunDuration, testsStartedAt)
+		return
 	}
 
 	// Run
-	if sdkEnv.ApacheBeamSdk == pb.Sdk_SDK_JAVA {
-		executor = setJavaExecutableFile(lc, pipelineId, cacheService, ctxWithTimeout, executorBuilder, appEnv.WorkingDir())
+	if lc.ExecutableName != nil {
+		if executor, err = setJavaExecutableFile(lc, pipelineId, cacheService, ctxWithTimeout, executorBuilder, workingDir); err != nil {
+			return
+		}
+	}
+	runStartedAt := time.Now()
+	for attempt := 0; ; attempt++ {
+		// the last attempt declares the transient error as the run error
+		var transientErrorPatterns []*regexp.Regexp
+		if attempt < appEnv.RunRetries() {
+			transientErrorPatterns = appEnv.TransientRunErrorPatterns()
+		}
+		logger.WithPipelineId(pipelineId).WithContext(ctx).WithPhase(phaseRun).Infof("Run() ...\n")
+		if withCrashReport {
+			_ = os.Remove(lc.GetAbsoluteCrashReportFilePath())
+		}
+		runCmd := executor.Run(ctxWithTimeout)
+		setRunEnv(runCmd, appEnv.RunEnvAllowlist(), lc.GetRunEnv())
+		setTraceId(ctxWithTimeout, runCmd)
+		setSampleDataset(runCmd, lc.GetAbsoluteSampleDatasetFilePath())
+		if withIntermediateSamples && sdkEnv.ApacheBeamSdk == pb.Sdk_SDK_PYTHON {
+			addPythonPath(runCmd, preparators.PythonSamplingHookDir(lc.GetAbsoluteSourceFilePath()))
+		}
+		recordInvocation(ctxWithTimeout, pipelineId, cacheService, cache.RunInvocation, runCmd, workingDir)
+		setCpuTimeLimit(runCmd, appEnv.RunCpuTimeLimit())
+		cgroup := setRunResourceLimits(pipelineId, runCmd, sdkEnv.ApacheBeamSdk, appEnv.RunMemoryLimit(), appEnv.RunCpuQuota())
+		var runError bytes.Buffer
+		var outputLimitChannel, outputLimitStopChannel chan bool
+		if appEnv.MaxOutputBytes() > 0 && appEnv.KillOnOutputLimit() {
+			outputLimitChannel, outputLimitStopChannel = make(chan bool, 1), make(chan bool, 1)
+		}
+		runOutputWriter := &streaming.RunOutputWriter{Ctx: ctxWithTimeout, CacheService: cacheService, PipelineId: pipelineId, MaxBytes: appEnv.MaxOutputBytes(), LimitChannel: outputLimitChannel, Format: runOutputFormat(ctxWithTimeout, cacheService, pipelineId), LogFilter: runLogFilter(ctxWithTimeout, cacheService, pipelineId)}
+		if runOutputWriter.Format != "" {
+			cacheService.SetValue(ctxWithTimeout, pipelineId, cache.RunRecords, []cache.RunRecord{})
+		}
+		if runOutputWriter.LogFilter != nil {
+			cacheService.SetValue(ctxWithTimeout, pipelineId, cache.RawRunOutput, "")
+		}
+		if appEnv.StripAnsiEscapes() {
+			runOutputWriter.Transformers = append(runOutputWriter.Transformers, &streaming.AnsiStripper{})
+		}
+		var runOutput io.Writer = runOutputWriter
+		var runErrorOutput io.Writer = &runError
+		if appEnv.OutputSamplingThreshold() > 0 && appEnv.OutputSamplingRate() > 1 {
+			runOutput = &streaming.SamplingWriter{Writer: runOutput, Threshold: appEnv.OutputSamplingThreshold(), Rate: appEnv.OutputSamplingRate()}
+		}
+		// element reports are dropped from the run output before it is sampled and parsed after secrets are redacted
+		var samplesWriter *streaming.IntermediateSamplesWriter
+		if withIntermediateSamples {
+			cacheService.SetValue(ctxWithTimeout, pipelineId, cache.IntermediateSamples, []cache.PCollectionSamples{})
+			samplesWriter = &streaming.IntermediateSamplesWriter{Writer: runOutput, Ctx: ctxWithTimeout, CacheService: cacheService, PipelineId: pipelineId, MaxSamples: appEnv.IntermediateSamples()}
+			runOutput = samplesWriter
+		}
+		var outputFlushers []flusher
+		if appEnv.OutputRedactionEnabled() && len(appEnv.SecretPatterns()) > 0 {
+			redactingOutput := &streaming.RedactingWriter{Writer: runOutput, Patterns: appEnv.SecretPatterns()}
+			redactingErrorOutput := &streaming.RedactingWriter{Writer: runErrorOutput, Patterns: appEnv.SecretPatterns()}
+			runOutput, runErrorOutput = redactingOutput, redactingErrorOutput
+			outputFlushers = []flusher{redactingOutput, redactingErrorOutput}
+		}
+		// the samples writer and then the run output writer are flushed after the redacting writers which write their rest to them
+		if samplesWriter != nil {
+			outputFlushers = append(outputFlushers, samplesWriter)
+		}
+		outputFlushers = append(outputFlushers, runOutputWriter)
+		if appEnv.MetricsSamplingInterval() > 0 {
+			cacheService.SetValue(ctxWithTimeout, pipelineId, cache.Metrics, []cache.MetricPoint{})
+			metricsWriter := &streaming.MetricsWriter{Ctx: ctxWithTimeout, CacheService: cacheService, PipelineId: pipelineId, SamplingInterval: appEnv.MetricsSamplingInterval()}
+			runOutput = io.MultiWriter(runOutput, metricsWriter)
+		}
+		runOutput = streaming.NewTranscodingWriter(runOutput, lc.GetOutputCharset())
+		runErrorOutput = streaming.NewTranscodingWriter(runErrorOutput, lc.GetOutputCharset())
+		// the transcoding writers are flushed first as they write the rest of the output to other flushers
+		for _, output := range []io.Writer{runErrorOutput, runOutput} {
+			if transcodingWriter, ok := output.(*streaming.TranscodingWriter); ok {
+				outputFlushers = append([]flusher{transcodingWriter}, outputFlushers...)
+			}
+		}
+		var activityWriter *streaming.ActivityWriter
+		if appEnv.StallWindow() > 0 {
+			activityWriter = streaming.NewActivityWriter(time.Now())
+			runOutput = io.MultiWriter(runOutput, activityWriter)
+			runErrorOutput = io.MultiWriter(runErrorOutput, activityWriter)
+		}
+		if stdin := runStdin(ctxWithTimeout, cacheService, pipelineId, lc); len(stdin) > 0 {
+			feedStdin(ctxWithTimeout, pipelineId, runCmd, stdin)
+		}
+		runCmdWithOutput(runCmd, runOutput, runErrorOutput, successChannel, errorChannel, outputFlushers...)
+
+		var stallChannel chan bool
+		if activityWriter != nil && runCmd.Process != nil {
+			stallChannel = make(chan bool, 1)
+			go stallCheck(ctxWithTimeout, pipelineId, runCmd.Process.Pid, activityWriter, appEnv.StallWindow(), stallChannel)
+		}
+		if outputLimitChannel != nil {
+			go stopOnOutputLimit(ctxWithTimeout, pipelineId, runCmd, outputLimitChannel, outputLimitStopChannel)
+		}
+		var diskQuotaDoneChannel, diskQuotaStopChannel chan bool
+		if appEnv.RunDiskQuota() > 0 && appEnv.DiskUsageSamplingInterval() > 0 && runCmd.Process != nil {
+			diskQuotaDoneChannel, diskQuotaStopChannel = make(chan bool), make(chan bool, 1)
+			go stopOnDiskQuota(ctxWithTimeout, pipelineId, cacheService, runCmd, lc.GetAbsoluteBaseFolderPath(), int64(appEnv.RunDiskQuota())*1024*1024, appEnv.DiskUsageSamplingInterval(), diskQuotaDoneChannel, diskQuotaStopChannel)
+		}
+		// the cause of the failure is decided before the run error is saved, so the status is saved only once
+		runFailureCause := func(ctx context.Context, errorData []byte) error {
+			switch {
+			case len(diskQuotaStopChannel) > 0:
+				processDiskQuota(ctx, cacheService, pipelineId)
+				return fmt.Errorf("%s: code processing exceeded the quota of disk usage", pipelineId)
+			case len(outputLimitStopChannel) > 0:
+				processOutputLimit(ctx, cacheService, pipelineId)
+				return fmt.Errorf("%s: run output exceeded the limit of size", pipelineId)
+			case (cgroup != nil && cgroup.isOomKilled()) || isJvmOutOfMemory(sdkEnv.ApacheBeamSdk, errorData):
+				processOom(ctx, cacheService, pipelineId)
+				return fmt.Errorf("%s: code processing exceeded the limit of memory", pipelineId)
+			}
+			return nil
+		}
+		err = processStep(ctxWithTimeout, pipelineId, cacheService, runCmd, cancelChannel, stallChannel, successChannel, nil, &runError, errorChannel, pb.Status_STATUS_RUN_ERROR, pb.Status_STATUS_FINISHED, transientErrorPatterns, appEnv.RunTimeout(), runFailureCause)
+		if diskQuotaDoneChannel != nil {
+			close(diskQuotaDoneChannel)
+		}
+		if cgroup != nil {
+			cgroup.remove()
+		}
+		if err != errTransientStep {
+			break
+		}
+		logger.WithPipelineId(pipelineId).WithContext(ctx).WithPhase(phaseRun).Warnf("Run: retrying after the transient error, output: %s\n", runError.String())
+		resetRunOutput(ctxWithTimeout, pipelineId, cacheService)
+	}
+	processDuration(ctxWithTimeout, pipelineId, cacheService, cache.RunDuration, runStartedAt)
+	if err != nil {
+		if withCrashReport {
+			var secretPatterns []*regexp.Regexp
+			if appEnv.OutputRedactionEnabled() {
+				secretPatterns = appEnv.SecretPatterns()
+			}
+			mergeCrashReport(ctxWithTimeout, pipelineId, cacheService, lc.GetAbsoluteCrashReportFilePath(), secretPatterns)
+		}
+		return
+	}
+
+	processFormattedOutput(ctxWithTimeout, sdkEnv.ApacheBeamSdk, pipelineId, cacheService)
+	if len(appEnv.AllowedOutputFileExtensions()) > 0 {
+		captureOutputFiles(ctxWithTimeout, lc, pipelineId, cacheService, appEnv.AllowedOutputFileExtensions(), appEnv.MaxOutputFiles())
+	}
+	if withCoverage {
+		captureCoverage(ctxWithTimeout, &executor, pipelineId, cacheService)
+	}
+}
+
+// mergeCrashReport appends the tail of the traceback which is written by the code to the file by crashReportFilePath
+// to cache.RunError, unless cache.RunError already contains it. Secrets matching secretPatterns are redacted from the tail.
+// In case the code didn't write the crash report cache.RunError isn't changed.
+func mergeCrashReport(ctx context.Context, pipelineId uuid.UUID, cacheService cache.Cache, crashReportFilePath string, secretPatterns []*regexp.Regexp) {
+	report, err := os.ReadFile(crashReportFilePath)
+	if err != nil || len(bytes.TrimSpace(report)) == 0 {
+		return
+	}
+	var redacted bytes.Buffer
+	redactingWriter := &streaming.RedactingWriter{Writer: &redacted, Patterns: secretPatterns}
+	_, _ = redactingWriter.Write(report)
+	_ = redactingWriter.Flush()
+	tail := strings.TrimSpace(redacted.String())
+
+	runError, err := GetProcessingOutput(ctx, cacheService, pipelineId, cache.RunError, "")
+	if err != nil || strings.Contains(runError, tail) {
+		return
 	}
-	logger.Infof("%s: Run() ...\n", pipelineId)
-	runCmd := executor.Run(ctxWithTimeout)
-	var runError bytes.Buffer
-	runOutput := streaming.RunOutputWriter{Ctx: ctxWithTimeout, CacheService: cacheService, PipelineId: pipelineId}
-	runCmdWithOutput(runCmd, &runOutput, &runError, successChannel, errorChannel)
+	logger.WithPipelineId(pipelineId).WithContext(ctx).WithPhase(phaseRun).Infof("Run: merging the crash report into the run error\n")
+	cacheService.SetValue(ctx, pipelineId, cache.RunError, runError+"\n"+tail)
+}
+
+// InFlightCount returns a number of Process invocations which are running now.
+// The server could be drained safely once it returns zero and no new code is accepted.
+func InFlightCount() int {
+	return int(atomic.LoadInt64(&inFlightCount))
+}
+
+// claimPipeline atomically marks the pipeline as processed by the calling invocation of Process
+// saving cache.ProcessingClaim into cache, so the claim is shared by all instances of the server which use the same cache.
+// Returns false if the pipeline is already claimed by another invocation or its status in cache is already final,
+// e.g. the invocation is retried after the pipeline is finished, so it shouldn't be processed again.
+// The status is checked only after the pipeline is claimed, so it couldn't be finished by another invocation in between.
+// In case the claim couldn't be saved into cache the pipeline is claimed, since other invocations couldn't check it either.
+func claimPipeline(ctx context.Context, cacheService cache.Cache, pipelineId uuid.UUID) bool {
+	claimed, err := cacheService.SetValueIfAbsent(ctx, pipelineId, cache.ProcessingClaim, true)
+	if err != nil {
+		logger.WithPipelineId(pipelineId).WithContext(ctx).Errorf("Process(): error during saving the claim of the pipeline: %s\n", err.Error())
+		return true
+	}
+	if !claimed {
+		return false
+	}
+	if value, err := cacheService.GetValue(ctx, pipelineId, cache.Status); err == nil {
+		if status, converted := value.(pb.Status); converted && IsFinished(status) {
+			releasePipeline(ctx, cacheService, pipelineId)
+			return false
+		}
+	}
+	return true
+}
+
+// releasePipeline deletes cache.ProcessingClaim of the pipeline from cache, so the pipeline could be claimed again.
+func releasePipeline(ctx context.Context, cacheService cache.Cache, pipelineId uuid.UUID) {
+	if err := cacheService.DeleteValue(ctx, pipelineId, cache.ProcessingClaim); err != nil {
+		logger.WithPipelineId(pipelineId).WithContext(ctx).Errorf("Process(): error during releasing the claim of the pipeline: %s\n", err.Error())
+	}
+}
+
+// isEmptySource returns true if the file with code contains only whitespaces.
+// In case the file couldn't be read returns false, so it is checked by validators of the SDK.
+func isEmptySource(filePath string) bool {
+	code, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return false
+	}
+	return len(bytes.TrimSpace(code)) == 0
+}
+
+// runTests runs tests against the code instead of running the code.
+// Saves output of the test harness truncated after maxOutputBytes (if it is positive) as cache.RunOutput and results of tests as cache.TestResults into cache.
+// In case some tests are failed saves playground.Status_STATUS_TEST_FAILED as cache.Status into cache.
+// Like the run step the test harness sees only variables of the server allowed by runEnvAllowlist and variables runEnv requested by the client.
+func runTests(ctx context.Context, pipelineId uuid.UUID, cacheService cache.Cache, executor *executors.Executor, testConfig *environment.TestConfig, cancelChannel, successChannel chan bool, errorChannel chan error, timeout time.Duration, maxOutputBytes int, runEnvAllowlist []string, runEnv map[string]string) {
+	logger.WithPipelineId(pipelineId).WithContext(ctx).WithPhase(phaseTest).Infof("Test() ...\n")
+	testCmd := executor.Test(ctx)
+	setRunEnv(testCmd, runEnvAllowlist, runEnv)
+	setTraceId(ctx, testCmd)
+	var testOutput bytes.Buffer
+	var testError bytes.Buffer
+	runOutput := streaming.RunOutputWriter{Ctx: ctx, CacheService: cacheService, PipelineId: pipelineId, MaxBytes: maxOutputBytes}
+	testCmd.Stdout = io.MultiWriter(&runOutput, &testOutput)
+	testCmd.Stderr = &testError
+	setProcessGroup(testCmd)
+	if err := testCmd.Start(); err != nil {
+		errorChannel <- err
+		successChannel <- false
+	}
+	go func(cmd *exec.Cmd, successChannel chan bool, errChannel chan error) {
+		defer recoverStep(successChannel, errChannel)
+		if cmd.Process == nil {
+			return
+		}
+		err := cmd.Wait()
+		processTestResults(ctx, testOutput.Bytes(), pipelineId, cacheService, testConfig)
+		if err != nil {
+			errChannel <- err
+			successChannel <- false
+		} else {
+			successChannel <- true
+		}
+	}(testCmd, successChannel, errorChannel)
+
+	_ = processStep(ctx, pipelineId, cacheService, testCmd, cancelChannel, nil, successChannel, nil, &testError, errorChannel, pb.Status_STATUS_TEST_FAILED, pb.Status_STATUS_FINISHED, nil, timeout, nil)
+}
+
+// processTestResults finds results of tests in the output of the test harness and saves them as cache.TestResults into cache
+func processTestResults(ctx context.Context, output []byte, pipelineId uuid.UUID, cacheService cache.Cache, testConfig *environment.TestConfig) {
+	results, err := parseTestResults(output, testConfig)
+	if err != nil {
+		logger.WithPipelineId(pipelineId).WithContext(ctx).WithPhase(phaseTest).Errorf("Test: error during parsing test results: %s\n", err.Error())
+		return
+	}
+	cacheService.SetValue(ctx, pipelineId, cache.TestResults, results)
+}
+
+// parseTestResults returns results of tests which are found in the output by testConfig.ResultPattern.
+// The test is passed if the value of the "result" group is one of testConfig.PassedResults.
+func parseTestResults(output []byte, testConfig *environment.TestConfig) ([]cache.TestResult, error) {
+	pattern, err := regexp.Compile(testConfig.ResultPattern)
+	if err != nil {
+		return nil, err
+	}
+	nameIndex, resultIndex := pattern.SubexpIndex("name"), pattern.SubexpIndex("result")
+	if nameIndex < 0 || resultIndex < 0 {
+		return nil, fmt.Errorf("result pattern should contain \"name\" and \"result\" groups: %s", testConfig.ResultPattern)
+	}
+	results := make([]cache.TestResult, 0)
+	for _, match := range pattern.FindAllSubmatch(output, -1) {
+		passed := false
+		for _, passedResult := range testConfig.PassedResults {
+			if string(match[resultIndex]) == passedResult {
+				passed = true
+				break
+			}
+		}
+		results = append(results, cache.TestResult{Name: string(match[nameIndex]), Passed: passed})
+	}
+	return results, nil
+}
+
+// processDependencies detects imports of the code and saves them as cache.Dependencies into cache.
+// In case imports couldn't be detected logs it, code processing isn't failed.
+func processDependencies(ctx context.Context, sdk pb.Sdk, filePath string, pipelineId uuid.UUID, cacheService cache.Cache) {
+	dependencies, err := utils.GetDependencies(sdk, filePath)
+	if err != nil {
+		logger.WithPipelineId(pipelineId).WithContext(ctx).Errorf("error during detecting dependencies: %s\n", err.Error())
+		return
+	}
+	cacheService.SetValue(ctx, pipelineId, cache.Dependencies, dependencies)
+}
+
+// checkImports checks that the code imports only packages and modules which are allowed by the environment of the SDK.
+// args[0] is *environment.BeamEnvs, args[1] is a list of paths to files with code.
+func checkImports(args ...interface{}) error {
+	sdkEnv := args[0].(*environment.BeamEnvs)
+	filePaths := args[1].([]string)
+	return utils.CheckImports(sdkEnv.ApacheBeamSdk, filePaths, sdkEnv.IsAllowedImport)
+}
+
+// createSampleDataset copies the file of the sample dataset to the folder of the pipeline.
+// args[0] is *fs_tool.LifeCycle with the sample dataset.
+func createSampleDataset(args ...interface{}) error {
+	lc := args[0].(*fs_tool.LifeCycle)
+	if err := lc.CreateSampleDatasetFile(); err != nil {
+		return fmt.Errorf("error during creating the sample dataset %s: %s", lc.GetSampleDatasetName(), err.Error())
+	}
+	return nil
+}
+
+// processDuration saves the duration of the step started at startedAt in milliseconds as subKey into cache
+func processDuration(ctx context.Context, pipelineId uuid.UUID, cacheService cache.Cache, subKey cache.SubKey, startedAt time.Time) {
+	cacheService.SetValue(ctx, pipelineId, subKey, int(time.Since(startedAt).Milliseconds()))
+}
+
+// isTransientError returns true if the error output of the step matches one of transientErrorPatterns
+func isTransientError(errorData []byte, transientErrorPatterns []*regexp.Regexp) bool {
+	for _, pattern := range transientErrorPatterns {
+		if pattern.Match(errorData) {
+			return true
+		}
+	}
+	return false
+}
+
+// resetRunOutput resets the run output, the flag of the truncated output and indexes of the run output and metrics in cache before the run step is retried,
+// so the output of the failed attempt isn't mixed with the output of the next attempt
+func resetRunOutput(ctx context.Context, pipelineId uuid.UUID, cacheService cache.Cache) {
+	cacheService.SetValue(ctx, pipelineId, cache.RunOutput, "")
+	cacheService.SetValue(ctx, pipelineId, cache.RunOutputIndex, 0)
+	cacheService.SetValue(ctx, pipelineId, cache.MetricsIndex, 0)
+	cacheService.SetValue(ctx, pipelineId, cache.OutputTruncated, false)
+}
+
+// processFormattedOutput formats run output from cache by the output formatter of the sdk and saves it as cache.FormattedOutput into cache.
+// In case run output doesn't exist in cache the code hasn't written anything, so empty output is formatted.
+// In case the output couldn't be formatted logs it and saves run output as is.
+func processFormattedOutput(ctx context.Context, sdk pb.Sdk, pipelineId uuid.UUID, cacheService cache.Cache) {
+	output := ""
+	if value, err := cacheService.GetValue(ctx, pipelineId, cache.RunOutput); err == nil {
+		output, _ = value.(string)
+	}
+	formatted, err := utils.FormatOutput(sdk, []byte(output))
+	if err != nil {
+		logger.WithPipelineId(pipelineId).WithContext(ctx).WithPhase(phaseRun).Errorf("error during formatting run output: %s\n", err.Error())
+	}
+	cacheService.SetValue(ctx, pipelineId, cache.FormattedOutput, string(formatted))
+}
+
+// captureCoverage prints the coverage summary of the code run under a coverage tool and saves it as cache.Coverage into cache
+func captureCoverage(ctx context.Context, executor *executors.Executor, pipelineId uuid.UUID, cacheService cache.Cache) {
+	logger.WithPipelineId(pipelineId).WithContext(ctx).WithPhase(phaseCoverage).Infof("Coverage() ...\n")
+	coverage, err := executor.Coverage(ctx).Output()
+	if err != nil {
+		logger.WithPipelineId(pipelineId).WithContext(ctx).WithPhase(phaseCoverage).Errorf("error during printing the coverage summary: %s\n", err.Error())
+		return
+	}
+	cacheService.SetValue(ctx, pipelineId, cache.Coverage, string(coverage))
+}
+
+// captureArtifact packs the file compiled from the code and saves it as cache.CompiledArtifact into cache.
+// In case the artifact couldn't be packed or is larger than maxSize bytes the reason is saved instead of its content.
+func captureArtifact(ctx context.Context, lc *fs_tool.LifeCycle, pipelineId uuid.UUID, cacheService cache.Cache, workingDir string, maxSize int) {
+	logger.WithPipelineId(pipelineId).WithContext(ctx).WithPhase(phaseArtifact).Infof("Artifact() ...\n")
+	artifact, err := packArtifact(lc, pipelineId, workingDir, maxSize)
+	if stderrors.Is(err, errArtifactTooLarge) {
+		artifact.Error = err.Error()
+	} else if err != nil {
+		logger.WithPipelineId(pipelineId).WithContext(ctx).WithPhase(phaseArtifact).Errorf("error during packing the artifact: %s\n", err.Error())
+		artifact.Error = fmt.Sprintf("artifact couldn't be packed: %s", err.Error())
+	}
+	cacheService.SetValue(ctx, pipelineId, cache.CompiledArtifact, artifact)
+}
+
+// processWasmArtifact saves the WebAssembly file compiled from the code as cache.WasmArtifact into cache,
+// so it is still served once folders of the pipeline are deleted.
+// In case the file couldn't be read or is larger than maxSize bytes the reason is saved instead of its content.
+func processWasmArtifact(ctx context.Context, pipelineId uuid.UUID, cacheService cache.Cache, wasmFilePath string, maxSize int) {
+	artifact := cache.Artifact{Name: filepath.Base(wasmFilePath)}
+	if info, err := os.Stat(wasmFilePath); err != nil {
+		logger.WithPipelineId(pipelineId).WithContext(ctx).WithPhase(phaseArtifact).Errorf("error during reading the .wasm file: %s\n", err.Error())
+		artifact.Error = fmt.Sprintf("artifact couldn't be read: %s", err.Error())
+	} else if info.Size() > int64(maxSize) {
+		artifact.Error = fmt.Sprintf("artifact is too large: %d bytes, maximum size is %d bytes", info.Size(), maxSize)
+	} else if artifact.Content, err = ioutil.ReadFile(wasmFilePath); err != nil {
+		logger.WithPipelineId(pipelineId).WithContext(ctx).WithPhase(phaseArtifact).Errorf("error during reading the .wasm file: %s\n", err.Error())
+		artifact.Error = fmt.Sprintf("artifact couldn't be read: %s", err.Error())
+	}
+	cacheService.SetValue(ctx, pipelineId, cache.WasmArtifact, artifact)
+}
+
+// recordInvocation keeps the working directory and argv of cmd to the cache using subKey.
+// Paths are relativized against workingDir so the invocation doesn't expose the layout of the server.
+func recordInvocation(ctx context.Context, pipelineId uuid.UUID, cacheService cache.Cache, subKey cache.SubKey, cmd *exec.Cmd, workingDir string) {
+	relativize := func(value string) string {
+		return strings.ReplaceAll(value, filepath.Clean(workingDir)+string(filepath.Separator), "")
+	}
+	invocation := cache.Invocation{WorkingDir: relativize(cmd.Dir), Args: make([]string, 0, len(cmd.Args))}
+	if relativeDir, err := filepath.Rel(workingDir, cmd.Dir); err == nil {
+		invocation.WorkingDir = relativeDir
+	}
+	for _, arg := range cmd.Args {
+		invocation.Args = append(invocation.Args, relativize(arg))
+	}
+	cacheService.SetValue(ctx, pipelineId, subKey, invocation)
+}
+
+// packArtifact returns the file compiled from the code.
+// In case LifeCycle resolves the entrypoint after compilation (e.g. java) returns the jar with all compiled files
+// and the entrypoint as the main class, otherwise returns the executable file as is.
+// Dependencies of the code aren't included into the artifact.
+// Sizes are checked before the artifact is read into memory: in case the executable file or the jar is larger
+// than maxSize bytes returns errArtifactTooLarge without the content.
+func packArtifact(lc *fs_tool.LifeCycle, pipelineId uuid.UUID, workingDir string, maxSize int) (cache.Artifact, error) {
+	if lc.ExecutableName == nil {
+		filePath := lc.GetAbsoluteExecutableFilePath()
+		artifact := cache.Artifact{Name: filepath.Base(filePath)}
+		info, err := os.Stat(filePath)
+		if err != nil {
+			return artifact, err
+		}
+		if info.Size() > int64(maxSize) {
+			return artifact, fmt.Errorf("%w: %d bytes, maximum size is %d bytes", errArtifactTooLarge, info.Size(), maxSize)
+		}
+		content, err := ioutil.ReadFile(filePath)
+		if err != nil {
+			return artifact, err
+		}
+		artifact.Content = content
+		return artifact, nil
+	}
+	artifact := cache.Artifact{Name: pipelineId.String() + jarExtension}
+	manifest := "Manifest-Version: 1.0\n"
+	if mainClass, err := lc.ExecutableName(pipelineId, workingDir); err == nil {
+		manifest += "Main-Class: " + mainClass + "\n"
+	}
+	content, err := utils.ZipFolder(lc.Folder.ExecutableFileFolder, map[string]string{jarManifestName: manifest}, maxSize)
+	if stderrors.Is(err, utils.ErrArchiveTooLarge) {
+		return artifact, fmt.Errorf("%w: maximum size is %d bytes", errArtifactTooLarge, maxSize)
+	}
+	if err != nil {
+		return artifact, err
+	}
+	artifact.Content = content
+	return artifact, nil
+}
 
-	err = processStep(ctxWithTimeout, pipelineId, cacheService, cancelChannel, successChannel, nil, &runError, errorChannel, pb.Status_STATUS_RUN_ERROR, pb.Status_STATUS_FINISHED)
+// captureOutputFiles reads back files written by the code and saves them as cache.OutputFiles into cache.
+// Files which extensions aren't in allowedExtensions aren't read, the reason is saved instead of their content.
+// Only first maxFiles files ordered by names are read, the rest of files are listed with the marker instead of their content.
+// In case maxFiles isn't positive all files are read.
+func captureOutputFiles(ctx context.Context, lc *fs_tool.LifeCycle, pipelineId uuid.UUID, cacheService cache.Cache, allowedExtensions []string, maxFiles int) {
+	filePaths, err := lc.GetAbsoluteOutputFilePaths()
 	if err != nil {
+		logger.WithPipelineId(pipelineId).WithContext(ctx).WithPhase(phaseRun).Errorf("error during getting output files: %s\n", err.Error())
 		return
 	}
+	outputFiles := make([]cache.OutputFile, 0, len(filePaths))
+	for i, filePath := range filePaths {
+		outputFile := cache.OutputFile{Name: filepath.Base(filePath)}
+		if maxFiles > 0 && i >= maxFiles {
+			outputFile.Error = fmt.Sprintf("output file isn't read, only first %d output files are read", maxFiles)
+		} else if extension := filepath.Ext(filePath); !isAllowedExtension(extension, allowedExtensions) {
+			outputFile.Error = fmt.Sprintf("output files with extension \"%s\" aren't allowed to be read", extension)
+		} else if content, err := ioutil.ReadFile(filePath); err != nil {
+			outputFile.Error = fmt.Sprintf("output file couldn't be read: %s", err.Error())
+		} else {
+			outputFile.Content = string(content)
+		}
+		outputFiles = append(outputFiles, outputFile)
+	}
+	cacheService.SetValue(ctx, pipelineId, cache.OutputFiles, outputFiles)
+}
+
+// isAllowedExtension checks if the file extension is one of allowedExtensions ignoring case
+func isAllowedExtension(extension string, allowedExtensions []string) bool {
+	for _, allowedExtension := range allowedExtensions {
+		if strings.EqualFold(extension, allowedExtension) {
+			return true
+		}
+	}
+	return false
 }
 
-// setJavaExecutableFile sets executable file name to runner (JAVA class name is known after compilation step)
-func setJavaExecutableFile(lc *fs_tool.LifeCycle, id uuid.UUID, service cache.Cache, ctx context.Context, executorBuilder *executors.ExecutorBuilder, dir string) executors.Executor {
+// setJavaExecutableFile sets executable file name to runner (JAVA class name and SCIO object name are known after compilation step).
+// It is used for each SDK which LifeCycle resolves the entrypoint after compilation.
+// In case the entrypoint couldn't be resolved saves playground.Status_STATUS_ERROR into cache and returns the error,
+// so code processing is stopped instead of running a wrong class.
+func setJavaExecutableFile(lc *fs_tool.LifeCycle, id uuid.UUID, service cache.Cache, ctx context.Context, executorBuilder *executors.ExecutorBuilder, dir string) (executors.Executor, error) {
 	className, err := lc.ExecutableName(id, dir)
 	if err != nil {
 		processSetupError(err, id, service, ctx)
+		return executors.Executor{}, err
 	}
-	return executorBuilder.WithRunner().WithExecutableFileName(className).Build()
+	return executorBuilder.WithRunner().WithExecutableFileName(className).Build(), nil
 }
 
 // processSetupError processes errors during the setting up an executor builder
 func processSetupError(err error, pipelineId uuid.UUID, cacheService cache.Cache, ctxWithTimeout context.Context) {
-	logger.Errorf("%s: error during setup builder: %s\n", pipelineId, err.Error())
-	cacheService.SetValue(ctxWithTimeout, pipelineId, cache.Status, pb.Status_STATUS_ERROR)
+	ctxWithTimeout, cancel := terminalContext(ctxWithTimeout)
+	defer cancel()
+	logger.WithPipelineId(pipelineId).WithContext(ctxWithTimeout).Errorf("error during setup builder: %s\n", err.Error())
+	setStatusWithTimestamp(ctxWithTimeout, pipelineId, cacheService, pb.Status_STATUS_ERROR)
 }
 
 // GetProcessingOutput gets processing output value from cache by key and subKey.
-// In case key doesn't exist in cache - returns an errors.NotFoundError.
-// In case subKey doesn't exist in cache for the key - returns an errors.NotFoundError.
-// In case value from cache by key and subKey couldn't be converted to string - returns an errors.InternalError.
+// In case key doesn't exist in cache - returns an errors.NotFoundError which wraps errors.ErrCacheMiss.
+// In case subKey doesn't exist in cache for the key - returns an errors.NotFoundError which wraps errors.ErrCacheMiss.
+// In case value from cache by key and subKey couldn't be converted to string - returns an errors.InternalError which wraps errors.ErrTypeMismatch.
 func GetProcessingOutput(ctx context.Context, cacheService cache.Cache, key uuid.UUID, subKey cache.SubKey, errorTitle string) (string, error) {
 	value, err := cacheService.GetValue(ctx, key, subKey)
 	if err != nil {
-		logger.Errorf("%s: GetStringValueFromCache(): cache.GetValue: error: %s", key, err.Error())
-		return "", errors.NotFoundError(errorTitle, fmt.Sprintf("Error during getting cache by key: %s, subKey: %s", key.String(), string(subKey)))
+		logger.WithPipelineId(key).Errorf("GetStringValueFromCache(): cache.GetValue: error: %s", err.Error())
+		return "", errors.WrappedNotFoundError(errorTitle, fmt.Sprintf("Error during getting cache by key: %s, subKey: %s", key.String(), string(subKey)), errors.CacheMissError(err))
 	}
 	stringValue, converted := value.(string)
 	if !converted {
-		logger.Errorf("%s: couldn't convert value to string: %s", key, value)
-		return "", errors.InternalError(errorTitle, fmt.Sprintf("Value from cache couldn't be converted to string: %s", value))
+		logger.WithPipelineId(key).Errorf("couldn't convert value to string: %s", value)
+		return "", errors.WrappedInternalError(errorTitle, fmt.Sprintf("Value from cache couldn't be converted to string: %s", value), errors.TypeMismatchError(fmt.Errorf("value of %T isn't string", value)))
+	}
+	return stringValue, nil
+}
+
+// GetProcessingOutputSince gets processing output from cache by key and subKey and returns only its bytes after fromIndex
+// with the index of the end of the output, which is used as fromIndex by the next call.
+// Indexes are offsets in bytes, so they align with indexes which are saved as cache.RunOutputIndex or cache.LogsIndex into cache.
+// In case output is shorter than fromIndex, e.g. the output is reset before the run step is retried, returns the whole output.
+// In case key doesn't exist in cache - returns an errors.NotFoundError.
+// In case value from cache by key and subKey couldn't be converted to string - returns an errors.InternalError.
+func GetProcessingOutputSince(ctx context.Context, cacheService cache.Cache, key uuid.UUID, subKey cache.SubKey, fromIndex int, errorTitle string) (string, int, error) {
+	output, err := GetProcessingOutput(ctx, cacheService, key, subKey, errorTitle)
+	if err != nil {
+		return "", 0, err
+	}
+	if fromIndex < 0 || fromIndex > len(output) {
+		fromIndex = 0
+	}
+	return output[fromIndex:], len(output), nil
+}
+
+// GetProcessingOutputPreview gets processing output from cache by key and subKey and returns its first and last previewSize bytes
+// with the marker of elided bytes in between.
+// In case output isn't longer than 2*previewSize or previewSize isn't positive returns the whole output.
+// In case key doesn't exist in cache - returns an errors.NotFoundError.
+// In case value from cache by key and subKey couldn't be converted to string - returns an errors.InternalError.
+func GetProcessingOutputPreview(ctx context.Context, cacheService cache.Cache, key uuid.UUID, subKey cache.SubKey, previewSize int, errorTitle string) (string, error) {
+	output, err := GetProcessingOutput(ctx, cacheService, key, subKey, errorTitle)
+	if err != nil {
+		return "", err
+	}
+	return previewOutput(output, previewSize), nil
+}
+
+// previewOutput returns the first and last size bytes of output with OutputPreviewMarkerFormat in between.
+// Bounds of the preview are moved to starts of UTF-8 characters, so characters aren't broken.
+func previewOutput(output string, size int) string {
+	if size <= 0 || len(output) <= 2*size {
+		return output
+	}
+	head := size
+	for head > 0 && !utf8.RuneStart(output[head]) {
+		head--
+	}
+	tail := len(output) - size
+	for tail < len(output) && !utf8.RuneStart(output[tail]) {
+		tail++
+	}
+	return output[:head] + fmt.Sprintf(OutputPreviewMarkerFormat, tail-head) + output[tail:]
+}
+
+// GetTimestamp gets wall-clock time value from cache by key and subKey.
+// In case key doesn't exist in cache - returns an errors.NotFoundError.
+// In case subKey doesn't exist in cache for the key - returns an errors.NotFoundError.
+// In case value from cache by key and subKey couldn't be converted to time.Time - returns an errors.InternalError.
+func GetTimestamp(ctx context.Context, cacheService cache.Cache, key uuid.UUID, subKey cache.SubKey, errorTitle string) (time.Time, error) {
+	value, err := cacheService.GetValue(ctx, key, subKey)
+	if err != nil {
+		logger.WithPipelineId(key).Errorf("GetTimestamp(): cache.GetValue: error: %s", err.Error())
+		return time.Time{}, errors.NotFoundError(errorTitle, fmt.Sprintf("Error during getting cache by key: %s, subKey: %s", key.String(), string(subKey)))
+	}
+	timestamp, converted := value.(time.Time)
+	if !converted {
+		logger.WithPipelineId(key).Errorf("couldn't convert value to time: %s", value)
+		return time.Time{}, errors.InternalError(errorTitle, fmt.Sprintf("Value from cache couldn't be converted to time: %s", value))
+	}
+	return timestamp, nil
+}
+
+// GetStatusTransitions gets wall-clock times when code processing reached statuses from cache by key ordered by time.
+// In case key doesn't exist in cache - returns an errors.NotFoundError.
+// In case value from cache by key couldn't be converted to map[playground.Status]time.Time - returns an errors.InternalError.
+func GetStatusTransitions(ctx context.Context, cacheService cache.Cache, key uuid.UUID, errorTitle string) ([]cache.StatusTransition, error) {
+	value, err := cacheService.GetValue(ctx, key, cache.Timestamps)
+	if err != nil {
+		logger.WithPipelineId(key).Errorf("GetStatusTransitions(): cache.GetValue: error: %s", err.Error())
+		return nil, errors.NotFoundError(errorTitle, fmt.Sprintf("Error during getting cache by key: %s, subKey: %s", key.String(), string(cache.Timestamps)))
+	}
+	timestamps, converted := value.(map[pb.Status]time.Time)
+	if !converted {
+		logger.WithPipelineId(key).Errorf("couldn't convert value to timestamps: %s", value)
+		return nil, errors.InternalError(errorTitle, fmt.Sprintf("Value from cache couldn't be converted to timestamps: %s", value))
+	}
+	transitions := make([]cache.StatusTransition, 0, len(timestamps))
+	for status, timestamp := range timestamps {
+		transitions = append(transitions, cache.StatusTransition{Status: status, Timestamp: timestamp})
+	}
+	sort.Slice(transitions, func(i, j int) bool {
+		if transitions[i].Timestamp.Equal(transitions[j].Timestamp) {
+			return transitions[i].Status < transitions[j].Status
+		}
+		return transitions[i].Timestamp.Before(transitions[j].Timestamp)
+	})
+	return transitions, nil
+}
+
+// GetProgress gets the percentage of completed steps of code processing from cache by key.
+// In case the progress isn't known returns -1.
+func GetProgress(ctx context.Context, cacheService cache.Cache, key uuid.UUID) int {
+	value, err := cacheService.GetValue(ctx, key, cache.Progress)
+	if err != nil {
+		return -1
+	}
+	progress, converted := value.(int)
+	if !converted {
+		return -1
+	}
+	return progress
+}
+
+// GetBuildScanUrl gets the url of the build scan from cache by key.
+// In case the build scan hasn't been published returns an empty string.
+func GetBuildScanUrl(ctx context.Context, cacheService cache.Cache, key uuid.UUID) string {
+	value, err := cacheService.GetValue(ctx, key, cache.BuildScanUrl)
+	if err != nil {
+		return ""
+	}
+	url, _ := value.(string)
+	return url
+}
+
+// GetCompileDiagnostics gets messages of the compiler grouped by source files from cache by key.
+// In case the code hasn't been compiled returns nil.
+func GetCompileDiagnostics(ctx context.Context, cacheService cache.Cache, key uuid.UUID) []cache.FileDiagnostics {
+	value, err := cacheService.GetValue(ctx, key, cache.CompileDiagnostics)
+	if err != nil {
+		return nil
+	}
+	diagnostics, _ := value.([]cache.FileDiagnostics)
+	return diagnostics
+}
+
+// GetValidationDiagnostics gets errors of the validation step with positions grouped by source files from cache by key.
+// For the Go SDK they also include messages of go build and go vet.
+// In case the validation step isn't failed returns nil.
+func GetValidationDiagnostics(ctx context.Context, cacheService cache.Cache, key uuid.UUID) []cache.FileDiagnostics {
+	value, err := cacheService.GetValue(ctx, key, cache.ValidationDiagnostics)
+	if err != nil {
+		return nil
+	}
+	diagnostics, _ := value.([]cache.FileDiagnostics)
+	return diagnostics
+}
+
+// GetCompileWarnings gets warnings of the compiler which don't fail the compilation from cache by key.
+// In case there are no warnings in cache returns nil.
+func GetCompileWarnings(ctx context.Context, cacheService cache.Cache, key uuid.UUID) []cache.CompileWarning {
+	value, err := cacheService.GetValue(ctx, key, cache.CompileWarnings)
+	if err != nil {
+		return nil
+	}
+	warnings, _ := value.([]cache.CompileWarning)
+	return warnings
+}
+
+// GetTestResults gets results of tests run against the code from cache by key.
+// In case key doesn't exist in cache - returns an errors.NotFoundError.
+// In case value from cache by key couldn't be converted to []cache.TestResult - returns an errors.InternalError.
+func GetTestResults(ctx context.Context, cacheService cache.Cache, key uuid.UUID, errorTitle string) ([]cache.TestResult, error) {
+	value, err := cacheService.GetValue(ctx, key, cache.TestResults)
+	if err != nil {
+		logger.WithPipelineId(key).Errorf("GetTestResults(): cache.GetValue: error: %s", err.Error())
+		return nil, errors.NotFoundError(errorTitle, fmt.Sprintf("Error during getting cache by key: %s, subKey: %s", key.String(), string(cache.TestResults)))
+	}
+	results, converted := value.([]cache.TestResult)
+	if !converted {
+		logger.WithPipelineId(key).Errorf("couldn't convert value to test results: %s", value)
+		return nil, errors.InternalError(errorTitle, fmt.Sprintf("Value from cache couldn't be converted to test results: %s", value))
+	}
+	return results, nil
+}
+
+// CompareOutputs compares normalized run outputs of two finished pipelines and saves the diff as cache.CrossSdkDiff of the first pipeline into cache.
+// Pipelines could be processed by servers of different SDKs if they use the same cache.
+// In case some pipeline isn't finished - returns an errors.InvalidArgumentError.
+// In case status or run output of some pipeline couldn't be got from cache - returns an error of getting it.
+func CompareOutputs(ctx context.Context, cacheService cache.Cache, firstKey, secondKey uuid.UUID, errorTitle string) (string, error) {
+	outputs := make([]string, 0, 2)
+	for _, key := range []uuid.UUID{firstKey, secondKey} {
+		status, err := GetProcessingStatus(ctx, cacheService, key, errorTitle)
+		if err != nil {
+			return "", err
+		}
+		if status != pb.Status_STATUS_FINISHED {
+			return "", errors.InvalidArgumentError(errorTitle, fmt.Sprintf("Pipeline %s isn't finished, status: %s", key.String(), status.String()))
+		}
+		output, err := GetProcessingOutput(ctx, cacheService, key, cache.RunOutput, errorTitle)
+		if err != nil {
+			return "", err
+		}
+		outputs = append(outputs, output)
+	}
+	diff := utils.DiffOutputs(outputs[0], outputs[1])
+	cacheService.SetValue(ctx, firstKey, cache.CrossSdkDiff, diff)
+	return diff, nil
+}
+
+// GetDependencies gets imports of the code from cache by key.
+// In case key doesn't exist in cache - returns an errors.NotFoundError.
+// In case value from cache by key couldn't be converted to []string - returns an errors.InternalError.
+func GetDependencies(ctx context.Context, cacheService cache.Cache, key uuid.UUID, errorTitle string) ([]string, error) {
+	value, err := cacheService.GetValue(ctx, key, cache.Dependencies)
+	if err != nil {
+		logger.WithPipelineId(key).Errorf("GetDependencies(): cache.GetValue: error: %s", err.Error())
+		return nil, errors.NotFoundError(errorTitle, fmt.Sprintf("Error during getting cache by key: %s, subKey: %s", key.String(), string(cache.Dependencies)))
+	}
+	dependencies, converted := value.([]string)
+	if !converted {
+		logger.WithPipelineId(key).Errorf("couldn't convert value to dependencies: %s", value)
+		return nil, errors.InternalError(errorTitle, fmt.Sprintf("Value from cache couldn't be converted to dependencies: %s", value))
+	}
+	return dependencies, nil
+}
+
+// GetOutputFiles gets files written by the run step from cache by key.
+// In case key doesn't exist in cache - returns an errors.NotFoundError.
+// In case value from cache by key couldn't be converted to []cache.OutputFile - returns an errors.InternalError.
+func GetOutputFiles(ctx context.Context, cacheService cache.Cache, key uuid.UUID, errorTitle string) ([]cache.OutputFile, error) {
+	value, err := cacheService.GetValue(ctx, key, cache.OutputFiles)
+	if err != nil {
+		logger.WithPipelineId(key).Errorf("GetOutputFiles(): cache.GetValue: error: %s", err.Error())
+		return nil, errors.NotFoundError(errorTitle, fmt.Sprintf("Error during getting cache by key: %s, subKey: %s", key.String(), string(cache.OutputFiles)))
+	}
+	outputFiles, converted := value.([]cache.OutputFile)
+	if !converted {
+		logger.WithPipelineId(key).Errorf("couldn't convert value to output files: %s", value)
+		return nil, errors.InternalError(errorTitle, fmt.Sprintf("Value from cache couldn't be converted to output files: %s", value))
+	}
+	return outputFiles, nil
+}
+
+// GetRunRecords gets records parsed from the run output in the JSON lines format from cache by key.
+// In case key doesn't exist in cache - returns an errors.NotFoundError.
+// In case value from cache by key couldn't be converted to []cache.RunRecord - returns an errors.InternalError.
+func GetRunRecords(ctx context.Context, cacheService cache.Cache, key uuid.UUID, errorTitle string) ([]cache.RunRecord, error) {
+	value, err := cacheService.GetValue(ctx, key, cache.RunRecords)
+	if err != nil {
+		logger.WithPipelineId(key).Errorf("GetRunRecords(): cache.GetValue: error: %s", err.Error())
+		return nil, errors.NotFoundError(errorTitle, fmt.Sprintf("Error during getting cache by key: %s, subKey: %s", key.String(), string(cache.RunRecords)))
+	}
+	records, converted := value.([]cache.RunRecord)
+	if !converted {
+		logger.WithPipelineId(key).Errorf("couldn't convert value to run records: %s", value)
+		return nil, errors.InternalError(errorTitle, fmt.Sprintf("Value from cache couldn't be converted to run records: %s", value))
+	}
+	return records, nil
+}
+
+// GetArtifact gets the file compiled from the code from cache by key.
+// In case the code is compiled to WebAssembly the .wasm file from cache.WasmArtifact is returned.
+// In case key doesn't exist in cache - returns an errors.NotFoundError.
+// In case value from cache by key couldn't be converted to cache.Artifact - returns an errors.InternalError.
+func GetArtifact(ctx context.Context, cacheService cache.Cache, key uuid.UUID, errorTitle string) (cache.Artifact, error) {
+	value, err := cacheService.GetValue(ctx, key, cache.WasmArtifact)
+	if err != nil {
+		value, err = cacheService.GetValue(ctx, key, cache.CompiledArtifact)
+	}
+	if err != nil {
+		logger.WithPipelineId(key).Errorf("GetArtifact(): cache.GetValue: error: %s", err.Error())
+		return cache.Artifact{}, errors.NotFoundError(errorTitle, fmt.Sprintf("Error during getting cache by key: %s, subKey: %s", key.String(), string(cache.CompiledArtifact)))
+	}
+	artifact, converted := value.(cache.Artifact)
+	if !converted {
+		logger.WithPipelineId(key).Errorf("couldn't convert value to artifact: %s", value)
+		return cache.Artifact{}, errors.InternalError(errorTitle, fmt.Sprintf("Value from cache couldn't be converted to artifact: %s", value))
+	}
+	return artifact, nil
+}
+
+// GetInvocation gets the command which is used to process the code from cache by key and subKey.
+// In case key doesn't exist in cache - returns an errors.NotFoundError.
+// In case value from cache by key and subKey couldn't be converted to cache.Invocation - returns an errors.InternalError.
+func GetInvocation(ctx context.Context, cacheService cache.Cache, key uuid.UUID, subKey cache.SubKey, errorTitle string) (cache.Invocation, error) {
+	value, err := cacheService.GetValue(ctx, key, subKey)
+	if err != nil {
+		logger.WithPipelineId(key).Errorf("GetInvocation(): cache.GetValue: error: %s", err.Error())
+		return cache.Invocation{}, errors.NotFoundError(errorTitle, fmt.Sprintf("Error during getting cache by key: %s, subKey: %s", key.String(), string(subKey)))
+	}
+	invocation, converted := value.(cache.Invocation)
+	if !converted {
+		logger.WithPipelineId(key).Errorf("couldn't convert value to invocation: %s", value)
+		return cache.Invocation{}, errors.InternalError(errorTitle, fmt.Sprintf("Value from cache couldn't be converted to invocation: %s", value))
+	}
+	return invocation, nil
+}
+
+// GetPipelineGroup gets the group of pipelines with variants of the same example from cache by key.
+// In case key doesn't exist in cache - returns an errors.NotFoundError.
+// In case value from cache by key couldn't be converted to cache.PipelineGroup - returns an errors.InternalError.
+func GetPipelineGroup(ctx context.Context, cacheService cache.Cache, key uuid.UUID, errorTitle string) (cache.PipelineGroup, error) {
+	value, err := cacheService.GetValue(ctx, key, cache.Group)
+	if err != nil {
+		logger.WithPipelineId(key).Errorf("GetPipelineGroup(): cache.GetValue: error: %s", err.Error())
+		return cache.PipelineGroup{}, errors.NotFoundError(errorTitle, fmt.Sprintf("Error during getting cache by key: %s, subKey: %s", key.String(), string(cache.Group)))
+	}
+	group, converted := value.(cache.PipelineGroup)
+	if !converted {
+		logger.WithPipelineId(key).Errorf("couldn't convert value to pipeline group: %s", value)
+		return cache.PipelineGroup{}, errors.InternalError(errorTitle, fmt.Sprintf("Value from cache couldn't be converted to pipeline group: %s", value))
+	}
+	return group, nil
+}
+
+// PersistResult persists status, compile output, run output, run error and source of the finished pipeline to resultStorage
+// and saves the reference to the persisted result as cache.PersistedResult into cache.
+// Outputs which aren't in cache are persisted as empty strings.
+func PersistResult(ctx context.Context, cacheService cache.Cache, resultStorage result_storage.Storage, pipelineId uuid.UUID) error {
+	status, err := GetProcessingStatus(ctx, cacheService, pipelineId, "PersistResult")
+	if err != nil {
+		return err
+	}
+	result := result_storage.Result{Status: status}
+	result.CompileOutput, _ = GetProcessingOutput(ctx, cacheService, pipelineId, cache.CompileOutput, "PersistResult")
+	result.RunOutput, _ = GetProcessingOutput(ctx, cacheService, pipelineId, cache.RunOutput, "PersistResult")
+	result.RunError, _ = GetProcessingOutput(ctx, cacheService, pipelineId, cache.RunError, "PersistResult")
+	result.Source, _ = GetProcessingOutput(ctx, cacheService, pipelineId, cache.Source, "PersistResult")
+	reference, err := result_storage.PutResult(ctx, resultStorage, pipelineId, result)
+	if err != nil {
+		return err
+	}
+	return cacheService.SetValue(ctx, pipelineId, cache.PersistedResult, reference)
+}
+
+// RestoreResult restores status, compile output, run output, run error and source of the pipeline persisted by PersistResult
+// into cache for expirationTime, so the pipeline could be read from cache as if it has just finished.
+// In case the result isn't persisted returns result_storage.ErrNotFound.
+func RestoreResult(ctx context.Context, cacheService cache.Cache, resultStorage result_storage.Storage, pipelineId uuid.UUID, expirationTime time.Duration) error {
+	result, err := result_storage.GetResult(ctx, resultStorage, pipelineId)
+	if err != nil {
+		return err
+	}
+	values := []struct {
+		subKey cache.SubKey
+		value  interface{}
+	}{
+		{cache.Status, result.Status},
+		{cache.CompileOutput, result.CompileOutput},
+		{cache.RunOutput, result.RunOutput},
+		{cache.RunError, result.RunError},
+		{cache.Source, result.Source},
+		{cache.RunOutputIndex, 0},
+		{cache.CompileOutputIndex, 0},
+		{cache.LogsIndex, 0},
+	}
+	for _, value := range values {
+		if err = cacheService.SetValue(ctx, pipelineId, value.subKey, value.value); err != nil {
+			return err
+		}
+	}
+	return cacheService.SetExpTime(ctx, pipelineId, expirationTime)
+}
+
+// GetProcessingStatus gets processing status from cache by key.
+// In case key doesn't exist in cache - returns an errors.NotFoundError.
+// In case value from cache by key and subKey couldn't be converted to playground.Status - returns an errors.InternalError.
+func GetProcessingStatus(ctx context.Context, cacheService cache.Cache, key uuid.UUID, errorTitle string) (pb.Status, error) {
+	value, err := cacheService.GetValue(ctx, key, cache.Status)
+	if err != nil {
+		logger.WithPipelineId(key).Errorf("GetStringValueFromCache(): cache.GetValue: error: %s", err.Error())
+		return pb.Status_STATUS_UNSPECIFIED, errors.NotFoundError(errorTitle, fmt.Sprintf("Error during getting cache by key: %s, subKey: %s", key.String(), string(cache.Status)))
+	}
+	statusValue, converted := value.(pb.Status)
+	if !converted {
+		logger.WithPipelineId(key).Errorf("couldn't convert value to correct status enum: %s", value)
+		return pb.Status_STATUS_UNSPECIFIED, errors.InternalError(errorTitle, fmt.Sprintf("Value from cache couldn't be converted to correct status enum: %s", value))
+	}
+	return statusValue, nil
+}
+
+// GetCancelReason gets the reason of the cancellation of code processing from cache by key.
+// In case key doesn't exist in cache - returns an errors.NotFoundError.
+// In case value from cache by key and subKey couldn't be converted to playground.CancelReason - returns an errors.InternalError.
+func GetCancelReason(ctx context.Context, cacheService cache.Cache, key uuid.UUID, errorTitle string) (pb.CancelReason, error) {
+	value, err := cacheService.GetValue(ctx, key, cache.CancelReason)
+	if err != nil {
+		logger.WithPipelineId(key).Errorf("GetCancelReason(): cache.GetValue: error: %s", err.Error())
+		return pb.CancelReason_CANCEL_REASON_UNSPECIFIED, errors.NotFoundError(errorTitle, fmt.Sprintf("Error during getting cache by key: %s, subKey: %s", key.String(), string(cache.CancelReason)))
+	}
+	reason, converted := value.(pb.CancelReason)
+	if !converted {
+		logger.WithPipelineId(key).Errorf("couldn't convert value to correct cancel reason enum: %s", value)
+		return pb.CancelReason_CANCEL_REASON_UNSPECIFIED, errors.InternalError(errorTitle, fmt.Sprintf("Value from cache couldn't be converted to correct cancel reason enum: %s", value))
+	}
+	return reason, nil
+}
+
+// processingStateReadAttempts is a maximum number of attempts to read the state of code processing while its status is changed
+const processingStateReadAttempts = 3
+
+// ProcessingState is a snapshot of the state of code processing which is read by GetProcessingState.
+// Outputs which aren't saved into cache yet are empty, HasExitCode is false until a command of the compile or run step is finished.
+type ProcessingState struct {
+	Status             pb.Status
+	CompileOutput      string
+	RunOutput          string
+	RunError           string
+	ExitCode           int
+	HasExitCode        bool
+	RunOutputIndex     int
+	CompileOutputIndex int
+	LogsIndex          int
+}
+
+// GetProcessingState gets the status, outputs, the exit code and last indexes of code processing from cache by key in one call.
+// The cache has no transactions, so values are read one by one in the order which keeps the snapshot consistent:
+// - The status is read before outputs, so outputs are at least as new as the status. Outputs aren't guaranteed to be complete once the status is final:
+// e.g. the output of the run step stopped by the timeout could be still written by streaming.RunOutputWriter after the timeout status is saved.
+// - Indexes are read before outputs, so each index doesn't exceed the length of its output.
+// - The run output could be newer than the status while the run step is running, since streaming.RunOutputWriter appends to it concurrently,
+// but it is a prefix of the run output which is read later unless the run output is reset before the run step is retried.
+// In case the status is changed while values are read they are read again up to processingStateReadAttempts times,
+// after that the snapshot with the status read before outputs is returned.
+// In case key doesn't exist in cache - returns an errors.NotFoundError.
+// In case some value couldn't be read from cache for another reason than its absence or couldn't be converted to its type - returns an errors.InternalError.
+func GetProcessingState(ctx context.Context, cacheService cache.Cache, key uuid.UUID, errorTitle string) (ProcessingState, error) {
+	var state ProcessingState
+	for attempt := 1; ; attempt++ {
+		status, err := GetProcessingStatus(ctx, cacheService, key, errorTitle)
+		if err != nil {
+			return ProcessingState{}, err
+		}
+		state, err = readProcessingState(ctx, cacheService, key, errorTitle)
+		if err != nil {
+			return ProcessingState{}, err
+		}
+		state.Status = status
+		lastStatus, err := GetProcessingStatus(ctx, cacheService, key, errorTitle)
+		if err != nil {
+			return ProcessingState{}, err
+		}
+		if lastStatus == status || attempt == processingStateReadAttempts {
+			return state, nil
+		}
+	}
+}
+
+// readProcessingState reads indexes, outputs and the exit code of code processing from cache by key.
+// Values which aren't saved into cache (errors.ErrCacheMiss) are left empty.
+// In case some value couldn't be read from cache for another reason, e.g. the cache is unavailable - returns an errors.InternalError.
+// In case some value from cache couldn't be converted to its type - returns an errors.InternalError.
+func readProcessingState(ctx context.Context, cacheService cache.Cache, key uuid.UUID, errorTitle string) (ProcessingState, error) {
+	var state ProcessingState
+	intValues := []struct {
+		subKey cache.SubKey
+		value  *int
+	}{
+		{cache.RunOutputIndex, &state.RunOutputIndex},
+		{cache.CompileOutputIndex, &state.CompileOutputIndex},
+		{cache.LogsIndex, &state.LogsIndex},
+	}
+	for _, intValue := range intValues {
+		value, found, err := getStateValue(ctx, cacheService, key, intValue.subKey, errorTitle)
+		if err != nil {
+			return ProcessingState{}, err
+		}
+		if !found {
+			continue
+		}
+		converted, ok := value.(int)
+		if !ok {
+			logger.WithPipelineId(key).Errorf("couldn't convert value to int: %s", value)
+			return ProcessingState{}, errors.InternalError(errorTitle, fmt.Sprintf("Value from cache couldn't be converted to int: %s", value))
+		}
+		*intValue.value = converted
+	}
+	stringValues := []struct {
+		subKey cache.SubKey
+		value  *string
+	}{
+		{cache.CompileOutput, &state.CompileOutput},
+		{cache.RunOutput, &state.RunOutput},
+		{cache.RunError, &state.RunError},
+	}
+	for _, stringValue := range stringValues {
+		value, found, err := getStateValue(ctx, cacheService, key, stringValue.subKey, errorTitle)
+		if err != nil {
+			return ProcessingState{}, err
+		}
+		if !found {
+			continue
+		}
+		converted, ok := value.(string)
+		if !ok {
+			logger.WithPipelineId(key).Errorf("couldn't convert value to string: %s", value)
+			return ProcessingState{}, errors.InternalError(errorTitle, fmt.Sprintf("Value from cache couldn't be converted to string: %s", value))
+		}
+		*stringValue.value = converted
+	}
+	value, found, err := getStateValue(ctx, cacheService, key, cache.ExitCode, errorTitle)
+	if err != nil {
+		return ProcessingState{}, err
+	}
+	if found {
+		if state.ExitCode, state.HasExitCode = value.(int); !state.HasExitCode {
+			logger.WithPipelineId(key).Errorf("couldn't convert value to int: %s", value)
+			return ProcessingState{}, errors.InternalError(errorTitle, fmt.Sprintf("Value from cache couldn't be converted to int: %s", value))
+		}
+	}
+	return state, nil
+}
+
+// getStateValue gets the value of the state of code processing from cache by key and subKey.
+// Returns false if the value isn't saved into cache yet.
+// In case the value couldn't be read from cache for another reason - returns an errors.InternalError.
+func getStateValue(ctx context.Context, cacheService cache.Cache, key uuid.UUID, subKey cache.SubKey, errorTitle string) (interface{}, bool, error) {
+	value, err := cacheService.GetValue(ctx, key, subKey)
+	if err == nil {
+		return value, true, nil
+	}
+	if stderrors.Is(err, errors.ErrCacheMiss) {
+		return nil, false, nil
+	}
+	logger.WithPipelineId(key).Errorf("GetProcessingState(): cache.GetValue: error: %s", err.Error())
+	return nil, false, errors.WrappedInternalError(errorTitle, fmt.Sprintf("Error during getting cache by key: %s, subKey: %s", key.String(), string(subKey)), err)
+}
+
+// IsFinished returns true if the status is final, so the processing of the code is stopped and the status is not changed anymore
+func IsFinished(status pb.Status) bool {
+	switch status {
+	case pb.Status_STATUS_UNSPECIFIED, pb.Status_STATUS_VALIDATING, pb.Status_STATUS_PREPARING, pb.Status_STATUS_COMPILING, pb.Status_STATUS_EXECUTING, pb.Status_STATUS_QUEUED:
+		return false
+	}
+	return true
+}
+
+// GetLastIndex gets last index for run output or logs from cache by key.
+// In case key doesn't exist in cache - returns an errors.NotFoundError.
+// In case value from cache by key and subKey couldn't be converted to int - returns an errors.InternalError.
+func GetLastIndex(ctx context.Context, cacheService cache.Cache, key uuid.UUID, subKey cache.SubKey, errorTitle string) (int, error) {
+	value, err := cacheService.GetValue(ctx, key, subKey)
+	if err != nil {
+		logger.WithPipelineId(key).Errorf("GetLastIndex(): cache.GetValue: error: %s", err.Error())
+		return 0, errors.NotFoundError(errorTitle, fmt.Sprintf("Error during getting cache by key: %s, subKey: %s", key.String(), string(subKey)))
+	}
+	intValue, converted := value.(int)
+	if !converted {
+		logger.WithPipelineId(key).Errorf("couldn't convert value to int: %s", value)
+		return 0, errors.InternalError(errorTitle, fmt.Sprintf("Value from cache couldn't be converted to int: %s", value))
+	}
+	return intValue, nil
+}
+
+// GetDuration gets the duration in milliseconds from cache by key and subKey (cache.CompileDuration, cache.RunDuration or cache.ExecuteTimeout).
+// In case key doesn't exist in cache, e.g. the step isn't completed yet - returns an errors.NotFoundError.
+// In case value from cache by key and subKey couldn't be converted to int - returns an errors.InternalError.
+func GetDuration(ctx context.Context, cacheService cache.Cache, key uuid.UUID, subKey cache.SubKey, errorTitle string) (int, error) {
+	value, err := cacheService.GetValue(ctx, key, subKey)
+	if err != nil {
+		return 0, errors.NotFoundError(errorTitle, fmt.Sprintf("Error during getting cache by key: %s, subKey: %s", key.String(), string(subKey)))
+	}
+	intValue, converted := value.(int)
+	if !converted {
+		logger.WithPipelineId(key).Errorf("couldn't convert value to int: %s", value)
+		return 0, errors.InternalError(errorTitle, fmt.Sprintf("Value from cache couldn't be converted to int: %s", value))
+	}
+	return intValue, nil
+}
+
+// GetMetrics gets element count metric points from cache by key.
+// In case key doesn't exist in cache - returns an errors.NotFoundError.
+// In case value from cache by key couldn't be converted to []cache.MetricPoint - returns an errors.InternalError.
+func GetMetrics(ctx context.Context, cacheService cache.Cache, key uuid.UUID, errorTitle string) ([]cache.MetricPoint, error) {
+	value, err := cacheService.GetValue(ctx, key, cache.Metrics)
+	if err != nil {
+		logger.WithPipelineId(key).Errorf("GetMetrics(): cache.GetValue: error: %s", err.Error())
+		return nil, errors.NotFoundError(errorTitle, fmt.Sprintf("Error during getting cache by key: %s, subKey: %s", key.String(), string(cache.Metrics)))
+	}
+	points, converted := value.([]cache.MetricPoint)
+	if !converted {
+		logger.WithPipelineId(key).Errorf("couldn't convert value to metric points: %s", value)
+		return nil, errors.InternalError(errorTitle, fmt.Sprintf("Value from cache couldn't be converted to metric points: %s", value))
+	}
+	return points, nil
+}
+
+// GetIntermediateSamples gets sample elements of PCollections from cache by key.
+// In case key doesn't exist in cache, e.g. the pipeline isn't instrumented - returns an errors.NotFoundError.
+// In case value from cache by key couldn't be converted to []cache.PCollectionSamples - returns an errors.InternalError.
+func GetIntermediateSamples(ctx context.Context, cacheService cache.Cache, key uuid.UUID, errorTitle string) ([]cache.PCollectionSamples, error) {
+	value, err := cacheService.GetValue(ctx, key, cache.IntermediateSamples)
+	if err != nil {
+		logger.WithPipelineId(key).Errorf("GetIntermediateSamples(): cache.GetValue: error: %s", err.Error())
+		return nil, errors.NotFoundError(errorTitle, fmt.Sprintf("Error during getting cache by key: %s, subKey: %s", key.String(), string(cache.IntermediateSamples)))
+	}
+	samples, converted := value.([]cache.PCollectionSamples)
+	if !converted {
+		logger.WithPipelineId(key).Errorf("couldn't convert value to intermediate samples: %s", value)
+		return nil, errors.InternalError(errorTitle, fmt.Sprintf("Value from cache couldn't be converted to intermediate samples: %s", value))
+	}
+	return samples, nil
+}
+
+// setCpuTimeLimit wraps the command into the shell which sets the limit of CPU time (RLIMIT_CPU) before executing it.
+// The command receives SIGXCPU once it exceeds the limit and SIGKILL a second later if it survives SIGXCPU.
+// In case limit isn't positive or the shell isn't found the command isn't limited.
+func setCpuTimeLimit(cmd *exec.Cmd, limit time.Duration) {
+	if limit <= 0 {
+		return
+	}
+	shellPath, err := exec.LookPath("sh")
+	if err != nil {
+		logger.Warnf("couldn't find the shell to limit CPU time of the command: %s\n", err.Error())
+		return
+	}
+	seconds := int(math.Ceil(limit.Seconds()))
+	script := fmt.Sprintf(`ulimit -S -t %d && ulimit -H -t %d && exec "$0" "$@"`, seconds, seconds+1)
+	cmd.Args = append([]string{"sh", "-c", script, cmd.Path}, cmd.Args[1:]...)
+	cmd.Path = shellPath
+}
+
+// isCpuTimeLimitExceeded returns true if the command of the step is killed by SIGXCPU because it has exceeded the limit of CPU time
+func isCpuTimeLimitExceeded(err error) bool {
+	exitErr, ok := err.(*exec.ExitError)
+	if !ok {
+		return false
+	}
+	status, ok := exitErr.Sys().(syscall.WaitStatus)
+	return ok && status.Signaled() && status.Signal() == syscall.SIGXCPU
+}
+
+// setProcessGroup sets the command to be started in its own process group, so its child processes could be signaled together with it
+func setProcessGroup(cmd *exec.Cmd) {
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.Setpgid = true
+}
+
+// stopOnOutputLimit stops the process group of the run step once its output exceeds the limit of size,
+// i.e. limitChannel receives a value. stopChannel receives a value before the run step is stopped.
+// In case ctx is done before the output exceeds the limit the run step isn't stopped.
+func stopOnOutputLimit(ctx context.Context, pipelineId uuid.UUID, cmd *exec.Cmd, limitChannel, stopChannel chan bool) {
+	select {
+	case <-ctx.Done():
+	case <-limitChannel:
+		stopChannel <- true
+		stopProcessGroup(pipelineId, cmd)
+	}
+}
+
+// stopOnDiskQuota samples the size of the folder of the pipeline by dirPath every interval during the run step
+// and saves its peak as cache.PeakDiskUsage into cache. Once the size exceeds quota bytes stopChannel receives a value
+// and the process group of the run step is stopped. Sampling is finished once doneChannel is closed or ctx is done.
+func stopOnDiskQuota(ctx context.Context, pipelineId uuid.UUID, cacheService cache.Cache, cmd *exec.Cmd, dirPath string, quota int64, interval time.Duration, doneChannel, stopChannel chan bool) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	var peak int64
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-doneChannel:
+			return
+		case <-ticker.C:
+			size, err := utils.GetDirSize(dirPath)
+			if err != nil {
+				logger.WithPipelineId(pipelineId).WithContext(ctx).Warnf("couldn't get disk usage of the run step: %s\n", err.Error())
+				continue
+			}
+			if size > peak {
+				peak = size
+				cacheService.SetValue(ctx, pipelineId, cache.PeakDiskUsage, int(peak))
+			}
+			if size > quota {
+				stopChannel <- true
+				stopProcessGroup(pipelineId, cmd)
+				return
+			}
+		}
+	}
+}
+
+// stopProcessGroup stops the process group of the started command which is set by setProcessGroup.
+// Sends SIGTERM to the group and waits for its processes to be stopped up to stopGracePeriod, then sends SIGKILL to the group.
+func stopProcessGroup(pipelineId uuid.UUID, cmd *exec.Cmd) {
+	if cmd.Process == nil {
+		return
+	}
+	pgid := cmd.Process.Pid
+	logger.WithPipelineId(pipelineId).Infof("sending SIGTERM to the process group %d\n", pgid)
+	if err := syscall.Kill(-pgid, syscall.SIGTERM); err != nil {
+		// the group is already stopped
+		return
+	}
+	ticker := time.NewTicker(50 * time.Millisecond)
+	defer ticker.Stop()
+	deadline := time.After(stopGracePeriod)
+	for {
+		select {
+		case <-ticker.C:
+			if err := syscall.Kill(-pgid, 0); err != nil {
+				return
+			}
+		case <-deadline:
+			logger.WithPipelineId(pipelineId).Warnf("process group %d isn't stopped during %s, sending SIGKILL\n", pgid, stopGracePeriod)
+			_ = syscall.Kill(-pgid, syscall.SIGKILL)
+			return
+		}
+	}
+}
+
+// setLocale sets LANG and LC_ALL of the command to the locale keeping the rest of the environment.
+// In case locale is empty the command uses the locale of the host.
+func setLocale(cmd *exec.Cmd, locale string) {
+	if locale == "" {
+		return
+	}
+	env := cmd.Env
+	if env == nil {
+		env = os.Environ()
+	}
+	cmd.Env = append(env, "LANG="+locale, "LC_ALL="+locale)
+}
+
+// setWasmTarget sets the environment of the compile command to build WebAssembly for running it in the browser
+func setWasmTarget(cmd *exec.Cmd) {
+	env := cmd.Env
+	if env == nil {
+		env = os.Environ()
+	}
+	cmd.Env = append(env, "GOOS=js", "GOARCH=wasm")
+}
+
+// setRunEnv replaces the environment of the command with variables of the server allowed by allowlist and variables requested by the client,
+// so the code doesn't see other variables of the server, e.g. credentials. Variables requested by the client override allowed ones.
+func setRunEnv(cmd *exec.Cmd, allowlist []string, env map[string]string) {
+	cmd.Env = make([]string, 0, len(allowlist)+len(env))
+	for _, name := range allowlist {
+		if value, ok := os.LookupEnv(name); ok {
+			cmd.Env = append(cmd.Env, name+"="+value)
+		}
+	}
+	cmd.Env = append(cmd.Env, runEnvVariables(env)...)
+}
+
+// runEnvVariables returns environment variables requested by the client in the form name=value in a deterministic order
+func runEnvVariables(env map[string]string) []string {
+	variables := make([]string, 0, len(env))
+	for name, value := range env {
+		variables = append(variables, name+"="+value)
+	}
+	sort.Strings(variables)
+	return variables
+}
+
+// setTraceId sets TraceIdEnv of the command to the trace id carried by ctx keeping the rest of the environment,
+// so logs of the code could be joined with logs of the backend. In case ctx doesn't carry the trace id the environment isn't changed.
+func setTraceId(ctx context.Context, cmd *exec.Cmd) {
+	traceId := logger.TraceIdFromContext(ctx)
+	if traceId == "" {
+		return
+	}
+	env := cmd.Env
+	if env == nil {
+		env = os.Environ()
+	}
+	cmd.Env = append(env, TraceIdEnv+"="+traceId)
+}
+
+// setSampleDataset sets SampleDatasetEnv to datasetPath in the environment of the started command.
+// In case datasetPath is empty the environment isn't changed.
+func setSampleDataset(cmd *exec.Cmd, datasetPath string) {
+	if datasetPath == "" {
+		return
+	}
+	env := cmd.Env
+	if env == nil {
+		env = os.Environ()
+	}
+	cmd.Env = append(env, SampleDatasetEnv+"="+datasetPath)
+}
+
+// addPythonPath adds dir to the beginning of PYTHONPATH of the command keeping the rest of the environment,
+// so modules from dir are imported by Python, e.g. the sampling hook imported as the sitecustomize module at startup.
+func addPythonPath(cmd *exec.Cmd, dir string) {
+	env := cmd.Env
+	if env == nil {
+		env = os.Environ()
+	}
+	pythonPath := dir
+	cmd.Env = make([]string, 0, len(env)+1)
+	for _, variable := range env {
+		if value := strings.TrimPrefix(variable, pythonPathEnv+"="); value != variable {
+			if value != "" {
+				pythonPath += string(os.PathListSeparator) + value
+			}
+			continue
+		}
+		cmd.Env = append(cmd.Env, variable)
+	}
+	cmd.Env = append(cmd.Env, pythonPathEnv+"="+pythonPath)
+}
+
+// runStdin returns chunks which are fed to the stdin of the run step: the scripted stdin of lc
+// or, in case it isn't set, the data saved to the cache as cache.StdInput fed at once.
+// Returns nil if there is no stdin for the run step.
+func runStdin(ctx context.Context, cacheService cache.Cache, pipelineId uuid.UUID, lc *fs_tool.LifeCycle) []streaming.StdinChunk {
+	if stdin := lc.GetStdin(); len(stdin) > 0 {
+		return stdin
+	}
+	value, err := cacheService.GetValue(ctx, pipelineId, cache.StdInput)
+	if err != nil {
+		return nil
+	}
+	data, ok := value.(string)
+	if !ok || data == "" {
+		return nil
+	}
+	return []streaming.StdinChunk{{Data: []byte(data)}}
+}
+
+// runOutputFormat returns the format of the run output saved to the cache as cache.OutputFormat.
+// Returns an empty string if the run output isn't parsed.
+func runOutputFormat(ctx context.Context, cacheService cache.Cache, pipelineId uuid.UUID) string {
+	value, err := cacheService.GetValue(ctx, pipelineId, cache.OutputFormat)
+	if err != nil {
+		return ""
 	}
-	return stringValue, nil
+	format, _ := value.(string)
+	return format
 }
 
-// GetProcessingStatus gets processing status from cache by key.
-// In case key doesn't exist in cache - returns an errors.NotFoundError.
-// In case value from cache by key and subKey couldn't be converted to playground.Status - returns an errors.InternalError.
-func GetProcessingStatus(ctx context.Context, cacheService cache.Cache, key uuid.UUID, errorTitle string) (pb.Status, error) {
-	value, err := cacheService.GetValue(ctx, key, cache.Status)
+// runLogFilter returns the pattern of log lines which are dropped from the run output saved to the cache as cache.LogLevelFilter.
+// Returns nil if the run output isn't filtered or the pattern couldn't be compiled.
+func runLogFilter(ctx context.Context, cacheService cache.Cache, pipelineId uuid.UUID) *regexp.Regexp {
+	value, err := cacheService.GetValue(ctx, pipelineId, cache.LogLevelFilter)
 	if err != nil {
-		logger.Errorf("%s: GetStringValueFromCache(): cache.GetValue: error: %s", key, err.Error())
-		return pb.Status_STATUS_UNSPECIFIED, errors.NotFoundError(errorTitle, fmt.Sprintf("Error during getting cache by key: %s, subKey: %s", key.String(), string(cache.Status)))
+		return nil
 	}
-	statusValue, converted := value.(pb.Status)
-	if !converted {
-		logger.Errorf("%s: couldn't convert value to correct status enum: %s", key, value)
-		return pb.Status_STATUS_UNSPECIFIED, errors.InternalError(errorTitle, fmt.Sprintf("Value from cache couldn't be converted to correct status enum: %s", value))
+	pattern, _ := value.(string)
+	if pattern == "" {
+		return nil
 	}
-	return statusValue, nil
+	filter, err := regexp.Compile(pattern)
+	if err != nil {
+		logger.WithPipelineId(pipelineId).WithContext(ctx).Warnf("runLogFilter(): incorrect log level filter: %s\n", err.Error())
+		return nil
+	}
+	return filter
 }
 
-// GetLastIndex gets last index for run output or logs from cache by key.
-// In case key doesn't exist in cache - returns an errors.NotFoundError.
-// In case value from cache by key and subKey couldn't be converted to int - returns an errors.InternalError.
-func GetLastIndex(ctx context.Context, cacheService cache.Cache, key uuid.UUID, subKey cache.SubKey, errorTitle string) (int, error) {
-	value, err := cacheService.GetValue(ctx, key, subKey)
+// feedStdin starts feeding chunks to the stdin of cmd in background until ctx is done.
+// It should be called before cmd is started.
+func feedStdin(ctx context.Context, pipelineId uuid.UUID, cmd *exec.Cmd, chunks []streaming.StdinChunk) {
+	stdin, err := cmd.StdinPipe()
 	if err != nil {
-		logger.Errorf("%s: GetLastIndex(): cache.GetValue: error: %s", key, err.Error())
-		return 0, errors.NotFoundError(errorTitle, fmt.Sprintf("Error during getting cache by key: %s, subKey: %s", key.String(), string(subKey)))
-	}
-	intValue, converted := value.(int)
-	if !converted {
-		logger.Errorf("%s: couldn't convert value to int: %s", key, value)
-		return 0, errors.InternalError(errorTitle, fmt.Sprintf("Value from cache couldn't be converted to int: %s", value))
+		logger.WithPipelineId(pipelineId).WithContext(ctx).Errorf("feedStdin(): error during getting stdin: %s\n", err.Error())
+		return
 	}
-	return intValue, nil
+	go streaming.FeedStdin(ctx, stdin, chunks)
+}
+
+// flusher is a writer which keeps a part of the output until it is flushed
+type flusher interface {
+	Flush() error
 }
 
-// runCmdWithOutput runs command with keeping stdOut and stdErr
-func runCmdWithOutput(cmd *exec.Cmd, stdOutput io.Writer, stdError *bytes.Buffer, successChannel chan bool, errorChannel chan error) {
+// runCmdWithOutput runs command with keeping stdOut and stdErr.
+// The command is started before returning, so its process could be inspected by the caller.
+// The command is started in its own process group, so it could be stopped with its child processes by stopProcessGroup.
+// flushers are flushed once the command is finished and before its result is sent to channels.
+func runCmdWithOutput(cmd *exec.Cmd, stdOutput io.Writer, stdError io.Writer, successChannel chan bool, errorChannel chan error, flushers ...flusher) {
 	cmd.Stdout = stdOutput
 	cmd.Stderr = stdError
+	setProcessGroup(cmd)
+	if err := cmd.Start(); err != nil {
+		errorChannel <- err
+		successChannel <- false
+		return
+	}
 	go func(cmd *exec.Cmd, successChannel chan bool, errChannel chan error) {
-		err := cmd.Run()
+		defer recoverStep(successChannel, errChannel)
+		err := cmd.Wait()
+		for _, f := range flushers {
+			if flushErr := f.Flush(); flushErr != nil && err == nil {
+				err = flushErr
+			}
+		}
 		if err != nil {
 			errChannel <- err
 			successChannel <- false
@@ -196,134 +1901,847 @@ func runCmdWithOutput(cmd *exec.Cmd, stdOutput io.Writer, stdError *bytes.Buffer
 	}(cmd, successChannel, errorChannel)
 }
 
-// processStep processes each executor's step with cancel and timeout checks.
-// If finishes by canceling, timeout or error - returns error.
+// runStepFunc runs the function of the step which sends its result to successChannel and errorChannel.
+// In case the function panics the panic is sent as the error of the step, so the server isn't crashed.
+func runStepFunc(stepFunc func(chan bool, chan error), successChannel chan bool, errorChannel chan error) {
+	defer recoverStep(successChannel, errorChannel)
+	stepFunc(successChannel, errorChannel)
+}
+
+// recoverStep recovers the panic of the goroutine of the step and sends it with the stack trace as errors.ErrPanic
+// to errorChannel and false to successChannel. It should be deferred by the goroutine.
+// Results aren't sent in case channels are full, i.e. the step has already sent its result before the panic.
+func recoverStep(successChannel chan bool, errorChannel chan error) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	select {
+	case errorChannel <- errors.PanicError(fmt.Errorf("%v\n%s", r, debug.Stack())):
+	default:
+	}
+	select {
+	case successChannel <- false:
+	default:
+	}
+}
+
+// stepFailureCause processes the failed command of the step in case its failure has a specific cause which is known only to the caller
+// of processStep, e.g. the run step is killed by the limit of memory, via saving the corresponding status into cache and returns error.
+// In case the failure has no specific cause returns nil, so processStep saves the error status of the step.
+type stepFailureCause func(ctx context.Context, errorData []byte) error
+
+// processStep processes each executor's step with cancel, stall and timeout checks.
+// cmd is the command of the step which is stopped with its child processes in case of canceling, it could be nil if the step doesn't run a command.
+// stallChannel could be nil if the step isn't checked for stalling.
+// If finishes by canceling, stalling, timeout or error - returns error.
+// If canceled - stops the command of the step and waits for its output to be flushed by waitCanceledStep before saving playground.Status_STATUS_CANCELED into cache.
+// If finishes by exceeding the limit of CPU time - saves playground.Status_STATUS_RESOURCE_LIMIT into cache and returns error.
+// If ctx passed to Process is done - stops the command of the step and saves playground.Status_STATUS_CANCELED into cache and returns error.
+// If finishes by a panic recovered by recoverStep - saves playground.Status_STATUS_ERROR into cache and returns error.
+// If finishes by error and failureCause isn't nil - calls it before saving any error status, in case it returns error,
+// e.g. the run step exceeded the limit of memory, the status is saved by failureCause and the error is returned instead.
+// If finishes by error matching one of transientErrorPatterns - returns errTransientStep without saving the error status into cache.
+// If stepTimeout is positive the step has its own timeout within ctx, in case it is exceeded saves the timeout status of the step into cache
+// and returns error. The command of the compile step is stopped at once and its partial output is saved as cache.CompileOutput
+// before the timeout status, commands of other steps are stopped once code processing is finished by the caller.
+// The timeout of the step is fixed, so it isn't extended with the timeout of code processing by extendTimeoutCheck.
+// If the compile step finishes successfully saves warnings found in its outputs as cache.CompileWarnings into cache.
 // If finishes successfully returns nil.
-func processStep(ctx context.Context, pipelineId uuid.UUID, cacheService cache.Cache, cancelChannel, successChannel chan bool, outDataBuffer, errorDataBuffer *bytes.Buffer, errorChannel chan error, errorCaseStatus, successCaseStatus pb.Status) error {
+func processStep(ctx context.Context, pipelineId uuid.UUID, cacheService cache.Cache, cmd *exec.Cmd, cancelChannel, stallChannel, successChannel chan bool, outDataBuffer, errorDataBuffer *bytes.Buffer, errorChannel chan error, errorCaseStatus, successCaseStatus pb.Status, transientErrorPatterns []*regexp.Regexp, stepTimeout time.Duration, failureCause stepFailureCause) error {
+	defer observeStepDuration(ctx, errorCaseStatus, time.Now())
+	stepCtx := ctx
+	if stepTimeout > 0 {
+		var cancelStepCtx context.CancelFunc
+		stepCtx, cancelStepCtx = context.WithTimeout(ctx, stepTimeout)
+		defer cancelStepCtx()
+	}
 	select {
-	case <-ctx.Done():
-		finishByTimeout(ctx, pipelineId, cacheService)
+	case <-stepCtx.Done():
+		if ctx.Err() == nil {
+			if errorCaseStatus == pb.Status_STATUS_COMPILE_ERROR && cmd != nil {
+				stopProcessGroup(pipelineId, cmd)
+				if compileOutput := waitPartialOutput(successChannel, errorChannel, outDataBuffer, errorDataBuffer); compileOutput != nil {
+					processTimedOutCompileOutput(ctx, pipelineId, cacheService, compileOutput)
+				}
+			}
+			processStepTimeout(ctx, cacheService, pipelineId, stepTimeoutStatuses[errorCaseStatus])
+			return fmt.Errorf("%s: step was finished by its timeout", pipelineId)
+		}
+		if isShutdown(ctx) {
+			if cmd != nil {
+				stopProcessGroup(pipelineId, cmd)
+			}
+			processCancel(shutdownContext(ctx), cacheService, pipelineId, pb.CancelReason_CANCEL_REASON_SHUTDOWN)
+			return fmt.Errorf("%s: code processing was stopped by shutdown", pipelineId)
+		}
+		var compileOutput []byte = nil
+		if errorCaseStatus == pb.Status_STATUS_COMPILE_ERROR {
+			compileOutput = waitPartialOutput(successChannel, errorChannel, outDataBuffer, errorDataBuffer)
+		}
+		finishByTimeout(ctx, pipelineId, cacheService, compileOutput)
 		return fmt.Errorf("%s: context was done", pipelineId)
 	case <-cancelChannel:
-		processCancel(ctx, cacheService, pipelineId)
+		if cmd != nil {
+			stopProcessGroup(pipelineId, cmd)
+			waitCanceledStep(ctx, pipelineId, successChannel, errorChannel)
+		}
+		processCancel(ctx, cacheService, pipelineId, cancelReason(ctx, cacheService, pipelineId))
 		return fmt.Errorf("%s: code processing was canceled", pipelineId)
+	case <-stallChannel:
+		processStall(ctx, cacheService, pipelineId)
+		return fmt.Errorf("%s: code processing was stalled", pipelineId)
 	case ok := <-successChannel:
 		var outData []byte = nil
 		if outDataBuffer != nil {
 			outData = outDataBuffer.Bytes()
 		}
+		var errorData []byte = nil
+		if errorDataBuffer != nil {
+			errorData = errorDataBuffer.Bytes()
+		}
+		if errorCaseStatus == pb.Status_STATUS_COMPILE_ERROR {
+			processBuildScanUrl(ctx, pipelineId, cacheService, outData, errorData)
+			processCompileDiagnostics(ctx, pipelineId, cacheService, outData, errorData)
+		}
 		if !ok {
 			err := <-errorChannel
-			var errorData []byte = nil
-			if errorDataBuffer != nil {
-				errorData = errorDataBuffer.Bytes()
+			if stderrors.Is(err, errors.ErrPanic) {
+				processPanic(ctx, cacheService, pipelineId, err)
+				return fmt.Errorf("%s: code processing panicked", pipelineId)
+			}
+			if cmd != nil {
+				processExitCode(ctx, pipelineId, cacheService, err)
+			}
+			if failureCause != nil {
+				if causeErr := failureCause(ctx, errorData); causeErr != nil {
+					return causeErr
+				}
+			}
+			if isTransientError(errorData, transientErrorPatterns) {
+				return errTransientStep
+			}
+			if isCpuTimeLimitExceeded(err) {
+				processResourceLimit(ctx, cacheService, pipelineId)
+				return fmt.Errorf("%s: code processing exceeded the limit of CPU time", pipelineId)
 			}
 			processError(ctx, err, errorData, pipelineId, cacheService, errorCaseStatus)
 			return fmt.Errorf("%s: code processing finishes with error: %s", pipelineId, err.Error())
 		}
+		if cmd != nil {
+			processExitCode(ctx, pipelineId, cacheService, nil)
+		}
+		if errorCaseStatus == pb.Status_STATUS_COMPILE_ERROR {
+			sdk, _ := ctx.Value(sdkKey{}).(pb.Sdk)
+			processCompileWarnings(ctx, sdk, pipelineId, cacheService, outData, errorData)
+		}
 		processSuccess(ctx, outData, pipelineId, cacheService, successCaseStatus)
 	}
 	return nil
 }
 
+// processExitCode saves the exit code of the finished command with the error err as cache.ExitCode into cache.
+// In case the command wasn't started, so it has no exit code, nothing is saved.
+func processExitCode(ctx context.Context, pipelineId uuid.UUID, cacheService cache.Cache, err error) {
+	if code, found := exitCode(err); found {
+		cacheService.SetValue(ctx, pipelineId, cache.ExitCode, code)
+	}
+}
+
+// exitCode returns the exit code of the finished command with the error err.
+// A command killed by a signal has the exit code 128+signal like in shells.
+// In case the command wasn't started returns false.
+func exitCode(err error) (int, bool) {
+	if err == nil {
+		return 0, true
+	}
+	exitErr, ok := err.(*exec.ExitError)
+	if !ok {
+		return 0, false
+	}
+	if status, ok := exitErr.Sys().(syscall.WaitStatus); ok && status.Signaled() {
+		return 128 + int(status.Signal()), true
+	}
+	return exitErr.ExitCode(), true
+}
+
+// waitPartialOutput waits until the command of the step is stopped because of the timeout
+// and returns everything it has written to outDataBuffer and errorDataBuffer so far.
+// In case the command isn't stopped during stoppedStepWaitingTime returns nil, buffers couldn't be read safely.
+func waitPartialOutput(successChannel chan bool, errorChannel chan error, outDataBuffer, errorDataBuffer *bytes.Buffer) []byte {
+	select {
+	case ok := <-successChannel:
+		if !ok {
+			<-errorChannel
+		}
+	case <-time.After(stoppedStepWaitingTime):
+		return nil
+	}
+	var output []byte
+	if outDataBuffer != nil {
+		output = append(output, outDataBuffer.Bytes()...)
+	}
+	if errorDataBuffer != nil {
+		output = append(output, errorDataBuffer.Bytes()...)
+	}
+	return output
+}
+
+// waitCanceledStep waits until the stopped command of the canceled step is finished by runCmdWithOutput,
+// so flushers of its output, e.g. streaming.RunOutputWriter, save the rest of the output into cache before folders of the pipeline are deleted.
+// Waits no longer than the grace period from ctx, in case it isn't set or ctx is done the step isn't waited for.
+func waitCanceledStep(ctx context.Context, pipelineId uuid.UUID, successChannel chan bool, errorChannel chan error) {
+	gracePeriod, _ := ctx.Value(cancelGracePeriodKey{}).(time.Duration)
+	if gracePeriod <= 0 {
+		return
+	}
+	timer := time.NewTimer(gracePeriod)
+	defer timer.Stop()
+	select {
+	case ok := <-successChannel:
+		if !ok {
+			<-errorChannel
+		}
+	case <-timer.C:
+		logger.WithPipelineId(pipelineId).WithContext(ctx).Warnf("output of the canceled step isn't flushed during %s\n", gracePeriod)
+	case <-ctx.Done():
+	}
+}
+
+// processBuildScanUrl finds the url of the build scan in the compilation output and saves it as cache.BuildScanUrl into cache.
+// If build scans aren't configured the output doesn't contain the url and nothing is saved.
+func processBuildScanUrl(ctx context.Context, pipelineId uuid.UUID, cacheService cache.Cache, outputs ...[]byte) {
+	for _, output := range outputs {
+		if match := buildScanUrlRegexp.FindSubmatch(output); match != nil {
+			logger.WithPipelineId(pipelineId).WithContext(ctx).Infof("build scan is published: %s\n", match[1])
+			cacheService.SetValue(ctx, pipelineId, cache.BuildScanUrl, string(match[1]))
+			return
+		}
+	}
+}
+
+// processCompileDiagnostics finds messages of the compiler in the compilation output
+// and saves them grouped by source files as cache.CompileDiagnostics into cache.
+// The output of the Go SDK is parsed by parseGoDiagnostics and saved as cache.ValidationDiagnostics instead,
+// the raw output is kept as cache.CompileOutput as is.
+func processCompileDiagnostics(ctx context.Context, pipelineId uuid.UUID, cacheService cache.Cache, outputs ...[]byte) {
+	if sdk, _ := ctx.Value(sdkKey{}).(pb.Sdk); sdk == pb.Sdk_SDK_GO {
+		cacheService.SetValue(ctx, pipelineId, cache.ValidationDiagnostics, parseGoDiagnostics(outputs...))
+		return
+	}
+	cacheService.SetValue(ctx, pipelineId, cache.CompileDiagnostics, parseDiagnostics(outputs...))
+}
+
+// parseDiagnostics finds messages of the compiler in the outputs and groups them by source files ordered by file names.
+// LifeCycle writes all source files into the same folder, so the name of the file relative to that folder is its base name.
+func parseDiagnostics(outputs ...[]byte) []cache.FileDiagnostics {
+	diagnosticsByFile := make(map[string][]cache.Diagnostic)
+	for _, output := range outputs {
+		for _, match := range diagnosticRegexp.FindAllSubmatch(output, -1) {
+			line, _ := strconv.Atoi(string(match[2]))
+			column, _ := strconv.Atoi(string(match[3]))
+			fileName := filepath.Base(string(match[1]))
+			message := strings.TrimSpace(string(match[4]))
+			diagnosticsByFile[fileName] = append(diagnosticsByFile[fileName], cache.Diagnostic{
+				Line:     line,
+				Column:   column,
+				Message:  message,
+				Severity: diagnosticSeverity(message),
+			})
+		}
+	}
+	return groupDiagnostics(diagnosticsByFile)
+}
+
+// parseGoDiagnostics finds messages of go build and go vet in the outputs and groups them by source files ordered by file names.
+// Indented lines following a message, e.g. "have" and "want" of type errors, are added to the message on separate lines.
+// Other lines, e.g. "# command-line-arguments" headers, are skipped.
+func parseGoDiagnostics(outputs ...[]byte) []cache.FileDiagnostics {
+	diagnosticsByFile := make(map[string][]cache.Diagnostic)
+	for _, output := range outputs {
+		lastFileName := ""
+		for _, outputLine := range strings.Split(string(output), "\n") {
+			outputLine = strings.TrimRight(outputLine, "\r")
+			if match := goDiagnosticRegexp.FindStringSubmatch(outputLine); match != nil {
+				line, _ := strconv.Atoi(match[2])
+				column, _ := strconv.Atoi(match[3])
+				lastFileName = filepath.Base(match[1])
+				message := strings.TrimSpace(match[4])
+				diagnosticsByFile[lastFileName] = append(diagnosticsByFile[lastFileName], cache.Diagnostic{
+					Line:     line,
+					Column:   column,
+					Message:  message,
+					Severity: diagnosticSeverity(message),
+				})
+				continue
+			}
+			continuation := strings.TrimSpace(outputLine)
+			if lastFileName != "" && continuation != "" && (strings.HasPrefix(outputLine, "\t") || strings.HasPrefix(outputLine, " ")) {
+				diagnostics := diagnosticsByFile[lastFileName]
+				diagnostics[len(diagnostics)-1].Message += "\n" + continuation
+				continue
+			}
+			lastFileName = ""
+		}
+	}
+	return groupDiagnostics(diagnosticsByFile)
+}
+
+// groupDiagnostics returns messages of the compiler grouped by source files ordered by file names
+func groupDiagnostics(diagnosticsByFile map[string][]cache.Diagnostic) []cache.FileDiagnostics {
+	fileDiagnostics := make([]cache.FileDiagnostics, 0, len(diagnosticsByFile))
+	for fileName, diagnostics := range diagnosticsByFile {
+		fileDiagnostics = append(fileDiagnostics, cache.FileDiagnostics{FileName: fileName, Diagnostics: diagnostics})
+	}
+	sort.Slice(fileDiagnostics, func(i, j int) bool {
+		return fileDiagnostics[i].FileName < fileDiagnostics[j].FileName
+	})
+	return fileDiagnostics
+}
+
+// diagnosticSeverity returns cache.DiagnosticSeverityWarning if the message of the compiler is a warning, otherwise cache.DiagnosticSeverityError
+func diagnosticSeverity(message string) string {
+	if strings.HasPrefix(strings.ToLower(message), cache.DiagnosticSeverityWarning) {
+		return cache.DiagnosticSeverityWarning
+	}
+	return cache.DiagnosticSeverityError
+}
+
+// processValidationDiagnostics finds errors with positions in the error of the validation step
+// and saves them grouped by source files as cache.ValidationDiagnostics into cache.
+func processValidationDiagnostics(ctx context.Context, pipelineId uuid.UUID, cacheService cache.Cache, err error) {
+	cacheService.SetValue(ctx, pipelineId, cache.ValidationDiagnostics, parseDiagnostics([]byte(err.Error())))
+}
+
+// processCompileWarnings saves warnings found in the outputs of the successful compile step as cache.CompileWarnings into cache.
+// The raw outputs are kept as cache.CompileOutput as is.
+func processCompileWarnings(ctx context.Context, sdk pb.Sdk, pipelineId uuid.UUID, cacheService cache.Cache, outputs ...[]byte) {
+	cacheService.SetValue(ctx, pipelineId, cache.CompileWarnings, parseCompileWarnings(sdk, outputs...))
+}
+
+// parseCompileWarnings finds warnings of the compiler in the outputs using patterns of the sdk.
+// In case the sdk has no patterns returns an empty slice.
+func parseCompileWarnings(sdk pb.Sdk, outputs ...[]byte) []cache.CompileWarning {
+	warnings := make([]cache.CompileWarning, 0)
+	for _, output := range outputs {
+		for _, pattern := range compileWarningPatterns[sdk] {
+			for _, match := range pattern.regexp.FindAllSubmatch(output, -1) {
+				warning := cache.CompileWarning{
+					FileName: filepath.Base(string(match[pattern.regexp.SubexpIndex("file")])),
+					Category: pattern.category,
+					Message:  strings.TrimSpace(string(match[pattern.regexp.SubexpIndex("message")])),
+				}
+				if index := pattern.regexp.SubexpIndex("line"); index >= 0 {
+					warning.Line, _ = strconv.Atoi(string(match[index]))
+				}
+				if index := pattern.regexp.SubexpIndex("lint"); index >= 0 && len(match[index]) > 0 {
+					warning.Category = string(match[index])
+					if category, found := lintCategories[warning.Category]; found {
+						warning.Category = category
+					}
+				}
+				warnings = append(warnings, warning)
+			}
+		}
+	}
+	return warnings
+}
+
+// stallCheck checks if the process of the run step is stalled, i.e. it doesn't write any output and doesn't use CPU during the window.
+// Such process is likely deadlocked, while a process spinning in a busy loop uses CPU and isn't considered as stalled.
+// If context is done or CPU usage of the process couldn't be got (e.g. it is finished) return.
+// If the process is stalled set true to stallChannel and return.
+func stallCheck(ctx context.Context, pipelineId uuid.UUID, pid int, activityWriter *streaming.ActivityWriter, window time.Duration, stallChannel chan bool) {
+	prevCpuTime, err := utils.GetProcessCpuTime(pid)
+	if err != nil {
+		logger.WithPipelineId(pipelineId).WithContext(ctx).Warnf("couldn't get CPU usage of the run step: %s\n", err.Error())
+		return
+	}
+	ticker := time.NewTicker(window)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			cpuTime, err := utils.GetProcessCpuTime(pid)
+			if err != nil {
+				return
+			}
+			cpuIdle := float64(cpuTime-prevCpuTime) < float64(window)*stallCpuUsageThreshold
+			prevCpuTime = cpuTime
+			if cpuIdle && activityWriter.IdleTime(time.Now()) >= window {
+				stallChannel <- true
+				return
+			}
+		}
+	}
+}
+
 // cancelCheck checks cancel flag for code processing.
 // If cancel flag doesn't exist in cache continue working.
 // If context is done it means that code processing was finished (successfully/with error/timeout). Return.
+// If cancel flag exists, and it is false continue working.
 // If cancel flag exists, and it is true it means that code processing was canceled. Set true to cancelChannel and return.
-func cancelCheck(ctx context.Context, pipelineId uuid.UUID, cancelChannel chan bool, cacheService cache.Cache) {
-	ticker := time.NewTicker(500 * time.Millisecond)
+// The flag is checked every interval, in case interval isn't positive every defaultCancelCheckInterval.
+func cancelCheck(ctx context.Context, pipelineId uuid.UUID, cancelChannel chan bool, interval time.Duration, cacheService cache.Cache) {
+	if interval <= 0 {
+		interval = defaultCancelCheckInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
 	for {
 		select {
 		case <-ctx.Done():
-			ticker.Stop()
 			return
 		case <-ticker.C:
 			cancel, err := cacheService.GetValue(ctx, pipelineId, cache.Canceled)
 			if err != nil {
 				continue
 			}
-			if cancel.(bool) {
+			if canceled, ok := cancel.(bool); ok && canceled {
 				cancelChannel <- true
+				return
 			}
+		}
+	}
+}
+
+// extendTimeoutCheck checks extend timeout flag for code processing.
+// If extend timeout flag doesn't exist in cache continue working.
+// If context is done it means that code processing was finished (successfully/with error/timeout). Return.
+// If extend timeout flag exists, and it is true extends the deadline of code processing by increment and resets the flag.
+func extendTimeoutCheck(ctx context.Context, pipelineId uuid.UUID, timeout *extendableTimeout, increment time.Duration, cacheService cache.Cache) {
+	ticker := time.NewTicker(500 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
 			return
+		case <-ticker.C:
+			extend, err := cacheService.GetValue(ctx, pipelineId, cache.ExtendTimeout)
+			if err != nil {
+				continue
+			}
+			if requested, ok := extend.(bool); !ok || !requested {
+				continue
+			}
+			if extended := timeout.extend(increment); extended > 0 {
+				logger.WithPipelineId(pipelineId).WithContext(ctx).Infof("timeout was extended by %s\n", extended)
+			} else {
+				logger.WithPipelineId(pipelineId).WithContext(ctx).Infof("timeout couldn't be extended\n")
+			}
+			cacheService.SetValue(ctx, pipelineId, cache.ExtendTimeout, false)
 		}
 	}
 }
 
-// DeleteFolders removes all prepared folders for received LifeCycle
-func DeleteFolders(pipelineId uuid.UUID, lc *fs_tool.LifeCycle) {
-	logger.Infof("%s: DeleteFolders() ...\n", pipelineId)
-	if err := lc.DeleteFolders(); err != nil {
-		logger.Error("%s: DeleteFolders(): %s\n", pipelineId, err.Error())
+// extendableTimeout keeps the deadline of code processing which could be pushed forward up to maxExtension in total
+type extendableTimeout struct {
+	mu           sync.Mutex
+	timer        *time.Timer
+	deadline     time.Time
+	extended     time.Duration
+	maxExtension time.Duration
+}
+
+// withExtendableTimeout returns a copy of the parent context which is canceled when the timeout expires.
+// Unlike context.WithTimeout the deadline of the returned context could be extended via extendableTimeout.
+func withExtendableTimeout(parent context.Context, timeout, maxExtension time.Duration) (context.Context, context.CancelFunc, *extendableTimeout) {
+	ctx, cancel := context.WithCancel(parent)
+	et := &extendableTimeout{deadline: time.Now().Add(timeout), maxExtension: maxExtension}
+	if timeout <= 0 {
+		cancel()
+		return ctx, cancel, et
+	}
+	et.timer = time.AfterFunc(timeout, cancel)
+	return ctx, func() {
+		et.mu.Lock()
+		et.timer.Stop()
+		et.mu.Unlock()
+		cancel()
+	}, et
+}
+
+// extend pushes the deadline forward by increment limited by the rest of the max extension.
+// Returns the duration which has been added to the deadline, zero if the deadline couldn't be extended.
+func (et *extendableTimeout) extend(increment time.Duration) time.Duration {
+	et.mu.Lock()
+	defer et.mu.Unlock()
+	if rest := et.maxExtension - et.extended; increment > rest {
+		increment = rest
+	}
+	if increment <= 0 || et.timer == nil || !et.timer.Stop() {
+		return 0
+	}
+	et.extended += increment
+	et.deadline = et.deadline.Add(increment)
+	et.timer.Reset(time.Until(et.deadline))
+	return increment
+}
+
+// DeleteFolders removes all prepared folders for received LifeCycle.
+// In case files are still held by a killed process retries it according to appEnv.
+// In case folders couldn't be removed returns the error, so the caller could record it.
+func DeleteFolders(pipelineId uuid.UUID, lc *fs_tool.LifeCycle, appEnv *environment.ApplicationEnvs) error {
+	logger.WithPipelineId(pipelineId).Infof("DeleteFolders() ...\n")
+	if err := lc.DeleteFoldersWithRetries(appEnv.DeleteFoldersRetries(), appEnv.DeleteFoldersRetryDelay()); err != nil {
+		logger.WithPipelineId(pipelineId).Errorf("DeleteFolders(): %s\n", err.Error())
+		return err
+	}
+	logger.WithPipelineId(pipelineId).Infof("DeleteFolders() complete\n")
+	logger.WithPipelineId(pipelineId).Infof("complete\n")
+	return nil
+}
+
+// SetStatus saves status of the pipeline which is set outside of code processing, e.g. while it waits in the queue,
+// the same way as statuses of code processing: with its time in cache.Timestamps.
+func SetStatus(ctx context.Context, cacheService cache.Cache, pipelineId uuid.UUID, status pb.Status) {
+	setStatusWithTimestamp(ctx, pipelineId, cacheService, status)
+}
+
+// finishByTimeout is used in case of runCode method finished by timeout.
+// In case of the compile step is finished by timeout compileOutput contains its partial output, otherwise it is nil.
+func finishByTimeout(ctx context.Context, pipelineId uuid.UUID, cacheService cache.Cache, compileOutput []byte) {
+	logger.WithPipelineId(pipelineId).WithContext(ctx).Errorf("code processing finishes because of timeout\n")
+	status, ok := ctx.Value(timeoutStatusKey{}).(pb.Status)
+	if !ok {
+		status = pb.Status_STATUS_RUN_TIMEOUT
+	}
+	// ctx is done by its timeout, so the writes use the context detached from it
+	ctx, cancel := terminalContext(ctx)
+	defer cancel()
+
+	if compileOutput != nil {
+		processTimedOutCompileOutput(ctx, pipelineId, cacheService, compileOutput)
 	}
-	logger.Infof("%s: DeleteFolders() complete\n", pipelineId)
-	logger.Infof("%s: complete\n", pipelineId)
+
+	// set to cache pipelineId: cache.SubKey_Status: Status_STATUS_RUN_TIMEOUT or Status_STATUS_CLIENT_TIMEOUT
+	setStatusWithTimestamp(ctx, pipelineId, cacheService, status)
 }
 
-// finishByTimeout is used in case of runCode method finished by timeout
-func finishByTimeout(ctx context.Context, pipelineId uuid.UUID, cacheService cache.Cache) {
-	logger.Errorf("%s: code processing finishes because of timeout\n", pipelineId)
+// processTimedOutCompileOutput saves the partial output of the compile step which is finished by timeout as cache.CompileOutput into cache
+func processTimedOutCompileOutput(ctx context.Context, pipelineId uuid.UUID, cacheService cache.Cache, compileOutput []byte) {
+	cacheService.SetValue(ctx, pipelineId, cache.CompileOutput, "error: compilation is finished by timeout, output: "+string(compileOutput))
+}
 
-	// set to cache pipelineId: cache.SubKey_Status: Status_STATUS_RUN_TIMEOUT
-	cacheService.SetValue(ctx, pipelineId, cache.Status, pb.Status_STATUS_RUN_TIMEOUT)
+// statusProgress contains the percentage of completed steps (validate, prepare, compile, run) of code processing
+// when it reaches the status. The compile step is considered as completed for SDKs which code isn't compiled.
+var statusProgress = map[pb.Status]int{
+	pb.Status_STATUS_PREPARING:        25,
+	pb.Status_STATUS_COMPILING:        50,
+	pb.Status_STATUS_EXECUTING:        75,
+	pb.Status_STATUS_FINISHED:         100,
+	pb.Status_STATUS_COMPILE_FINISHED: 100,
 }
 
-// processError processes error received during processing code via setting a corresponding status and output to cache
+// processError processes error received during processing code via setting a corresponding status and output to cache.
+// The time of the status is saved as cache.Timestamps into cache.
 func processError(ctx context.Context, err error, data []byte, pipelineId uuid.UUID, cacheService cache.Cache, status pb.Status) {
+	ctx, cancel := terminalContext(ctx)
+	defer cancel()
 	switch status {
 	case pb.Status_STATUS_VALIDATION_ERROR:
-		logger.Errorf("%s: Validate: %s\n", pipelineId, err.Error())
+		logger.WithPipelineId(pipelineId).WithContext(ctx).WithPhase(phaseValidate).Errorf("Validate: %s\n", err.Error())
+
+		processValidationDiagnostics(ctx, pipelineId, cacheService, err)
 
-		cacheService.SetValue(ctx, pipelineId, cache.Status, pb.Status_STATUS_VALIDATION_ERROR)
+		setStatusWithTimestamp(ctx, pipelineId, cacheService, pb.Status_STATUS_VALIDATION_ERROR)
 	case pb.Status_STATUS_PREPARATION_ERROR:
-		logger.Errorf("%s: Prepare: %s\n", pipelineId, err.Error())
+		logger.WithPipelineId(pipelineId).WithContext(ctx).WithPhase(phasePrepare).Errorf("Prepare: %s\n", err.Error())
 
-		cacheService.SetValue(ctx, pipelineId, cache.Status, pb.Status_STATUS_PREPARATION_ERROR)
+		if stderrors.Is(err, utils.ErrDisallowedImports) || stderrors.Is(err, utils.ErrInvalidArchive) {
+			cacheService.SetValue(ctx, pipelineId, cache.RunError, err.Error())
+		}
+
+		setStatusWithTimestamp(ctx, pipelineId, cacheService, pb.Status_STATUS_PREPARATION_ERROR)
 	case pb.Status_STATUS_COMPILE_ERROR:
-		logger.Errorf("%s: Compile: err: %s, output: %s\n", pipelineId, err.Error(), data)
+		logger.WithPipelineId(pipelineId).WithContext(ctx).WithPhase(phaseCompile).Errorf("Compile: err: %s, output: %s\n", err.Error(), data)
 
 		cacheService.SetValue(ctx, pipelineId, cache.CompileOutput, "error: "+err.Error()+", output: "+string(data))
 
-		cacheService.SetValue(ctx, pipelineId, cache.Status, pb.Status_STATUS_COMPILE_ERROR)
+		setStatusWithTimestamp(ctx, pipelineId, cacheService, pb.Status_STATUS_COMPILE_ERROR)
 	case pb.Status_STATUS_RUN_ERROR:
-		logger.Errorf("%s: Run: err: %s, output: %s\n", pipelineId, err.Error(), data)
+		logger.WithPipelineId(pipelineId).WithContext(ctx).WithPhase(phaseRun).Errorf("Run: err: %s, output: %s\n", err.Error(), data)
+
+		cacheService.SetValue(ctx, pipelineId, cache.RunError, "error: "+err.Error()+", output: "+string(data))
+
+		setStatusWithTimestamp(ctx, pipelineId, cacheService, pb.Status_STATUS_RUN_ERROR)
+	case pb.Status_STATUS_TEST_FAILED:
+		logger.WithPipelineId(pipelineId).WithContext(ctx).WithPhase(phaseTest).Errorf("Test: err: %s, output: %s\n", err.Error(), data)
 
 		cacheService.SetValue(ctx, pipelineId, cache.RunError, "error: "+err.Error()+", output: "+string(data))
 
-		cacheService.SetValue(ctx, pipelineId, cache.Status, pb.Status_STATUS_RUN_ERROR)
+		setStatusWithTimestamp(ctx, pipelineId, cacheService, pb.Status_STATUS_TEST_FAILED)
+	}
+}
+
+// setStatusWithTimestamp saves the time when code processing reaches status to cache.Timestamps before saving status as cache.Status,
+// so the status is never observed without its timestamp
+func setStatusWithTimestamp(ctx context.Context, pipelineId uuid.UUID, cacheService cache.Cache, status pb.Status) {
+	timestamps := make(map[pb.Status]time.Time)
+	if value, err := cacheService.GetValue(ctx, pipelineId, cache.Timestamps); err == nil {
+		if saved, ok := value.(map[pb.Status]time.Time); ok {
+			for savedStatus, timestamp := range saved {
+				timestamps[savedStatus] = timestamp
+			}
+		}
+	}
+	timestamps[status] = time.Now()
+	cacheService.SetValue(ctx, pipelineId, cache.Timestamps, timestamps)
+	setStatus(ctx, pipelineId, cacheService, status)
+}
+
+// setStatus saves status as cache.Status into cache.
+// In case the write fails retries it according to the cacheWriteRetry policy from ctx doubling the delay after each retry
+// up to maxCacheWriteRetryDelay. Retries are stopped once ctx is done.
+// In case the status still isn't saved logs the error and tries to save playground.Status_STATUS_ERROR once,
+// so the client doesn't wait for the status which is never saved.
+// In case the status is final subscribers to the run output of the pipeline are notified, so streams of the output are ended.
+func setStatus(ctx context.Context, pipelineId uuid.UUID, cacheService cache.Cache, status pb.Status) {
+	if IsFinished(status) {
+		defer streaming.NotifyRunOutput(pipelineId)
+	}
+	policy, _ := ctx.Value(cacheWriteRetryKey{}).(cacheWriteRetry)
+	delay := policy.delay
+	err := cacheService.SetValue(ctx, pipelineId, cache.Status, status)
+	for retry := 0; err != nil && retry < policy.retries && ctx.Err() == nil; retry++ {
+		logger.WithPipelineId(pipelineId).WithContext(ctx).Warnf("setStatus(): retry %d of saving status %s into cache: %s\n", retry+1, status, err.Error())
+		if delay > maxCacheWriteRetryDelay {
+			delay = maxCacheWriteRetryDelay
+		}
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			// the loop is stopped by its condition
+			continue
+		}
+		delay *= 2
+		err = cacheService.SetValue(ctx, pipelineId, cache.Status, status)
+	}
+	if err == nil {
+		return
+	}
+	logger.WithPipelineId(pipelineId).WithContext(ctx).Errorf("setStatus(): status %s isn't saved into cache: %s\n", status, err.Error())
+	if status == pb.Status_STATUS_ERROR {
+		return
+	}
+	if err = cacheService.SetValue(ctx, pipelineId, cache.Status, pb.Status_STATUS_ERROR); err != nil {
+		logger.WithPipelineId(pipelineId).WithContext(ctx).Errorf("setStatus(): status %s isn't saved into cache: %s\n", pb.Status_STATUS_ERROR, err.Error())
 	}
 }
 
-// processSuccess processes case after successful code processing via setting a corresponding status and output to cache
+// processSuccess processes case after successful code processing via setting a corresponding status, output and progress to cache.
+// The time of the status is saved as cache.Timestamps into cache.
 func processSuccess(ctx context.Context, output []byte, pipelineId uuid.UUID, cacheService cache.Cache, status pb.Status) {
 	switch status {
 	case pb.Status_STATUS_PREPARING:
-		logger.Infof("%s: Validate() finish\n", pipelineId)
+		logger.WithPipelineId(pipelineId).WithContext(ctx).WithPhase(phaseValidate).Infof("Validate() finish\n")
 
-		cacheService.SetValue(ctx, pipelineId, cache.Status, pb.Status_STATUS_PREPARING)
+		setStatusWithTimestamp(ctx, pipelineId, cacheService, pb.Status_STATUS_PREPARING)
 	case pb.Status_STATUS_COMPILING:
-		logger.Infof("%s: Prepare() finish\n", pipelineId)
+		logger.WithPipelineId(pipelineId).WithContext(ctx).WithPhase(phasePrepare).Infof("Prepare() finish\n")
 
-		cacheService.SetValue(ctx, pipelineId, cache.Status, pb.Status_STATUS_COMPILING)
+		setStatusWithTimestamp(ctx, pipelineId, cacheService, pb.Status_STATUS_COMPILING)
 	case pb.Status_STATUS_EXECUTING:
-		logger.Infof("%s: Compile() finish\n", pipelineId)
+		logger.WithPipelineId(pipelineId).WithContext(ctx).WithPhase(phaseCompile).Infof("Compile() finish\n")
 
 		cacheService.SetValue(ctx, pipelineId, cache.CompileOutput, string(output))
 
 		cacheService.SetValue(ctx, pipelineId, cache.RunOutput, "")
 
-		cacheService.SetValue(ctx, pipelineId, cache.Status, pb.Status_STATUS_EXECUTING)
+		setStatusWithTimestamp(ctx, pipelineId, cacheService, pb.Status_STATUS_EXECUTING)
+	case pb.Status_STATUS_COMPILE_FINISHED:
+		logger.WithPipelineId(pipelineId).WithContext(ctx).WithPhase(phaseCompile).Infof("Compile() finish, the code isn't run\n")
+
+		cacheService.SetValue(ctx, pipelineId, cache.CompileOutput, string(output))
+
+		setStatusWithTimestamp(ctx, pipelineId, cacheService, pb.Status_STATUS_COMPILE_FINISHED)
 	case pb.Status_STATUS_FINISHED:
-		logger.Infof("%s: Run() finish\n", pipelineId)
+		logger.WithPipelineId(pipelineId).WithContext(ctx).WithPhase(phaseRun).Infof("Run() finish\n")
+
+		setStatusWithTimestamp(ctx, pipelineId, cacheService, pb.Status_STATUS_FINISHED)
+	}
+	if progress, found := statusProgress[status]; found {
+		cacheService.SetValue(ctx, pipelineId, cache.Progress, progress)
+	}
+}
+
+// isShutdown returns true if the context passed to Process is done, so code processing is stopped from outside rather than by its timeout
+func isShutdown(ctx context.Context) bool {
+	done, ok := ctx.Value(shutdownKey{}).(<-chan struct{})
+	if !ok || done == nil {
+		return false
+	}
+	select {
+	case <-done:
+		return true
+	default:
+		return false
+	}
+}
 
-		cacheService.SetValue(ctx, pipelineId, cache.Status, pb.Status_STATUS_FINISHED)
+// shutdownContext returns the context for writes into cache after code processing is stopped by shutdown.
+// The context passed to Process is already canceled then and caches like redis reject writes with it,
+// so like the shutdown of the server the writes use context.Background() which keeps only the SDK,
+// the retry policy of writes of statuses and the trace id from ctx.
+func shutdownContext(ctx context.Context) context.Context {
+	shutdownCtx := logger.ContextWithTraceId(context.Background(), logger.TraceIdFromContext(ctx))
+	if sdk, ok := ctx.Value(sdkKey{}).(pb.Sdk); ok {
+		shutdownCtx = context.WithValue(shutdownCtx, sdkKey{}, sdk)
+	}
+	if policy, ok := ctx.Value(cacheWriteRetryKey{}).(cacheWriteRetry); ok {
+		shutdownCtx = context.WithValue(shutdownCtx, cacheWriteRetryKey{}, policy)
 	}
+	return shutdownCtx
+}
+
+// terminalContext returns the context for writes of the terminal status (timeout, cancel, error) of code processing into cache.
+// ctx is often done by then, e.g. by the timeout of code processing, and caches like redis reject writes with it,
+// so the writes use the context detached from ctx by shutdownContext which is done only after terminalWriteTimeout.
+func terminalContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(shutdownContext(ctx), terminalWriteTimeout)
 }
 
 // processCancel process case when code processing was canceled
-func processCancel(ctx context.Context, cacheService cache.Cache, pipelineId uuid.UUID) {
-	logger.Infof("%s: was canceled\n", pipelineId)
+func processCancel(ctx context.Context, cacheService cache.Cache, pipelineId uuid.UUID, reason pb.CancelReason) {
+	ctx, cancel := terminalContext(ctx)
+	defer cancel()
+	logger.WithPipelineId(pipelineId).WithContext(ctx).Infof("was canceled, reason: %s\n", reason)
+
+	// set to cache pipelineId: cache.SubKey_CancelReason: who has canceled code processing
+	cacheService.SetValue(ctx, pipelineId, cache.CancelReason, reason)
 
 	// set to cache pipelineId: cache.SubKey_Status: pb.Status_STATUS_CANCELED
-	cacheService.SetValue(ctx, pipelineId, cache.Status, pb.Status_STATUS_CANCELED)
+	setStatusWithTimestamp(ctx, pipelineId, cacheService, pb.Status_STATUS_CANCELED)
+
+	// set to cache pipelineId: cache.SubKey_CancelAcknowledged: time of the cancellation, so the client knows that the cancellation took effect
+	cacheService.SetValue(ctx, pipelineId, cache.CancelAcknowledged, time.Now())
+}
+
+// clampExecuteTimeout returns the timeout of code processing requested by the client clamped between minTimeout and maxTimeout.
+// In case the requested timeout isn't positive returns an error.
+func clampExecuteTimeout(requested, minTimeout, maxTimeout time.Duration) (time.Duration, error) {
+	if requested <= 0 {
+		return 0, fmt.Errorf("execute timeout should be positive: %s", requested)
+	}
+	if requested < minTimeout {
+		return minTimeout, nil
+	}
+	if maxTimeout > 0 && requested > maxTimeout {
+		return maxTimeout, nil
+	}
+	return requested, nil
+}
+
+// cancelReason returns the reason saved into cache together with the cancel flag.
+// In case the reason isn't saved the pipeline is considered to be canceled by the user.
+func cancelReason(ctx context.Context, cacheService cache.Cache, pipelineId uuid.UUID) pb.CancelReason {
+	value, err := cacheService.GetValue(ctx, pipelineId, cache.CancelReason)
+	if err != nil {
+		return pb.CancelReason_CANCEL_REASON_USER
+	}
+	reason, converted := value.(pb.CancelReason)
+	if !converted || reason == pb.CancelReason_CANCEL_REASON_UNSPECIFIED {
+		return pb.CancelReason_CANCEL_REASON_USER
+	}
+	return reason
+}
+
+// observeStepDuration adds the duration of the step since startedAt to metrics of its phase by the SDK of the code
+func observeStepDuration(ctx context.Context, errorCaseStatus pb.Status, startedAt time.Time) {
+	sdk, _ := ctx.Value(sdkKey{}).(pb.Sdk)
+	metrics.Default.ObservePhaseDuration(sdk.String(), stepPhases[errorCaseStatus], time.Since(startedAt))
+}
+
+// countFinalStatus counts the pipeline by its final status from cache and the SDK of the code in metrics.
+// Canceled and timed out pipelines are counted by their own statuses.
+func countFinalStatus(ctx context.Context, pipelineId uuid.UUID, cacheService cache.Cache) {
+	value, err := cacheService.GetValue(ctx, pipelineId, cache.Status)
+	if err != nil {
+		return
+	}
+	status, converted := value.(pb.Status)
+	if !converted || !IsFinished(status) {
+		return
+	}
+	sdk, _ := ctx.Value(sdkKey{}).(pb.Sdk)
+	metrics.Default.IncStatus(sdk.String(), status.String())
+}
+
+// processStepTimeout processes the step which is finished by its own timeout via setting a corresponding status to cache
+func processStepTimeout(ctx context.Context, cacheService cache.Cache, pipelineId uuid.UUID, status pb.Status) {
+	ctx, cancel := terminalContext(ctx)
+	defer cancel()
+	logger.WithPipelineId(pipelineId).WithContext(ctx).Errorf("step is finished by its timeout: %s\n", status)
+
+	// set to cache pipelineId: cache.SubKey_Status: the timeout status of the step
+	setStatusWithTimestamp(ctx, pipelineId, cacheService, status)
+}
+
+// processResourceLimit processes the step which exceeded the limit of CPU time via setting a corresponding status and the hint to cache
+func processResourceLimit(ctx context.Context, cacheService cache.Cache, pipelineId uuid.UUID) {
+	ctx, cancel := terminalContext(ctx)
+	defer cancel()
+	logger.WithPipelineId(pipelineId).WithContext(ctx).WithPhase(phaseRun).Warnf("run step exceeded the limit of CPU time\n")
+
+	cacheService.SetValue(ctx, pipelineId, cache.RunError, cpuTimeLimitHint)
+	// set to cache pipelineId: cache.SubKey_Status: pb.Status_STATUS_RESOURCE_LIMIT
+	setStatusWithTimestamp(ctx, pipelineId, cacheService, pb.Status_STATUS_RESOURCE_LIMIT)
+}
+
+// processOom processes the run step which is killed because it exceeded the limit of memory via setting a corresponding status and the hint to cache
+func processOom(ctx context.Context, cacheService cache.Cache, pipelineId uuid.UUID) {
+	ctx, cancel := terminalContext(ctx)
+	defer cancel()
+	logger.WithPipelineId(pipelineId).WithContext(ctx).WithPhase(phaseRun).Warnf("run step exceeded the limit of memory\n")
+
+	cacheService.SetValue(ctx, pipelineId, cache.RunError, oomHint)
+	// set to cache pipelineId: cache.SubKey_Status: pb.Status_STATUS_RUN_OOM
+	setStatusWithTimestamp(ctx, pipelineId, cacheService, pb.Status_STATUS_RUN_OOM)
+}
+
+// processOutputLimit processes the run step which is stopped because its output exceeded the limit of size
+// via setting a corresponding status and the hint to cache
+func processOutputLimit(ctx context.Context, cacheService cache.Cache, pipelineId uuid.UUID) {
+	ctx, cancel := terminalContext(ctx)
+	defer cancel()
+	logger.WithPipelineId(pipelineId).WithContext(ctx).WithPhase(phaseRun).Warnf("run step exceeded the limit of output size\n")
+
+	cacheService.SetValue(ctx, pipelineId, cache.RunError, outputLimitHint)
+	// set to cache pipelineId: cache.SubKey_Status: pb.Status_STATUS_RUN_ERROR
+	setStatusWithTimestamp(ctx, pipelineId, cacheService, pb.Status_STATUS_RUN_ERROR)
+}
+
+// processDiskQuota processes the run step which is stopped because the folder of the pipeline exceeded the quota of size
+// via setting a corresponding status and the hint to cache
+func processDiskQuota(ctx context.Context, cacheService cache.Cache, pipelineId uuid.UUID) {
+	ctx, cancel := terminalContext(ctx)
+	defer cancel()
+	logger.WithPipelineId(pipelineId).WithContext(ctx).WithPhase(phaseRun).Warnf("run step exceeded the quota of disk usage\n")
+
+	cacheService.SetValue(ctx, pipelineId, cache.RunError, diskQuotaHint)
+	// set to cache pipelineId: cache.SubKey_Status: pb.Status_STATUS_RUN_DISK_QUOTA_EXCEEDED
+	setStatusWithTimestamp(ctx, pipelineId, cacheService, pb.Status_STATUS_RUN_DISK_QUOTA_EXCEEDED)
+}
+
+// processPanic processes the step which goroutine panicked via setting playground.Status_STATUS_ERROR to cache
+func processPanic(ctx context.Context, cacheService cache.Cache, pipelineId uuid.UUID, err error) {
+	ctx, cancel := terminalContext(ctx)
+	defer cancel()
+	logger.WithPipelineId(pipelineId).WithContext(ctx).Errorf("step panicked: %s\n", err.Error())
+
+	// set to cache pipelineId: cache.SubKey_Status: pb.Status_STATUS_ERROR
+	setStatusWithTimestamp(ctx, pipelineId, cacheService, pb.Status_STATUS_ERROR)
+}
+
+// processStall processes the stalled run step via setting a corresponding status and the hint to cache
+func processStall(ctx context.Context, cacheService cache.Cache, pipelineId uuid.UUID) {
+	ctx, cancel := terminalContext(ctx)
+	defer cancel()
+	logger.WithPipelineId(pipelineId).WithContext(ctx).WithPhase(phaseRun).Warnf("run step is stalled\n")
+
+	cacheService.SetValue(ctx, pipelineId, cache.RunError, stallHint)
+	// set to cache pipelineId: cache.SubKey_Status: pb.Status_STATUS_RUN_STALLED
+	setStatusWithTimestamp(ctx, pipelineId, cacheService, pb.Status_STATUS_RUN_STALLED)
 }
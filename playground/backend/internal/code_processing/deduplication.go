@@ -0,0 +1,183 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package code_processing
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"beam.apache.org/playground/backend/internal/cache"
+	"beam.apache.org/playground/backend/internal/fs_tool"
+	"beam.apache.org/playground/backend/internal/logger"
+	"beam.apache.org/playground/backend/internal/utils"
+	"context"
+	"crypto/sha256"
+	"github.com/google/uuid"
+	"hash"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// deduplicatedSubKeys are cache values of the finished pipeline which are reused for identical code.
+// They are all results written by Process, values of the request itself (options, indexes of the client, cancellation) aren't copied.
+// The status is copied separately after them, so clients don't see the final status before the output.
+var deduplicatedSubKeys = []cache.SubKey{
+	cache.CompileOutput, cache.RunOutput, cache.RunError, cache.FormattedOutput, cache.RawRunOutput, cache.RunRecords,
+	cache.Logs, cache.Metrics, cache.IntermediateSamples, cache.OutputFiles, cache.OutputTruncated,
+	cache.CompileDiagnostics, cache.ValidationDiagnostics, cache.CompileWarnings, cache.TestResults, cache.Coverage,
+	cache.BuildScanUrl, cache.Dependencies, cache.SdkVersion, cache.CompiledArtifact, cache.WasmArtifact,
+	cache.CompileInvocation, cache.RunInvocation, cache.CompileDuration, cache.RunDuration, cache.ExitCode,
+	cache.PeakDiskUsage, cache.Timestamps,
+}
+
+// deduplicationKey returns the key under which the result of code processing is shared with identical code.
+// The key is the SHA-256 hash of the SDK, the canonical form of the code, additional source files and options of lc which change the result.
+// Returns false if the result couldn't be shared: lc has files with tests or stdin, or the code couldn't be read.
+// The hash of submissions of the server (submissionHash) covers the same fields, so options added here should be added there too.
+func deduplicationKey(ctx context.Context, cacheService cache.Cache, pipelineId uuid.UUID, sdk pb.Sdk, lc *fs_tool.LifeCycle) (uuid.UUID, bool) {
+	if len(lc.GetAbsoluteTestFilePaths()) > 0 || len(runStdin(ctx, cacheService, pipelineId, lc)) > 0 {
+		return uuid.Nil, false
+	}
+	code, err := ioutil.ReadFile(lc.GetAbsoluteSourceFilePath())
+	if err != nil {
+		logger.WithPipelineId(pipelineId).WithContext(ctx).Errorf("deduplicationKey(): error during reading the code: %s\n", err.Error())
+		return uuid.Nil, false
+	}
+	source, err := utils.CanonicalizeSource(sdk, string(code))
+	if err != nil {
+		source = string(code)
+	}
+	h := sha256.New()
+	writeHashField(h, sdk.String())
+	writeHashField(h, source)
+	writeHashField(h, strconv.FormatBool(lc.IsCompileOnly()))
+	writeHashField(h, strconv.FormatBool(lc.IsWasm()))
+	writeHashField(h, lc.GetOutputCharset())
+	writeHashField(h, runOutputFormat(ctx, cacheService, pipelineId))
+	var logFilter string
+	if filter := runLogFilter(ctx, cacheService, pipelineId); filter != nil {
+		logFilter = filter.String()
+	}
+	writeHashField(h, logFilter)
+	writeHashField(h, lc.GetBeamVersion())
+	for _, arg := range lc.GetPipelineArgs() {
+		writeHashField(h, arg)
+	}
+	writeHashField(h, "")
+	for _, library := range lc.GetClasspathLibraries() {
+		writeHashField(h, library)
+	}
+	writeHashField(h, "")
+	if dependencyPath := lc.GetAbsoluteDependencyFilePath(); dependencyPath != "" {
+		dependency, err := ioutil.ReadFile(dependencyPath)
+		if err != nil {
+			logger.WithPipelineId(pipelineId).WithContext(ctx).Errorf("deduplicationKey(): error during reading the dependency: %s\n", err.Error())
+			return uuid.Nil, false
+		}
+		writeHashField(h, filepath.Base(dependencyPath))
+		writeHashField(h, string(dependency))
+	}
+	writeHashField(h, "")
+	writeHashField(h, lc.GetSampleDatasetName())
+	for _, variable := range runEnvVariables(lc.GetRunEnv()) {
+		writeHashField(h, variable)
+	}
+	for _, filePath := range lc.GetAbsoluteAdditionalSourceFilePaths() {
+		content, err := ioutil.ReadFile(filePath)
+		if err != nil {
+			logger.WithPipelineId(pipelineId).WithContext(ctx).Errorf("deduplicationKey(): error during reading the additional source file: %s\n", err.Error())
+			return uuid.Nil, false
+		}
+		writeHashField(h, filepath.Base(filePath))
+		writeHashField(h, string(content))
+	}
+	key, err := uuid.FromBytes(h.Sum(nil)[:16])
+	if err != nil {
+		return uuid.Nil, false
+	}
+	return key, true
+}
+
+// writeHashField writes value to h followed by the separator, so values couldn't be mixed up
+func writeHashField(h hash.Hash, value string) {
+	h.Write([]byte(value))
+	h.Write([]byte{0})
+}
+
+// reuseResult copies the result of identical code saved under key into cache by pipelineId.
+// Only the result with playground.Status_STATUS_FINISHED is reused, errors and stale results aren't kept under key.
+// Returns false if there is no such result, so the code should be processed from scratch.
+func reuseResult(ctx context.Context, cacheService cache.Cache, pipelineId, key uuid.UUID) bool {
+	status, err := cacheService.GetValue(ctx, key, cache.Status)
+	if err != nil || status != pb.Status_STATUS_FINISHED {
+		return false
+	}
+	logger.WithPipelineId(pipelineId).WithContext(ctx).Infof("reuses the result of identical code %s\n", key)
+	copySubKeys(ctx, cacheService, key, pipelineId)
+	cacheService.SetValue(ctx, pipelineId, cache.Progress, statusProgress[pb.Status_STATUS_FINISHED])
+	setStatusWithTimestamp(ctx, pipelineId, cacheService, pb.Status_STATUS_FINISHED)
+	return true
+}
+
+// saveResult saves the result of code processing by pipelineId under key for expiration time,
+// so it could be reused for identical code. The result is saved only if its status is playground.Status_STATUS_FINISHED.
+func saveResult(ctx context.Context, cacheService cache.Cache, pipelineId, key uuid.UUID, expiration time.Duration) {
+	status, err := cacheService.GetValue(ctx, pipelineId, cache.Status)
+	if err != nil || status != pb.Status_STATUS_FINISHED {
+		return
+	}
+	copySubKeys(ctx, cacheService, pipelineId, key)
+	cacheService.SetValue(ctx, key, cache.Status, pb.Status_STATUS_FINISHED)
+	if err = cacheService.SetExpTime(ctx, key, expiration); err != nil {
+		logger.WithPipelineId(pipelineId).WithContext(ctx).Errorf("saveResult(): error during set expiration time of %s: %s\n", key, err.Error())
+	}
+}
+
+// copySubKeys copies deduplicatedSubKeys from one key to another, values which aren't found are skipped.
+// cache.Timestamps are merged, so timestamps of statuses already saved by the other key are kept.
+func copySubKeys(ctx context.Context, cacheService cache.Cache, from, to uuid.UUID) {
+	for _, subKey := range deduplicatedSubKeys {
+		value, err := cacheService.GetValue(ctx, from, subKey)
+		if err != nil {
+			continue
+		}
+		if subKey == cache.Timestamps {
+			value = mergeTimestamps(ctx, cacheService, to, value)
+		}
+		cacheService.SetValue(ctx, to, subKey, value)
+	}
+}
+
+// mergeTimestamps returns timestamps of statuses from value complemented with cache.Timestamps saved by pipelineId,
+// saved ones take precedence. Returns value as is if it isn't a map of timestamps.
+func mergeTimestamps(ctx context.Context, cacheService cache.Cache, pipelineId uuid.UUID, value interface{}) interface{} {
+	copied, ok := value.(map[pb.Status]time.Time)
+	if !ok {
+		return value
+	}
+	timestamps := make(map[pb.Status]time.Time, len(copied))
+	for status, timestamp := range copied {
+		timestamps[status] = timestamp
+	}
+	if saved, err := cacheService.GetValue(ctx, pipelineId, cache.Timestamps); err == nil {
+		if saved, ok := saved.(map[pb.Status]time.Time); ok {
+			for status, timestamp := range saved {
+				timestamps[status] = timestamp
+			}
+		}
+	}
+	return timestamps
+}
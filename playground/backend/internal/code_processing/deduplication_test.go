@@ -0,0 +1,179 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package code_processing
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"beam.apache.org/playground/backend/internal/cache"
+	"beam.apache.org/playground/backend/internal/fs_tool"
+	"beam.apache.org/playground/backend/internal/streaming"
+	"context"
+	"github.com/google/uuid"
+	"os"
+	"testing"
+	"time"
+)
+
+// newSourceLifeCycle returns java LifeCycle with created folders and the source file with code
+func newSourceLifeCycle(t *testing.T, code string) *fs_tool.LifeCycle {
+	lc, err := fs_tool.NewLifeCycle(pb.Sdk_SDK_JAVA, uuid.New(), os.Getenv("APP_WORK_DIR"))
+	if err != nil {
+		t.Fatalf("error during creating LifeCycle: %s", err.Error())
+	}
+	if err = lc.CreateFolders(); err != nil {
+		t.Fatalf("error during creating folders: %s", err.Error())
+	}
+	if _, err = lc.CreateSourceCodeFile(code); err != nil {
+		t.Fatalf("error during creating the source file: %s", err.Error())
+	}
+	return lc
+}
+
+func Test_deduplicationKey(t *testing.T) {
+	const code = "class A {\n  // MOCK_COMMENT\n  void f() {}\n}\n"
+	tests := []struct {
+		name      string
+		code      string
+		setup     func(lc *fs_tool.LifeCycle)
+		wantSame  bool
+		wantShare bool
+	}{
+		{
+			// Test case with calling deduplicationKey method with the same code in another format and without comments.
+			// As a result, want to receive the same key.
+			name:      "canonical form of the code",
+			code:      "class A {\n  void f() {}\n}\n",
+			wantSame:  true,
+			wantShare: true,
+		},
+		{
+			// Test case with calling deduplicationKey method with the same code and pipeline args.
+			// As a result, want to receive another key.
+			name:      "pipeline args",
+			code:      code,
+			setup:     func(lc *fs_tool.LifeCycle) { lc.SetPipelineArgs([]string{"--streaming"}) },
+			wantSame:  false,
+			wantShare: true,
+		},
+		{
+			// Test case with calling deduplicationKey method with the same code in compile-only mode.
+			// As a result, want to receive another key.
+			name:      "compile only",
+			code:      code,
+			setup:     func(lc *fs_tool.LifeCycle) { lc.SetCompileOnly(true) },
+			wantSame:  false,
+			wantShare: true,
+		},
+		{
+			// Test case with calling deduplicationKey method with the code which reads the scripted stdin.
+			// As a result, want to receive false since the result depends on the timing of stdin.
+			name:      "stdin",
+			code:      code,
+			setup:     func(lc *fs_tool.LifeCycle) { lc.SetStdin([]streaming.StdinChunk{{Data: []byte("MOCK_INPUT")}}) },
+			wantShare: false,
+		},
+	}
+	ctx := context.Background()
+	base := newSourceLifeCycle(t, code)
+	defer base.DeleteFolders()
+	baseKey, ok := deduplicationKey(ctx, cacheService, uuid.New(), pb.Sdk_SDK_JAVA, base)
+	if !ok {
+		t.Fatal("deduplicationKey() couldn't build the key of the code")
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lc := newSourceLifeCycle(t, tt.code)
+			defer lc.DeleteFolders()
+			if tt.setup != nil {
+				tt.setup(lc)
+			}
+			key, ok := deduplicationKey(ctx, cacheService, uuid.New(), pb.Sdk_SDK_JAVA, lc)
+			if ok != tt.wantShare {
+				t.Fatalf("deduplicationKey() ok = %v, want %v", ok, tt.wantShare)
+			}
+			if ok && (key == baseKey) != tt.wantSame {
+				t.Errorf("deduplicationKey() key = %s, base key = %s, want same %v", key, baseKey, tt.wantSame)
+			}
+		})
+	}
+}
+
+func Test_saveResult(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    pb.Status
+		wantReuse bool
+	}{
+		{
+			// Test case with calling saveResult method for the finished pipeline and reusing its result by another pipeline.
+			// As a result, want to receive the output, other results and the status of the finished pipeline with its timestamp for another pipeline.
+			name:      "finished pipeline",
+			status:    pb.Status_STATUS_FINISHED,
+			wantReuse: true,
+		},
+		{
+			// Test case with calling saveResult method for the pipeline failed at the run step.
+			// As a result, want the result to be not reused by another pipeline.
+			name:      "failed pipeline",
+			status:    pb.Status_STATUS_RUN_ERROR,
+			wantReuse: false,
+		},
+	}
+	ctx := context.Background()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pipelineId, key, reusingPipelineId := uuid.New(), uuid.New(), uuid.New()
+			_ = cacheService.SetValue(ctx, pipelineId, cache.CompileOutput, "MOCK_COMPILE_OUTPUT")
+			_ = cacheService.SetValue(ctx, pipelineId, cache.RunOutput, "MOCK_RUN_OUTPUT")
+			_ = cacheService.SetValue(ctx, pipelineId, cache.ExitCode, 0)
+			_ = cacheService.SetValue(ctx, pipelineId, cache.RunDuration, time.Second)
+			_ = cacheService.SetValue(ctx, pipelineId, cache.Timestamps, map[pb.Status]time.Time{pb.Status_STATUS_EXECUTING: time.Unix(1, 0)})
+			_ = cacheService.SetValue(ctx, pipelineId, cache.Status, tt.status)
+			_ = cacheService.SetValue(ctx, reusingPipelineId, cache.Timestamps, map[pb.Status]time.Time{pb.Status_STATUS_VALIDATING: time.Unix(2, 0)})
+
+			saveResult(ctx, cacheService, pipelineId, key, time.Minute)
+			if got := reuseResult(ctx, cacheService, reusingPipelineId, key); got != tt.wantReuse {
+				t.Fatalf("reuseResult() = %v, want %v", got, tt.wantReuse)
+			}
+			if !tt.wantReuse {
+				return
+			}
+			if status, _ := cacheService.GetValue(ctx, reusingPipelineId, cache.Status); status != pb.Status_STATUS_FINISHED {
+				t.Errorf("reuseResult() status = %v, want %v", status, pb.Status_STATUS_FINISHED)
+			}
+			if output, _ := cacheService.GetValue(ctx, reusingPipelineId, cache.RunOutput); output != "MOCK_RUN_OUTPUT" {
+				t.Errorf("reuseResult() run output = %v, want %v", output, "MOCK_RUN_OUTPUT")
+			}
+			if output, _ := cacheService.GetValue(ctx, reusingPipelineId, cache.CompileOutput); output != "MOCK_COMPILE_OUTPUT" {
+				t.Errorf("reuseResult() compile output = %v, want %v", output, "MOCK_COMPILE_OUTPUT")
+			}
+			if exitCode, _ := cacheService.GetValue(ctx, reusingPipelineId, cache.ExitCode); exitCode != 0 {
+				t.Errorf("reuseResult() exit code = %v, want %v", exitCode, 0)
+			}
+			if duration, _ := cacheService.GetValue(ctx, reusingPipelineId, cache.RunDuration); duration != time.Second {
+				t.Errorf("reuseResult() run duration = %v, want %v", duration, time.Second)
+			}
+			value, _ := cacheService.GetValue(ctx, reusingPipelineId, cache.Timestamps)
+			timestamps, _ := value.(map[pb.Status]time.Time)
+			if !timestamps[pb.Status_STATUS_VALIDATING].Equal(time.Unix(2, 0)) || !timestamps[pb.Status_STATUS_EXECUTING].Equal(time.Unix(1, 0)) {
+				t.Errorf("reuseResult() timestamps = %v, want saved and copied timestamps", timestamps)
+			}
+			if _, ok := timestamps[pb.Status_STATUS_FINISHED]; !ok {
+				t.Errorf("reuseResult() timestamps = %v, want timestamp of %s", timestamps, pb.Status_STATUS_FINISHED)
+			}
+		})
+	}
+}
@@ -0,0 +1,301 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package code_processing
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"beam.apache.org/playground/backend/internal/logger"
+	"bufio"
+	"bytes"
+	"fmt"
+	"github.com/google/uuid"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// cgroupRoot is the mount point of the cgroup v2 hierarchy
+	cgroupRoot = "/sys/fs/cgroup"
+	// cgroupCpuPeriod is the period of CPU quota of the cgroup in microseconds
+	cgroupCpuPeriod = 100000
+	// cgroupRemovalTime is a maximum time to wait for processes of the cgroup to be killed before the cgroup is removed
+	cgroupRemovalTime = time.Second
+	// oomHint is saved as cache.RunError when the run step is killed because it exceeds the limit of memory
+	oomHint = "the pipeline has used more memory than it is allowed, try to reduce the amount of data kept in memory"
+	// jvmCommand is the name of the command which runs the code of JVM SDKs
+	jvmCommand = "java"
+	// jvmOomMarker is written by the JVM into the error output when it fails to allocate memory
+	jvmOomMarker = "java.lang.OutOfMemoryError"
+	// cgroupServerLeaf is the name of the leaf child of the cgroup of the server which processes of the server are moved into,
+	// so controllers could be enabled for cgroups of run steps
+	cgroupServerLeaf = "playground-server"
+)
+
+var (
+	// runCgroupParentOnce prepares the cgroup in which cgroups of run steps are created only once
+	runCgroupParentOnce sync.Once
+	// runCgroupParentPath is the path of the cgroup in which cgroups of run steps are created
+	runCgroupParentPath string
+	// runCgroupParentErr is the error of preparing the cgroup in which cgroups of run steps are created
+	runCgroupParentErr error
+)
+
+// runCgroup is the cgroup v2 which limits memory and CPU usage of the command of the run step
+type runCgroup struct {
+	path string
+}
+
+// newRunCgroup creates the cgroup for the run step of the pipeline inside the cgroup of the server prepared by prepareRunCgroupParent.
+// memoryLimit is in megabytes and cpuQuota is in percents of one CPU, zero values mean that the resource isn't limited.
+// In case cgroup v2 isn't available or the cgroup couldn't be created returns error.
+func newRunCgroup(pipelineId uuid.UUID, memoryLimit, cpuQuota int) (*runCgroup, error) {
+	runCgroupParentOnce.Do(func() {
+		runCgroupParentPath, runCgroupParentErr = prepareRunCgroupParent()
+	})
+	if runCgroupParentErr != nil {
+		return nil, runCgroupParentErr
+	}
+	cgroup := &runCgroup{path: filepath.Join(runCgroupParentPath, "playground-"+pipelineId.String())}
+	if err := os.Mkdir(cgroup.path, 0755); err != nil {
+		return nil, err
+	}
+	if memoryLimit > 0 {
+		if err := cgroup.write("memory.max", strconv.Itoa(memoryLimit*1024*1024)); err != nil {
+			cgroup.remove()
+			return nil, err
+		}
+		// the memory isn't swapped instead of killing the command
+		_ = cgroup.write("memory.swap.max", "0")
+	}
+	if cpuQuota > 0 {
+		if err := cgroup.write("cpu.max", cpuMax(cpuQuota)); err != nil {
+			cgroup.remove()
+			return nil, err
+		}
+	}
+	return cgroup, nil
+}
+
+// prepareRunCgroupParent returns the path of the cgroup of the server in which cgroups of run steps are created
+// after enabling memory and CPU controllers for its children by enableRunControllers.
+// The path is read before processes of the server are moved into its leaf child, so it is prepared only once.
+// In case cgroup v2 isn't available or controllers couldn't be enabled returns error.
+func prepareRunCgroupParent() (string, error) {
+	content, err := ioutil.ReadFile("/proc/self/cgroup")
+	if err != nil {
+		return "", err
+	}
+	parent, found := parseCgroupPath(content)
+	if !found {
+		return "", fmt.Errorf("cgroup v2 isn't available")
+	}
+	parentPath := filepath.Join(cgroupRoot, parent)
+	if _, err := os.Stat(filepath.Join(parentPath, "cgroup.controllers")); err != nil {
+		return "", fmt.Errorf("cgroup v2 isn't available: %s", err.Error())
+	}
+	if err := enableRunControllers(parentPath); err != nil {
+		return "", err
+	}
+	return parentPath, nil
+}
+
+// enableRunControllers enables memory and CPU controllers for children of the cgroup by parentPath.
+// cgroup v2 doesn't allow to enable controllers for children of the non-root cgroup which has processes,
+// so in case controllers couldn't be enabled at once moves processes of the cgroup into its leaf child cgroupServerLeaf and tries again.
+// In case controllers still couldn't be enabled returns error.
+func enableRunControllers(parentPath string) error {
+	subtreeControlPath := filepath.Join(parentPath, "cgroup.subtree_control")
+	if err := ioutil.WriteFile(subtreeControlPath, []byte("+memory +cpu"), 0644); err == nil {
+		return nil
+	}
+	leafPath := filepath.Join(parentPath, cgroupServerLeaf)
+	if err := os.Mkdir(leafPath, 0755); err != nil && !os.IsExist(err) {
+		return fmt.Errorf("couldn't create the leaf cgroup of the server: %s", err.Error())
+	}
+	procs, err := ioutil.ReadFile(filepath.Join(parentPath, "cgroup.procs"))
+	if err != nil {
+		return fmt.Errorf("couldn't read processes of the cgroup of the server: %s", err.Error())
+	}
+	for _, pid := range strings.Fields(string(procs)) {
+		// the process could be finished after the list is read
+		if err := ioutil.WriteFile(filepath.Join(leafPath, "cgroup.procs"), []byte(pid), 0644); err != nil && !isFinishedProcess(pid) {
+			return fmt.Errorf("couldn't move the process %s into the leaf cgroup of the server: %s", pid, err.Error())
+		}
+	}
+	if err := ioutil.WriteFile(subtreeControlPath, []byte("+memory +cpu"), 0644); err != nil {
+		return fmt.Errorf("couldn't enable controllers for cgroups of run steps: %s", err.Error())
+	}
+	return nil
+}
+
+// isFinishedProcess returns true if the process with pid doesn't exist anymore
+func isFinishedProcess(pid string) bool {
+	_, err := os.Stat(filepath.Join("/proc", pid))
+	return os.IsNotExist(err)
+}
+
+// addCmd wraps the command into the shell which moves itself into the cgroup before executing the command.
+// It should be called before cmd is started.
+func (c *runCgroup) addCmd(cmd *exec.Cmd) error {
+	shellPath, err := exec.LookPath("sh")
+	if err != nil {
+		return err
+	}
+	script := fmt.Sprintf(`echo $$ > '%s' && exec "$0" "$@"`, filepath.Join(c.path, "cgroup.procs"))
+	cmd.Args = append([]string{"sh", "-c", script, cmd.Path}, cmd.Args[1:]...)
+	cmd.Path = shellPath
+	return nil
+}
+
+// isOomKilled returns true if some process of the cgroup has been killed because the cgroup exceeded the limit of memory
+func (c *runCgroup) isOomKilled() bool {
+	content, err := ioutil.ReadFile(filepath.Join(c.path, "memory.events"))
+	if err != nil {
+		return false
+	}
+	return parseOomKills(content) > 0
+}
+
+// remove kills processes which are left in the cgroup and removes the cgroup.
+// In case the cgroup couldn't be removed during cgroupRemovalTime logs it.
+func (c *runCgroup) remove() {
+	_ = c.write("cgroup.kill", "1")
+	deadline := time.Now().Add(cgroupRemovalTime)
+	for {
+		err := os.Remove(c.path)
+		if err == nil || os.IsNotExist(err) {
+			return
+		}
+		if time.Now().After(deadline) {
+			logger.Warnf("couldn't remove the cgroup %s: %s\n", c.path, err.Error())
+			return
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+func (c *runCgroup) write(fileName, value string) error {
+	return ioutil.WriteFile(filepath.Join(c.path, fileName), []byte(value), 0644)
+}
+
+// parseCgroupPath returns the path of the cgroup v2 of the process from the content of /proc/self/cgroup
+func parseCgroupPath(content []byte) (string, bool) {
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		if path := strings.TrimPrefix(scanner.Text(), "0::"); path != scanner.Text() {
+			return path, true
+		}
+	}
+	return "", false
+}
+
+// parseOomKills returns the number of processes killed by OOM killer from the content of memory.events of the cgroup
+func parseOomKills(content []byte) int {
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && fields[0] == "oom_kill" {
+			count, _ := strconv.Atoi(fields[1])
+			return count
+		}
+	}
+	return 0
+}
+
+// cpuMax returns the value of cpu.max of the cgroup for the quota in percents of one CPU
+func cpuMax(quota int) string {
+	return fmt.Sprintf("%d %d", quota*cgroupCpuPeriod/100, cgroupCpuPeriod)
+}
+
+// setMemoryLimit wraps the command into the shell which sets the limit of virtual memory (RLIMIT_AS) before executing it.
+// It is used when cgroup v2 isn't available, so the command fails to allocate memory over the limit instead of being killed.
+// In case limit isn't positive or the shell isn't found the command isn't limited.
+func setMemoryLimit(cmd *exec.Cmd, limit int) {
+	if limit <= 0 {
+		return
+	}
+	shellPath, err := exec.LookPath("sh")
+	if err != nil {
+		logger.Warnf("couldn't find the shell to limit memory of the command: %s\n", err.Error())
+		return
+	}
+	script := fmt.Sprintf(`ulimit -v %d && exec "$0" "$@"`, limit*1024)
+	cmd.Args = append([]string{"sh", "-c", script, cmd.Path}, cmd.Args[1:]...)
+	cmd.Path = shellPath
+}
+
+// setJvmMemoryLimit passes the maximum heap size to the JVM of the command, so the JVM fails with OutOfMemoryError over the limit.
+// It is used instead of the limit of virtual memory for JVM SDKs since the JVM reserves much more virtual memory than it uses.
+// The command could be already wrapped into the shell by other limits, the option is added after the java command then.
+// In case limit isn't positive or the command doesn't run java the command isn't limited.
+func setJvmMemoryLimit(cmd *exec.Cmd, limit int) {
+	if limit <= 0 {
+		return
+	}
+	for i, arg := range cmd.Args {
+		if filepath.Base(arg) != jvmCommand {
+			continue
+		}
+		args := append([]string{}, cmd.Args[:i+1]...)
+		args = append(args, fmt.Sprintf("-Xmx%dm", limit))
+		cmd.Args = append(args, cmd.Args[i+1:]...)
+		return
+	}
+	logger.Warnf("couldn't find the java command to limit memory of the command\n")
+}
+
+// isJvmSdk returns true if the code of sdk is run by the JVM
+func isJvmSdk(sdk pb.Sdk) bool {
+	return sdk == pb.Sdk_SDK_JAVA || sdk == pb.Sdk_SDK_SCIO
+}
+
+// isJvmOutOfMemory returns true if the error output of the command of JVM SDK shows that the JVM failed to allocate memory
+func isJvmOutOfMemory(sdk pb.Sdk, errorOutput []byte) bool {
+	return isJvmSdk(sdk) && bytes.Contains(errorOutput, []byte(jvmOomMarker))
+}
+
+// setRunResourceLimits limits memory and CPU usage of the command of the run step by the cgroup and returns the cgroup.
+// It should be called before cmd is started, the cgroup should be removed once cmd is finished.
+// In case cgroup v2 isn't available falls back to the maximum heap size of the JVM for JVM SDKs and to the limit
+// of virtual memory for other SDKs and returns nil, CPU usage isn't limited then.
+// In case memoryLimit and cpuQuota aren't positive the command isn't limited and returns nil.
+func setRunResourceLimits(pipelineId uuid.UUID, cmd *exec.Cmd, sdk pb.Sdk, memoryLimit, cpuQuota int) *runCgroup {
+	if memoryLimit <= 0 && cpuQuota <= 0 {
+		return nil
+	}
+	cgroup, err := newRunCgroup(pipelineId, memoryLimit, cpuQuota)
+	if err == nil {
+		err = cgroup.addCmd(cmd)
+		if err == nil {
+			return cgroup
+		}
+		cgroup.remove()
+	}
+	if isJvmSdk(sdk) {
+		logger.WithPipelineId(pipelineId).Warnf("couldn't limit resources of the run step by cgroup, falling back to the maximum heap size: %s\n", err.Error())
+		setJvmMemoryLimit(cmd, memoryLimit)
+		return nil
+	}
+	logger.WithPipelineId(pipelineId).Warnf("couldn't limit resources of the run step by cgroup, falling back to rlimit: %s\n", err.Error())
+	setMemoryLimit(cmd, memoryLimit)
+	return nil
+}
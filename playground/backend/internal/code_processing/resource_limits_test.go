@@ -0,0 +1,271 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package code_processing
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_parseCgroupPath(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		want      string
+		wantFound bool
+	}{
+		{
+			// Test case with calling parseCgroupPath method with cgroup v2 of the process.
+			// As a result, want to receive the path of the cgroup.
+			name:      "cgroup v2",
+			content:   "0::/system.slice/playground.service\n",
+			want:      "/system.slice/playground.service",
+			wantFound: true,
+		},
+		{
+			// Test case with calling parseCgroupPath method with hybrid cgroups.
+			// As a result, want to receive the path of the cgroup v2.
+			name:      "hybrid cgroups",
+			content:   "4:memory:/playground\n1:cpu:/\n0::/\n",
+			want:      "/",
+			wantFound: true,
+		},
+		{
+			// Test case with calling parseCgroupPath method with cgroup v1 only.
+			// As a result, want the path not to be found.
+			name:      "cgroup v1",
+			content:   "4:memory:/playground\n1:cpu:/\n",
+			wantFound: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, found := parseCgroupPath([]byte(tt.content))
+			if got != tt.want || found != tt.wantFound {
+				t.Errorf("parseCgroupPath() got = %s, %v, want %s, %v", got, found, tt.want, tt.wantFound)
+			}
+		})
+	}
+}
+
+func Test_parseOomKills(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    int
+	}{
+		{
+			// Test case with calling parseOomKills method with events of the cgroup which has been OOM-killed.
+			// As a result, want to receive the number of killed processes.
+			name:    "OOM-killed",
+			content: "low 0\nhigh 0\nmax 12\noom 1\noom_kill 1\n",
+			want:    1,
+		},
+		{
+			// Test case with calling parseOomKills method with events of the cgroup which hasn't been OOM-killed.
+			// As a result, want to receive zero.
+			name:    "not OOM-killed",
+			content: "low 0\nhigh 0\nmax 0\noom 0\noom_kill 0\n",
+			want:    0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseOomKills([]byte(tt.content)); got != tt.want {
+				t.Errorf("parseOomKills() got = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_cpuMax(t *testing.T) {
+	if got := cpuMax(50); got != "50000 100000" {
+		t.Errorf("cpuMax() got = %s, want %s", got, "50000 100000")
+	}
+}
+
+func Test_enableRunControllers(t *testing.T) {
+	pid := strconv.Itoa(os.Getpid())
+	tests := []struct {
+		name string
+		// subtreeControlIsDir makes writes into cgroup.subtree_control fail like in the cgroup which has processes
+		subtreeControlIsDir bool
+		wantErr             bool
+		wantMovedPid        string
+	}{
+		{
+			// Test case with calling enableRunControllers method with the cgroup which allows to enable controllers at once.
+			// As a result, want no error and processes of the cgroup not to be moved.
+			name:                "controllers are enabled at once",
+			subtreeControlIsDir: false,
+			wantErr:             false,
+		},
+		{
+			// Test case with calling enableRunControllers method with the cgroup which doesn't allow to enable controllers.
+			// As a result, want processes of the cgroup to be moved into the leaf cgroup and the error to be returned.
+			name:                "controllers couldn't be enabled",
+			subtreeControlIsDir: true,
+			wantErr:             true,
+			wantMovedPid:        pid,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parentPath := t.TempDir()
+			if err := os.WriteFile(filepath.Join(parentPath, "cgroup.procs"), []byte(pid+"\n"), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if tt.subtreeControlIsDir {
+				if err := os.Mkdir(filepath.Join(parentPath, "cgroup.subtree_control"), 0755); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := enableRunControllers(parentPath); (err != nil) != tt.wantErr {
+				t.Errorf("enableRunControllers() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			moved, _ := os.ReadFile(filepath.Join(parentPath, cgroupServerLeaf, "cgroup.procs"))
+			if string(moved) != tt.wantMovedPid {
+				t.Errorf("enableRunControllers() moved processes = %q, want %q", moved, tt.wantMovedPid)
+			}
+		})
+	}
+}
+
+func Test_setMemoryLimit(t *testing.T) {
+	cmd := exec.Command("sh", "-c", "ulimit -v")
+	setMemoryLimit(cmd, 256)
+	output, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("setMemoryLimit() command is failed: %s", err.Error())
+	}
+	if got := strings.TrimSpace(string(output)); got != "262144" {
+		t.Errorf("setMemoryLimit() limit of virtual memory = %s, want %s", got, "262144")
+	}
+}
+
+func Test_setJvmMemoryLimit(t *testing.T) {
+	// commands print args they are called with, so the args which the JVM receives are checked
+	binDir := t.TempDir()
+	for _, name := range []string{"java", "python"} {
+		if err := os.WriteFile(filepath.Join(binDir, name), []byte("#!/bin/sh\necho \"$@\"\n"), 0700); err != nil {
+			t.Fatal(err)
+		}
+	}
+	javaPath, pythonPath := filepath.Join(binDir, "java"), filepath.Join(binDir, "python")
+	tests := []struct {
+		name       string
+		cmd        func() *exec.Cmd
+		limit      int
+		wantOutput string
+	}{
+		{
+			// Test case with calling setJvmMemoryLimit method with the java command.
+			// As a result, want the maximum heap size to be passed to the JVM before other args.
+			name:       "java command",
+			cmd:        func() *exec.Cmd { return exec.Command(javaPath, "-cp", "bin:", "Main") },
+			limit:      256,
+			wantOutput: "-Xmx256m -cp bin: Main",
+		},
+		{
+			// Test case with calling setJvmMemoryLimit method with the java command wrapped into the shell by the limit of CPU time.
+			// As a result, want the maximum heap size to be passed to the JVM before other args.
+			name: "wrapped java command",
+			cmd: func() *exec.Cmd {
+				cmd := exec.Command(javaPath, "-cp", "bin:", "Main")
+				setCpuTimeLimit(cmd, time.Minute)
+				return cmd
+			},
+			limit:      256,
+			wantOutput: "-Xmx256m -cp bin: Main",
+		},
+		{
+			// Test case with calling setJvmMemoryLimit method with the command which doesn't run java.
+			// As a result, want the command not to be changed.
+			name:       "not java command",
+			cmd:        func() *exec.Cmd { return exec.Command(pythonPath, "main.py") },
+			limit:      256,
+			wantOutput: "main.py",
+		},
+		{
+			// Test case with calling setJvmMemoryLimit method without the limit.
+			// As a result, want the command not to be changed.
+			name:       "no limit",
+			cmd:        func() *exec.Cmd { return exec.Command(javaPath, "Main") },
+			limit:      0,
+			wantOutput: "Main",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := tt.cmd()
+			setJvmMemoryLimit(cmd, tt.limit)
+			output, err := cmd.Output()
+			if err != nil {
+				t.Fatalf("setJvmMemoryLimit() command is failed: %s", err.Error())
+			}
+			if got := strings.TrimSpace(string(output)); got != tt.wantOutput {
+				t.Errorf("setJvmMemoryLimit() args = %q, want %q", got, tt.wantOutput)
+			}
+		})
+	}
+}
+
+func Test_isJvmOutOfMemory(t *testing.T) {
+	tests := []struct {
+		name        string
+		sdk         pb.Sdk
+		errorOutput string
+		want        bool
+	}{
+		{
+			// Test case with calling isJvmOutOfMemory method with the error output of Java code which failed to allocate memory.
+			// As a result, want to receive true.
+			name:        "java out of memory",
+			sdk:         pb.Sdk_SDK_JAVA,
+			errorOutput: "Exception in thread \"main\" java.lang.OutOfMemoryError: Java heap space",
+			want:        true,
+		},
+		{
+			// Test case with calling isJvmOutOfMemory method with the error output of Java code which failed with another error.
+			// As a result, want to receive false.
+			name:        "java other error",
+			sdk:         pb.Sdk_SDK_SCIO,
+			errorOutput: "Exception in thread \"main\" java.lang.NullPointerException",
+			want:        false,
+		},
+		{
+			// Test case with calling isJvmOutOfMemory method with the error output of Python code which mentions OutOfMemoryError.
+			// As a result, want to receive false since the code isn't run by the JVM.
+			name:        "not jvm sdk",
+			sdk:         pb.Sdk_SDK_PYTHON,
+			errorOutput: "java.lang.OutOfMemoryError",
+			want:        false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isJvmOutOfMemory(tt.sdk, []byte(tt.errorOutput)); got != tt.want {
+				t.Errorf("isJvmOutOfMemory() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package code_processing
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"beam.apache.org/playground/backend/internal/environment"
+	"beam.apache.org/playground/backend/internal/logger"
+	"beam.apache.org/playground/backend/internal/setup_tools/builder"
+	"beam.apache.org/playground/backend/internal/setup_tools/life_cycle"
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// warmupOutputFileName is the name of the file with the output of the compile step of the warmup
+const warmupOutputFileName = "warmup.log"
+
+// warmupSnippets contains trivial pipelines for each SDK which are validated and compiled to warm up the toolchain
+var warmupSnippets = map[pb.Sdk]string{
+	pb.Sdk_SDK_JAVA: `import org.apache.beam.sdk.Pipeline;
+import org.apache.beam.sdk.options.PipelineOptionsFactory;
+
+public class Warmup {
+    public static void main(String[] args) {
+        Pipeline.create(PipelineOptionsFactory.fromArgs(args).create());
+    }
+}
+`,
+	pb.Sdk_SDK_GO: `package main
+
+import "github.com/apache/beam/sdks/v2/go/pkg/beam"
+
+func main() {
+	beam.Init()
+	beam.NewPipeline()
+}
+`,
+	pb.Sdk_SDK_PYTHON: `import apache_beam as beam
+
+with beam.Pipeline() as p:
+    p | beam.Create([1])
+`,
+	pb.Sdk_SDK_SCIO: `import com.spotify.scio._
+
+object Warmup {
+  def main(cmdlineArgs: Array[String]): Unit = {
+    val (sc, args) = ContextAndArgs(cmdlineArgs)
+  }
+}
+`,
+}
+
+// warmedUpSdks contains SDKs which are warmed up or are being warmed up by Warmup
+var warmedUpSdks sync.Map
+
+// Warmup validates and compiles a trivial pipeline of the SDK of sdkEnv, so the toolchain and its on-disk caches
+// are warm before the first code is processed. The SDK is warmed up once, calls for the warmed up SDK return at once.
+// In case the warmup fails returns an error, so the SDK could be warmed up by the next call.
+// Warmup is stopped once ctx is done, the duration of the warmup is logged.
+func Warmup(ctx context.Context, appEnv *environment.ApplicationEnvs, sdkEnv *environment.BeamEnvs) error {
+	sdk := sdkEnv.ApacheBeamSdk
+	if _, warmedUp := warmedUpSdks.LoadOrStore(sdk, true); warmedUp {
+		return nil
+	}
+	startedAt := time.Now()
+	if err := warmup(ctx, appEnv, sdkEnv); err != nil {
+		warmedUpSdks.Delete(sdk)
+		logger.Warnf("Warmup(): sdk %s isn't warmed up in %s: %s\n", sdk, time.Since(startedAt), err.Error())
+		return err
+	}
+	logger.Infof("Warmup(): sdk %s is warmed up in %s\n", sdk, time.Since(startedAt))
+	return nil
+}
+
+// warmup validates, prepares and compiles the snippet of the SDK in folders which are set up the same way as folders
+// of the code to process, e.g. go.mod and go.sum are copied for Go, and are deleted at the end
+func warmup(ctx context.Context, appEnv *environment.ApplicationEnvs, sdkEnv *environment.BeamEnvs) error {
+	snippet, ok := warmupSnippets[sdkEnv.ApacheBeamSdk]
+	if !ok || sdkEnv.ExecutorConfig == nil {
+		return fmt.Errorf("sdk %s couldn't be warmed up", sdkEnv.ApacheBeamSdk)
+	}
+	pipelineId := uuid.New()
+	lc, err := life_cycle.Setup(sdkEnv.ApacheBeamSdk, snippet, pipelineId, appEnv.SdkWorkingDir(sdkEnv.ApacheBeamSdk), sdkEnv.PreparedModDir(), sdkEnv.LifeCycleConfig)
+	if err != nil {
+		return err
+	}
+	defer DeleteFolders(pipelineId, lc, appEnv)
+	executorBuilder, err := builder.SetupExecutorBuilder(lc.GetAbsoluteSourceFilePath(), nil, lc.GetAbsoluteBaseFolderPath(), lc.GetAbsoluteExecutableFilePath(), nil, "", "", sdkEnv)
+	if err != nil {
+		return err
+	}
+	executor := executorBuilder.Build()
+	if err = runWarmupStep(ctx, executor.Validate()); err != nil {
+		return fmt.Errorf("validation error: %s", err.Error())
+	}
+	if err = runWarmupStep(ctx, executor.Prepare()); err != nil {
+		return fmt.Errorf("preparation error: %s", err.Error())
+	}
+	// the code of SDKs without compiling, e.g. Python, is only validated and prepared
+	if sdkEnv.ExecutorConfig.CompileCmd == "" || sdkEnv.ApacheBeamSdk == pb.Sdk_SDK_PYTHON {
+		return nil
+	}
+	// the output is written to the file instead of the pipe, so processes of the group left after the command is killed don't block it
+	outputFilePath := filepath.Join(lc.GetAbsoluteBaseFolderPath(), warmupOutputFileName)
+	output, err := os.Create(outputFilePath)
+	if err != nil {
+		return err
+	}
+	defer output.Close()
+	compileCmd := executor.Compile(ctx)
+	compileCmd.Stdout, compileCmd.Stderr = output, output
+	setProcessGroup(compileCmd)
+	err = compileCmd.Run()
+	if ctx.Err() != nil {
+		stopProcessGroup(pipelineId, compileCmd)
+		return ctx.Err()
+	}
+	if err != nil {
+		compileOutput, _ := os.ReadFile(outputFilePath)
+		return fmt.Errorf("compilation error: %s, output: %s", err.Error(), compileOutput)
+	}
+	return nil
+}
+
+// runWarmupStep runs the validate or prepare step of the warmup and waits until it is finished or ctx is done
+func runWarmupStep(ctx context.Context, stepFunc func(chan bool, chan error)) error {
+	successChannel := make(chan bool, 1)
+	errorChannel := make(chan error, 1)
+	go runStepFunc(stepFunc, successChannel, errorChannel)
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case ok := <-successChannel:
+		if !ok {
+			return <-errorChannel
+		}
+		return nil
+	}
+}
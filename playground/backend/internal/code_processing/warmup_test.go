@@ -0,0 +1,170 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package code_processing
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"beam.apache.org/playground/backend/internal/environment"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// prepareWarmupModDir creates the folder with go.mod and go.sum of the module which is used to compile the Go snippet
+func prepareWarmupModDir(t *testing.T) string {
+	preparedModDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(preparedModDir, "go.mod"), []byte("module warmup\n\ngo 1.16\n"), 0600); err != nil {
+		t.Fatalf("error during prepare go.mod: %s", err.Error())
+	}
+	if err := os.WriteFile(filepath.Join(preparedModDir, "go.sum"), nil, 0600); err != nil {
+		t.Fatalf("error during prepare go.sum: %s", err.Error())
+	}
+	return preparedModDir
+}
+
+func TestWarmup(t *testing.T) {
+	preparedModDir := prepareWarmupModDir(t)
+	type args struct {
+		sdkEnv  *environment.BeamEnvs
+		timeout time.Duration
+	}
+	tests := []struct {
+		name string
+		args args
+		// warmedUp is true if the SDK is marked as warmed up before the call
+		warmedUp     bool
+		wantErr      bool
+		wantWarmedUp bool
+	}{
+		{
+			// Test case with calling Warmup method with the SDK without a warmup snippet.
+			// As a result, want to receive an error and the SDK isn't marked as warmed up.
+			name: "sdk without snippet",
+			args: args{
+				sdkEnv:  environment.NewBeamEnvs(pb.Sdk_SDK_UNSPECIFIED, &environment.ExecutorConfig{}, ""),
+				timeout: time.Minute,
+			},
+			wantErr:      true,
+			wantWarmedUp: false,
+		},
+		{
+			// Test case with calling Warmup method with the SDK without an executor config.
+			// As a result, want to receive an error and the SDK isn't marked as warmed up.
+			name: "sdk without executor config",
+			args: args{
+				sdkEnv:  environment.NewBeamEnvs(pb.Sdk_SDK_GO, nil, ""),
+				timeout: time.Minute,
+			},
+			wantErr:      true,
+			wantWarmedUp: false,
+		},
+		{
+			// Test case with calling Warmup method with the compile command which doesn't exist.
+			// As a result, want to receive an error and the SDK isn't marked as warmed up, so it could be warmed up again.
+			name: "compilation fails",
+			args: args{
+				sdkEnv:  environment.NewBeamEnvs(pb.Sdk_SDK_GO, &environment.ExecutorConfig{CompileCmd: "MOCK_MISSING_CMD"}, preparedModDir),
+				timeout: time.Minute,
+			},
+			wantErr:      true,
+			wantWarmedUp: false,
+		},
+		{
+			// Test case with calling Warmup method with the warmup which takes longer than the timeout.
+			// As a result, want to receive an error and the SDK isn't marked as warmed up.
+			name: "warmup times out",
+			args: args{
+				sdkEnv:  environment.NewBeamEnvs(pb.Sdk_SDK_GO, &environment.ExecutorConfig{CompileCmd: "sh", CompileArgs: []string{"-c", "exec sleep 10"}}, preparedModDir),
+				timeout: 500 * time.Millisecond,
+			},
+			wantErr:      true,
+			wantWarmedUp: false,
+		},
+		{
+			// Test case with calling Warmup method with the compilation which succeeds.
+			// As a result, want to receive no error and the SDK is marked as warmed up.
+			name: "compilation succeeds",
+			args: args{
+				sdkEnv:  environment.NewBeamEnvs(pb.Sdk_SDK_GO, &environment.ExecutorConfig{CompileCmd: "true"}, preparedModDir),
+				timeout: time.Minute,
+			},
+			wantErr:      false,
+			wantWarmedUp: true,
+		},
+		{
+			// Test case with calling Warmup method with the SDK which is already warmed up.
+			// As a result, want to receive no error at once even though the SDK couldn't be warmed up again.
+			name: "sdk is already warmed up",
+			args: args{
+				sdkEnv:  environment.NewBeamEnvs(pb.Sdk_SDK_GO, nil, ""),
+				timeout: time.Minute,
+			},
+			warmedUp:     true,
+			wantErr:      false,
+			wantWarmedUp: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warmedUpSdks.Delete(tt.args.sdkEnv.ApacheBeamSdk)
+			if tt.warmedUp {
+				warmedUpSdks.Store(tt.args.sdkEnv.ApacheBeamSdk, true)
+			}
+			defer warmedUpSdks.Delete(tt.args.sdkEnv.ApacheBeamSdk)
+			appEnv := environment.NewApplicationEnvs(t.TempDir(), nil, time.Second)
+			ctx, cancel := context.WithTimeout(context.Background(), tt.args.timeout)
+			defer cancel()
+			if err := Warmup(ctx, appEnv, tt.args.sdkEnv); (err != nil) != tt.wantErr {
+				t.Errorf("Warmup() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if _, warmedUp := warmedUpSdks.Load(tt.args.sdkEnv.ApacheBeamSdk); warmedUp != tt.wantWarmedUp {
+				t.Errorf("Warmup() warmed up = %v, want %v", warmedUp, tt.wantWarmedUp)
+			}
+		})
+	}
+}
+
+func Test_warmup_goModule(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go isn't installed")
+	}
+	snippet := warmupSnippets[pb.Sdk_SDK_GO]
+	// the snippet without dependencies is compiled, so the compilation doesn't depend on the module cache
+	warmupSnippets[pb.Sdk_SDK_GO] = "package main\n\nfunc main() {}\n"
+	defer func() { warmupSnippets[pb.Sdk_SDK_GO] = snippet }()
+	workingDir := t.TempDir()
+	appEnv := environment.NewApplicationEnvs(workingDir, nil, time.Second)
+	sdkEnv := environment.NewBeamEnvs(pb.Sdk_SDK_GO, &environment.ExecutorConfig{CompileCmd: "go", CompileArgs: []string{"build", "-o", "bin"}}, prepareWarmupModDir(t))
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	// the compilation succeeds only in case go.mod of the prepared module is copied like for the code to process
+	if err := warmup(ctx, appEnv, sdkEnv); err != nil {
+		t.Fatalf("warmup() error = %v, want the compilation to succeed", err)
+	}
+	entries, err := os.ReadDir(filepath.Join(workingDir, "executable_files"))
+	if err != nil {
+		t.Fatalf("error during read the working dir: %s", err.Error())
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			t.Errorf("warmup() left the folder %s, want folders to be deleted", entry.Name())
+		}
+	}
+}
@@ -16,7 +16,12 @@
 package environment
 
 import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
 	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
 	"time"
 )
 
@@ -90,14 +95,253 @@ type ApplicationEnvs struct {
 
 	// pipelineExecuteTimeout is timeout for code processing
 	pipelineExecuteTimeout time.Duration
+
+	// timeoutExtension is a duration which is added to the code processing timeout by one extension request
+	timeoutExtension time.Duration
+
+	// maxTimeoutExtension is a maximum total duration which could be added to the code processing timeout
+	maxTimeoutExtension time.Duration
+
+	// metricsSamplingInterval is a minimum interval between two element count metric points of the run step
+	metricsSamplingInterval time.Duration
+
+	// outputSamplingThreshold is a number of lines of the run output after which the output is sampled
+	outputSamplingThreshold int
+
+	// outputSamplingRate is N where only 1 of every N lines of the run output is kept after sampling is activated
+	outputSamplingRate int
+
+	// allowedOutputFileExtensions is a list of extensions of output files which are read back after the run step
+	allowedOutputFileExtensions []string
+
+	// maxOutputFiles is a maximum number of output files which are read back after the run step
+	maxOutputFiles int
+
+	// examplesValidationParallelism is a maximum number of examples which are validated concurrently at startup
+	examplesValidationParallelism int
+
+	// blockedSourcePatterns is a list of patterns of known-malicious code which is rejected during validation
+	blockedSourcePatterns []*regexp.Regexp
+
+	// disallowedPathPatterns is a list of patterns of absolute paths which the code isn't allowed to refer to
+	disallowedPathPatterns []*regexp.Regexp
+
+	// compileLocale is a locale which is set to the compile command to keep messages of the compiler consistent
+	compileLocale string
+
+	// deleteFoldersRetries is a number of retries of the deletion of folders which files are still held by a process
+	deleteFoldersRetries int
+
+	// deleteFoldersRetryDelay is a delay between retries of the deletion of folders
+	deleteFoldersRetryDelay time.Duration
+
+	// coverageEnabled is true if the code is run under a coverage tool of the SDK
+	coverageEnabled bool
+
+	// statusExpirationTimes contains expiration times for cache keys which are set when the pipeline reaches the status
+	statusExpirationTimes map[pb.Status]time.Duration
+
+	// submissionWindow is a duration within which identical submissions of the code are rejected
+	submissionWindow time.Duration
+
+	// stallWindow is a duration without output and CPU usage after which the run step is considered as stalled
+	stallWindow time.Duration
+
+	// artifactDownloadEnabled is true if the file compiled from the code could be downloaded
+	artifactDownloadEnabled bool
+
+	// maxArtifactSize is a maximum size in bytes of the file compiled from the code which could be downloaded
+	maxArtifactSize int
+
+	// maxConcurrentPipelines is a maximum number of pipelines which are processed at the same time
+	maxConcurrentPipelines int
+
+	// maxQueueWait is a maximum duration which the pipeline waits in the queue for processing
+	maxQueueWait time.Duration
+
+	// resultStorageType is a type of the object storage where results of finished pipelines are persisted
+	resultStorageType string
+
+	// resultStorageAddress is a bucket or a directory of the object storage where results of finished pipelines are persisted
+	resultStorageAddress string
+
+	// resultStorageCredentials is a path to the file with credentials of the object storage
+	resultStorageCredentials string
+
+	// runRetries is a maximum number of retries of the run step which is failed with a transient error
+	runRetries int
+
+	// transientRunErrorPatterns is a list of patterns of run errors which are caused by the infrastructure rather than by the code
+	transientRunErrorPatterns []*regexp.Regexp
+
+	// outputRedactionEnabled is true if secrets are redacted in the output of the run step before it is saved
+	outputRedactionEnabled bool
+
+	// secretPatterns is a list of patterns of secrets which are redacted in the output of the run step
+	secretPatterns []*regexp.Regexp
+
+	// outputPreviewSize is a number of bytes from the start and from the end of the output which are returned in its preview
+	outputPreviewSize int
+
+	// runCpuTimeLimit is a maximum CPU time which the run step could use
+	runCpuTimeLimit time.Duration
+
+	// sdkCheckEnabled is true if the toolchain of the SDK is checked at startup
+	sdkCheckEnabled bool
+
+	// sdkCritical is true if the server isn't ready when the toolchain of the SDK is broken
+	sdkCritical bool
+
+	// intermediateSamples is a maximum number of elements which are sampled from each PCollection of the pipeline
+	intermediateSamples int
+
+	// validateTimeout is a timeout for the validate step
+	validateTimeout time.Duration
+
+	// prepareTimeout is a timeout for the prepare step
+	prepareTimeout time.Duration
+
+	// compileTimeout is a timeout for the compile step
+	compileTimeout time.Duration
+
+	// runTimeout is a timeout for the run step (or tests run instead of it)
+	runTimeout time.Duration
+
+	// runMemoryLimit is a maximum memory in megabytes which the run step could use
+	runMemoryLimit int
+
+	// runCpuQuota is a maximum CPU usage of the run step in percents of one CPU
+	runCpuQuota int
+
+	// resultDeduplicationTime is a duration during which the finished result is reused for identical code
+	resultDeduplicationTime time.Duration
+
+	// jsonLogging is whether logs are written as JSON lines with structured fields
+	jsonLogging bool
+
+	// maxOutputBytes is a maximum size of the run output in bytes which is kept in cache
+	maxOutputBytes int
+
+	// killOnOutputLimit is whether the run step is stopped once its output exceeds maxOutputBytes
+	killOnOutputLimit bool
+
+	// shutdownGracePeriod is a maximum duration which the server waits for running pipelines on shutdown
+	shutdownGracePeriod time.Duration
+
+	// janitorInterval is an interval of removing stale folders of pipelines which haven't been deleted after code processing
+	janitorInterval time.Duration
+
+	// staleFolderAge is a minimum duration since the last modification of the folder of the pipeline after which it is stale
+	staleFolderAge time.Duration
+
+	// runEnvAllowlist is a list of names of environment variables of the server which are passed to the run step
+	runEnvAllowlist []string
+
+	// runEnvDenylist is a list of names of environment variables which couldn't be set by the client for the run step
+	runEnvDenylist []string
+
+	// cacheWriteRetries is a number of retries of failed writes of statuses of code processing into cache
+	cacheWriteRetries int
+
+	// cacheWriteRetryDelay is a delay before the first retry of the failed write into cache, doubled after each retry
+	cacheWriteRetryDelay time.Duration
+
+	// minPipelineExecuteTimeout is the minimum timeout of code processing which could be requested by the client
+	minPipelineExecuteTimeout time.Duration
+
+	// maxPipelineExecuteTimeout is the maximum timeout of code processing which could be requested by the client
+	maxPipelineExecuteTimeout time.Duration
+
+	// maxDependencySize is a maximum size in bytes of the artifact of a library uploaded by the client
+	maxDependencySize int
+
+	// warmupEnabled is used to compile a trivial pipeline at startup to warm up the toolchain of the SDK
+	warmupEnabled bool
+
+	// warmupTimeout is a maximum duration of the warmup of the toolchain of the SDK
+	warmupTimeout time.Duration
+
+	// stripAnsiEscapes is true if ANSI escape sequences (e.g. colors) are removed from the run output before it is saved
+	stripAnsiEscapes bool
+
+	// runDiskQuota is a maximum size in megabytes of the folder of the pipeline during the run step
+	runDiskQuota int
+
+	// diskUsageSamplingInterval is an interval between samples of the size of the folder of the pipeline
+	diskUsageSamplingInterval time.Duration
+
+	// sdkWorkingDirs contains root working directories of SDKs which don't use the app working directory
+	sdkWorkingDirs map[pb.Sdk]string
+
+	// cancelCheckInterval is an interval between checks of the cancel flag of the pipeline
+	cancelCheckInterval time.Duration
+
+	// cancelGracePeriod is a maximum time to wait for the output of the canceled step to be flushed
+	cancelGracePeriod time.Duration
+
+	// metricsAddress is an address of the listener which serves metrics of code processing
+	metricsAddress string
 }
 
 // NewApplicationEnvs constructor for ApplicationEnvs
 func NewApplicationEnvs(workingDir string, cacheEnvs *CacheEnvs, pipelineExecuteTimeout time.Duration) *ApplicationEnvs {
 	return &ApplicationEnvs{
-		workingDir:             workingDir,
-		cacheEnvs:              cacheEnvs,
-		pipelineExecuteTimeout: pipelineExecuteTimeout,
+		workingDir:                    workingDir,
+		cacheEnvs:                     cacheEnvs,
+		pipelineExecuteTimeout:        pipelineExecuteTimeout,
+		timeoutExtension:              defaultTimeoutExtension,
+		maxTimeoutExtension:           defaultMaxTimeoutExtension,
+		metricsSamplingInterval:       defaultMetricsSamplingInterval,
+		outputSamplingThreshold:       defaultOutputSamplingThreshold,
+		outputSamplingRate:            defaultOutputSamplingRate,
+		allowedOutputFileExtensions:   strings.Split(defaultAllowedOutputFileExtensions, ","),
+		maxOutputFiles:                defaultMaxOutputFiles,
+		examplesValidationParallelism: defaultExamplesValidationParallelism,
+		compileLocale:                 defaultCompileLocale,
+		deleteFoldersRetries:          defaultDeleteFoldersRetries,
+		deleteFoldersRetryDelay:       defaultDeleteFoldersRetryDelay,
+		coverageEnabled:               defaultCoverageEnabled,
+		submissionWindow:              defaultSubmissionWindow,
+		stallWindow:                   defaultStallWindow,
+		artifactDownloadEnabled:       defaultArtifactDownloadEnabled,
+		maxArtifactSize:               defaultMaxArtifactSize,
+		maxConcurrentPipelines:        defaultMaxConcurrentPipelines,
+		maxQueueWait:                  defaultMaxQueueWait,
+		runRetries:                    defaultRunRetries,
+		outputRedactionEnabled:        defaultOutputRedactionEnabled,
+		secretPatterns:                defaultSecretPatterns,
+		outputPreviewSize:             defaultOutputPreviewSize,
+		runCpuTimeLimit:               defaultRunCpuTimeLimit,
+		sdkCheckEnabled:               defaultSdkCheckEnabled,
+		sdkCritical:                   defaultSdkCritical,
+		intermediateSamples:           defaultIntermediateSamples,
+		validateTimeout:               defaultStepTimeout,
+		prepareTimeout:                defaultStepTimeout,
+		compileTimeout:                defaultStepTimeout,
+		runTimeout:                    defaultStepTimeout,
+		runMemoryLimit:                defaultRunMemoryLimit,
+		runCpuQuota:                   defaultRunCpuQuota,
+		resultDeduplicationTime:       defaultResultDeduplicationTime,
+		maxOutputBytes:                defaultMaxOutputBytes,
+		killOnOutputLimit:             defaultKillOnOutputLimit,
+		shutdownGracePeriod:           defaultShutdownGracePeriod,
+		janitorInterval:               defaultJanitorInterval,
+		staleFolderAge:                defaultStaleFolderAge,
+		runEnvAllowlist:               strings.Split(defaultRunEnvAllowlist, ","),
+		runEnvDenylist:                strings.Split(defaultRunEnvDenylist, ","),
+		cacheWriteRetries:             defaultCacheWriteRetries,
+		cacheWriteRetryDelay:          defaultCacheWriteRetryDelay,
+		minPipelineExecuteTimeout:     defaultMinPipelineExecuteTimeout,
+		maxPipelineExecuteTimeout:     defaultMaxPipelineExecuteTimeout,
+		maxDependencySize:             defaultMaxDependencySize,
+		warmupEnabled:                 defaultWarmupEnabled,
+		warmupTimeout:                 defaultWarmupTimeout,
+		stripAnsiEscapes:              defaultStripAnsiEscapes,
+		runDiskQuota:                  defaultRunDiskQuota,
+		diskUsageSamplingInterval:     defaultDiskUsageSamplingInterval,
+		cancelCheckInterval:           defaultCancelCheckInterval,
+		cancelGracePeriod:             defaultCancelGracePeriod,
+		metricsAddress:                defaultMetricsAddress,
 	}
 }
 
@@ -115,3 +359,431 @@ func (ae *ApplicationEnvs) CacheEnvs() *CacheEnvs {
 func (ae *ApplicationEnvs) PipelineExecuteTimeout() time.Duration {
 	return ae.pipelineExecuteTimeout
 }
+
+// TimeoutExtension returns a duration which is added to the code processing timeout by one extension request
+func (ae *ApplicationEnvs) TimeoutExtension() time.Duration {
+	return ae.timeoutExtension
+}
+
+// MaxTimeoutExtension returns a maximum total duration which could be added to the code processing timeout.
+// Zero value means that the timeout couldn't be extended.
+func (ae *ApplicationEnvs) MaxTimeoutExtension() time.Duration {
+	return ae.maxTimeoutExtension
+}
+
+// MetricsSamplingInterval returns a minimum interval between two element count metric points of the run step.
+// Zero value means that element count metrics aren't collected.
+func (ae *ApplicationEnvs) MetricsSamplingInterval() time.Duration {
+	return ae.metricsSamplingInterval
+}
+
+// OutputSamplingThreshold returns a number of lines of the run output after which the output is sampled.
+// Zero value means that the output isn't sampled.
+func (ae *ApplicationEnvs) OutputSamplingThreshold() int {
+	return ae.outputSamplingThreshold
+}
+
+// OutputSamplingRate returns N where only 1 of every N lines of the run output is kept after sampling is activated
+func (ae *ApplicationEnvs) OutputSamplingRate() int {
+	return ae.outputSamplingRate
+}
+
+// AllowedOutputFileExtensions returns a list of extensions of output files which are read back after the run step.
+// Empty list means that output files aren't read back.
+func (ae *ApplicationEnvs) AllowedOutputFileExtensions() []string {
+	return ae.allowedOutputFileExtensions
+}
+
+// MaxOutputFiles returns a maximum number of output files which are read back after the run step.
+// The rest of output files are listed without their content. Zero value means that the number of output files isn't limited.
+func (ae *ApplicationEnvs) MaxOutputFiles() int {
+	return ae.maxOutputFiles
+}
+
+// ExamplesValidationParallelism returns a maximum number of examples which are validated concurrently at startup.
+// Zero value means that examples aren't validated at startup.
+func (ae *ApplicationEnvs) ExamplesValidationParallelism() int {
+	return ae.examplesValidationParallelism
+}
+
+// BlockedSourcePatterns returns a list of patterns of known-malicious code which is rejected during validation.
+// Empty list means that code isn't checked for known-malicious patterns.
+func (ae *ApplicationEnvs) BlockedSourcePatterns() []*regexp.Regexp {
+	return ae.blockedSourcePatterns
+}
+
+// DisallowedPathPatterns returns a list of patterns of absolute paths which the code isn't allowed to refer to.
+// Paths are looked for in string literals of the code during validation.
+// Empty list means that paths aren't checked.
+func (ae *ApplicationEnvs) DisallowedPathPatterns() []*regexp.Regexp {
+	return ae.disallowedPathPatterns
+}
+
+// CompileLocale returns a locale which is set as LANG and LC_ALL to the compile command.
+// Empty value means that the compile command uses the locale of the host.
+func (ae *ApplicationEnvs) CompileLocale() string {
+	return ae.compileLocale
+}
+
+// DeleteFoldersRetries returns a number of retries of the deletion of folders which files are still held by a process.
+// Zero value means that the deletion isn't retried.
+func (ae *ApplicationEnvs) DeleteFoldersRetries() int {
+	return ae.deleteFoldersRetries
+}
+
+// DeleteFoldersRetryDelay returns a delay between retries of the deletion of folders
+func (ae *ApplicationEnvs) DeleteFoldersRetryDelay() time.Duration {
+	return ae.deleteFoldersRetryDelay
+}
+
+// CoverageEnabled returns true if the code is run under a coverage tool of the SDK and the coverage summary is saved.
+// The code isn't run under a coverage tool by default because of its overhead.
+func (ae *ApplicationEnvs) CoverageEnabled() bool {
+	return ae.coverageEnabled
+}
+
+// StatusExpirationTimes returns expiration times for cache keys which are set when the pipeline reaches the status.
+// Final statuses which aren't in the map set the expiration time of cache keys, other statuses don't change expiration time of the pipeline.
+func (ae *ApplicationEnvs) StatusExpirationTimes() map[pb.Status]time.Duration {
+	return ae.statusExpirationTimes
+}
+
+// SubmissionWindow returns a duration within which identical submissions of the code are rejected.
+// Zero value means that identical submissions aren't rejected.
+func (ae *ApplicationEnvs) SubmissionWindow() time.Duration {
+	return ae.submissionWindow
+}
+
+// StallWindow returns a duration without output and CPU usage after which the run step is considered as stalled.
+// Zero value means that stalled run steps aren't detected.
+func (ae *ApplicationEnvs) StallWindow() time.Duration {
+	return ae.stallWindow
+}
+
+// ArtifactDownloadEnabled returns true if the file compiled from the code could be downloaded
+func (ae *ApplicationEnvs) ArtifactDownloadEnabled() bool {
+	return ae.artifactDownloadEnabled
+}
+
+// MaxArtifactSize returns a maximum size in bytes of the file compiled from the code which could be downloaded
+func (ae *ApplicationEnvs) MaxArtifactSize() int {
+	return ae.maxArtifactSize
+}
+
+// MaxConcurrentPipelines returns a maximum number of pipelines which are processed at the same time.
+// Zero value means that the number of pipelines isn't limited.
+func (ae *ApplicationEnvs) MaxConcurrentPipelines() int {
+	return ae.maxConcurrentPipelines
+}
+
+// MaxQueueWait returns a maximum duration which the pipeline waits in the queue for processing.
+// Zero value means that the pipeline waits until it could be processed.
+func (ae *ApplicationEnvs) MaxQueueWait() time.Duration {
+	return ae.maxQueueWait
+}
+
+// ResultStorageType returns a type of the object storage where results of finished pipelines are persisted ("local" or "gcs").
+// Empty value means that results of pipelines aren't persisted.
+func (ae *ApplicationEnvs) ResultStorageType() string {
+	return ae.resultStorageType
+}
+
+// ResultStorageAddress returns a bucket or a directory of the object storage where results of finished pipelines are persisted
+func (ae *ApplicationEnvs) ResultStorageAddress() string {
+	return ae.resultStorageAddress
+}
+
+// ResultStorageCredentials returns a path to the file with credentials of the object storage.
+// Empty value means that default credentials of the environment are used.
+func (ae *ApplicationEnvs) ResultStorageCredentials() string {
+	return ae.resultStorageCredentials
+}
+
+// RunRetries returns a maximum number of retries of the run step which is failed with an error matching one of TransientRunErrorPatterns.
+// Zero value means that failed run steps aren't retried.
+func (ae *ApplicationEnvs) RunRetries() int {
+	return ae.runRetries
+}
+
+// TransientRunErrorPatterns returns a list of patterns of run errors which are caused by the infrastructure rather than by the code,
+// e.g. a refused connection to a mock service. Patterns are matched against the error output of the run step.
+// Empty list means that failed run steps aren't retried.
+func (ae *ApplicationEnvs) TransientRunErrorPatterns() []*regexp.Regexp {
+	return ae.transientRunErrorPatterns
+}
+
+// OutputRedactionEnabled returns true if secrets matching SecretPatterns are replaced in the run output and run logs before they are saved.
+// Trusted deployments could disable it to avoid the overhead.
+func (ae *ApplicationEnvs) OutputRedactionEnabled() bool {
+	return ae.outputRedactionEnabled
+}
+
+// SecretPatterns returns a list of patterns of secrets which are redacted in the run output and run logs.
+// By default contains patterns of common token formats.
+func (ae *ApplicationEnvs) SecretPatterns() []*regexp.Regexp {
+	return ae.secretPatterns
+}
+
+// OutputPreviewSize returns a number of bytes from the start and from the end of the output which are returned in its preview.
+// Zero value means that the whole output is returned in the preview.
+func (ae *ApplicationEnvs) OutputPreviewSize() int {
+	return ae.outputPreviewSize
+}
+
+// RunCpuTimeLimit returns a maximum CPU time which the run step could use, rounded up to seconds.
+// The run step exceeding it is stopped independently of the timeout of code processing.
+// Zero value means that CPU time of the run step isn't limited.
+func (ae *ApplicationEnvs) RunCpuTimeLimit() time.Duration {
+	return ae.runCpuTimeLimit
+}
+
+// SdkCheckEnabled returns true if the server checks at startup that commands of the toolchain of the SDK could be found
+func (ae *ApplicationEnvs) SdkCheckEnabled() bool {
+	return ae.sdkCheckEnabled
+}
+
+// SdkCritical returns true if the server reports that it isn't ready via the health service when the toolchain of the SDK is broken.
+// Otherwise the server stays ready and rejects codes of the SDK.
+func (ae *ApplicationEnvs) SdkCritical() bool {
+	return ae.sdkCritical
+}
+
+// IntermediateSamples returns a maximum number of elements which are sampled from each PCollection of the pipeline.
+// Pipelines are instrumented during the prepare step, currently only Python pipelines are supported.
+// Zero value means that pipelines aren't instrumented.
+func (ae *ApplicationEnvs) IntermediateSamples() int {
+	return ae.intermediateSamples
+}
+
+// ValidateTimeout returns a timeout for the validate step.
+// Zero value means that the step is limited only by the timeout of code processing.
+func (ae *ApplicationEnvs) ValidateTimeout() time.Duration {
+	return ae.validateTimeout
+}
+
+// PrepareTimeout returns a timeout for the prepare step.
+// Zero value means that the step is limited only by the timeout of code processing.
+func (ae *ApplicationEnvs) PrepareTimeout() time.Duration {
+	return ae.prepareTimeout
+}
+
+// CompileTimeout returns a timeout for the compile step.
+// Zero value means that the step is limited only by the timeout of code processing.
+func (ae *ApplicationEnvs) CompileTimeout() time.Duration {
+	return ae.compileTimeout
+}
+
+// RunTimeout returns a timeout for the run step, each retry of the run step and tests run instead of it have the whole timeout.
+// The timeout caps the run step regardless of the timeout of code processing, i.e. extensions of the timeout requested by the client
+// and the execute timeout of the request don't let the run step work longer than it.
+// Zero value means that the step is limited only by the timeout of code processing.
+func (ae *ApplicationEnvs) RunTimeout() time.Duration {
+	return ae.runTimeout
+}
+
+// RunMemoryLimit returns a maximum memory in megabytes which the run step could use.
+// The run step is limited by a cgroup if cgroup v2 is available, otherwise by the limit of its virtual memory.
+// Zero value means that memory of the run step isn't limited.
+func (ae *ApplicationEnvs) RunMemoryLimit() int {
+	return ae.runMemoryLimit
+}
+
+// RunCpuQuota returns a maximum CPU usage of the run step in percents of one CPU, e.g. 50 is a half of one CPU.
+// The run step is limited only if cgroup v2 is available.
+// Zero value means that CPU usage of the run step isn't limited.
+func (ae *ApplicationEnvs) RunCpuQuota() int {
+	return ae.runCpuQuota
+}
+
+// ResultDeduplicationTime returns a duration during which the result of successfully finished code processing
+// is reused for identical code with the same SDK and options instead of processing it again.
+// Zero value means that identical code is always processed from scratch.
+func (ae *ApplicationEnvs) ResultDeduplicationTime() time.Duration {
+	return ae.resultDeduplicationTime
+}
+
+// JsonLogging returns whether logs are written as JSON lines with structured fields, e.g. pipelineId.
+// False value means that logs are written as plain lines.
+func (ae *ApplicationEnvs) JsonLogging() bool {
+	return ae.jsonLogging
+}
+
+// MaxOutputBytes returns a maximum size of the run output in bytes which is kept in cache.
+// The rest of the output is skipped and the marker of the truncated output is written instead.
+// Zero value means that the run output isn't truncated.
+func (ae *ApplicationEnvs) MaxOutputBytes() int {
+	return ae.maxOutputBytes
+}
+
+// KillOnOutputLimit returns whether the run step is stopped once its output exceeds MaxOutputBytes.
+func (ae *ApplicationEnvs) KillOnOutputLimit() bool {
+	return ae.killOnOutputLimit
+}
+
+// ShutdownGracePeriod returns a maximum duration which the server waits on shutdown for running pipelines to be canceled.
+// Pipelines which aren't finished during it are marked as canceled without waiting for them.
+func (ae *ApplicationEnvs) ShutdownGracePeriod() time.Duration {
+	return ae.shutdownGracePeriod
+}
+
+// JanitorInterval returns an interval of removing stale folders of pipelines which haven't been deleted after code processing.
+// Zero means that stale folders aren't removed.
+func (ae *ApplicationEnvs) JanitorInterval() time.Duration {
+	return ae.janitorInterval
+}
+
+// StaleFolderAge returns a minimum duration since the last modification of the folder of the pipeline after which
+// the folder is removed by the janitor in case the pipeline is finished or unknown to cache.
+func (ae *ApplicationEnvs) StaleFolderAge() time.Duration {
+	return ae.staleFolderAge
+}
+
+// RunEnvAllowlist returns a list of names of environment variables of the server which are passed to the run step.
+// Other variables of the server aren't visible to the code.
+func (ae *ApplicationEnvs) RunEnvAllowlist() []string {
+	return ae.runEnvAllowlist
+}
+
+// RunEnvDenylist returns a list of names of environment variables which couldn't be set by the client for the run step.
+// The name ending with "*" denies all variables with this prefix.
+func (ae *ApplicationEnvs) RunEnvDenylist() []string {
+	return ae.runEnvDenylist
+}
+
+// ValidateRunEnv returns an error if some of environment variables requested by the client for the run step
+// has an incorrect name or is denied by RunEnvDenylist.
+func (ae *ApplicationEnvs) ValidateRunEnv(env map[string]string) error {
+	for name := range env {
+		if !envNamePattern.MatchString(name) {
+			return fmt.Errorf("environment variable name %q is incorrect", name)
+		}
+		for _, denied := range ae.runEnvDenylist {
+			if name == denied || (strings.HasSuffix(denied, "*") && strings.HasPrefix(name, strings.TrimSuffix(denied, "*"))) {
+				return fmt.Errorf("environment variable %q isn't allowed", name)
+			}
+		}
+	}
+	return nil
+}
+
+// CacheWriteRetries returns a number of retries of failed writes of statuses of code processing into cache
+func (ae *ApplicationEnvs) CacheWriteRetries() int {
+	return ae.cacheWriteRetries
+}
+
+// CacheWriteRetryDelay returns a delay before the first retry of the failed write into cache.
+// The delay is doubled after each retry.
+func (ae *ApplicationEnvs) CacheWriteRetryDelay() time.Duration {
+	return ae.cacheWriteRetryDelay
+}
+
+// MinPipelineExecuteTimeout returns the minimum timeout of code processing which could be requested by the client
+func (ae *ApplicationEnvs) MinPipelineExecuteTimeout() time.Duration {
+	return ae.minPipelineExecuteTimeout
+}
+
+// MaxPipelineExecuteTimeout returns the maximum timeout of code processing which could be requested by the client
+func (ae *ApplicationEnvs) MaxPipelineExecuteTimeout() time.Duration {
+	return ae.maxPipelineExecuteTimeout
+}
+
+// MaxDependencySize returns a maximum size in bytes of the artifact of a library uploaded by the client
+func (ae *ApplicationEnvs) MaxDependencySize() int {
+	return ae.maxDependencySize
+}
+
+// WarmupEnabled returns true if a trivial pipeline is compiled at startup to warm up the toolchain of the SDK
+func (ae *ApplicationEnvs) WarmupEnabled() bool {
+	return ae.warmupEnabled
+}
+
+// WarmupTimeout returns a maximum duration of the warmup of the toolchain of the SDK
+func (ae *ApplicationEnvs) WarmupTimeout() time.Duration {
+	return ae.warmupTimeout
+}
+
+// StripAnsiEscapes returns true if ANSI escape sequences, e.g. colors of some runners, are removed from the run output before it is saved
+func (ae *ApplicationEnvs) StripAnsiEscapes() bool {
+	return ae.stripAnsiEscapes
+}
+
+// RunDiskQuota returns a maximum size in megabytes of the folder of the pipeline during the run step.
+// The run step which writes more files to the folder is stopped. Zero means that the size isn't limited.
+func (ae *ApplicationEnvs) RunDiskQuota() int {
+	return ae.runDiskQuota
+}
+
+// DiskUsageSamplingInterval returns an interval between samples of the size of the folder of the pipeline during the run step
+func (ae *ApplicationEnvs) DiskUsageSamplingInterval() time.Duration {
+	return ae.diskUsageSamplingInterval
+}
+
+// CancelCheckInterval returns an interval between checks of the cancel flag of the pipeline in cache.
+// Shorter intervals make canceling more responsive, longer ones reduce the load of cache.
+func (ae *ApplicationEnvs) CancelCheckInterval() time.Duration {
+	return ae.cancelCheckInterval
+}
+
+// CancelGracePeriod returns a maximum time to wait after the command of the canceled step is stopped for the step to finish.
+// Writers of the run output keep the rest of the output, e.g. the last incomplete line, until the command is finished and flush it afterwards,
+// so the wait lets the final output be saved into cache before the status is set to canceled and folders of the pipeline are deleted.
+// Zero value means that the step isn't waited for and the final output could be lost.
+func (ae *ApplicationEnvs) CancelGracePeriod() time.Duration {
+	return ae.cancelGracePeriod
+}
+
+// MetricsAddress returns an address of the listener which serves metrics of code processing at /metrics.
+// The listener is separate from the one of the API, so metrics are served with any protocol. Empty means metrics aren't served.
+func (ae *ApplicationEnvs) MetricsAddress() string {
+	return ae.metricsAddress
+}
+
+// SdkWorkingDir returns root working directory for folders of pipelines of the SDK.
+// If the root isn't configured for the SDK returns root working directory of application.
+func (ae *ApplicationEnvs) SdkWorkingDir(sdk pb.Sdk) string {
+	if workingDir, ok := ae.sdkWorkingDirs[sdk]; ok {
+		return workingDir
+	}
+	return ae.workingDir
+}
+
+// WorkingDirs returns root working directory of application and all root working directories configured for SDKs without duplicates
+func (ae *ApplicationEnvs) WorkingDirs() []string {
+	workingDirs := []string{ae.workingDir}
+	for _, workingDir := range ae.sdkWorkingDirs {
+		found := false
+		for _, existing := range workingDirs {
+			if existing == workingDir {
+				found = true
+				break
+			}
+		}
+		if !found {
+			workingDirs = append(workingDirs, workingDir)
+		}
+	}
+	sort.Strings(workingDirs[1:])
+	return workingDirs
+}
+
+// ValidateSdkWorkingDirs returns an error if some root working directory configured for SDKs doesn't exist or isn't writable
+func (ae *ApplicationEnvs) ValidateSdkWorkingDirs() error {
+	for sdk, workingDir := range ae.sdkWorkingDirs {
+		info, err := os.Stat(workingDir)
+		if err != nil {
+			return fmt.Errorf("working dir %s of %s doesn't exist: %s", workingDir, sdk, err.Error())
+		}
+		if !info.IsDir() {
+			return fmt.Errorf("working dir %s of %s isn't a directory", workingDir, sdk)
+		}
+		file, err := os.CreateTemp(workingDir, ".write_check_")
+		if err != nil {
+			return fmt.Errorf("working dir %s of %s isn't writable: %s", workingDir, sdk, err.Error())
+		}
+		file.Close()
+		if err = os.Remove(file.Name()); err != nil {
+			return fmt.Errorf("couldn't remove check file from working dir %s of %s: %s", workingDir, sdk, err.Error())
+		}
+	}
+	return nil
+}
@@ -16,7 +16,10 @@
 package environment
 
 import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
 	"fmt"
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 	"time"
@@ -260,3 +263,175 @@ func TestApplicationEnvs_PipelineExecuteTimeout(t *testing.T) {
 		})
 	}
 }
+
+func TestApplicationEnvs_ValidateRunEnv(t *testing.T) {
+	ae := NewApplicationEnvs("MOCK_WORKING_DIR", &CacheEnvs{}, 0)
+	tests := []struct {
+		name    string
+		env     map[string]string
+		wantErr bool
+	}{
+		{
+			// Test case with calling ValidateRunEnv method with the variable which isn't denied.
+			// As a result, want to receive no error.
+			name:    "allowed variable",
+			env:     map[string]string{"GOOGLE_CLOUD_PROJECT": "MOCK_PROJECT"},
+			wantErr: false,
+		},
+		{
+			// Test case with calling ValidateRunEnv method with the variable which overrides HOME.
+			// As a result, want to receive an error.
+			name:    "override HOME",
+			env:     map[string]string{"HOME": "/tmp"},
+			wantErr: true,
+		},
+		{
+			// Test case with calling ValidateRunEnv method with the variable denied by the prefix.
+			// As a result, want to receive an error.
+			name:    "denied by prefix",
+			env:     map[string]string{"LD_PRELOAD": "/tmp/lib.so"},
+			wantErr: true,
+		},
+		{
+			// Test case with calling ValidateRunEnv method with the variable with incorrect name.
+			// As a result, want to receive an error.
+			name:    "incorrect name",
+			env:     map[string]string{"MOCK=NAME": "MOCK_VALUE"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ae.ValidateRunEnv(tt.env); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateRunEnv() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestApplicationEnvs_SdkWorkingDir(t *testing.T) {
+	ae := NewApplicationEnvs("MOCK_WORKING_DIR", &CacheEnvs{}, 0)
+	ae.sdkWorkingDirs = map[pb.Sdk]string{pb.Sdk_SDK_GO: "MOCK_GO_WORKING_DIR"}
+	tests := []struct {
+		name string
+		sdk  pb.Sdk
+		want string
+	}{
+		{
+			// Test case with calling SdkWorkingDir method with the SDK which has its own working dir.
+			// As a result, want to receive the working dir of the SDK.
+			name: "working dir of the SDK",
+			sdk:  pb.Sdk_SDK_GO,
+			want: "MOCK_GO_WORKING_DIR",
+		},
+		{
+			// Test case with calling SdkWorkingDir method with the SDK which doesn't have its own working dir.
+			// As a result, want to receive the working dir of application.
+			name: "working dir of application",
+			sdk:  pb.Sdk_SDK_JAVA,
+			want: "MOCK_WORKING_DIR",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ae.SdkWorkingDir(tt.sdk); got != tt.want {
+				t.Errorf("SdkWorkingDir() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplicationEnvs_WorkingDirs(t *testing.T) {
+	tests := []struct {
+		name           string
+		sdkWorkingDirs map[pb.Sdk]string
+		want           []string
+	}{
+		{
+			// Test case with calling WorkingDirs method without working dirs of SDKs.
+			// As a result, want to receive only the working dir of application.
+			name:           "without working dirs of SDKs",
+			sdkWorkingDirs: nil,
+			want:           []string{"MOCK_WORKING_DIR"},
+		},
+		{
+			// Test case with calling WorkingDirs method with working dirs of SDKs which repeat each other and the working dir of application.
+			// As a result, want to receive each working dir once.
+			name: "with duplicated working dirs",
+			sdkWorkingDirs: map[pb.Sdk]string{
+				pb.Sdk_SDK_GO:     "MOCK_TMPFS",
+				pb.Sdk_SDK_JAVA:   "MOCK_WORKING_DIR",
+				pb.Sdk_SDK_PYTHON: "MOCK_DISK",
+				pb.Sdk_SDK_SCIO:   "MOCK_TMPFS",
+			},
+			want: []string{"MOCK_WORKING_DIR", "MOCK_DISK", "MOCK_TMPFS"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ae := NewApplicationEnvs("MOCK_WORKING_DIR", &CacheEnvs{}, 0)
+			ae.sdkWorkingDirs = tt.sdkWorkingDirs
+			if got := ae.WorkingDirs(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("WorkingDirs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplicationEnvs_ValidateSdkWorkingDirs(t *testing.T) {
+	workingDir := t.TempDir()
+	filePath := filepath.Join(workingDir, "mock.txt")
+	if err := os.WriteFile(filePath, []byte("MOCK_CONTENT"), 0600); err != nil {
+		t.Fatalf("couldn't create the file: %s", err.Error())
+	}
+	tests := []struct {
+		name           string
+		sdkWorkingDirs map[pb.Sdk]string
+		wantErr        bool
+	}{
+		{
+			// Test case with calling ValidateSdkWorkingDirs method without working dirs of SDKs.
+			// As a result, want to receive no error.
+			name:           "without working dirs of SDKs",
+			sdkWorkingDirs: nil,
+			wantErr:        false,
+		},
+		{
+			// Test case with calling ValidateSdkWorkingDirs method with the existing writable working dir.
+			// As a result, want to receive no error and no files left in the working dir.
+			name:           "writable working dir",
+			sdkWorkingDirs: map[pb.Sdk]string{pb.Sdk_SDK_GO: workingDir},
+			wantErr:        false,
+		},
+		{
+			// Test case with calling ValidateSdkWorkingDirs method with the working dir which doesn't exist.
+			// As a result, want to receive an error.
+			name:           "missing working dir",
+			sdkWorkingDirs: map[pb.Sdk]string{pb.Sdk_SDK_GO: filepath.Join(workingDir, "missing")},
+			wantErr:        true,
+		},
+		{
+			// Test case with calling ValidateSdkWorkingDirs method with the file instead of the working dir.
+			// As a result, want to receive an error.
+			name:           "file instead of working dir",
+			sdkWorkingDirs: map[pb.Sdk]string{pb.Sdk_SDK_GO: filePath},
+			wantErr:        true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ae := NewApplicationEnvs("MOCK_WORKING_DIR", &CacheEnvs{}, 0)
+			ae.sdkWorkingDirs = tt.sdkWorkingDirs
+			if err := ae.ValidateSdkWorkingDirs(); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateSdkWorkingDirs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			entries, err := os.ReadDir(workingDir)
+			if err != nil {
+				t.Fatalf("couldn't read the working dir: %s", err.Error())
+			}
+			if len(entries) != 1 {
+				t.Errorf("ValidateSdkWorkingDirs() left %d entries in the working dir, want 1", len(entries))
+			}
+		})
+	}
+}
@@ -17,18 +17,81 @@ package environment
 
 import (
 	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"beam.apache.org/playground/backend/internal/errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 )
 
+// supportedSdks contains SDKs which code could be processed by the backend.
+var supportedSdks = map[pb.Sdk]bool{
+	pb.Sdk_SDK_JAVA:   true,
+	pb.Sdk_SDK_GO:     true,
+	pb.Sdk_SDK_PYTHON: true,
+	pb.Sdk_SDK_SCIO:   true,
+}
+
+// IsSupportedSdk returns true if code of the SDK could be processed by the backend
+func IsSupportedSdk(sdk pb.Sdk) bool {
+	return supportedSdks[sdk]
+}
+
+// SdkInfo describes the SDK for clients:
+// - Sdk: the SDK
+// - Version: the version of Apache Beam of the SDK (empty if it isn't known)
+// - SdkVersion: the version of the language of the SDK, e.g. "11" for Java 11 (empty if it isn't known)
+// - Enabled: whether code of the SDK could be processed by the server
+type SdkInfo struct {
+	Sdk        pb.Sdk
+	Version    string
+	SdkVersion string
+	Enabled    bool
+}
+
 // ExecutorConfig contains all environment variables needed for compiling and execution of the code commands:
 // - CompileCmd: command to compile files with code
 // - RunCmd: command to run compiled code
 // - CompileArgs: arguments which are needed to compile files with code
 // - RunArgs: arguments which are needed to run compiled code
+// - Test: configuration to run tests against the code (nil if the SDK doesn't support tests)
+// - Coverage: configuration to run the code under a coverage tool (nil if the SDK doesn't support coverage)
 type ExecutorConfig struct {
-	CompileCmd  string   `json:"compile_cmd"`
-	RunCmd      string   `json:"run_cmd"`
+	CompileCmd  string          `json:"compile_cmd"`
+	RunCmd      string          `json:"run_cmd"`
+	CompileArgs []string        `json:"compile_args"`
+	RunArgs     []string        `json:"run_args"`
+	Test        *TestConfig     `json:"test"`
+	Coverage    *CoverageConfig `json:"coverage"`
+}
+
+// TestConfig contains all environment variables needed to run tests against the code:
+// - CompileArgs: arguments which are needed to compile files with code together with files with tests (empty if tests aren't compiled)
+// - Cmd: command to run the test harness
+// - Args: arguments which are needed to run the test harness
+// - ResultPattern: regular expression to find results of tests in the output of the test harness, should contain "name" and "result" groups
+// - PassedResults: values of the "result" group which mean that the test is passed
+type TestConfig struct {
+	CompileArgs   []string `json:"compile_args"`
+	Cmd           string   `json:"cmd"`
+	Args          []string `json:"args"`
+	ResultPattern string   `json:"result_pattern"`
+	PassedResults []string `json:"passed_results"`
+}
+
+// CoverageConfig contains all environment variables needed to run the code under a coverage tool:
+// - CompileArgs: arguments which are needed to compile files with code with coverage instrumentation (empty if compile arguments aren't changed)
+// - RunCmd: command which wraps the command to run compiled code (empty if the command to run compiled code isn't changed)
+// - RunArgs: arguments which are added before arguments to run compiled code
+// - ReportCmd: command to print the coverage summary after the run
+// - ReportArgs: arguments which are needed to print the coverage summary
+type CoverageConfig struct {
 	CompileArgs []string `json:"compile_args"`
+	RunCmd      string   `json:"run_cmd"`
 	RunArgs     []string `json:"run_args"`
+	ReportCmd   string   `json:"report_cmd"`
+	ReportArgs  []string `json:"report_args"`
 }
 
 // NewExecutorConfig creates and returns ExecutorConfig
@@ -36,11 +99,31 @@ func NewExecutorConfig(compileCmd string, runCmd string, compileArgs []string, r
 	return &ExecutorConfig{CompileCmd: compileCmd, RunCmd: runCmd, CompileArgs: compileArgs, RunArgs: runArgs}
 }
 
+// LifeCycleConfig contains SDK specific conventions of files which are used to process code:
+// - SourceFileExtension: extension of the file with code
+// - ExecutableFileExtension: extension of the file that should be executed
+// - Compiled: whether the code should be compiled before execution
+// - EntrypointResolver: name of the strategy to find the entrypoint after compilation (empty if the executable file is the entrypoint)
+type LifeCycleConfig struct {
+	SourceFileExtension     string `json:"source_file_extension"`
+	ExecutableFileExtension string `json:"executable_file_extension"`
+	Compiled                bool   `json:"compiled"`
+	EntrypointResolver      string `json:"entrypoint_resolver"`
+}
+
 // BeamEnvs contains all environments related of ApacheBeam. These will use to run pipelines
 type BeamEnvs struct {
-	ApacheBeamSdk  pb.Sdk
-	ExecutorConfig *ExecutorConfig
-	preparedModDir string
+	ApacheBeamSdk          pb.Sdk
+	ExecutorConfig         *ExecutorConfig
+	LifeCycleConfig        *LifeCycleConfig
+	preparedModDir         string
+	beamVersion            string
+	sdkVersion             string
+	classpathLibraries     map[string]string //paths to jars of libraries which are allowed to be added to the classpath by their names
+	allowedPipelineOptions []string          //pipeline options which are allowed to be passed by requests, as "name" for any value or "name=value" for the value only
+	beamVersionsDir        string            //folder with jars of other versions of Apache Beam in subfolders named by versions (empty if only the default version is available)
+	allowedImports         []string          //packages and modules which are allowed to be imported by the code with nested ones (nil if imports aren't checked)
+	sampleDatasets         map[string]string //paths to files of sample datasets which could be requested for the code by their names
 }
 
 // NewBeamEnvs is a BeamEnvs constructor
@@ -52,3 +135,219 @@ func NewBeamEnvs(apacheBeamSdk pb.Sdk, executorConfig *ExecutorConfig, preparedM
 func (b *BeamEnvs) PreparedModDir() string {
 	return b.preparedModDir
 }
+
+// BeamVersion returns the version of Apache Beam which is used to process code (empty if it isn't known)
+func (b *BeamEnvs) BeamVersion() string {
+	return b.beamVersion
+}
+
+// SdkVersion returns the version of the language of the SDK which is used to process code (empty if it isn't known)
+func (b *BeamEnvs) SdkVersion() string {
+	return b.sdkVersion
+}
+
+// ClasspathLibraryPath returns the path to the jar of the library which is allowed to be added to the classpath.
+// In case the library isn't allowed returns false.
+func (b *BeamEnvs) ClasspathLibraryPath(name string) (string, bool) {
+	path, ok := b.classpathLibraries[name]
+	return path, ok
+}
+
+// SampleDatasetPath returns the path to the file of the sample dataset from the catalog of the server by its name.
+// In case the dataset isn't in the catalog returns false.
+func (b *BeamEnvs) SampleDatasetPath(name string) (string, bool) {
+	path, ok := b.sampleDatasets[name]
+	return path, ok
+}
+
+// AllowedImports returns packages and modules which are allowed to be imported by the code, e.g. org.apache.beam or apache_beam.
+// In case imports aren't checked returns nil.
+func (b *BeamEnvs) AllowedImports() []string {
+	return b.allowedImports
+}
+
+// IsAllowedImport returns true if name is one of AllowedImports or is nested in one of them,
+// e.g. org.apache.beam.sdk.Pipeline for org.apache.beam or github.com/apache/beam/sdks/v2/go/pkg/beam for github.com/apache/beam/sdks/v2/go.
+// In case imports aren't checked returns true.
+func (b *BeamEnvs) IsAllowedImport(name string) bool {
+	if b.allowedImports == nil {
+		return true
+	}
+	for _, allowed := range b.allowedImports {
+		if name == allowed || strings.HasPrefix(name, allowed+".") || strings.HasPrefix(name, allowed+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// WithClasspathLibraries returns a copy of BeamEnvs which ExecutorConfig adds jars from libraryPaths to the classpath
+// of compilation, run and tests of the java code. BeamEnvs itself isn't changed.
+// In case libraryPaths is empty or the SDK isn't java returns BeamEnvs as is.
+func (b *BeamEnvs) WithClasspathLibraries(libraryPaths []string) *BeamEnvs {
+	if len(libraryPaths) == 0 || b.ApacheBeamSdk != pb.Sdk_SDK_JAVA || b.ExecutorConfig == nil {
+		return b
+	}
+	classpath := ":" + strings.Join(libraryPaths, ":")
+	executorConfig := *b.ExecutorConfig
+	executorConfig.CompileArgs = appendToLastArg(executorConfig.CompileArgs, classpath)
+	executorConfig.RunArgs = appendToArg(executorConfig.RunArgs, 1, classpath)
+	if b.ExecutorConfig.Test != nil {
+		testConfig := *b.ExecutorConfig.Test
+		testConfig.CompileArgs = appendToLastArg(testConfig.CompileArgs, classpath)
+		testConfig.Args = appendToArg(testConfig.Args, 3, classpath)
+		executorConfig.Test = &testConfig
+	}
+	beamEnvs := *b
+	beamEnvs.ExecutorConfig = &executorConfig
+	return &beamEnvs
+}
+
+// WithBeamVersion returns a copy of BeamEnvs which ExecutorConfig uses jars of Apache Beam of the version instead of jars of
+// the default version to compile, run and test the code. Jars of the version are looked up by names of the default jars
+// in the subfolder of the version, jars which aren't found there are used as is. BeamEnvs itself isn't changed.
+// In case the version is empty or it is the default version returns BeamEnvs as is.
+// In case the version isn't available on the server returns an error which wraps errors.ErrUnavailableVersion.
+func (b *BeamEnvs) WithBeamVersion(version string) (*BeamEnvs, error) {
+	if version == "" || version == b.beamVersion {
+		return b, nil
+	}
+	if b.beamVersionsDir == "" || b.ExecutorConfig == nil || (b.ApacheBeamSdk != pb.Sdk_SDK_JAVA && b.ApacheBeamSdk != pb.Sdk_SDK_SCIO) {
+		return nil, errors.UnavailableVersionError(fmt.Errorf("only the default version of Apache Beam is available for %s", b.ApacheBeamSdk))
+	}
+	if filepath.Base(version) != version || strings.HasPrefix(version, ".") {
+		return nil, errors.UnavailableVersionError(fmt.Errorf("incorrect version of Apache Beam: %s", version))
+	}
+	versionDir := filepath.Join(b.beamVersionsDir, version)
+	if info, err := os.Stat(versionDir); err != nil || !info.IsDir() {
+		return nil, errors.UnavailableVersionError(fmt.Errorf("version %s of Apache Beam isn't available", version))
+	}
+	executorConfig := *b.ExecutorConfig
+	executorConfig.CompileArgs = withVersionJars(executorConfig.CompileArgs, versionDir)
+	executorConfig.RunArgs = withVersionJars(executorConfig.RunArgs, versionDir)
+	if b.ExecutorConfig.Test != nil {
+		testConfig := *b.ExecutorConfig.Test
+		testConfig.CompileArgs = withVersionJars(testConfig.CompileArgs, versionDir)
+		testConfig.Args = withVersionJars(testConfig.Args, versionDir)
+		executorConfig.Test = &testConfig
+	}
+	beamEnvs := *b
+	beamEnvs.ExecutorConfig = &executorConfig
+	beamEnvs.beamVersion = version
+	return &beamEnvs, nil
+}
+
+// withVersionJars returns a copy of args where each jar of a classpath is replaced by the jar with the same name
+// from versionDir in case it exists there
+func withVersionJars(args []string, versionDir string) []string {
+	result := make([]string, 0, len(args))
+	for _, arg := range args {
+		entries := strings.Split(arg, ":")
+		for i, entry := range entries {
+			if filepath.Ext(entry) != jarExt {
+				continue
+			}
+			versionJar := filepath.Join(versionDir, filepath.Base(entry))
+			if _, err := os.Stat(versionJar); err == nil {
+				entries[i] = versionJar
+			}
+		}
+		result = append(result, strings.Join(entries, ":"))
+	}
+	return result
+}
+
+// ValidatePipelineArgs returns an error if some of args isn't an allowed pipeline option.
+// Each argument should be in the form --name or --name=value. The option allowed as "name" accepts any value,
+// the option allowed as "name=value" accepts only this value.
+func (b *BeamEnvs) ValidatePipelineArgs(args []string) error {
+	for _, arg := range args {
+		if !b.isAllowedPipelineArg(arg) {
+			return fmt.Errorf("pipeline argument %q isn't allowed", arg)
+		}
+	}
+	return nil
+}
+
+// isAllowedPipelineArg returns true if arg is in the form --name or --name=value and matches some of allowed pipeline options
+func (b *BeamEnvs) isAllowedPipelineArg(arg string) bool {
+	if !strings.HasPrefix(arg, "--") {
+		return false
+	}
+	option := strings.TrimPrefix(arg, "--")
+	name := strings.SplitN(option, "=", 2)[0]
+	if name == "" || strings.HasPrefix(name, "-") {
+		return false
+	}
+	for _, allowed := range b.allowedPipelineOptions {
+		if allowed == name || allowed == option {
+			return true
+		}
+	}
+	return false
+}
+
+// appendToLastArg returns a copy of args where value is appended to the last argument
+func appendToLastArg(args []string, value string) []string {
+	return appendToArg(args, len(args)-1, value)
+}
+
+// appendToArg returns a copy of args where value is appended to the argument with the index.
+// In case there is no such argument returns a copy of args.
+func appendToArg(args []string, index int, value string) []string {
+	result := append([]string{}, args...)
+	if index >= 0 && index < len(result) {
+		result[index] += value
+	}
+	return result
+}
+
+// AvailableSdks returns all known SDKs ordered by their values.
+// Only the SDK of the server is enabled if it is supported, the version is resolved only for the enabled SDK.
+func (b *BeamEnvs) AvailableSdks() []SdkInfo {
+	sdks := make([]SdkInfo, 0, len(pb.Sdk_name))
+	for value := range pb.Sdk_name {
+		sdk := pb.Sdk(value)
+		if sdk == pb.Sdk_SDK_UNSPECIFIED {
+			continue
+		}
+		info := SdkInfo{Sdk: sdk}
+		if sdk == b.ApacheBeamSdk && IsSupportedSdk(sdk) {
+			info.Version = b.beamVersion
+			info.SdkVersion = b.sdkVersion
+			info.Enabled = true
+		}
+		sdks = append(sdks, info)
+	}
+	sort.Slice(sdks, func(i, j int) bool {
+		return sdks[i].Sdk < sdks[j].Sdk
+	})
+	return sdks
+}
+
+// CheckAvailability returns whether code of the sdk with the sdkVersion of the language and the beamVersion
+// of Apache Beam could be processed by the server. Empty sdkVersion or beamVersion matches any version.
+// In case the combination isn't available also returns the nearest available alternatives:
+// enabled SDKs of the same sdk go first, then all other enabled SDKs.
+func (b *BeamEnvs) CheckAvailability(sdk pb.Sdk, sdkVersion, beamVersion string) (bool, []SdkInfo) {
+	var sameSdk, otherSdks []SdkInfo
+	for _, info := range b.AvailableSdks() {
+		if !info.Enabled {
+			continue
+		}
+		if info.Sdk != sdk {
+			otherSdks = append(otherSdks, info)
+			continue
+		}
+		if matchesVersion(info.SdkVersion, sdkVersion) && matchesVersion(info.Version, beamVersion) {
+			return true, nil
+		}
+		sameSdk = append(sameSdk, info)
+	}
+	return false, append(sameSdk, otherSdks...)
+}
+
+// matchesVersion returns true if the requested version is empty or equals the available one
+func matchesVersion(available, requested string) bool {
+	return requested == "" || available == requested
+}
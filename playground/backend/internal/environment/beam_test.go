@@ -17,6 +17,12 @@ package environment
 
 import (
 	playground "beam.apache.org/playground/backend/internal/api/v1"
+	"beam.apache.org/playground/backend/internal/errors"
+	stderrors "errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
 	"testing"
 )
 
@@ -55,3 +61,413 @@ func TestBeamEnvs_PreparedModDir(t *testing.T) {
 		})
 	}
 }
+
+func TestBeamEnvs_AvailableSdks(t *testing.T) {
+	type fields struct {
+		ApacheBeamSdk playground.Sdk
+		beamVersion   string
+		sdkVersion    string
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   []SdkInfo
+	}{
+		{
+			// Test case with calling AvailableSdks method with supported SDK of the server.
+			// As a result, want to receive all SDKs where only the server's SDK is enabled and has the version.
+			name: "supported sdk",
+			fields: fields{
+				ApacheBeamSdk: playground.Sdk_SDK_GO,
+				beamVersion:   "2.33.0",
+				sdkVersion:    "1.16",
+			},
+			want: []SdkInfo{
+				{Sdk: playground.Sdk_SDK_JAVA},
+				{Sdk: playground.Sdk_SDK_GO, Version: "2.33.0", SdkVersion: "1.16", Enabled: true},
+				{Sdk: playground.Sdk_SDK_PYTHON},
+				{Sdk: playground.Sdk_SDK_SCIO},
+			},
+		},
+		{
+			// Test case with calling AvailableSdks method with unsupported SDK of the server.
+			// As a result, want to receive all SDKs where no SDK is enabled.
+			name: "unsupported sdk",
+			fields: fields{
+				ApacheBeamSdk: playground.Sdk_SDK_UNSPECIFIED,
+				beamVersion:   "2.33.0",
+			},
+			want: []SdkInfo{
+				{Sdk: playground.Sdk_SDK_JAVA},
+				{Sdk: playground.Sdk_SDK_GO},
+				{Sdk: playground.Sdk_SDK_PYTHON},
+				{Sdk: playground.Sdk_SDK_SCIO},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &BeamEnvs{
+				ApacheBeamSdk: tt.fields.ApacheBeamSdk,
+				beamVersion:   tt.fields.beamVersion,
+				sdkVersion:    tt.fields.sdkVersion,
+			}
+			if got := b.AvailableSdks(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("AvailableSdks() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBeamEnvs_CheckAvailability(t *testing.T) {
+	b := &BeamEnvs{
+		ApacheBeamSdk: playground.Sdk_SDK_JAVA,
+		beamVersion:   "2.33.0",
+		sdkVersion:    "8",
+	}
+	enabledJava := []SdkInfo{{Sdk: playground.Sdk_SDK_JAVA, Version: "2.33.0", SdkVersion: "8", Enabled: true}}
+	type args struct {
+		sdk         playground.Sdk
+		sdkVersion  string
+		beamVersion string
+	}
+	tests := []struct {
+		name             string
+		args             args
+		want             bool
+		wantAlternatives []SdkInfo
+	}{
+		{
+			// Test case with calling CheckAvailability method with the server's SDK and versions.
+			// As a result, want to receive true without alternatives.
+			name: "available",
+			args: args{sdk: playground.Sdk_SDK_JAVA, sdkVersion: "8", beamVersion: "2.33.0"},
+			want: true,
+		},
+		{
+			// Test case with calling CheckAvailability method with the server's SDK and empty versions.
+			// As a result, want to receive true since empty versions match any version.
+			name: "any version",
+			args: args{sdk: playground.Sdk_SDK_JAVA},
+			want: true,
+		},
+		{
+			// Test case with calling CheckAvailability method with the server's SDK and another version of the language.
+			// As a result, want to receive false and the server's SDK as an alternative.
+			name:             "unavailable sdk version",
+			args:             args{sdk: playground.Sdk_SDK_JAVA, sdkVersion: "17", beamVersion: "2.33.0"},
+			want:             false,
+			wantAlternatives: enabledJava,
+		},
+		{
+			// Test case with calling CheckAvailability method with the server's SDK and another version of Apache Beam.
+			// As a result, want to receive false and the server's SDK as an alternative.
+			name:             "unavailable beam version",
+			args:             args{sdk: playground.Sdk_SDK_JAVA, beamVersion: "2.50.0"},
+			want:             false,
+			wantAlternatives: enabledJava,
+		},
+		{
+			// Test case with calling CheckAvailability method with another SDK.
+			// As a result, want to receive false and the server's SDK as an alternative.
+			name:             "unavailable sdk",
+			args:             args{sdk: playground.Sdk_SDK_GO},
+			want:             false,
+			wantAlternatives: enabledJava,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotAlternatives := b.CheckAvailability(tt.args.sdk, tt.args.sdkVersion, tt.args.beamVersion)
+			if got != tt.want {
+				t.Errorf("CheckAvailability() got = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(gotAlternatives, tt.wantAlternatives) {
+				t.Errorf("CheckAvailability() gotAlternatives = %v, want %v", gotAlternatives, tt.wantAlternatives)
+			}
+		})
+	}
+}
+
+func TestBeamEnvs_WithClasspathLibraries(t *testing.T) {
+	javaConfig := &ExecutorConfig{
+		CompileArgs: []string{"-d", "bin", "-classpath", "beam.jar"},
+		RunArgs:     []string{"-cp", "bin:beam.jar"},
+		Test: &TestConfig{
+			CompileArgs: []string{"-d", "bin", "-classpath", "beam.jar:junit.jar"},
+			Args:        []string{"-jar", "junit.jar", "--class-path", "bin:beam.jar", "--scan-class-path"},
+		},
+	}
+	type fields struct {
+		ApacheBeamSdk  playground.Sdk
+		ExecutorConfig *ExecutorConfig
+	}
+	tests := []struct {
+		name         string
+		fields       fields
+		libraryPaths []string
+		want         *ExecutorConfig
+	}{
+		{
+			// Test case with calling WithClasspathLibraries method without libraries.
+			// As a result, want to receive the same ExecutorConfig.
+			name:         "without libraries",
+			fields:       fields{ApacheBeamSdk: playground.Sdk_SDK_JAVA, ExecutorConfig: javaConfig},
+			libraryPaths: nil,
+			want:         javaConfig,
+		},
+		{
+			// Test case with calling WithClasspathLibraries method with libraries for not java SDK.
+			// As a result, want to receive the same ExecutorConfig.
+			name:         "not java sdk",
+			fields:       fields{ApacheBeamSdk: playground.Sdk_SDK_GO, ExecutorConfig: &ExecutorConfig{RunArgs: []string{}}},
+			libraryPaths: []string{"first.jar"},
+			want:         &ExecutorConfig{RunArgs: []string{}},
+		},
+		{
+			// Test case with calling WithClasspathLibraries method with libraries for java SDK.
+			// As a result, want to receive ExecutorConfig where libraries are added to classpaths of compilation, run and tests.
+			name:         "java sdk",
+			fields:       fields{ApacheBeamSdk: playground.Sdk_SDK_JAVA, ExecutorConfig: javaConfig},
+			libraryPaths: []string{"first.jar", "second.jar"},
+			want: &ExecutorConfig{
+				CompileArgs: []string{"-d", "bin", "-classpath", "beam.jar:first.jar:second.jar"},
+				RunArgs:     []string{"-cp", "bin:beam.jar:first.jar:second.jar"},
+				Test: &TestConfig{
+					CompileArgs: []string{"-d", "bin", "-classpath", "beam.jar:junit.jar:first.jar:second.jar"},
+					Args:        []string{"-jar", "junit.jar", "--class-path", "bin:beam.jar:first.jar:second.jar", "--scan-class-path"},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &BeamEnvs{
+				ApacheBeamSdk:  tt.fields.ApacheBeamSdk,
+				ExecutorConfig: tt.fields.ExecutorConfig,
+			}
+			if got := b.WithClasspathLibraries(tt.libraryPaths); !reflect.DeepEqual(got.ExecutorConfig, tt.want) {
+				t.Errorf("WithClasspathLibraries() = %v, want %v", got.ExecutorConfig, tt.want)
+			}
+			if tt.fields.ApacheBeamSdk == playground.Sdk_SDK_JAVA && javaConfig.RunArgs[1] != "bin:beam.jar" {
+				t.Errorf("WithClasspathLibraries() changes ExecutorConfig of the origin BeamEnvs")
+			}
+		})
+	}
+}
+
+func TestBeamEnvs_WithBeamVersion(t *testing.T) {
+	versionsDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(versionsDir, "2.35.0"), fs.ModePerm); err != nil {
+		panic(err)
+	}
+	if err := os.WriteFile(filepath.Join(versionsDir, "2.35.0", "beam.jar"), []byte("MOCK_JAR"), 0600); err != nil {
+		panic(err)
+	}
+	versionJar := filepath.Join(versionsDir, "2.35.0", "beam.jar")
+	javaConfig := &ExecutorConfig{
+		CompileArgs: []string{"-d", "bin", "-classpath", "/opt/beam.jar"},
+		RunArgs:     []string{"-cp", "bin:/opt/beam.jar:/opt/runner.jar"},
+		Test: &TestConfig{
+			CompileArgs: []string{"-d", "bin", "-classpath", "/opt/beam.jar:/opt/junit.jar"},
+			Args:        []string{"-jar", "/opt/junit.jar", "--class-path", "bin:/opt/beam.jar:/opt/runner.jar", "--scan-class-path"},
+		},
+	}
+	type fields struct {
+		ApacheBeamSdk   playground.Sdk
+		beamVersion     string
+		beamVersionsDir string
+	}
+	tests := []struct {
+		name        string
+		fields      fields
+		version     string
+		want        *ExecutorConfig
+		wantVersion string
+		wantErr     bool
+	}{
+		{
+			// Test case with calling WithBeamVersion method without the version.
+			// As a result, want to receive the same ExecutorConfig and the default version.
+			name:        "default version",
+			fields:      fields{ApacheBeamSdk: playground.Sdk_SDK_JAVA, beamVersion: "2.36.0", beamVersionsDir: versionsDir},
+			version:     "",
+			want:        javaConfig,
+			wantVersion: "2.36.0",
+		},
+		{
+			// Test case with calling WithBeamVersion method with the version which is available.
+			// As a result, want to receive ExecutorConfig where jars of the version replace jars with the same names.
+			name:    "available version",
+			fields:  fields{ApacheBeamSdk: playground.Sdk_SDK_JAVA, beamVersion: "2.36.0", beamVersionsDir: versionsDir},
+			version: "2.35.0",
+			want: &ExecutorConfig{
+				CompileArgs: []string{"-d", "bin", "-classpath", versionJar},
+				RunArgs:     []string{"-cp", "bin:" + versionJar + ":/opt/runner.jar"},
+				Test: &TestConfig{
+					CompileArgs: []string{"-d", "bin", "-classpath", versionJar + ":/opt/junit.jar"},
+					Args:        []string{"-jar", "/opt/junit.jar", "--class-path", "bin:" + versionJar + ":/opt/runner.jar", "--scan-class-path"},
+				},
+			},
+			wantVersion: "2.35.0",
+		},
+		{
+			// Test case with calling WithBeamVersion method with the version which isn't available.
+			// As a result, want to receive an error.
+			name:    "unavailable version",
+			fields:  fields{ApacheBeamSdk: playground.Sdk_SDK_JAVA, beamVersion: "2.36.0", beamVersionsDir: versionsDir},
+			version: "2.34.0",
+			wantErr: true,
+		},
+		{
+			// Test case with calling WithBeamVersion method with the version which is a path.
+			// As a result, want to receive an error.
+			name:    "incorrect version",
+			fields:  fields{ApacheBeamSdk: playground.Sdk_SDK_JAVA, beamVersion: "2.36.0", beamVersionsDir: versionsDir},
+			version: "../2.35.0",
+			wantErr: true,
+		},
+		{
+			// Test case with calling WithBeamVersion method for the SDK which has only the default version.
+			// As a result, want to receive an error.
+			name:    "not java sdk",
+			fields:  fields{ApacheBeamSdk: playground.Sdk_SDK_PYTHON, beamVersion: "2.36.0", beamVersionsDir: versionsDir},
+			version: "2.35.0",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &BeamEnvs{
+				ApacheBeamSdk:   tt.fields.ApacheBeamSdk,
+				ExecutorConfig:  javaConfig,
+				beamVersion:     tt.fields.beamVersion,
+				beamVersionsDir: tt.fields.beamVersionsDir,
+			}
+			got, err := b.WithBeamVersion(tt.version)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("WithBeamVersion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if !stderrors.Is(err, errors.ErrUnavailableVersion) {
+					t.Errorf("WithBeamVersion() error = %v, want %v", err, errors.ErrUnavailableVersion)
+				}
+				return
+			}
+			if !reflect.DeepEqual(got.ExecutorConfig, tt.want) {
+				t.Errorf("WithBeamVersion() = %v, want %v", got.ExecutorConfig, tt.want)
+			}
+			if got.BeamVersion() != tt.wantVersion {
+				t.Errorf("WithBeamVersion() version = %v, want %v", got.BeamVersion(), tt.wantVersion)
+			}
+			if javaConfig.RunArgs[1] != "bin:/opt/beam.jar:/opt/runner.jar" {
+				t.Errorf("WithBeamVersion() changes ExecutorConfig of the origin BeamEnvs")
+			}
+		})
+	}
+}
+
+func TestBeamEnvs_ValidatePipelineArgs(t *testing.T) {
+	b := &BeamEnvs{
+		ApacheBeamSdk:          playground.Sdk_SDK_JAVA,
+		allowedPipelineOptions: []string{"streaming", "runner=DirectRunner"},
+	}
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr bool
+	}{
+		{
+			// Test case with calling ValidatePipelineArgs method without arguments.
+			// As a result, want to receive no error.
+			name:    "no args",
+			args:    nil,
+			wantErr: false,
+		},
+		{
+			// Test case with calling ValidatePipelineArgs method with options allowed by name and by value.
+			// As a result, want to receive no error.
+			name:    "allowed args",
+			args:    []string{"--streaming", "--streaming=false", "--runner=DirectRunner"},
+			wantErr: false,
+		},
+		{
+			// Test case with calling ValidatePipelineArgs method with another value of the option allowed only with its value.
+			// As a result, want to receive an error.
+			name:    "disallowed value",
+			args:    []string{"--runner=DataflowRunner"},
+			wantErr: true,
+		},
+		{
+			// Test case with calling ValidatePipelineArgs method with the option which isn't allowed.
+			// As a result, want to receive an error.
+			name:    "disallowed option",
+			args:    []string{"--streaming", "--tempLocation=/tmp"},
+			wantErr: true,
+		},
+		{
+			// Test case with calling ValidatePipelineArgs method with the argument which isn't in the form --name or --name=value.
+			// As a result, want to receive an error.
+			name:    "incorrect form",
+			args:    []string{"streaming"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := b.ValidatePipelineArgs(tt.args); (err != nil) != tt.wantErr {
+				t.Errorf("ValidatePipelineArgs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBeamEnvs_IsAllowedImport(t *testing.T) {
+	tests := []struct {
+		name           string
+		allowedImports []string
+		importName     string
+		want           bool
+	}{
+		{
+			// Test case with calling IsAllowedImport method when imports aren't checked.
+			// As a result, want to receive true.
+			name:           "imports aren't checked",
+			allowedImports: nil,
+			importName:     "com.example.Secret",
+			want:           true,
+		},
+		{
+			// Test case with calling IsAllowedImport method with the class nested in the allowed java package.
+			// As a result, want to receive true.
+			name:           "nested java class",
+			allowedImports: []string{"java", "org.apache.beam"},
+			importName:     "org.apache.beam.sdk.Pipeline",
+			want:           true,
+		},
+		{
+			// Test case with calling IsAllowedImport method with the package nested in the allowed go package.
+			// As a result, want to receive true.
+			name:           "nested go package",
+			allowedImports: []string{"fmt", "github.com/apache/beam/sdks/v2/go"},
+			importName:     "github.com/apache/beam/sdks/v2/go/pkg/beam",
+			want:           true,
+		},
+		{
+			// Test case with calling IsAllowedImport method with the package which only starts with the allowed name.
+			// As a result, want to receive false.
+			name:           "package with the same prefix",
+			allowedImports: []string{"apache_beam"},
+			importName:     "apache_beam_fork",
+			want:           false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &BeamEnvs{allowedImports: tt.allowedImports}
+			if got := b.IsAllowedImport(tt.importName); got != tt.want {
+				t.Errorf("IsAllowedImport() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -23,40 +23,193 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
 )
 
 const (
-	serverIpKey                   = "SERVER_IP"
-	serverPortKey                 = "SERVER_PORT"
-	beamSdkKey                    = "BEAM_SDK"
-	workingDirKey                 = "APP_WORK_DIR"
-	preparedModDirKey             = "PREPARED_MOD_DIR"
-	cacheTypeKey                  = "CACHE_TYPE"
-	cacheAddressKey               = "CACHE_ADDRESS"
-	beamPathKey                   = "BEAM_PATH"
-	beamRunnerKey                 = "BEAM_RUNNER"
-	SLF4jKey                      = "SLF4J"
-	cacheKeyExpirationTimeKey     = "KEY_EXPIRATION_TIME"
-	pipelineExecuteTimeoutKey     = "PIPELINE_EXPIRATION_TIMEOUT"
-	protocolTypeKey               = "PROTOCOL_TYPE"
-	defaultProtocol               = "HTTP"
-	defaultIp                     = "localhost"
-	defaultPort                   = 8080
-	defaultSdk                    = pb.Sdk_SDK_JAVA
-	defaultBeamSdkPath            = "/opt/apache/beam/jars/beam-sdks-java-harness.jar"
-	defaultCacheType              = "local"
-	defaultCacheAddress           = "localhost:6379"
-	defaultCacheKeyExpirationTime = time.Minute * 15
-	defaultPipelineExecuteTimeout = time.Minute * 10
-	defaultBeamRunner             = "/opt/apache/beam/jars/beam-runners-direct.jar"
-	defaultSLF4j                  = "/opt/apache/beam/jars/slf4j-jdk14.jar"
-	jsonExt                       = ".json"
-	configFolderName              = "configs"
+	serverIpKey                          = "SERVER_IP"
+	serverPortKey                        = "SERVER_PORT"
+	beamSdkKey                           = "BEAM_SDK"
+	workingDirKey                        = "APP_WORK_DIR"
+	preparedModDirKey                    = "PREPARED_MOD_DIR"
+	cacheTypeKey                         = "CACHE_TYPE"
+	cacheAddressKey                      = "CACHE_ADDRESS"
+	beamPathKey                          = "BEAM_PATH"
+	beamVersionKey                       = "BEAM_VERSION"
+	sdkVersionKey                        = "SDK_VERSION"
+	beamRunnerKey                        = "BEAM_RUNNER"
+	SLF4jKey                             = "SLF4J"
+	junitPathKey                         = "JUNIT_PATH"
+	scioPathKey                          = "SCIO_PATH"
+	cacheKeyExpirationTimeKey            = "KEY_EXPIRATION_TIME"
+	pipelineExecuteTimeoutKey            = "PIPELINE_EXPIRATION_TIMEOUT"
+	timeoutExtensionKey                  = "PIPELINE_TIMEOUT_EXTENSION"
+	maxTimeoutExtensionKey               = "MAX_PIPELINE_TIMEOUT_EXTENSION"
+	metricsSamplingIntervalKey           = "METRICS_SAMPLING_INTERVAL"
+	outputSamplingThresholdKey           = "OUTPUT_SAMPLING_THRESHOLD"
+	outputSamplingRateKey                = "OUTPUT_SAMPLING_RATE"
+	allowedOutputFileExtensionsKey       = "ALLOWED_OUTPUT_FILE_EXTENSIONS"
+	maxOutputFilesKey                    = "MAX_OUTPUT_FILES"
+	examplesValidationParallelismKey     = "EXAMPLES_VALIDATION_PARALLELISM"
+	blockedSourcePatternsKey             = "BLOCKED_SOURCE_PATTERNS"
+	disallowedPathPatternsKey            = "DISALLOWED_PATH_PATTERNS"
+	compileLocaleKey                     = "COMPILE_LOCALE"
+	deleteFoldersRetriesKey              = "DELETE_FOLDERS_RETRIES"
+	deleteFoldersRetryDelayKey           = "DELETE_FOLDERS_RETRY_DELAY"
+	coverageEnabledKey                   = "COVERAGE_ENABLED"
+	statusExpirationTimesKey             = "STATUS_KEY_EXPIRATION_TIMES"
+	submissionWindowKey                  = "SUBMISSION_WINDOW"
+	stallWindowKey                       = "STALL_WINDOW"
+	artifactDownloadEnabledKey           = "ARTIFACT_DOWNLOAD_ENABLED"
+	maxArtifactSizeKey                   = "MAX_ARTIFACT_SIZE"
+	maxConcurrentPipelinesKey            = "MAX_CONCURRENT_PIPELINES"
+	maxQueueWaitKey                      = "MAX_QUEUE_WAIT"
+	resultStorageTypeKey                 = "RESULT_STORAGE_TYPE"
+	resultStorageAddressKey              = "RESULT_STORAGE_ADDRESS"
+	resultStorageCredentialsKey          = "RESULT_STORAGE_CREDENTIALS"
+	runRetriesKey                        = "RUN_RETRIES"
+	transientRunErrorPatternsKey         = "TRANSIENT_RUN_ERROR_PATTERNS"
+	outputRedactionEnabledKey            = "OUTPUT_REDACTION_ENABLED"
+	secretPatternsKey                    = "SECRET_PATTERNS"
+	outputPreviewSizeKey                 = "OUTPUT_PREVIEW_SIZE"
+	runCpuTimeLimitKey                   = "RUN_CPU_TIME_LIMIT"
+	sdkCheckEnabledKey                   = "SDK_CHECK_ENABLED"
+	sdkCriticalKey                       = "SDK_CRITICAL"
+	intermediateSamplesKey               = "INTERMEDIATE_SAMPLES"
+	validateTimeoutKey                   = "VALIDATE_TIMEOUT"
+	prepareTimeoutKey                    = "PREPARE_TIMEOUT"
+	compileTimeoutKey                    = "COMPILE_TIMEOUT"
+	runTimeoutKey                        = "RUN_TIMEOUT"
+	runMemoryLimitKey                    = "RUN_MEMORY_LIMIT_MB"
+	runCpuQuotaKey                       = "RUN_CPU_QUOTA_PERCENT"
+	resultDeduplicationTimeKey           = "RESULT_DEDUPLICATION_TIME"
+	logFormatKey                         = "LOG_FORMAT"
+	maxOutputBytesKey                    = "MAX_OUTPUT_BYTES"
+	killOnOutputLimitKey                 = "KILL_ON_OUTPUT_LIMIT"
+	shutdownGracePeriodKey               = "SHUTDOWN_GRACE_PERIOD"
+	janitorIntervalKey                   = "JANITOR_INTERVAL"
+	staleFolderAgeKey                    = "STALE_FOLDER_AGE"
+	runEnvAllowlistKey                   = "RUN_ENV_ALLOWLIST"
+	runEnvDenylistKey                    = "RUN_ENV_DENYLIST"
+	cacheWriteRetriesKey                 = "CACHE_WRITE_RETRIES"
+	cacheWriteRetryDelayKey              = "CACHE_WRITE_RETRY_DELAY"
+	minPipelineExecuteTimeoutKey         = "MIN_PIPELINE_EXECUTE_TIMEOUT"
+	maxPipelineExecuteTimeoutKey         = "MAX_PIPELINE_EXECUTE_TIMEOUT"
+	maxDependencySizeKey                 = "MAX_DEPENDENCY_SIZE"
+	warmupEnabledKey                     = "WARMUP_ENABLED"
+	warmupTimeoutKey                     = "WARMUP_TIMEOUT"
+	stripAnsiEscapesKey                  = "STRIP_ANSI_ESCAPES"
+	runDiskQuotaKey                      = "RUN_DISK_QUOTA_MB"
+	diskUsageSamplingIntervalKey         = "DISK_USAGE_SAMPLING_INTERVAL"
+	sdkWorkingDirsKey                    = "SDK_WORK_DIRS"
+	cancelCheckIntervalKey               = "CANCEL_CHECK_INTERVAL"
+	cancelGracePeriodKey                 = "CANCEL_GRACE_PERIOD"
+	metricsAddressKey                    = "METRICS_ADDRESS"
+	jacocoAgentPathKey                   = "JACOCO_AGENT_PATH"
+	jacocoCliPathKey                     = "JACOCO_CLI_PATH"
+	allowedClasspathLibrariesKey         = "ALLOWED_CLASSPATH_LIBRARIES"
+	classpathLibrariesDirKey             = "CLASSPATH_LIBRARIES_DIR"
+	sampleDatasetsKey                    = "SAMPLE_DATASETS"
+	sampleDatasetsDirKey                 = "SAMPLE_DATASETS_DIR"
+	beamVersionsDirKey                   = "BEAM_VERSIONS_DIR"
+	allowedPipelineOptionsKey            = "ALLOWED_PIPELINE_OPTIONS"
+	allowedImportsKey                    = "ALLOWED_IMPORTS"
+	protocolTypeKey                      = "PROTOCOL_TYPE"
+	defaultProtocol                      = "HTTP"
+	defaultIp                            = "localhost"
+	defaultPort                          = 8080
+	defaultSdk                           = pb.Sdk_SDK_JAVA
+	defaultBeamSdkPath                   = "/opt/apache/beam/jars/beam-sdks-java-harness.jar"
+	defaultCacheType                     = "local"
+	defaultCacheAddress                  = "localhost:6379"
+	defaultCacheKeyExpirationTime        = time.Minute * 15
+	defaultPipelineExecuteTimeout        = time.Minute * 10
+	defaultTimeoutExtension              = time.Minute * 1
+	defaultMaxTimeoutExtension           = 0
+	defaultMetricsSamplingInterval       = 0
+	defaultOutputSamplingThreshold       = 0
+	defaultOutputSamplingRate            = 10
+	defaultAllowedOutputFileExtensions   = ".txt,.csv,.json"
+	defaultMaxOutputFiles                = 100
+	defaultExamplesValidationParallelism = 0
+	defaultCompileLocale                 = "C.UTF-8"
+	defaultDeleteFoldersRetries          = 3
+	defaultDeleteFoldersRetryDelay       = time.Millisecond * 100
+	defaultCoverageEnabled               = false
+	defaultSubmissionWindow              = 0
+	defaultStallWindow                   = 0
+	defaultArtifactDownloadEnabled       = false
+	defaultMaxArtifactSize               = 10 * 1024 * 1024
+	defaultMaxConcurrentPipelines        = 0
+	defaultMaxQueueWait                  = 10 * time.Second
+	defaultRunRetries                    = 0
+	defaultOutputRedactionEnabled        = true
+	defaultOutputPreviewSize             = 1024
+	defaultRunCpuTimeLimit               = 0
+	defaultSdkCheckEnabled               = false
+	defaultSdkCritical                   = true
+	defaultIntermediateSamples           = 0
+	defaultStepTimeout                   = 0
+	defaultRunMemoryLimit                = 0
+	defaultRunCpuQuota                   = 0
+	defaultResultDeduplicationTime       = 0
+	defaultLogFormat                     = "text"
+	jsonLogFormat                        = "json"
+	defaultMaxOutputBytes                = 0
+	defaultKillOnOutputLimit             = false
+	defaultShutdownGracePeriod           = 20 * time.Second
+	defaultJanitorInterval               = 10 * time.Minute
+	defaultStaleFolderAge                = time.Hour
+	defaultRunEnvAllowlist               = "PATH,HOME,LANG,LC_ALL,TZ,TMPDIR,JAVA_HOME"
+	defaultRunEnvDenylist                = "PATH,HOME,LD_*,JAVA_TOOL_OPTIONS,_JAVA_OPTIONS,JDK_JAVA_OPTIONS,PYTHONPATH,PYTHONHOME,PYTHONSTARTUP"
+	defaultCacheWriteRetries             = 3
+	defaultCacheWriteRetryDelay          = 100 * time.Millisecond
+	defaultMinPipelineExecuteTimeout     = 10 * time.Second
+	defaultMaxPipelineExecuteTimeout     = 30 * time.Minute
+	defaultMaxDependencySize             = 10 * 1024 * 1024
+	defaultWarmupEnabled                 = true
+	defaultWarmupTimeout                 = 2 * time.Minute
+	defaultStripAnsiEscapes              = true
+	defaultRunDiskQuota                  = 0
+	defaultDiskUsageSamplingInterval     = time.Second
+	defaultCancelCheckInterval           = 500 * time.Millisecond
+	defaultCancelGracePeriod             = time.Second
+	defaultMetricsAddress                = ":9090"
+	defaultBeamRunner                    = "/opt/apache/beam/jars/beam-runners-direct.jar"
+	defaultSLF4j                         = "/opt/apache/beam/jars/slf4j-jdk14.jar"
+	defaultJunitPath                     = "/opt/apache/beam/jars/junit-platform-console-standalone.jar"
+	defaultScioPath                      = "/opt/apache/beam/jars/scio.jar"
+	defaultJacocoAgentPath               = "/opt/apache/beam/jars/jacocoagent.jar"
+	defaultJacocoCliPath                 = "/opt/apache/beam/jars/jacococli.jar"
+	defaultClasspathLibrariesDir         = "/opt/apache/beam/libraries"
+	defaultSampleDatasetsDir             = "/opt/apache/beam/datasets"
+	jarExt                               = ".jar"
+	jsonExt                              = ".json"
+	configFolderName                     = "configs"
 )
 
+// defaultSecretPatterns contains patterns of common token formats which are redacted in the output by default
+var defaultSecretPatterns = []*regexp.Regexp{
+	// AWS access key id
+	regexp.MustCompile(`\b(AKIA|ASIA)[0-9A-Z]{16}\b`),
+	// GitHub token
+	regexp.MustCompile(`\bgh[pousr]_[0-9A-Za-z]{36,}\b`),
+	// Google API key
+	regexp.MustCompile(`\bAIza[0-9A-Za-z_\-]{35}`),
+	// Slack token
+	regexp.MustCompile(`\bxox[abposr]-[0-9A-Za-z\-]{10,}`),
+	// Stripe secret key
+	regexp.MustCompile(`\b[rs]k_live_[0-9A-Za-z]{24,}\b`),
+	// header of PEM private key
+	regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----`),
+}
+
+// envNamePattern is a pattern of names of environment variables which could be set by the client
+var envNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
 // Environment operates with environment structures: NetworkEnvs, BeamEnvs, ApplicationEnvs
 // Environment contains all environment variables which are used by the application
 type Environment struct {
@@ -87,6 +240,63 @@ func NewEnvironment(networkEnvs NetworkEnvs, beamEnvs BeamEnvs, appEnvs Applicat
 //	- cache expiration time: 15 minutes
 //	- type of cache: local
 //	- cache address: localhost:6379
+//	- pipeline timeout extension: 1 minute
+//	- max pipeline timeout extension: 0 (timeout couldn't be extended)
+//	- metrics sampling interval: 0 (element count metrics aren't collected)
+//	- output sampling threshold: 0 (run output isn't sampled)
+//	- output sampling rate: 10
+//	- allowed output file extensions: .txt,.csv,.json
+//	- max output files: 100
+//	- examples validation parallelism: 0 (examples aren't validated at startup)
+//	- blocked source patterns: none
+//	- disallowed path patterns: none (paths in the code aren't checked)
+//	- compile locale: C.UTF-8
+//	- delete folders retries: 3
+//	- delete folders retry delay: 100 milliseconds
+//	- coverage enabled: false (code isn't run under a coverage tool)
+//	- status expiration times: none (final statuses set the expiration time of cache keys)
+//	- submission window: 0 (identical submissions aren't rejected)
+//	- stall window: 0 (stalled run steps aren't detected)
+//	- artifact download enabled: false (compiled files couldn't be downloaded)
+//	- max artifact size: 10 MiB
+//	- max concurrent pipelines: 0 (the number of pipelines isn't limited)
+//	- max queue wait: 10s (pipelines which wait in the queue longer are rejected, zero value makes them wait until they could be processed)
+//	- result storage type: none (results of pipelines aren't persisted)
+//	- run retries: 0 (failed run steps aren't retried)
+//	- transient run error patterns: none (failed run steps aren't retried)
+//	- output redaction enabled: true
+//	- secret patterns: common token formats (AWS, GitHub, Google, Slack, Stripe keys and PEM private keys)
+//	- output preview size: 1024 bytes
+//	- run CPU time limit: 0 (CPU time of the run step isn't limited)
+//	- SDK check enabled: false (the toolchain of the SDK isn't checked at startup)
+//	- SDK critical: true
+//	- intermediate samples: 0 (pipelines aren't instrumented to sample elements of PCollections)
+//	- validate, prepare, compile and run timeouts: 0 (steps are limited only by the timeout of code processing, a positive run timeout caps the run step even if the timeout of code processing is extended)
+//	- run memory limit: 0 MB (memory of the run step isn't limited)
+//	- run CPU quota: 0 percent (CPU usage of the run step isn't limited)
+//	- result deduplication time: 0 (identical code is always processed from scratch)
+//	- log format: text (logs are written as plain lines, "json" writes them as JSON lines)
+//	- max output bytes: 0 (the run output isn't truncated)
+//	- kill on output limit: false (the run step continues after its output is truncated)
+//	- shutdown grace period: 20s
+//	- janitor interval: 10 minutes (0 means stale folders of pipelines aren't removed)
+//	- stale folder age: 1 hour
+//	- run env allowlist: PATH,HOME,LANG,LC_ALL,TZ,TMPDIR,JAVA_HOME (other variables of the server aren't passed to the run step)
+//	- run env denylist: PATH,HOME,LD_*,JAVA_TOOL_OPTIONS,_JAVA_OPTIONS,JDK_JAVA_OPTIONS,PYTHONPATH,PYTHONHOME,PYTHONSTARTUP
+//	- cache write retries: 3 (0 means failed writes of statuses aren't retried)
+//	- cache write retry delay: 100ms (doubled after each retry)
+//	- min pipeline execute timeout: 10s (shorter timeouts requested by the client are raised to it)
+//	- max pipeline execute timeout: 30 minutes (longer timeouts requested by the client are lowered to it, 0 means they aren't lowered)
+//	- max dependency size: 10 MiB (0 means the size of dependencies uploaded by the client isn't limited)
+//	- warmup enabled: true (a trivial pipeline is compiled at startup to warm up the toolchain of the SDK)
+//	- warmup timeout: 2 minutes
+//	- strip ansi escapes: true (ANSI escape sequences are removed from the run output)
+//	- run disk quota: 0 (the size of the folder of the pipeline isn't limited)
+//	- disk usage sampling interval: 1 second
+//	- sdk working dirs: empty (folders of pipelines of all SDKs are created in the app working dir)
+//	- cancel check interval: 500ms
+//	- cancel grace period: 1 second (0 means the output of the canceled run step isn't waited for)
+//	- metrics address: :9090 (empty means metrics of code processing aren't served)
 // If os environment variables don't contain a value for app working dir - returns error.
 func GetApplicationEnvsFromOsEnvs() (*ApplicationEnvs, error) {
 	pipelineExecuteTimeout := defaultPipelineExecuteTimeout
@@ -110,7 +320,72 @@ func GetApplicationEnvsFromOsEnvs() (*ApplicationEnvs, error) {
 	}
 
 	if value, present := os.LookupEnv(workingDirKey); present {
-		return NewApplicationEnvs(value, NewCacheEnvs(cacheType, cacheAddress, cacheExpirationTime), pipelineExecuteTimeout), nil
+		appEnvs := NewApplicationEnvs(value, NewCacheEnvs(cacheType, cacheAddress, cacheExpirationTime), pipelineExecuteTimeout)
+		appEnvs.timeoutExtension = getDurationEnv(timeoutExtensionKey, defaultTimeoutExtension)
+		appEnvs.maxTimeoutExtension = getDurationEnv(maxTimeoutExtensionKey, defaultMaxTimeoutExtension)
+		appEnvs.metricsSamplingInterval = getDurationEnv(metricsSamplingIntervalKey, defaultMetricsSamplingInterval)
+		appEnvs.outputSamplingThreshold = getIntEnv(outputSamplingThresholdKey, defaultOutputSamplingThreshold)
+		appEnvs.outputSamplingRate = getIntEnv(outputSamplingRateKey, defaultOutputSamplingRate)
+		appEnvs.allowedOutputFileExtensions = getListEnv(allowedOutputFileExtensionsKey, defaultAllowedOutputFileExtensions)
+		appEnvs.maxOutputFiles = getIntEnv(maxOutputFilesKey, defaultMaxOutputFiles)
+		appEnvs.examplesValidationParallelism = getIntEnv(examplesValidationParallelismKey, defaultExamplesValidationParallelism)
+		appEnvs.blockedSourcePatterns = getRegexpListEnv(blockedSourcePatternsKey)
+		appEnvs.disallowedPathPatterns = getRegexpListEnv(disallowedPathPatternsKey)
+		appEnvs.compileLocale = getEnv(compileLocaleKey, defaultCompileLocale)
+		appEnvs.deleteFoldersRetries = getIntEnv(deleteFoldersRetriesKey, defaultDeleteFoldersRetries)
+		appEnvs.deleteFoldersRetryDelay = getDurationEnv(deleteFoldersRetryDelayKey, defaultDeleteFoldersRetryDelay)
+		appEnvs.coverageEnabled = getBoolEnv(coverageEnabledKey, defaultCoverageEnabled)
+		appEnvs.statusExpirationTimes = getStatusDurationsEnv(statusExpirationTimesKey)
+		appEnvs.submissionWindow = getDurationEnv(submissionWindowKey, defaultSubmissionWindow)
+		appEnvs.stallWindow = getDurationEnv(stallWindowKey, defaultStallWindow)
+		appEnvs.artifactDownloadEnabled = getBoolEnv(artifactDownloadEnabledKey, defaultArtifactDownloadEnabled)
+		appEnvs.maxArtifactSize = getIntEnv(maxArtifactSizeKey, defaultMaxArtifactSize)
+		appEnvs.maxConcurrentPipelines = getIntEnv(maxConcurrentPipelinesKey, defaultMaxConcurrentPipelines)
+		appEnvs.maxQueueWait = getDurationEnv(maxQueueWaitKey, defaultMaxQueueWait)
+		appEnvs.resultStorageType = getEnv(resultStorageTypeKey, "")
+		appEnvs.resultStorageAddress = getEnv(resultStorageAddressKey, "")
+		appEnvs.resultStorageCredentials = getEnv(resultStorageCredentialsKey, "")
+		appEnvs.runRetries = getIntEnv(runRetriesKey, defaultRunRetries)
+		appEnvs.transientRunErrorPatterns = getRegexpListEnv(transientRunErrorPatternsKey)
+		appEnvs.outputRedactionEnabled = getBoolEnv(outputRedactionEnabledKey, defaultOutputRedactionEnabled)
+		if secretPatterns := getRegexpListEnv(secretPatternsKey); len(secretPatterns) > 0 {
+			appEnvs.secretPatterns = secretPatterns
+		}
+		appEnvs.outputPreviewSize = getIntEnv(outputPreviewSizeKey, defaultOutputPreviewSize)
+		appEnvs.runCpuTimeLimit = getDurationEnv(runCpuTimeLimitKey, defaultRunCpuTimeLimit)
+		appEnvs.sdkCheckEnabled = getBoolEnv(sdkCheckEnabledKey, defaultSdkCheckEnabled)
+		appEnvs.sdkCritical = getBoolEnv(sdkCriticalKey, defaultSdkCritical)
+		appEnvs.intermediateSamples = getIntEnv(intermediateSamplesKey, defaultIntermediateSamples)
+		appEnvs.validateTimeout = getDurationEnv(validateTimeoutKey, defaultStepTimeout)
+		appEnvs.prepareTimeout = getDurationEnv(prepareTimeoutKey, defaultStepTimeout)
+		appEnvs.compileTimeout = getDurationEnv(compileTimeoutKey, defaultStepTimeout)
+		appEnvs.runTimeout = getDurationEnv(runTimeoutKey, defaultStepTimeout)
+		appEnvs.runMemoryLimit = getIntEnv(runMemoryLimitKey, defaultRunMemoryLimit)
+		appEnvs.runCpuQuota = getIntEnv(runCpuQuotaKey, defaultRunCpuQuota)
+		appEnvs.resultDeduplicationTime = getDurationEnv(resultDeduplicationTimeKey, defaultResultDeduplicationTime)
+		appEnvs.jsonLogging = strings.EqualFold(getEnv(logFormatKey, defaultLogFormat), jsonLogFormat)
+		appEnvs.maxOutputBytes = getIntEnv(maxOutputBytesKey, defaultMaxOutputBytes)
+		appEnvs.killOnOutputLimit = getBoolEnv(killOnOutputLimitKey, defaultKillOnOutputLimit)
+		appEnvs.shutdownGracePeriod = getDurationEnv(shutdownGracePeriodKey, defaultShutdownGracePeriod)
+		appEnvs.janitorInterval = getDurationEnv(janitorIntervalKey, defaultJanitorInterval)
+		appEnvs.staleFolderAge = getDurationEnv(staleFolderAgeKey, defaultStaleFolderAge)
+		appEnvs.runEnvAllowlist = getListEnv(runEnvAllowlistKey, defaultRunEnvAllowlist)
+		appEnvs.runEnvDenylist = getListEnv(runEnvDenylistKey, defaultRunEnvDenylist)
+		appEnvs.cacheWriteRetries = getIntEnv(cacheWriteRetriesKey, defaultCacheWriteRetries)
+		appEnvs.cacheWriteRetryDelay = getDurationEnv(cacheWriteRetryDelayKey, defaultCacheWriteRetryDelay)
+		appEnvs.minPipelineExecuteTimeout = getDurationEnv(minPipelineExecuteTimeoutKey, defaultMinPipelineExecuteTimeout)
+		appEnvs.maxPipelineExecuteTimeout = getDurationEnv(maxPipelineExecuteTimeoutKey, defaultMaxPipelineExecuteTimeout)
+		appEnvs.maxDependencySize = getIntEnv(maxDependencySizeKey, defaultMaxDependencySize)
+		appEnvs.warmupEnabled = getBoolEnv(warmupEnabledKey, defaultWarmupEnabled)
+		appEnvs.warmupTimeout = getDurationEnv(warmupTimeoutKey, defaultWarmupTimeout)
+		appEnvs.stripAnsiEscapes = getBoolEnv(stripAnsiEscapesKey, defaultStripAnsiEscapes)
+		appEnvs.runDiskQuota = getIntEnv(runDiskQuotaKey, defaultRunDiskQuota)
+		appEnvs.diskUsageSamplingInterval = getDurationEnv(diskUsageSamplingIntervalKey, defaultDiskUsageSamplingInterval)
+		appEnvs.sdkWorkingDirs = getSdkPathsEnv(sdkWorkingDirsKey)
+		appEnvs.cancelCheckInterval = getDurationEnv(cancelCheckIntervalKey, defaultCancelCheckInterval)
+		appEnvs.cancelGracePeriod = getDurationEnv(cancelGracePeriodKey, defaultCancelGracePeriod)
+		appEnvs.metricsAddress = getEnv(metricsAddressKey, defaultMetricsAddress)
+		return appEnvs, nil
 	}
 	return nil, errors.New("APP_WORK_DIR env should be provided with os.env")
 }
@@ -138,6 +413,11 @@ func GetNetworkEnvsFromOsEnvs() (*NetworkEnvs, error) {
 // Lookups in os environment variables and takes value for Apache Beam SDK.
 // If os environment variables don't contain a value for Apache Beam SDK - returns error.
 // Configures ExecutorConfig with config file.
+// For java SDK resolves libraries which are allowed to be added to the classpath by requests (none by default).
+// Pipeline options which are allowed to be passed by requests are taken from ALLOWED_PIPELINE_OPTIONS (none by default).
+// For java and scio SDKs jars of other versions of Apache Beam are looked up in BEAM_VERSIONS_DIR (only the default version is available by default).
+// Packages and modules which are allowed to be imported by the code are taken from ALLOWED_IMPORTS (imports aren't checked by default).
+// Sample datasets which could be requested for the code are taken from SAMPLE_DATASETS (none by default).
 func ConfigureBeamEnvs(workDir string) (*BeamEnvs, error) {
 	sdk := pb.Sdk_SDK_UNSPECIFIED
 	preparedModDir, modDirExist := os.LookupEnv(preparedModDirKey)
@@ -165,7 +445,75 @@ func ConfigureBeamEnvs(workDir string) (*BeamEnvs, error) {
 	if err != nil {
 		return nil, err
 	}
-	return NewBeamEnvs(sdk, executorConfig, preparedModDir), nil
+	lifeCycleConfig, err := getLifeCycleConfigFromJson(configPath)
+	if err != nil {
+		return nil, err
+	}
+	beamEnvs := NewBeamEnvs(sdk, executorConfig, preparedModDir)
+	beamEnvs.LifeCycleConfig = lifeCycleConfig
+	beamEnvs.beamVersion = getEnv(beamVersionKey, "")
+	beamEnvs.sdkVersion = getEnv(sdkVersionKey, "")
+	beamEnvs.allowedPipelineOptions = getAllowedPipelineOptions()
+	if allowedImports := getListEnv(allowedImportsKey, ""); len(allowedImports) > 0 {
+		beamEnvs.allowedImports = allowedImports
+	}
+	if sdk == pb.Sdk_SDK_JAVA {
+		beamEnvs.classpathLibraries = getClasspathLibraries()
+	}
+	beamEnvs.sampleDatasets = getSampleDatasets()
+	if sdk == pb.Sdk_SDK_JAVA || sdk == pb.Sdk_SDK_SCIO {
+		beamEnvs.beamVersionsDir = getEnv(beamVersionsDirKey, "")
+	}
+	return beamEnvs, nil
+}
+
+// getClasspathLibraries returns paths to jars of libraries which are allowed to be added to the classpath by their names.
+// Jars are looked up in the folder from CLASSPATH_LIBRARIES_DIR as {name}.jar.
+// In case ALLOWED_CLASSPATH_LIBRARIES isn't provided returns nil, so no libraries are allowed.
+func getClasspathLibraries() map[string]string {
+	librariesDir := getEnv(classpathLibrariesDirKey, defaultClasspathLibrariesDir)
+	var libraries map[string]string
+	for _, name := range getListEnv(allowedClasspathLibrariesKey, "") {
+		if filepath.Base(name) != name || strings.HasPrefix(name, ".") {
+			log.Printf("couldn't use provided %s library %s. Skipping it\n", allowedClasspathLibrariesKey, name)
+			continue
+		}
+		if libraries == nil {
+			libraries = make(map[string]string)
+		}
+		libraries[name] = filepath.Join(librariesDir, name+jarExt)
+	}
+	return libraries
+}
+
+// getSampleDatasets returns paths to files of sample datasets which could be requested for the code by their names.
+// Files are looked up in the folder from SAMPLE_DATASETS_DIR by names from SAMPLE_DATASETS, e.g. kinglear.txt.
+// In case SAMPLE_DATASETS isn't provided returns nil, so no datasets could be requested.
+func getSampleDatasets() map[string]string {
+	datasetsDir := getEnv(sampleDatasetsDirKey, defaultSampleDatasetsDir)
+	var datasets map[string]string
+	for _, name := range getListEnv(sampleDatasetsKey, "") {
+		if filepath.Base(name) != name || strings.HasPrefix(name, ".") {
+			log.Printf("couldn't use provided %s dataset %s. Skipping it\n", sampleDatasetsKey, name)
+			continue
+		}
+		if datasets == nil {
+			datasets = make(map[string]string)
+		}
+		datasets[name] = filepath.Join(datasetsDir, name)
+	}
+	return datasets
+}
+
+// getAllowedPipelineOptions returns pipeline options which are allowed to be passed by requests without leading dashes,
+// each option is either a name which allows any value or name=value which allows only this value.
+// In case ALLOWED_PIPELINE_OPTIONS isn't provided returns nil, so no options are allowed.
+func getAllowedPipelineOptions() []string {
+	var options []string
+	for _, option := range getListEnv(allowedPipelineOptionsKey, "") {
+		options = append(options, strings.TrimLeft(option, "-"))
+	}
+	return options
 }
 
 // createExecutorConfig creates ExecutorConfig that corresponds to specific Apache Beam SDK.
@@ -184,12 +532,28 @@ func createExecutorConfig(apacheBeamSdk pb.Sdk, configPath string) (*ExecutorCon
 			getEnv(SLF4jKey, defaultSLF4j),
 		}, ":")
 		executorConfig.RunArgs[1] += jars
+		if executorConfig.Test != nil {
+			junitPath := getEnv(junitPathKey, defaultJunitPath)
+			executorConfig.Test.CompileArgs = append(executorConfig.Test.CompileArgs, getEnv(beamPathKey, defaultBeamSdkPath)+":"+junitPath)
+			executorConfig.Test.Args = append([]string{"-jar", junitPath}, executorConfig.Test.Args...)
+			executorConfig.Test.Args[3] += jars
+		}
+		if executorConfig.Coverage != nil {
+			executorConfig.Coverage.RunArgs[0] = "-javaagent:" + getEnv(jacocoAgentPathKey, defaultJacocoAgentPath) + executorConfig.Coverage.RunArgs[0]
+			executorConfig.Coverage.ReportArgs = append([]string{"-jar", getEnv(jacocoCliPathKey, defaultJacocoCliPath)}, executorConfig.Coverage.ReportArgs...)
+		}
 	case pb.Sdk_SDK_GO:
 		// Go sdk doesn't need any additional arguments from the config file
 	case pb.Sdk_SDK_PYTHON:
 		// Python sdk doesn't need any additional arguments from the config file
 	case pb.Sdk_SDK_SCIO:
-		return nil, errors.New("not yet supported")
+		// scio jar is an assembly which contains Apache Beam SDK and Scala library
+		executorConfig.CompileArgs = append(executorConfig.CompileArgs, getEnv(scioPathKey, defaultScioPath))
+		executorConfig.RunArgs[1] += strings.Join([]string{
+			getEnv(scioPathKey, defaultScioPath),
+			getEnv(beamRunnerKey, defaultBeamRunner),
+			getEnv(SLF4jKey, defaultSLF4j),
+		}, ":")
 	}
 	return executorConfig, nil
 }
@@ -208,6 +572,131 @@ func getConfigFromJson(configPath string) (*ExecutorConfig, error) {
 	return &executorConfig, err
 }
 
+// getLifeCycleConfigFromJson reads the "life_cycle" section of a json file to LifeCycleConfig.
+// If the section is absent returns nil, so default conventions of the SDK are used.
+func getLifeCycleConfigFromJson(configPath string) (*LifeCycleConfig, error) {
+	file, err := ioutil.ReadFile(configPath)
+	if err != nil {
+		return nil, err
+	}
+	sdkConfig := struct {
+		LifeCycle *LifeCycleConfig `json:"life_cycle"`
+	}{}
+	err = json.Unmarshal(file, &sdkConfig)
+	if err != nil {
+		return nil, err
+	}
+	return sdkConfig.LifeCycle, nil
+}
+
+// getDurationEnv returns an environment variable converted to time.Duration or default value.
+// In case the value couldn't be converted logs it and returns default value.
+func getDurationEnv(key string, defaultValue time.Duration) time.Duration {
+	if value, present := os.LookupEnv(key); present {
+		if converted, err := time.ParseDuration(value); err == nil {
+			return converted
+		}
+		log.Printf("couldn't convert provided %s. Using default %s\n", key, defaultValue)
+	}
+	return defaultValue
+}
+
+// getIntEnv returns an environment variable converted to int or default value.
+// In case the value couldn't be converted logs it and returns default value.
+func getIntEnv(key string, defaultValue int) int {
+	if value, present := os.LookupEnv(key); present {
+		if converted, err := strconv.Atoi(value); err == nil {
+			return converted
+		}
+		log.Printf("couldn't convert provided %s. Using default %d\n", key, defaultValue)
+	}
+	return defaultValue
+}
+
+// getBoolEnv returns an environment variable converted to bool or default value.
+// In case the value couldn't be converted logs it and returns default value.
+func getBoolEnv(key string, defaultValue bool) bool {
+	if value, present := os.LookupEnv(key); present {
+		if converted, err := strconv.ParseBool(value); err == nil {
+			return converted
+		}
+		log.Printf("couldn't convert provided %s. Using default %t\n", key, defaultValue)
+	}
+	return defaultValue
+}
+
+// getListEnv returns a comma-separated environment variable or default value split to the list of not empty values
+func getListEnv(key, defaultValue string) []string {
+	values := make([]string, 0)
+	for _, value := range strings.Split(getEnv(key, defaultValue), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// getRegexpListEnv returns an environment variable with one regular expression per line compiled to the list of regular expressions.
+// In case some regular expression couldn't be compiled logs it and skips it.
+func getRegexpListEnv(key string) []*regexp.Regexp {
+	var patterns []*regexp.Regexp
+	for _, value := range strings.Split(getEnv(key, ""), "\n") {
+		if value = strings.TrimSpace(value); value == "" {
+			continue
+		}
+		pattern, err := regexp.Compile(value)
+		if err != nil {
+			log.Printf("couldn't compile provided %s pattern %s. Skipping it\n", key, value)
+			continue
+		}
+		patterns = append(patterns, pattern)
+	}
+	return patterns
+}
+
+// getStatusDurationsEnv returns a comma-separated environment variable of STATUS=duration pairs converted to the map.
+// In case some pair couldn't be converted logs it and skips it.
+func getStatusDurationsEnv(key string) map[pb.Status]time.Duration {
+	var durations map[pb.Status]time.Duration
+	for _, value := range getListEnv(key, "") {
+		pair := strings.SplitN(value, "=", 2)
+		status, found := pb.Status_value[strings.TrimSpace(pair[0])]
+		if !found || len(pair) != 2 {
+			log.Printf("couldn't convert provided %s pair %s. Skipping it\n", key, value)
+			continue
+		}
+		duration, err := time.ParseDuration(strings.TrimSpace(pair[1]))
+		if err != nil {
+			log.Printf("couldn't convert provided %s pair %s. Skipping it\n", key, value)
+			continue
+		}
+		if durations == nil {
+			durations = make(map[pb.Status]time.Duration)
+		}
+		durations[pb.Status(status)] = duration
+	}
+	return durations
+}
+
+// getSdkPathsEnv returns a comma-separated environment variable of SDK=path pairs converted to the map.
+// In case some pair couldn't be converted logs it and skips it.
+func getSdkPathsEnv(key string) map[pb.Sdk]string {
+	var paths map[pb.Sdk]string
+	for _, value := range getListEnv(key, "") {
+		pair := strings.SplitN(value, "=", 2)
+		sdk, found := pb.Sdk_value[strings.TrimSpace(pair[0])]
+		if !found || pb.Sdk(sdk) == pb.Sdk_SDK_UNSPECIFIED || len(pair) != 2 || strings.TrimSpace(pair[1]) == "" {
+			log.Printf("couldn't convert provided %s pair %s. Skipping it\n", key, value)
+			continue
+		}
+		if paths == nil {
+			paths = make(map[pb.Sdk]string)
+		}
+		paths[pb.Sdk(sdk)] = filepath.Clean(strings.TrimSpace(pair[1]))
+	}
+	return paths
+}
+
 // getEnv returns an environment variable or default value
 func getEnv(key, defaultValue string) string {
 	if value, ok := os.LookupEnv(key); ok {
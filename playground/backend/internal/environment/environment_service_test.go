@@ -24,10 +24,12 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 )
 
 const (
 	javaConfig = "{\n  \"compile_cmd\": \"javac\",\n  \"run_cmd\": \"java\",\n  \"compile_args\": [\"-d\", \"bin\", \"-classpath\"],\n  \"run_args\": [\"-cp\", \"bin:\"]\n}"
+	scioConfig = "{\n  \"compile_cmd\": \"scalac\",\n  \"run_cmd\": \"java\",\n  \"compile_args\": [\"-d\", \"bin\", \"-classpath\"],\n  \"run_args\": [\"-cp\", \"bin:\"]\n}"
 )
 
 func TestMain(m *testing.M) {
@@ -49,6 +51,10 @@ func setup() error {
 	if err != nil {
 		return err
 	}
+	err = os.WriteFile(filepath.Join(configFolderName, playground.Sdk_SDK_SCIO.String()+jsonExt), []byte(scioConfig), 0600)
+	if err != nil {
+		return err
+	}
 	os.Clearenv()
 	return nil
 }
@@ -197,6 +203,33 @@ func Test_getApplicationEnvsFromOsEnvs(t *testing.T) {
 		envsToSet map[string]string
 	}{
 		{name: "working dir is provided", want: NewApplicationEnvs("/app", &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime}, defaultPipelineExecuteTimeout), wantErr: false, envsToSet: map[string]string{workingDirKey: "/app"}},
+		{name: "timeout extension is provided", want: func() *ApplicationEnvs {
+			appEnvs := NewApplicationEnvs("/app", &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime}, defaultPipelineExecuteTimeout)
+			appEnvs.timeoutExtension = 30 * time.Second
+			appEnvs.maxTimeoutExtension = 5 * time.Minute
+			return appEnvs
+		}(), wantErr: false, envsToSet: map[string]string{workingDirKey: "/app", timeoutExtensionKey: "30s", maxTimeoutExtensionKey: "5m"}},
+		{name: "status expiration times are provided", want: func() *ApplicationEnvs {
+			appEnvs := NewApplicationEnvs("/app", &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime}, defaultPipelineExecuteTimeout)
+			appEnvs.statusExpirationTimes = map[playground.Status]time.Duration{playground.Status_STATUS_FINISHED: time.Hour, playground.Status_STATUS_EXECUTING: 5 * time.Minute}
+			return appEnvs
+		}(), wantErr: false, envsToSet: map[string]string{workingDirKey: "/app", statusExpirationTimesKey: "STATUS_FINISHED=1h, STATUS_EXECUTING=5m, STATUS_UNKNOWN=1m"}},
+		{name: "sdk working dirs are provided", want: func() *ApplicationEnvs {
+			appEnvs := NewApplicationEnvs("/app", &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime}, defaultPipelineExecuteTimeout)
+			appEnvs.sdkWorkingDirs = map[playground.Sdk]string{playground.Sdk_SDK_GO: "/mnt/tmpfs", playground.Sdk_SDK_PYTHON: "/mnt/disk"}
+			return appEnvs
+		}(), wantErr: false, envsToSet: map[string]string{workingDirKey: "/app", sdkWorkingDirsKey: "SDK_GO=/mnt/tmpfs/, SDK_PYTHON=/mnt/disk, SDK_UNSPECIFIED=/mnt, SDK_JAVA=, SDK_MOCK=/mnt"}},
+		{name: "cancel envs are provided", want: func() *ApplicationEnvs {
+			appEnvs := NewApplicationEnvs("/app", &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime}, defaultPipelineExecuteTimeout)
+			appEnvs.cancelCheckInterval = 100 * time.Millisecond
+			appEnvs.cancelGracePeriod = 5 * time.Second
+			return appEnvs
+		}(), wantErr: false, envsToSet: map[string]string{workingDirKey: "/app", cancelCheckIntervalKey: "100ms", cancelGracePeriodKey: "5s"}},
+		{name: "metrics address is provided", want: func() *ApplicationEnvs {
+			appEnvs := NewApplicationEnvs("/app", &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime}, defaultPipelineExecuteTimeout)
+			appEnvs.metricsAddress = "localhost:9100"
+			return appEnvs
+		}(), wantErr: false, envsToSet: map[string]string{workingDirKey: "/app", metricsAddressKey: "localhost:9100"}},
 		{name: "working dir isn't provided", want: nil, wantErr: true},
 	}
 	for _, tt := range tests {
@@ -237,6 +270,12 @@ func Test_createExecutorConfig(t *testing.T) {
 			want:    NewExecutorConfig("javac", "java", []string{"-d", "bin", "-classpath", defaultBeamSdkPath}, []string{"-cp", "bin:" + jars}),
 			wantErr: false,
 		},
+		{
+			name:    "create scio executor configuration from json file",
+			args:    args{apacheBeamSdk: playground.Sdk_SDK_SCIO, configPath: filepath.Join(configFolderName, playground.Sdk_SDK_SCIO.String()+jsonExt)},
+			want:    NewExecutorConfig("scalac", "java", []string{"-d", "bin", "-classpath", defaultScioPath}, []string{"-cp", "bin:" + strings.Join([]string{defaultScioPath, defaultBeamRunner, defaultSLF4j}, ":")}),
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
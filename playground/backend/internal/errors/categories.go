@@ -0,0 +1,71 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errors
+
+import (
+	stderrors "errors"
+	"fmt"
+)
+
+var (
+	// ErrCacheMiss is the category of errors caused by a value which doesn't exist in cache
+	ErrCacheMiss = stderrors.New("cache miss")
+	// ErrTypeMismatch is the category of errors caused by a value from cache which has an unexpected type
+	ErrTypeMismatch = stderrors.New("type mismatch")
+	// ErrPanic is the category of errors caused by a panic which is recovered in a goroutine of code processing
+	ErrPanic = stderrors.New("panic")
+	// ErrUnavailableVersion is the category of errors caused by a requested version of Apache Beam which isn't available on the server
+	ErrUnavailableVersion = stderrors.New("unavailable version")
+)
+
+// categoryError is an error of some category which wraps its cause.
+// errors.Is matches both the category and the cause.
+type categoryError struct {
+	category error
+	cause    error
+}
+
+func (e *categoryError) Error() string {
+	return fmt.Sprintf("%s: %s", e.category, e.cause)
+}
+
+func (e *categoryError) Is(target error) bool {
+	return target == e.category
+}
+
+func (e *categoryError) Unwrap() error {
+	return e.cause
+}
+
+// CacheMissError Returns ErrCacheMiss which wraps the cause
+func CacheMissError(cause error) error {
+	return &categoryError{category: ErrCacheMiss, cause: cause}
+}
+
+// TypeMismatchError Returns ErrTypeMismatch which wraps the cause
+func TypeMismatchError(cause error) error {
+	return &categoryError{category: ErrTypeMismatch, cause: cause}
+}
+
+// PanicError Returns ErrPanic which wraps the cause
+func PanicError(cause error) error {
+	return &categoryError{category: ErrPanic, cause: cause}
+}
+
+// UnavailableVersionError Returns ErrUnavailableVersion which wraps the cause
+func UnavailableVersionError(cause error) error {
+	return &categoryError{category: ErrUnavailableVersion, cause: cause}
+}
@@ -30,7 +30,44 @@ func NotFoundError(title string, message string) error {
 	return status.Errorf(codes.NotFound, "%s: %s", title, message)
 }
 
+// WrappedNotFoundError Returns error with NotFound code error and message like "title: message" which wraps err,
+// so err is retrievable via errors.Is and errors.As
+func WrappedNotFoundError(title string, message string, err error) error {
+	return &grpcError{status: status.Newf(codes.NotFound, "%s: %s", title, message), err: err}
+}
+
 // InternalError Returns error with Internal code error and message like "title: message"
 func InternalError(title string, message string) error {
 	return status.Errorf(codes.Internal, "%s: %s", title, message)
 }
+
+// WrappedInternalError Returns error with Internal code error and message like "title: message" which wraps err,
+// so err is retrievable via errors.Is and errors.As
+func WrappedInternalError(title string, message string, err error) error {
+	return &grpcError{status: status.Newf(codes.Internal, "%s: %s", title, message), err: err}
+}
+
+// UnavailableError Returns error with Unavailable code error and message like "title: message"
+func UnavailableError(title string, message string) error {
+	return status.Errorf(codes.Unavailable, "%s: %s", title, message)
+}
+
+// grpcError is an error with gRPC status which wraps another error.
+// gRPC server uses its status as status of the response.
+type grpcError struct {
+	status *status.Status
+	err    error
+}
+
+func (e *grpcError) Error() string {
+	return e.status.Err().Error()
+}
+
+// GRPCStatus returns gRPC status of the error
+func (e *grpcError) GRPCStatus() *status.Status {
+	return e.status
+}
+
+func (e *grpcError) Unwrap() error {
+	return e.err
+}
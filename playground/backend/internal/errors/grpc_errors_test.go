@@ -16,6 +16,9 @@
 package errors
 
 import (
+	stderrors "errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"strings"
 	"testing"
 )
@@ -100,3 +103,84 @@ func TestNotFoundError(t *testing.T) {
 		})
 	}
 }
+
+func TestUnavailableError(t *testing.T) {
+	type args struct {
+		title   string
+		message string
+	}
+	tests := []struct {
+		name     string
+		args     args
+		expected string
+		wantErr  bool
+	}{
+		{name: "TestUnavailableError", args: args{title: "TEST_TITLE", message: "TEST_MESSAGE"},
+			expected: "rpc error: code = Unavailable desc = TEST_TITLE: TEST_MESSAGE", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := UnavailableError(tt.args.title, tt.args.message)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UnavailableError() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !strings.EqualFold(err.Error(), tt.expected) {
+				t.Errorf("UnavailableError() error = %v, wantErr %v", err.Error(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestWrappedNotFoundError(t *testing.T) {
+	cause := stderrors.New("MOCK_CAUSE")
+	tests := []struct {
+		name     string
+		err      error
+		expected string
+		target   error
+	}{
+		{
+			// Test case with calling WrappedNotFoundError with an error of ErrCacheMiss category.
+			// As a result, want to receive gRPC error with NotFound code which wraps ErrCacheMiss and its cause.
+			name:     "wraps cache miss",
+			err:      CacheMissError(cause),
+			expected: "rpc error: code = NotFound desc = TEST_TITLE: TEST_MESSAGE",
+			target:   ErrCacheMiss,
+		},
+		{
+			// Test case with calling WrappedNotFoundError with an error of ErrCacheMiss category.
+			// As a result, want to receive gRPC error with NotFound code which wraps the cause of ErrCacheMiss.
+			name:     "wraps cause of cache miss",
+			err:      CacheMissError(cause),
+			expected: "rpc error: code = NotFound desc = TEST_TITLE: TEST_MESSAGE",
+			target:   cause,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := WrappedNotFoundError("TEST_TITLE", "TEST_MESSAGE", tt.err)
+			if err.Error() != tt.expected {
+				t.Errorf("WrappedNotFoundError() error = %v, want %v", err.Error(), tt.expected)
+			}
+			if status.Code(err) != codes.NotFound {
+				t.Errorf("WrappedNotFoundError() code = %v, want %v", status.Code(err), codes.NotFound)
+			}
+			if !stderrors.Is(err, tt.target) {
+				t.Errorf("WrappedNotFoundError() error = %v, doesn't wrap %v", err, tt.target)
+			}
+			if stderrors.Is(err, ErrTypeMismatch) {
+				t.Errorf("WrappedNotFoundError() error = %v, wraps %v", err, ErrTypeMismatch)
+			}
+		})
+	}
+}
+
+func TestWrappedInternalError(t *testing.T) {
+	err := WrappedInternalError("TEST_TITLE", "TEST_MESSAGE", TypeMismatchError(stderrors.New("MOCK_CAUSE")))
+	if status.Code(err) != codes.Internal {
+		t.Errorf("WrappedInternalError() code = %v, want %v", status.Code(err), codes.Internal)
+	}
+	if !stderrors.Is(err, ErrTypeMismatch) {
+		t.Errorf("WrappedInternalError() error = %v, doesn't wrap %v", err, ErrTypeMismatch)
+	}
+}
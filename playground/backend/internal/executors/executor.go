@@ -16,27 +16,33 @@
 package executors
 
 import (
+	"beam.apache.org/playground/backend/internal/errors"
 	"beam.apache.org/playground/backend/internal/preparators"
 	"beam.apache.org/playground/backend/internal/validators"
 	"context"
+	"fmt"
 	"os/exec"
 	"sync"
 )
 
 //CmdConfiguration for base cmd code execution
 type CmdConfiguration struct {
-	fileName    string
-	workingDir  string
-	commandName string
-	commandArgs []string
+	fileName        string
+	workingDir      string
+	commandName     string
+	commandArgs     []string
+	pipelineArgs    []string
+	additionalFiles []string
 }
 
 // Executor struct for all sdks (Java/Python/Go/SCIO)
 type Executor struct {
-	compileArgs CmdConfiguration
-	runArgs     CmdConfiguration
-	validators  []validators.Validator
-	preparators []preparators.Preparator
+	compileArgs  CmdConfiguration
+	runArgs      CmdConfiguration
+	testArgs     CmdConfiguration
+	coverageArgs CmdConfiguration
+	validators   []validators.Validator
+	preparators  []preparators.Preparator
 }
 
 // Validate returns the function that applies all validators of executor
@@ -48,6 +54,11 @@ func (ex *Executor) Validate() func(chan bool, chan error) {
 			wg.Add(1)
 			go func(validationErrors chan error, validator validators.Validator) {
 				defer wg.Done()
+				defer func() {
+					if r := recover(); r != nil {
+						validationErrors <- errors.PanicError(fmt.Errorf("validator panicked: %v", r))
+					}
+				}()
 				err := validator.Validator(validator.Args...)
 				if err != nil {
 					validationErrors <- err
@@ -81,19 +92,42 @@ func (ex *Executor) Prepare() func(chan bool, chan error) {
 }
 
 // Compile prepares the Cmd for code compilation
+// Additional source files are compiled together with the file
 // Returns Cmd instance
 func (ex *Executor) Compile(ctx context.Context) *exec.Cmd {
-	args := append(ex.compileArgs.commandArgs, ex.compileArgs.fileName)
+	args := append(append([]string{}, ex.compileArgs.commandArgs...), ex.compileArgs.additionalFiles...)
+	args = append(args, ex.compileArgs.fileName)
 	cmd := exec.CommandContext(ctx, ex.compileArgs.commandName, args...)
 	cmd.Dir = ex.compileArgs.workingDir
 	return cmd
 }
 
 // Run prepares the Cmd for execution of the code
+// Pipeline options are passed after the executable file name
 // Returns Cmd instance
 func (ex *Executor) Run(ctx context.Context) *exec.Cmd {
-	args := append(ex.runArgs.commandArgs, ex.runArgs.fileName)
+	args := append([]string{}, ex.runArgs.commandArgs...)
+	if ex.runArgs.fileName != "" {
+		args = append(args, ex.runArgs.fileName)
+	}
+	args = append(args, ex.runArgs.pipelineArgs...)
 	cmd := exec.CommandContext(ctx, ex.runArgs.commandName, args...)
 	cmd.Dir = ex.runArgs.workingDir
 	return cmd
 }
+
+// Test prepares the Cmd for running tests against the code
+// Returns Cmd instance
+func (ex *Executor) Test(ctx context.Context) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, ex.testArgs.commandName, ex.testArgs.commandArgs...)
+	cmd.Dir = ex.testArgs.workingDir
+	return cmd
+}
+
+// Coverage prepares the Cmd for printing the coverage summary after the code is run under a coverage tool
+// Returns Cmd instance
+func (ex *Executor) Coverage(ctx context.Context) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, ex.coverageArgs.commandName, ex.coverageArgs.commandArgs...)
+	cmd.Dir = ex.coverageArgs.workingDir
+	return cmd
+}
@@ -37,6 +37,16 @@ type RunBuilder struct {
 	ExecutorBuilder
 }
 
+//TestBuilder facet of ExecutorBuilder
+type TestBuilder struct {
+	ExecutorBuilder
+}
+
+//CoverageBuilder facet of ExecutorBuilder
+type CoverageBuilder struct {
+	ExecutorBuilder
+}
+
 //ValidatorBuilder facet of ExecutorBuilder
 type ValidatorBuilder struct {
 	ExecutorBuilder
@@ -62,6 +72,16 @@ func (b *ExecutorBuilder) WithRunner() *RunBuilder {
 	return &RunBuilder{*b}
 }
 
+// WithTestRunner - Lives chains to type *ExecutorBuilder and returns a *TestBuilder
+func (b *ExecutorBuilder) WithTestRunner() *TestBuilder {
+	return &TestBuilder{*b}
+}
+
+// WithCoverage - Lives chains to type *ExecutorBuilder and returns a *CoverageBuilder
+func (b *ExecutorBuilder) WithCoverage() *CoverageBuilder {
+	return &CoverageBuilder{*b}
+}
+
 // WithValidator - Lives chains to type *ExecutorBuilder and returns a *CompileBuilder
 func (b *ExecutorBuilder) WithValidator() *ValidatorBuilder {
 	return &ValidatorBuilder{*b}
@@ -96,6 +116,14 @@ func (b *CompileBuilder) WithFileName(fileName string) *CompileBuilder {
 	return b
 }
 
+//WithAdditionalFileNames adds names of source files which are compiled together with the file to executor
+func (b *CompileBuilder) WithAdditionalFileNames(fileNames []string) *CompileBuilder {
+	b.actions = append(b.actions, func(e *Executor) {
+		e.compileArgs.additionalFiles = fileNames
+	})
+	return b
+}
+
 //WithWorkingDir adds dir path to executor
 func (b *CompileBuilder) WithWorkingDir(dir string) *CompileBuilder {
 	b.actions = append(b.actions, func(e *Executor) {
@@ -128,6 +156,14 @@ func (b *RunBuilder) WithExecutableFileName(name string) *RunBuilder {
 	return b
 }
 
+//WithPipelineArgs adds pipeline options which are passed after the executable file name to executor
+func (b *RunBuilder) WithPipelineArgs(pipelineArgs []string) *RunBuilder {
+	b.actions = append(b.actions, func(e *Executor) {
+		e.runArgs.pipelineArgs = pipelineArgs
+	})
+	return b
+}
+
 //WithGraphOutput adds the need of graph output to executor
 func (b *RunBuilder) WithGraphOutput() *RunBuilder {
 	b.actions = append(b.actions, func(e *Executor) {
@@ -144,6 +180,54 @@ func (b *RunBuilder) WithWorkingDir(dir string) *RunBuilder {
 	return b
 }
 
+//WithCommand adds test command to executor
+func (b *TestBuilder) WithCommand(testCmd string) *TestBuilder {
+	b.actions = append(b.actions, func(e *Executor) {
+		e.testArgs.commandName = testCmd
+	})
+	return b
+}
+
+//WithArgs adds test args to executor
+func (b *TestBuilder) WithArgs(testArgs []string) *TestBuilder {
+	b.actions = append(b.actions, func(e *Executor) {
+		e.testArgs.commandArgs = testArgs
+	})
+	return b
+}
+
+//WithWorkingDir adds dir path to executor
+func (b *TestBuilder) WithWorkingDir(dir string) *TestBuilder {
+	b.actions = append(b.actions, func(e *Executor) {
+		e.testArgs.workingDir = dir
+	})
+	return b
+}
+
+//WithCommand adds coverage report command to executor
+func (b *CoverageBuilder) WithCommand(reportCmd string) *CoverageBuilder {
+	b.actions = append(b.actions, func(e *Executor) {
+		e.coverageArgs.commandName = reportCmd
+	})
+	return b
+}
+
+//WithArgs adds coverage report args to executor
+func (b *CoverageBuilder) WithArgs(reportArgs []string) *CoverageBuilder {
+	b.actions = append(b.actions, func(e *Executor) {
+		e.coverageArgs.commandArgs = reportArgs
+	})
+	return b
+}
+
+//WithWorkingDir adds dir path to executor
+func (b *CoverageBuilder) WithWorkingDir(dir string) *CoverageBuilder {
+	b.actions = append(b.actions, func(e *Executor) {
+		e.coverageArgs.workingDir = dir
+	})
+	return b
+}
+
 //WithSdkValidators sets validators to executor
 func (b *ValidatorBuilder) WithSdkValidators(validators *[]validators.Validator) *ValidatorBuilder {
 	b.actions = append(b.actions, func(e *Executor) {
@@ -152,6 +236,14 @@ func (b *ValidatorBuilder) WithSdkValidators(validators *[]validators.Validator)
 	return b
 }
 
+//WithExtraValidators adds validators to validators which are already set to executor
+func (b *ValidatorBuilder) WithExtraValidators(validators *[]validators.Validator) *ValidatorBuilder {
+	b.actions = append(b.actions, func(e *Executor) {
+		e.validators = append(e.validators, *validators...)
+	})
+	return b
+}
+
 //WithSdkPreparators sets preparators to executor
 func (b *PreparatorBuilder) WithSdkPreparators(preparators *[]preparators.Preparator) *PreparatorBuilder {
 	b.actions = append(b.actions, func(e *Executor) {
@@ -160,6 +252,14 @@ func (b *PreparatorBuilder) WithSdkPreparators(preparators *[]preparators.Prepar
 	return b
 }
 
+//WithExtraPreparators adds preparators to preparators which are already set to executor
+func (b *PreparatorBuilder) WithExtraPreparators(preparators *[]preparators.Preparator) *PreparatorBuilder {
+	b.actions = append(b.actions, func(e *Executor) {
+		e.preparators = append(e.preparators, *preparators...)
+	})
+	return b
+}
+
 //Build builds the executor object
 func (b *ExecutorBuilder) Build() Executor {
 	executor := Executor{}
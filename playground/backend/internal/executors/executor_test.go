@@ -18,9 +18,11 @@ package executors
 import (
 	pb "beam.apache.org/playground/backend/internal/api/v1"
 	"beam.apache.org/playground/backend/internal/environment"
+	"beam.apache.org/playground/backend/internal/errors"
 	"beam.apache.org/playground/backend/internal/preparators"
 	"beam.apache.org/playground/backend/internal/validators"
 	"context"
+	stderrors "errors"
 	"os"
 	"os/exec"
 	"reflect"
@@ -61,6 +63,52 @@ func BaseExecutorBuilder(envs environment.BeamEnvs, workingDir string, filePath
 	return &builder
 }
 
+func TestExecutor_Validate(t *testing.T) {
+	tests := []struct {
+		name       string
+		validators []validators.Validator
+		want       bool
+		wantErr    error
+	}{
+		{
+			// Test case with calling Validate method with the validator which passes.
+			// As a result, want to receive true without errors.
+			name: "validator passes",
+			validators: []validators.Validator{{Validator: func(args ...interface{}) error {
+				return nil
+			}}},
+			want: true,
+		},
+		{
+			// Test case with calling Validate method with the validator which dereferences nil.
+			// As a result, want to receive false with errors.ErrPanic instead of crashing.
+			name: "validator panics",
+			validators: []validators.Validator{{Validator: func(args ...interface{}) error {
+				var validator *validators.Validator
+				return validator.Validator(args...)
+			}}},
+			want:    false,
+			wantErr: errors.ErrPanic,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ex := &Executor{validators: tt.validators}
+			successChannel := make(chan bool, 1)
+			errorChannel := make(chan error, 1)
+			ex.Validate()(successChannel, errorChannel)
+			if got := <-successChannel; got != tt.want {
+				t.Errorf("Validate() got = %v, want %v", got, tt.want)
+			}
+			if tt.wantErr != nil {
+				if err := <-errorChannel; !stderrors.Is(err, tt.wantErr) {
+					t.Errorf("Validate() error = %v, want %v", err, tt.wantErr)
+				}
+			}
+		})
+	}
+}
+
 func TestExecutor_Compile(t *testing.T) {
 	type fields struct {
 		compileArgs CmdConfiguration
@@ -96,6 +144,22 @@ func TestExecutor_Compile(t *testing.T) {
 				ProcessState: nil,
 			},
 		},
+		{
+			name: "TestCompileWithAdditionalFiles",
+			fields: fields{
+				compileArgs: CmdConfiguration{
+					fileName:        "filePath",
+					additionalFiles: []string{"helperPath"},
+					workingDir:      "./",
+					commandName:     "testCommand",
+					commandArgs:     []string{"-d", "bin"},
+				},
+			},
+			want: &exec.Cmd{
+				Path: "testCommand",
+				Args: []string{"javac", "-d", "bin", "helperPath", "filePath"},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -148,6 +212,24 @@ func TestExecutor_Run(t *testing.T) {
 				ProcessState: nil,
 			},
 		},
+		{
+			// Test case with calling Run method with pipeline options.
+			// As a result, want to receive the command where pipeline options follow the executable file name.
+			name: "TestRunWithPipelineArgs",
+			fields: fields{
+				runArgs: CmdConfiguration{
+					fileName:     "main.py",
+					workingDir:   "./",
+					commandName:  "testCommand",
+					commandArgs:  []string{"-u"},
+					pipelineArgs: []string{"--streaming"},
+				},
+			},
+			want: &exec.Cmd{
+				Path: "testCommand",
+				Args: []string{"testCommand", "-u", "main.py", "--streaming"},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -163,6 +245,78 @@ func TestExecutor_Run(t *testing.T) {
 	}
 }
 
+func TestExecutor_Test(t *testing.T) {
+	type fields struct {
+		testArgs CmdConfiguration
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   *exec.Cmd
+	}{
+		{
+			name: "TestTest",
+			fields: fields{
+				testArgs: CmdConfiguration{
+					workingDir:  "./",
+					commandName: "testCommand",
+					commandArgs: []string{"test", "-v", "./src"},
+				},
+			},
+			want: &exec.Cmd{
+				Path: "testCommand",
+				Args: []string{"go", "test", "-v", "./src"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ex := &Executor{
+				testArgs: tt.fields.testArgs,
+			}
+			if got := ex.Test(context.Background()); !reflect.DeepEqual(got.String(), tt.want.String()) {
+				t.Errorf("WithTestRunner() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExecutor_Coverage(t *testing.T) {
+	type fields struct {
+		coverageArgs CmdConfiguration
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   *exec.Cmd
+	}{
+		{
+			name: "TestCoverage",
+			fields: fields{
+				coverageArgs: CmdConfiguration{
+					workingDir:  "./",
+					commandName: "reportCommand",
+					commandArgs: []string{"-m", "coverage", "report"},
+				},
+			},
+			want: &exec.Cmd{
+				Path: "reportCommand",
+				Args: []string{"python3", "-m", "coverage", "report"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ex := &Executor{
+				coverageArgs: tt.fields.coverageArgs,
+			}
+			if got := ex.Coverage(context.Background()); !reflect.DeepEqual(got.String(), tt.want.String()) {
+				t.Errorf("WithCoverage() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestBaseExecutorBuilder(t *testing.T) {
 	validatorsFuncs := validators.GetJavaValidators("filePath")
 	preparatorsFuncs := preparators.GetJavaPreparators("filePath")
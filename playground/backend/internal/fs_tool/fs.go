@@ -17,16 +17,26 @@ package fs_tool
 
 import (
 	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"beam.apache.org/playground/backend/internal/environment"
+	"beam.apache.org/playground/backend/internal/streaming"
+	"errors"
 	"fmt"
 	"github.com/google/uuid"
 	"io"
 	"io/fs"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
 )
 
 const (
-	fileMode = 0600
+	fileMode                 = 0600
+	crashReportFileExtension = ".crash"
+	wasmFileExtension        = ".wasm"
+	datasetsFolderName       = "datasets"
 )
 
 // Folder contains names of folders with executable and compiled files.
@@ -52,20 +62,118 @@ type LifeCycle struct {
 	Extension      Extension
 	ExecutableName func(uuid.UUID, string) (string, error)
 	pipelineId     uuid.UUID
+	testFileNames  []string               //files with tests which should be run against the code
+	extraFileNames []string               //additional source files which should be compiled and run together with the code
+	libraryPaths   []string               //jars of libraries which should be added to the classpath
+	outputCharset  string                 //charset of the run output (empty if it is UTF-8)
+	clientTimeout  time.Duration          //timeout of code processing requested by the client (zero if it isn't requested)
+	stdin          []streaming.StdinChunk //scripted stdin of the run step
+	compileOnly    bool                   //whether code processing is stopped once the code is compiled
+	pipelineArgs   []string               //pipeline options which are passed to the run command
+	beamVersion    string                 //version of Apache Beam requested by the client (empty if the default version is used)
+	runEnv         map[string]string      //environment variables of the run step requested by the client
+	wasm           bool                   //whether the code is compiled to WebAssembly instead of running it
+	executeTimeout time.Duration          //timeout of code processing desired by the client instead of the default one (zero if it isn't requested)
+	dependencyName string                 //artifact of a library uploaded by the client (empty if it isn't uploaded)
+	datasetName    string                 //name of the sample dataset requested by the client (empty if it isn't requested)
+	datasetPath    string                 //path to the file of the sample dataset in the catalog of the server
+}
+
+// dependencyFileExtensions contains extensions of artifacts of libraries which could be uploaded by the client for each SDK
+var dependencyFileExtensions = map[pb.Sdk]string{
+	pb.Sdk_SDK_JAVA:   ".jar",
+	pb.Sdk_SDK_PYTHON: ".whl",
+}
+
+// defaultLifeCycles contains constructors of LifeCycle with default conventions of files for each supported SDK.
+var defaultLifeCycles = map[pb.Sdk]func(uuid.UUID, string) *LifeCycle{
+	pb.Sdk_SDK_JAVA:   newJavaLifeCycle,
+	pb.Sdk_SDK_GO:     newGoLifeCycle,
+	pb.Sdk_SDK_PYTHON: newPythonLifeCycle,
+	pb.Sdk_SDK_SCIO:   newScioLifeCycle,
+}
+
+// entrypointResolvers contains functions which find the name that should be executed in the folder with executable files
+// compiled from the file with code with the given name, by the name of the strategy.
+var entrypointResolvers = map[string]func(string, string) (string, error){
+	javaClassEntrypointResolver:   executableName,
+	scalaObjectEntrypointResolver: scalaObjectName,
 }
 
 // NewLifeCycle returns a corresponding LifeCycle depending on the given SDK.
 // workingDir should be existed and be prepared to create/delete/modify folders into him.
 func NewLifeCycle(sdk pb.Sdk, pipelineId uuid.UUID, workingDir string) (*LifeCycle, error) {
-	switch sdk {
-	case pb.Sdk_SDK_JAVA:
-		return newJavaLifeCycle(pipelineId, workingDir), nil
-	case pb.Sdk_SDK_GO:
-		return newGoLifeCycle(pipelineId, workingDir), nil
-	case pb.Sdk_SDK_PYTHON:
-		return newPythonLifeCycle(pipelineId, workingDir), nil
-	default:
-		return nil, fmt.Errorf("%s isn't supported now", sdk)
+	return NewLifeCycleWithConfig(sdk, nil, pipelineId, workingDir)
+}
+
+// NewLifeCycleWithConfig returns LifeCycle which follows the given SDK's conventions of files.
+// In case config is nil, returns LifeCycle with default conventions of the given SDK.
+// workingDir should be existed and be prepared to create/delete/modify folders into him.
+func NewLifeCycleWithConfig(sdk pb.Sdk, config *environment.LifeCycleConfig, pipelineId uuid.UUID, workingDir string) (*LifeCycle, error) {
+	return NewLifeCycleWithFolderName(sdk, config, pipelineId, workingDir, PipelineIdFolderName)
+}
+
+// NewLifeCycleWithFolderName returns LifeCycle which follows the given SDK's conventions of files
+// and processes code in the folder named by folderName ({workingDir}/executable_files/{folderName(pipelineId)}).
+// It allows to use deterministic folder names instead of the pipelineId, e.g. in tests.
+// In case config is nil, returns LifeCycle with default conventions of the given SDK.
+func NewLifeCycleWithFolderName(sdk pb.Sdk, config *environment.LifeCycleConfig, pipelineId uuid.UUID, workingDir string, folderName FolderName) (*LifeCycle, error) {
+	lc, err := newLifeCycleWithConfig(sdk, config, pipelineId, workingDir)
+	if err != nil {
+		return nil, err
+	}
+	lc.setBaseFolder(filepath.Join(workingDir, baseFileFolder, folderName(pipelineId)))
+	return lc, nil
+}
+
+// newLifeCycleWithConfig returns LifeCycle which follows the given SDK's conventions of files
+// and processes code in the folder named by the pipelineId.
+func newLifeCycleWithConfig(sdk pb.Sdk, config *environment.LifeCycleConfig, pipelineId uuid.UUID, workingDir string) (*LifeCycle, error) {
+	if config == nil {
+		newLifeCycle, ok := defaultLifeCycles[sdk]
+		if !ok {
+			return nil, fmt.Errorf("%s isn't supported now", sdk)
+		}
+		return newLifeCycle(pipelineId, workingDir), nil
+	}
+
+	var lc *LifeCycle
+	if config.Compiled {
+		lc = newCompilingLifeCycle(pipelineId, workingDir, config.SourceFileExtension, config.ExecutableFileExtension)
+	} else {
+		lc = newInterpretedLifeCycle(pipelineId, workingDir, config.SourceFileExtension)
+	}
+	if config.EntrypointResolver != "" {
+		resolver, ok := entrypointResolvers[config.EntrypointResolver]
+		if !ok {
+			return nil, fmt.Errorf("unknown entrypoint resolver: %s", config.EntrypointResolver)
+		}
+		lc.setEntrypointResolver(resolver)
+	}
+	return lc, nil
+}
+
+// setEntrypointResolver sets ExecutableName which finds the name that should be executed
+// in the folder with executable files using the given resolver.
+func (l *LifeCycle) setEntrypointResolver(resolver func(string, string) (string, error)) {
+	l.ExecutableName = func(uuid.UUID, string) (string, error) {
+		return resolver(l.Folder.ExecutableFileFolder, l.pipelineId.String()+l.Extension.SourceFileExtension)
+	}
+}
+
+// setBaseFolder moves all folders which will be used for code execution into the given base folder.
+func (l *LifeCycle) setBaseFolder(baseFolder string) {
+	oldBaseFolder := l.Folder.BaseFolder
+	rebase := func(folder string) string {
+		return filepath.Join(baseFolder, strings.TrimPrefix(folder, oldBaseFolder))
+	}
+	for i, folder := range l.folderGlobs {
+		l.folderGlobs[i] = rebase(folder)
+	}
+	l.Folder = Folder{
+		BaseFolder:           rebase(l.Folder.BaseFolder),
+		SourceFileFolder:     rebase(l.Folder.SourceFileFolder),
+		ExecutableFileFolder: rebase(l.Folder.ExecutableFileFolder),
 	}
 }
 
@@ -91,6 +199,53 @@ func (l *LifeCycle) DeleteFolders() error {
 	return nil
 }
 
+// DeleteFoldersWithRetries deletes all previously provisioned folders.
+// In case files are still held by a killed process (device or resource busy, directory not empty)
+// retries the deletion up to retries times waiting delay between attempts.
+func (l *LifeCycle) DeleteFoldersWithRetries(retries int, delay time.Duration) error {
+	err := l.DeleteFolders()
+	for attempt := 0; attempt < retries && isBusyError(err); attempt++ {
+		time.Sleep(delay)
+		err = l.DeleteFolders()
+	}
+	return err
+}
+
+// isBusyError checks if the error is caused by files which are still held by some process
+func isBusyError(err error) bool {
+	return errors.Is(err, syscall.EBUSY) || errors.Is(err, syscall.ENOTEMPTY)
+}
+
+// StalePipelineFolders returns paths of folders of pipelines in workingDir ({workingDir}/executable_files/{pipelineId})
+// by pipelineIds which haven't been modified for olderThan. Folders which aren't named by the pipelineId are skipped.
+// In case there is no folder with executable files returns an empty map.
+func StalePipelineFolders(workingDir string, olderThan time.Duration) (map[uuid.UUID]string, error) {
+	folders := make(map[uuid.UUID]string)
+	baseFolder := filepath.Join(workingDir, baseFileFolder)
+	entries, err := os.ReadDir(baseFolder)
+	if errors.Is(err, fs.ErrNotExist) {
+		return folders, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		pipelineId, err := uuid.Parse(entry.Name())
+		if err != nil {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || time.Since(info.ModTime()) < olderThan {
+			continue
+		}
+		folders[pipelineId] = filepath.Join(baseFolder, entry.Name())
+	}
+	return folders, nil
+}
+
 // CreateSourceCodeFile creates an executable file (i.e. file.{sourceFileExtension}).
 func (l *LifeCycle) CreateSourceCodeFile(code string) (string, error) {
 	if _, err := os.Stat(l.Folder.SourceFileFolder); os.IsNotExist(err) {
@@ -106,6 +261,276 @@ func (l *LifeCycle) CreateSourceCodeFile(code string) (string, error) {
 	return fileName, nil
 }
 
+// CreateTestFiles creates files with tests next to the file with code (i.e. {sourceFileFolder}/{fileName}).
+// files contains names of files and their code, names shouldn't contain path separators.
+func (l *LifeCycle) CreateTestFiles(files map[string]string) error {
+	if _, err := os.Stat(l.Folder.SourceFileFolder); os.IsNotExist(err) {
+		return err
+	}
+
+	fileNames := make([]string, 0, len(files))
+	for fileName := range files {
+		if fileName == "" || filepath.Base(fileName) != fileName {
+			return fmt.Errorf("incorrect name of the file with tests: %s", fileName)
+		}
+		fileNames = append(fileNames, fileName)
+	}
+	sort.Strings(fileNames)
+	for _, fileName := range fileNames {
+		err := os.WriteFile(filepath.Join(l.Folder.SourceFileFolder, fileName), []byte(files[fileName]), fileMode)
+		if err != nil {
+			return err
+		}
+	}
+	l.testFileNames = fileNames
+	return nil
+}
+
+// CreateAdditionalSourceFiles creates additional source files next to the file with code (i.e. {sourceFileFolder}/{fileName}),
+// which are compiled and run together with the code. The file with code remains the entrypoint.
+// files contains names of files and their code, names shouldn't contain path separators and should have the source file extension.
+func (l *LifeCycle) CreateAdditionalSourceFiles(files map[string]string) error {
+	if _, err := os.Stat(l.Folder.SourceFileFolder); os.IsNotExist(err) {
+		return err
+	}
+
+	mainFileName := l.pipelineId.String() + l.Extension.SourceFileExtension
+	fileNames := make([]string, 0, len(files))
+	for fileName := range files {
+		if fileName == "" || filepath.Base(fileName) != fileName || fileName == mainFileName {
+			return fmt.Errorf("incorrect name of the source file: %s", fileName)
+		}
+		if filepath.Ext(fileName) != l.Extension.SourceFileExtension {
+			return fmt.Errorf("source file %s should have extension %s", fileName, l.Extension.SourceFileExtension)
+		}
+		fileNames = append(fileNames, fileName)
+	}
+	sort.Strings(fileNames)
+	for _, fileName := range fileNames {
+		err := os.WriteFile(filepath.Join(l.Folder.SourceFileFolder, fileName), []byte(files[fileName]), fileMode)
+		if err != nil {
+			return err
+		}
+	}
+	l.extraFileNames = fileNames
+	return nil
+}
+
+// CreateDependencyFile creates the artifact of a library uploaded by the client in the base folder (i.e. {baseFolder}/{fileName}),
+// which is used to compile and run the code. fileName shouldn't contain path separators and should have the extension
+// of artifacts of the sdk, e.g. .jar for the Java SDK.
+func (l *LifeCycle) CreateDependencyFile(sdk pb.Sdk, fileName string, content []byte) error {
+	extension, ok := dependencyFileExtensions[sdk]
+	if !ok {
+		return fmt.Errorf("dependencies aren't supported by %s", sdk)
+	}
+	if fileName == "" || filepath.Base(fileName) != fileName {
+		return fmt.Errorf("incorrect name of the dependency: %s", fileName)
+	}
+	if filepath.Ext(fileName) != extension {
+		return fmt.Errorf("dependency %s should have extension %s", fileName, extension)
+	}
+	if err := os.WriteFile(filepath.Join(l.Folder.BaseFolder, fileName), content, fileMode); err != nil {
+		return err
+	}
+	l.dependencyName = fileName
+	return nil
+}
+
+// GetAbsoluteDependencyFilePath returns absolute filepath to the artifact created by CreateDependencyFile.
+// In case the artifact wasn't created returns an empty string.
+func (l *LifeCycle) GetAbsoluteDependencyFilePath() string {
+	if l.dependencyName == "" {
+		return ""
+	}
+	absoluteFilePath, _ := filepath.Abs(filepath.Join(l.Folder.BaseFolder, l.dependencyName))
+	return absoluteFilePath
+}
+
+// SetSampleDataset sets the name of the sample dataset and the path to its file in the catalog of the server,
+// the file is copied to the datasets folder by CreateSampleDatasetFile.
+func (l *LifeCycle) SetSampleDataset(name, path string) {
+	l.datasetName = name
+	l.datasetPath = path
+}
+
+// GetSampleDatasetName returns the name of the sample dataset set by SetSampleDataset (empty if it isn't set)
+func (l *LifeCycle) GetSampleDatasetName() string {
+	return l.datasetName
+}
+
+// CreateSampleDatasetFile copies the file of the sample dataset set by SetSampleDataset to the datasets folder
+// of the base folder (i.e. {baseFolder}/datasets/{name}), so it is deleted with other folders by DeleteFolders.
+// In case the dataset isn't set does nothing.
+func (l *LifeCycle) CreateSampleDatasetFile() error {
+	if l.datasetName == "" {
+		return nil
+	}
+	source, err := os.Open(l.datasetPath)
+	if err != nil {
+		return err
+	}
+	defer source.Close()
+	datasetFolder := filepath.Join(l.Folder.BaseFolder, datasetsFolderName)
+	if err = os.MkdirAll(datasetFolder, fs.ModePerm); err != nil {
+		return err
+	}
+	destination, err := os.OpenFile(filepath.Join(datasetFolder, l.datasetName), os.O_RDWR|os.O_CREATE|os.O_TRUNC, fileMode)
+	if err != nil {
+		return err
+	}
+	defer destination.Close()
+	_, err = io.Copy(destination, source)
+	return err
+}
+
+// GetAbsoluteSampleDatasetFilePath returns absolute filepath to the copy of the sample dataset created by CreateSampleDatasetFile.
+// In case the dataset isn't set returns an empty string.
+func (l *LifeCycle) GetAbsoluteSampleDatasetFilePath() string {
+	if l.datasetName == "" {
+		return ""
+	}
+	absoluteFilePath, _ := filepath.Abs(filepath.Join(l.Folder.BaseFolder, datasetsFolderName, l.datasetName))
+	return absoluteFilePath
+}
+
+// GetAbsoluteAdditionalSourceFilePaths returns absolute filepaths to additional source files created by CreateAdditionalSourceFiles.
+// In case additional source files weren't created returns an empty slice.
+func (l *LifeCycle) GetAbsoluteAdditionalSourceFilePaths() []string {
+	filePaths := make([]string, 0, len(l.extraFileNames))
+	for _, fileName := range l.extraFileNames {
+		absoluteFilePath, _ := filepath.Abs(filepath.Join(l.Folder.SourceFileFolder, fileName))
+		filePaths = append(filePaths, absoluteFilePath)
+	}
+	return filePaths
+}
+
+// GetAbsoluteSourceFilePaths returns absolute filepaths to all source files of the code:
+// the file with code which is the entrypoint goes first, then additional source files.
+func (l *LifeCycle) GetAbsoluteSourceFilePaths() []string {
+	return append([]string{l.GetAbsoluteSourceFilePath()}, l.GetAbsoluteAdditionalSourceFilePaths()...)
+}
+
+// GetAbsoluteTestFilePaths returns absolute filepaths to files with tests created by CreateTestFiles.
+// In case files with tests weren't created returns an empty slice.
+func (l *LifeCycle) GetAbsoluteTestFilePaths() []string {
+	filePaths := make([]string, 0, len(l.testFileNames))
+	for _, fileName := range l.testFileNames {
+		absoluteFilePath, _ := filepath.Abs(filepath.Join(l.Folder.SourceFileFolder, fileName))
+		filePaths = append(filePaths, absoluteFilePath)
+	}
+	return filePaths
+}
+
+// SetClasspathLibraries sets paths to jars of libraries which should be added to the classpath to process code.
+func (l *LifeCycle) SetClasspathLibraries(libraryPaths []string) {
+	l.libraryPaths = libraryPaths
+}
+
+// GetClasspathLibraries returns paths to jars of libraries set by SetClasspathLibraries.
+// In case libraries weren't set returns an empty slice.
+func (l *LifeCycle) GetClasspathLibraries() []string {
+	return append([]string{}, l.libraryPaths...)
+}
+
+// SetOutputCharset sets the charset of the output of the code which should be transcoded to UTF-8.
+func (l *LifeCycle) SetOutputCharset(charset string) {
+	l.outputCharset = charset
+}
+
+// GetOutputCharset returns the charset of the output of the code set by SetOutputCharset.
+// In case the charset wasn't set returns an empty string, so the output is in UTF-8.
+func (l *LifeCycle) GetOutputCharset() string {
+	return l.outputCharset
+}
+
+// SetClientTimeout sets the timeout of code processing requested by the client.
+func (l *LifeCycle) SetClientTimeout(timeout time.Duration) {
+	l.clientTimeout = timeout
+}
+
+// GetClientTimeout returns the timeout of code processing set by SetClientTimeout.
+// In case the timeout wasn't set returns zero, so only the timeout of the server is used.
+func (l *LifeCycle) GetClientTimeout() time.Duration {
+	return l.clientTimeout
+}
+
+// SetExecuteTimeout sets the timeout of code processing desired by the client instead of the default timeout of the server.
+func (l *LifeCycle) SetExecuteTimeout(timeout time.Duration) {
+	l.executeTimeout = timeout
+}
+
+// GetExecuteTimeout returns the timeout of code processing set by SetExecuteTimeout.
+// In case the timeout wasn't set returns zero, so the default timeout of the server is used.
+func (l *LifeCycle) GetExecuteTimeout() time.Duration {
+	return l.executeTimeout
+}
+
+// SetCompileOnly sets whether code processing is stopped once the code is compiled instead of running it.
+func (l *LifeCycle) SetCompileOnly(compileOnly bool) {
+	l.compileOnly = compileOnly
+}
+
+// IsCompileOnly returns whether code processing is stopped once the code is compiled, set by SetCompileOnly.
+// In case it wasn't set returns false, so the code is run.
+func (l *LifeCycle) IsCompileOnly() bool {
+	return l.compileOnly
+}
+
+// SetWasm sets whether the code is compiled to WebAssembly to run it in the browser instead of running it on the server.
+func (l *LifeCycle) SetWasm(wasm bool) {
+	l.wasm = wasm
+}
+
+// IsWasm returns whether the code is compiled to WebAssembly, set by SetWasm.
+// In case it wasn't set returns false, so the code is compiled for the server.
+func (l *LifeCycle) IsWasm() bool {
+	return l.wasm
+}
+
+// SetPipelineArgs sets pipeline options which are passed to the run command after the executable file.
+func (l *LifeCycle) SetPipelineArgs(args []string) {
+	l.pipelineArgs = args
+}
+
+// GetPipelineArgs returns pipeline options which are passed to the run command, set by SetPipelineArgs.
+// In case they weren't set returns nil, so the code is run with its default options.
+func (l *LifeCycle) GetPipelineArgs() []string {
+	return l.pipelineArgs
+}
+
+// SetBeamVersion sets the version of Apache Beam which is used to compile and run the code instead of the default version.
+func (l *LifeCycle) SetBeamVersion(version string) {
+	l.beamVersion = version
+}
+
+// GetBeamVersion returns the version of Apache Beam set by SetBeamVersion.
+// In case it wasn't set returns an empty string, so the default version is used.
+func (l *LifeCycle) GetBeamVersion() string {
+	return l.beamVersion
+}
+
+// SetRunEnv sets environment variables which are set for the run step in addition to variables allowed by the server.
+func (l *LifeCycle) SetRunEnv(env map[string]string) {
+	l.runEnv = env
+}
+
+// GetRunEnv returns environment variables set by SetRunEnv.
+func (l *LifeCycle) GetRunEnv() map[string]string {
+	return l.runEnv
+}
+
+// SetStdin sets chunks of the scripted stdin which are fed to the run step.
+func (l *LifeCycle) SetStdin(chunks []streaming.StdinChunk) {
+	l.stdin = chunks
+}
+
+// GetStdin returns chunks of the scripted stdin set by SetStdin.
+// In case stdin wasn't set returns nil, so the run step doesn't read anything from stdin.
+func (l *LifeCycle) GetStdin() []streaming.StdinChunk {
+	return l.stdin
+}
+
 // GetAbsoluteSourceFilePath returns absolute filepath to executable file (/path/to/workingDir/executable_files/{pipelineId}/src/{pipelineId}.{sourceFileExtension}).
 func (l *LifeCycle) GetAbsoluteSourceFilePath() string {
 	fileName := l.pipelineId.String() + l.Extension.SourceFileExtension
@@ -166,6 +591,43 @@ func (l *LifeCycle) GetAbsoluteExecutableFilePath() string {
 	return absoluteFilePath
 }
 
+// GetAbsoluteOutputFilePaths returns absolute filepaths to files which are written by the code to the base folder
+// (/path/to/workingDir/executable_files/{pipelineId}/{fileName}). Files with code and executable files are excluded.
+func (l *LifeCycle) GetAbsoluteOutputFilePaths() ([]string, error) {
+	entries, err := os.ReadDir(l.GetAbsoluteBaseFolderPath())
+	if err != nil {
+		return nil, err
+	}
+	filePaths := make([]string, 0)
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			continue
+		}
+		filePath := filepath.Join(l.GetAbsoluteBaseFolderPath(), entry.Name())
+		if filePath == l.GetAbsoluteSourceFilePath() || filePath == l.GetAbsoluteExecutableFilePath() || filePath == l.GetAbsoluteCrashReportFilePath() {
+			continue
+		}
+		filePaths = append(filePaths, filePath)
+	}
+	return filePaths, nil
+}
+
+// GetAbsoluteCrashReportFilePath returns absolute filepath to the file where the code writes a tail of the traceback
+// in case it crashes (/path/to/workingDir/executable_files/{pipelineId}/{pipelineId}.crash).
+func (l *LifeCycle) GetAbsoluteCrashReportFilePath() string {
+	fileName := l.pipelineId.String() + crashReportFileExtension
+	absoluteFilePath, _ := filepath.Abs(filepath.Join(l.Folder.BaseFolder, fileName))
+	return absoluteFilePath
+}
+
+// GetAbsoluteWasmFilePath returns absolute filepath to the WebAssembly file compiled from the code
+// (/path/to/workingDir/executable_files/{pipelineId}/bin/{pipelineId}.wasm).
+func (l *LifeCycle) GetAbsoluteWasmFilePath() string {
+	fileName := l.pipelineId.String() + wasmFileExtension
+	absoluteFilePath, _ := filepath.Abs(filepath.Join(l.Folder.ExecutableFileFolder, fileName))
+	return absoluteFilePath
+}
+
 // GetAbsoluteBaseFolderPath returns absolute path to executable folder (/path/to/workingDir/executable_files/{pipelineId}).
 func (l *LifeCycle) GetAbsoluteBaseFolderPath() string {
 	absoluteFilePath, _ := filepath.Abs(l.Folder.BaseFolder)
@@ -17,6 +17,7 @@ package fs_tool
 
 import (
 	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"beam.apache.org/playground/backend/internal/environment"
 	"beam.apache.org/playground/backend/internal/logger"
 	"fmt"
 	"github.com/google/uuid"
@@ -24,7 +25,10 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"strings"
+	"syscall"
 	"testing"
+	"time"
 )
 
 const (
@@ -147,6 +151,302 @@ func TestLifeCycle_CreateExecutableFile(t *testing.T) {
 	}
 }
 
+func TestLifeCycle_CreateTestFiles(t *testing.T) {
+	pipelineId := uuid.New()
+	baseFileFolder := fmt.Sprintf("%s_%s", baseFileFolder, pipelineId)
+	srcFileFolder := baseFileFolder + "/src"
+	absSrcFileFolder, _ := filepath.Abs(srcFileFolder)
+
+	type args struct {
+		files map[string]string
+	}
+	tests := []struct {
+		name          string
+		createFolders []string
+		args          args
+		want          []string
+		wantErr       bool
+	}{
+		{
+			name:    "source folder doesn't exist",
+			args:    args{files: map[string]string{"Test.java": "TEST_CODE"}},
+			want:    []string{},
+			wantErr: true,
+		},
+		{
+			name:          "incorrect file name",
+			createFolders: []string{srcFileFolder},
+			args:          args{files: map[string]string{"../Test.java": "TEST_CODE"}},
+			want:          []string{},
+			wantErr:       true,
+		},
+		{
+			name:          "source folder exists",
+			createFolders: []string{srcFileFolder},
+			args:          args{files: map[string]string{"b_test.go": "TEST_CODE", "a_test.go": "TEST_CODE"}},
+			want:          []string{filepath.Join(absSrcFileFolder, "a_test.go"), filepath.Join(absSrcFileFolder, "b_test.go")},
+			wantErr:       false,
+		},
+	}
+	for _, tt := range tests {
+		for _, folder := range tt.createFolders {
+			os.MkdirAll(folder, fs.ModePerm)
+		}
+		t.Run(tt.name, func(t *testing.T) {
+			l := &LifeCycle{
+				Folder:     Folder{SourceFileFolder: srcFileFolder},
+				pipelineId: pipelineId,
+			}
+			err := l.CreateTestFiles(tt.args.files)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CreateTestFiles() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got := l.GetAbsoluteTestFilePaths(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetAbsoluteTestFilePaths() got = %v, want %v", got, tt.want)
+			}
+			for _, filePath := range tt.want {
+				if _, err := os.Stat(filePath); err != nil {
+					t.Errorf("CreateTestFiles() doesn't create file: %s", filePath)
+				}
+			}
+		})
+		os.RemoveAll(baseFileFolder)
+	}
+}
+
+func TestLifeCycle_CreateDependencyFile(t *testing.T) {
+	pipelineId := uuid.New()
+	baseFileFolder := fmt.Sprintf("%s_%s", baseFileFolder, pipelineId)
+	absBaseFileFolder, _ := filepath.Abs(baseFileFolder)
+	if err := os.MkdirAll(baseFileFolder, fs.ModePerm); err != nil {
+		t.Fatalf("error during prepare folders: %s", err.Error())
+	}
+	defer os.RemoveAll(baseFileFolder)
+
+	type args struct {
+		sdk      pb.Sdk
+		fileName string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			// Test case with calling CreateDependencyFile method for the SDK which doesn't support dependencies.
+			// As a result, want to receive an error.
+			name:    "sdk doesn't support dependencies",
+			args:    args{sdk: pb.Sdk_SDK_GO, fileName: "transforms.jar"},
+			want:    "",
+			wantErr: true,
+		},
+		{
+			// Test case with calling CreateDependencyFile method with the name containing path separators.
+			// As a result, want to receive an error.
+			name:    "incorrect file name",
+			args:    args{sdk: pb.Sdk_SDK_JAVA, fileName: "../transforms.jar"},
+			want:    "",
+			wantErr: true,
+		},
+		{
+			// Test case with calling CreateDependencyFile method with the extension of the other SDK.
+			// As a result, want to receive an error.
+			name:    "incorrect file extension",
+			args:    args{sdk: pb.Sdk_SDK_JAVA, fileName: "transforms.whl"},
+			want:    "",
+			wantErr: true,
+		},
+		{
+			// Test case with calling CreateDependencyFile method with the wheel for the Python SDK.
+			// As a result, want to find the wheel in the base folder.
+			name:    "python wheel",
+			args:    args{sdk: pb.Sdk_SDK_PYTHON, fileName: "transforms.whl"},
+			want:    filepath.Join(absBaseFileFolder, "transforms.whl"),
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := &LifeCycle{
+				Folder:     Folder{BaseFolder: baseFileFolder},
+				pipelineId: pipelineId,
+			}
+			err := l.CreateDependencyFile(tt.args.sdk, tt.args.fileName, []byte("MOCK_DEPENDENCY"))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CreateDependencyFile() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got := l.GetAbsoluteDependencyFilePath(); got != tt.want {
+				t.Errorf("GetAbsoluteDependencyFilePath() got = %v, want %v", got, tt.want)
+			}
+			if tt.want != "" {
+				if _, err := os.Stat(tt.want); err != nil {
+					t.Errorf("CreateDependencyFile() doesn't create file: %s", tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestLifeCycle_CreateSampleDatasetFile(t *testing.T) {
+	pipelineId := uuid.New()
+	baseFileFolder := fmt.Sprintf("%s_%s", baseFileFolder, pipelineId)
+	absBaseFileFolder, _ := filepath.Abs(baseFileFolder)
+	if err := os.MkdirAll(baseFileFolder, fs.ModePerm); err != nil {
+		t.Fatalf("error during prepare folders: %s", err.Error())
+	}
+	defer os.RemoveAll(baseFileFolder)
+	datasetPath := filepath.Join(t.TempDir(), "kinglear.txt")
+	if err := os.WriteFile(datasetPath, []byte("MOCK_DATASET"), fileMode); err != nil {
+		t.Fatalf("error during prepare the dataset: %s", err.Error())
+	}
+
+	type args struct {
+		name string
+		path string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			// Test case with calling CreateSampleDatasetFile method without the sample dataset.
+			// As a result, want to receive no error and no file.
+			name:    "dataset isn't set",
+			args:    args{},
+			want:    "",
+			wantErr: false,
+		},
+		{
+			// Test case with calling CreateSampleDatasetFile method with the dataset which file doesn't exist.
+			// As a result, want to receive an error.
+			name:    "dataset file doesn't exist",
+			args:    args{name: "missing.txt", path: filepath.Join(filepath.Dir(datasetPath), "missing.txt")},
+			want:    "",
+			wantErr: true,
+		},
+		{
+			// Test case with calling CreateSampleDatasetFile method with the dataset from the catalog.
+			// As a result, want to find the copy of the dataset in the datasets folder.
+			name:    "dataset is copied",
+			args:    args{name: "kinglear.txt", path: datasetPath},
+			want:    filepath.Join(absBaseFileFolder, datasetsFolderName, "kinglear.txt"),
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := &LifeCycle{
+				Folder:     Folder{BaseFolder: baseFileFolder},
+				pipelineId: pipelineId,
+			}
+			l.SetSampleDataset(tt.args.name, tt.args.path)
+			err := l.CreateSampleDatasetFile()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CreateSampleDatasetFile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got := l.GetAbsoluteSampleDatasetFilePath(); got != tt.want {
+				t.Errorf("GetAbsoluteSampleDatasetFilePath() got = %v, want %v", got, tt.want)
+			}
+			if tt.want != "" {
+				if content, err := os.ReadFile(tt.want); err != nil || string(content) != "MOCK_DATASET" {
+					t.Errorf("CreateSampleDatasetFile() doesn't copy the dataset: %s", tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestLifeCycle_CreateAdditionalSourceFiles(t *testing.T) {
+	pipelineId := uuid.New()
+	baseFileFolder := fmt.Sprintf("%s_%s", baseFileFolder, pipelineId)
+	srcFileFolder := baseFileFolder + "/src"
+	absSrcFileFolder, _ := filepath.Abs(srcFileFolder)
+
+	type args struct {
+		files map[string]string
+	}
+	tests := []struct {
+		name          string
+		createFolders []string
+		args          args
+		want          []string
+		wantErr       bool
+	}{
+		{
+			name:    "source folder doesn't exist",
+			args:    args{files: map[string]string{"Helper.java": "HELPER_CODE"}},
+			want:    []string{},
+			wantErr: true,
+		},
+		{
+			name:          "incorrect file name",
+			createFolders: []string{srcFileFolder},
+			args:          args{files: map[string]string{"../Helper.java": "HELPER_CODE"}},
+			want:          []string{},
+			wantErr:       true,
+		},
+		{
+			name:          "name of the file with code",
+			createFolders: []string{srcFileFolder},
+			args:          args{files: map[string]string{pipelineId.String() + javaSourceFileExtension: "HELPER_CODE"}},
+			want:          []string{},
+			wantErr:       true,
+		},
+		{
+			name:          "incorrect file extension",
+			createFolders: []string{srcFileFolder},
+			args:          args{files: map[string]string{"helper.py": "HELPER_CODE"}},
+			want:          []string{},
+			wantErr:       true,
+		},
+		{
+			name:          "source folder exists",
+			createFolders: []string{srcFileFolder},
+			args:          args{files: map[string]string{"Utils.java": "UTILS_CODE", "Helper.java": "HELPER_CODE"}},
+			want:          []string{filepath.Join(absSrcFileFolder, "Helper.java"), filepath.Join(absSrcFileFolder, "Utils.java")},
+			wantErr:       false,
+		},
+	}
+	for _, tt := range tests {
+		for _, folder := range tt.createFolders {
+			os.MkdirAll(folder, fs.ModePerm)
+		}
+		t.Run(tt.name, func(t *testing.T) {
+			l := &LifeCycle{
+				Folder:     Folder{SourceFileFolder: srcFileFolder},
+				Extension:  Extension{SourceFileExtension: javaSourceFileExtension},
+				pipelineId: pipelineId,
+			}
+			err := l.CreateAdditionalSourceFiles(tt.args.files)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CreateAdditionalSourceFiles() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got := l.GetAbsoluteAdditionalSourceFilePaths(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetAbsoluteAdditionalSourceFilePaths() got = %v, want %v", got, tt.want)
+			}
+			mainFilePath, _ := filepath.Abs(filepath.Join(srcFileFolder, pipelineId.String()+javaSourceFileExtension))
+			if got := l.GetAbsoluteSourceFilePaths(); !reflect.DeepEqual(got, append([]string{mainFilePath}, tt.want...)) {
+				t.Errorf("GetAbsoluteSourceFilePaths() got = %v, want the file with code first", got)
+			}
+			for _, filePath := range tt.want {
+				if _, err := os.Stat(filePath); err != nil {
+					t.Errorf("CreateAdditionalSourceFiles() doesn't create file: %s", filePath)
+				}
+			}
+		})
+		os.RemoveAll(baseFileFolder)
+	}
+}
+
 func TestLifeCycle_CreateFolders(t *testing.T) {
 	pipelineId := uuid.New()
 	baseFileFolder := fmt.Sprintf("%s_%s", baseFileFolder, pipelineId)
@@ -228,6 +528,154 @@ func TestLifeCycle_DeleteFolders(t *testing.T) {
 	}
 }
 
+func TestLifeCycle_DeleteFoldersWithRetries(t *testing.T) {
+	pipelineId := uuid.New()
+	baseFileFolder := fmt.Sprintf("%s_%s", baseFileFolder, pipelineId)
+	if err := os.MkdirAll(filepath.Join(baseFileFolder, "src"), fs.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	type args struct {
+		retries int
+		delay   time.Duration
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			// Test case with deleting existing folders.
+			// As a result, want to receive no error and folders are removed.
+			name:    "delete existing folders",
+			args:    args{retries: 3, delay: time.Millisecond},
+			wantErr: false,
+		},
+		{
+			// Test case with deleting already removed folders without retries.
+			// As a result, want to receive no error.
+			name:    "delete removed folders",
+			args:    args{retries: 0, delay: 0},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := &LifeCycle{
+				folderGlobs: []string{baseFileFolder},
+				pipelineId:  pipelineId,
+			}
+			if err := l.DeleteFoldersWithRetries(tt.args.retries, tt.args.delay); (err != nil) != tt.wantErr {
+				t.Errorf("DeleteFoldersWithRetries() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if _, err := os.Stat(baseFileFolder); !os.IsNotExist(err) {
+				t.Errorf("DeleteFoldersWithRetries() folder %s still exists", baseFileFolder)
+			}
+		})
+	}
+}
+
+func Test_isBusyError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{
+			// Test case with "device or resource busy" error returned by os package.
+			// As a result, want to receive true.
+			name: "device or resource busy",
+			err:  &os.PathError{Op: "unlinkat", Path: "path", Err: syscall.EBUSY},
+			want: true,
+		},
+		{
+			// Test case with "directory not empty" error returned by os package.
+			// As a result, want to receive true.
+			name: "directory not empty",
+			err:  &os.PathError{Op: "unlinkat", Path: "path", Err: syscall.ENOTEMPTY},
+			want: true,
+		},
+		{
+			// Test case with another error.
+			// As a result, want to receive false.
+			name: "permission denied",
+			err:  &os.PathError{Op: "unlinkat", Path: "path", Err: syscall.EACCES},
+			want: false,
+		},
+		{
+			// Test case without error.
+			// As a result, want to receive false.
+			name: "nil error",
+			err:  nil,
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isBusyError(tt.err); got != tt.want {
+				t.Errorf("isBusyError() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStalePipelineFolders(t *testing.T) {
+	workingDir := t.TempDir()
+	stalePipelineId := uuid.New()
+	freshPipelineId := uuid.New()
+	for _, name := range []string{stalePipelineId.String(), freshPipelineId.String(), "NOT_PIPELINE_FOLDER"} {
+		if err := os.MkdirAll(filepath.Join(workingDir, baseFileFolder, name), fs.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+	}
+	staleTime := time.Now().Add(-time.Hour)
+	for _, name := range []string{stalePipelineId.String(), "NOT_PIPELINE_FOLDER"} {
+		if err := os.Chtimes(filepath.Join(workingDir, baseFileFolder, name), staleTime, staleTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	type args struct {
+		workingDir string
+		olderThan  time.Duration
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    map[uuid.UUID]string
+		wantErr bool
+	}{
+		{
+			// Test case with calling StalePipelineFolders method with the working dir without executable files.
+			// As a result, want to receive an empty map.
+			name:    "no executable files",
+			args:    args{workingDir: t.TempDir(), olderThan: time.Minute},
+			want:    map[uuid.UUID]string{},
+			wantErr: false,
+		},
+		{
+			// Test case with calling StalePipelineFolders method with stale and fresh folders.
+			// As a result, want to receive only the stale folder of the pipeline.
+			name:    "stale and fresh folders",
+			args:    args{workingDir: workingDir, olderThan: time.Minute},
+			want:    map[uuid.UUID]string{stalePipelineId: filepath.Join(workingDir, baseFileFolder, stalePipelineId.String())},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := StalePipelineFolders(tt.args.workingDir, tt.args.olderThan)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("StalePipelineFolders() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("StalePipelineFolders() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestNewLifeCycle(t *testing.T) {
 	pipelineId := uuid.New()
 	workingDir := "workingDir"
@@ -264,8 +712,7 @@ func TestNewLifeCycle(t *testing.T) {
 					SourceFileExtension:     javaSourceFileExtension,
 					ExecutableFileExtension: javaCompiledFileExtension,
 				},
-				ExecutableName: executableName,
-				pipelineId:     pipelineId,
+				pipelineId: pipelineId,
 			},
 			wantErr: false,
 		},
@@ -303,6 +750,220 @@ func TestNewLifeCycle(t *testing.T) {
 	}
 }
 
+func TestNewLifeCycleWithConfig(t *testing.T) {
+	pipelineId := uuid.New()
+	workingDir := "workingDir"
+	baseFileFolder := fmt.Sprintf("%s/%s/%s", workingDir, baseFileFolder, pipelineId)
+	srcFileFolder := baseFileFolder + "/src"
+	binFileFolder := baseFileFolder + "/bin"
+
+	type args struct {
+		sdk        pb.Sdk
+		config     *environment.LifeCycleConfig
+		pipelineId uuid.UUID
+		workingDir string
+	}
+	tests := []struct {
+		name              string
+		args              args
+		want              *LifeCycle
+		wantEntrypointSet bool
+		wantErr           bool
+	}{
+		{
+			// Test case with calling NewLifeCycleWithConfig method without config.
+			// As a result, want to receive LifeCycle with default conventions of the SDK.
+			name: "default config",
+			args: args{
+				sdk:        pb.Sdk_SDK_PYTHON,
+				config:     nil,
+				pipelineId: pipelineId,
+				workingDir: workingDir,
+			},
+			want: &LifeCycle{
+				folderGlobs: []string{baseFileFolder},
+				Folder: Folder{
+					BaseFolder:           baseFileFolder,
+					SourceFileFolder:     baseFileFolder,
+					ExecutableFileFolder: baseFileFolder,
+				},
+				Extension: Extension{
+					SourceFileExtension:     pythonExecutableFileExtension,
+					ExecutableFileExtension: pythonExecutableFileExtension,
+				},
+				pipelineId: pipelineId,
+			},
+			wantEntrypointSet: false,
+			wantErr:           false,
+		},
+		{
+			// Test case with calling NewLifeCycleWithConfig method with config of compiled SDK.
+			// As a result, want to receive LifeCycle which follows conventions from the config.
+			name: "compiled sdk config",
+			args: args{
+				sdk: pb.Sdk_SDK_SCIO,
+				config: &environment.LifeCycleConfig{
+					SourceFileExtension:     ".scala",
+					ExecutableFileExtension: ".class",
+					Compiled:                true,
+					EntrypointResolver:      javaClassEntrypointResolver,
+				},
+				pipelineId: pipelineId,
+				workingDir: workingDir,
+			},
+			want: &LifeCycle{
+				folderGlobs: []string{baseFileFolder, srcFileFolder, binFileFolder},
+				Folder: Folder{
+					BaseFolder:           baseFileFolder,
+					SourceFileFolder:     srcFileFolder,
+					ExecutableFileFolder: binFileFolder,
+				},
+				Extension: Extension{
+					SourceFileExtension:     ".scala",
+					ExecutableFileExtension: ".class",
+				},
+				pipelineId: pipelineId,
+			},
+			wantEntrypointSet: true,
+			wantErr:           false,
+		},
+		{
+			// Test case with calling NewLifeCycleWithConfig method with unknown entrypoint resolver.
+			// As a result, want to receive an error.
+			name: "unknown entrypoint resolver",
+			args: args{
+				sdk: pb.Sdk_SDK_JAVA,
+				config: &environment.LifeCycleConfig{
+					SourceFileExtension: javaSourceFileExtension,
+					Compiled:            true,
+					EntrypointResolver:  "MOCK_RESOLVER",
+				},
+				pipelineId: pipelineId,
+				workingDir: workingDir,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewLifeCycleWithConfig(tt.args.sdk, tt.args.config, tt.args.pipelineId, tt.args.workingDir)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewLifeCycleWithConfig() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got.folderGlobs, tt.want.folderGlobs) {
+				t.Errorf("NewLifeCycleWithConfig() folderGlobs = %v, want %v", got.folderGlobs, tt.want.folderGlobs)
+			}
+			if !reflect.DeepEqual(got.Folder, tt.want.Folder) {
+				t.Errorf("NewLifeCycleWithConfig() Folder = %v, want %v", got.Folder, tt.want.Folder)
+			}
+			if !reflect.DeepEqual(got.Extension, tt.want.Extension) {
+				t.Errorf("NewLifeCycleWithConfig() Extension = %v, want %v", got.Extension, tt.want.Extension)
+			}
+			if (got.ExecutableName != nil) != tt.wantEntrypointSet {
+				t.Errorf("NewLifeCycleWithConfig() ExecutableName is set = %v, want %v", got.ExecutableName != nil, tt.wantEntrypointSet)
+			}
+		})
+	}
+}
+
+func TestNewLifeCycleWithFolderName(t *testing.T) {
+	pipelineId := uuid.New()
+	folderName := func(uuid.UUID) string { return "MOCK_FOLDER" }
+	baseFileFolder := fmt.Sprintf("%s/%s/%s", preparedWorkDir, baseFileFolder, "MOCK_FOLDER")
+	srcFileFolder := baseFileFolder + "/src"
+	binFileFolder := baseFileFolder + "/bin"
+
+	type args struct {
+		sdk        pb.Sdk
+		pipelineId uuid.UUID
+		workingDir string
+		folderName FolderName
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *LifeCycle
+		wantErr bool
+	}{
+		{
+			// Test case with calling NewLifeCycleWithFolderName method with deterministic folder name.
+			// As a result, want to receive LifeCycle which folders are named by the given folder name.
+			name: "deterministic folder name",
+			args: args{
+				sdk:        pb.Sdk_SDK_JAVA,
+				pipelineId: pipelineId,
+				workingDir: preparedWorkDir,
+				folderName: folderName,
+			},
+			want: &LifeCycle{
+				folderGlobs: []string{baseFileFolder, srcFileFolder, binFileFolder},
+				Folder: Folder{
+					BaseFolder:           baseFileFolder,
+					SourceFileFolder:     srcFileFolder,
+					ExecutableFileFolder: binFileFolder,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			// Test case with calling NewLifeCycleWithFolderName method with unsupported SDK.
+			// As a result, want to receive an error.
+			name: "unsupported sdk",
+			args: args{
+				sdk:        pb.Sdk_SDK_UNSPECIFIED,
+				pipelineId: pipelineId,
+				workingDir: preparedWorkDir,
+				folderName: folderName,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewLifeCycleWithFolderName(tt.args.sdk, nil, tt.args.pipelineId, tt.args.workingDir, tt.args.folderName)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewLifeCycleWithFolderName() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got.folderGlobs, tt.want.folderGlobs) {
+				t.Errorf("NewLifeCycleWithFolderName() folderGlobs = %v, want %v", got.folderGlobs, tt.want.folderGlobs)
+			}
+			if !reflect.DeepEqual(got.Folder, tt.want.Folder) {
+				t.Errorf("NewLifeCycleWithFolderName() Folder = %v, want %v", got.Folder, tt.want.Folder)
+			}
+			if err := got.CreateFolders(); err != nil {
+				t.Errorf("NewLifeCycleWithFolderName() CreateFolders() error = %v", err)
+			}
+			for _, folder := range tt.want.folderGlobs {
+				if _, err := os.Stat(folder); err != nil {
+					t.Errorf("NewLifeCycleWithFolderName() folder %s isn't created: %v", folder, err)
+				}
+			}
+			if err := os.WriteFile(filepath.Join(binFileFolder, "temp.class"), []byte("TEMP_DATA"), fileMode); err != nil {
+				t.Fatalf("error during prepare compiled file: %s", err.Error())
+			}
+			if name, err := got.ExecutableName(tt.args.pipelineId, tt.args.workingDir); err != nil || name != "temp" {
+				t.Errorf("NewLifeCycleWithFolderName() ExecutableName() = %v, %v, want temp", name, err)
+			}
+			if err := got.DeleteFolders(); err != nil {
+				t.Errorf("NewLifeCycleWithFolderName() DeleteFolders() error = %v", err)
+			}
+			if _, err := os.Stat(baseFileFolder); !os.IsNotExist(err) {
+				t.Errorf("NewLifeCycleWithFolderName() folder %s isn't deleted", baseFileFolder)
+			}
+		})
+	}
+}
+
 func TestLifeCycle_GetAbsoluteExecutableFilePath(t *testing.T) {
 	pipelineId := uuid.New()
 	baseFileFolder := fmt.Sprintf("%s_%s", baseFileFolder, pipelineId)
@@ -351,6 +1012,77 @@ func TestLifeCycle_GetAbsoluteExecutableFilePath(t *testing.T) {
 	}
 }
 
+func TestLifeCycle_GetAbsoluteWasmFilePath(t *testing.T) {
+	pipelineId := uuid.New()
+	lc := newGoLifeCycle(pipelineId, preparedWorkDir)
+	wantPath, _ := filepath.Abs(filepath.Join(lc.Folder.ExecutableFileFolder, pipelineId.String()+".wasm"))
+	tests := []struct {
+		name string
+		want string
+	}{
+		{
+			// Test case with calling GetAbsoluteWasmFilePath method of the go LifeCycle.
+			// As a result, want the path of the .wasm file in the folder with compiled files, so it is deleted with other folders.
+			name: "wasm file in the folder with compiled files",
+			want: wantPath,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := lc.GetAbsoluteWasmFilePath(); got != tt.want {
+				t.Errorf("GetAbsoluteWasmFilePath() got = %v, want %v", got, tt.want)
+			}
+			if !strings.HasPrefix(lc.GetAbsoluteWasmFilePath(), lc.GetAbsoluteBaseFolderPath()) {
+				t.Errorf("GetAbsoluteWasmFilePath() should be in the base folder %s", lc.GetAbsoluteBaseFolderPath())
+			}
+		})
+	}
+}
+
+func TestLifeCycle_GetAbsoluteOutputFilePaths(t *testing.T) {
+	pipelineId := uuid.New()
+	lc := newPythonLifeCycle(pipelineId, preparedWorkDir)
+	outputFilePath, _ := filepath.Abs(filepath.Join(lc.Folder.BaseFolder, "output.txt"))
+	tests := []struct {
+		name    string
+		prepare func()
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:    "base folder doesn't exist",
+			prepare: func() {},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "base folder contains output files",
+			prepare: func() {
+				_ = lc.CreateFolders()
+				_, _ = lc.CreateSourceCodeFile("TEST_CODE")
+				_ = os.WriteFile(outputFilePath, []byte("TEST_OUTPUT"), fileMode)
+				_ = os.MkdirAll(filepath.Join(lc.Folder.BaseFolder, "output_folder"), fs.ModePerm)
+			},
+			want:    []string{outputFilePath},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.prepare()
+			got, err := lc.GetAbsoluteOutputFilePaths()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetAbsoluteOutputFilePaths() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetAbsoluteOutputFilePaths() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	_ = lc.DeleteFolders()
+}
+
 func TestLifeCycle_GetAbsoluteExecutableFilesFolderPath(t *testing.T) {
 	pipelineId := uuid.New()
 	baseFileFolder := fmt.Sprintf("%s_%s", baseFileFolder, pipelineId)
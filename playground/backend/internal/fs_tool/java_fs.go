@@ -16,7 +16,9 @@
 package fs_tool
 
 import (
+	"encoding/binary"
 	"errors"
+	"fmt"
 	"github.com/google/uuid"
 	"os"
 	"path/filepath"
@@ -24,28 +26,180 @@ import (
 )
 
 const (
-	javaSourceFileExtension   = ".java"
-	javaCompiledFileExtension = ".class"
+	javaSourceFileExtension     = ".java"
+	javaCompiledFileExtension   = ".class"
+	javaClassEntrypointResolver = "java_class"
+	javaClassMagic              = 0xCAFEBABE
+	javaMainMethodName          = "main"
+	javaMainMethodDescriptor    = "([Ljava/lang/String;)V"
+	javaPublicStaticFlags       = 0x0001 | 0x0008
 )
 
 // newJavaLifeCycle creates LifeCycle with java SDK environment.
 func newJavaLifeCycle(pipelineId uuid.UUID, workingDir string) *LifeCycle {
 	javaLifeCycle := newCompilingLifeCycle(pipelineId, workingDir, javaSourceFileExtension, javaCompiledFileExtension)
-	javaLifeCycle.ExecutableName = executableName
+	javaLifeCycle.setEntrypointResolver(executableName)
 	return javaLifeCycle
 }
 
-// executableName returns name that should be executed (HelloWorld for HelloWorld.class for java SDK)
-func executableName(pipelineId uuid.UUID, workingDir string) (string, error) {
-	baseFileFolder := filepath.Join(workingDir, baseFileFolder, pipelineId.String())
-	binFileFolder := filepath.Join(baseFileFolder, compiledFolderName)
-	dirEntries, err := os.ReadDir(binFileFolder)
+// executableName returns name that should be executed from the folder with compiled files (HelloWorld for HelloWorld.class for java SDK).
+// The class declaring public static void main(String[]) is executed, classes compiled from sourceFileName go first,
+// then top-level classes. In case some compiled file couldn't be parsed and no such class is found the last compiled file is executed.
+// In case all compiled files are parsed and none of them declares the main method returns an error.
+func executableName(executableFileFolder, sourceFileName string) (string, error) {
+	dirEntries, err := os.ReadDir(executableFileFolder)
 	if err != nil {
 		return "", err
 	}
 	if len(dirEntries) < 1 {
 		return "", errors.New("number of executable files should be at least one")
 	}
-	//TODO need to find a class with a main method instead of using the last file
+	mainClass, mainClassRank := "", 0
+	unparsed := false
+	for _, entry := range dirEntries {
+		if filepath.Ext(entry.Name()) != javaCompiledFileExtension {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(executableFileFolder, entry.Name()))
+		if err != nil {
+			return "", err
+		}
+		class, err := parseJavaClass(content)
+		if err != nil {
+			unparsed = true
+			continue
+		}
+		if !class.hasMainMethod {
+			continue
+		}
+		name := strings.TrimSuffix(entry.Name(), javaCompiledFileExtension)
+		rank := 1
+		if !strings.Contains(name, "$") {
+			rank++
+		}
+		if class.sourceFile == sourceFileName {
+			rank += 2
+		}
+		if rank > mainClassRank {
+			mainClass, mainClassRank = name, rank
+		}
+	}
+	if mainClass != "" {
+		return mainClass, nil
+	}
+	if !unparsed {
+		return "", errors.New("no compiled class declares public static void main(String[])")
+	}
 	return strings.Split(dirEntries[len(dirEntries)-1].Name(), ".")[0], nil
 }
+
+// javaClass contains properties of the compiled java class which are needed to find the entrypoint:
+// - hasMainMethod: whether the class declares public static void main(String[])
+// - sourceFile: name of the file the class is compiled from (empty if the class file doesn't keep it)
+type javaClass struct {
+	hasMainMethod bool
+	sourceFile    string
+}
+
+// parseJavaClass parses the compiled java class according to the class file format of the JVM specification.
+// Only the constant pool, methods and the SourceFile attribute are read, other parts are skipped.
+func parseJavaClass(content []byte) (javaClass, error) {
+	r := &classReader{data: content}
+	if r.u4() != javaClassMagic {
+		return javaClass{}, errors.New("incorrect magic number of the class file")
+	}
+	r.skip(4) // minor and major versions
+	utf8Constants := make(map[int]string)
+	constantPoolCount := int(r.u2())
+	for i := 1; i < constantPoolCount && r.err == nil; i++ {
+		tag := r.u1()
+		switch tag {
+		case 1: // Utf8
+			utf8Constants[i] = string(r.bytes(int(r.u2())))
+		case 7, 8, 16, 19, 20: // Class, String, MethodType, Module, Package
+			r.skip(2)
+		case 15: // MethodHandle
+			r.skip(3)
+		case 3, 4, 9, 10, 11, 12, 17, 18: // Integer, Float, Fieldref, Methodref, InterfaceMethodref, NameAndType, Dynamic, InvokeDynamic
+			r.skip(4)
+		case 5, 6: // Long, Double take two entries of the constant pool
+			r.skip(8)
+			i++
+		default:
+			return javaClass{}, fmt.Errorf("unknown tag %d of the constant pool", tag)
+		}
+	}
+	r.skip(6) // access flags, this class and super class
+	r.skip(2 * int(r.u2()))
+	var class javaClass
+	for _, isMethod := range []bool{false, true} {
+		count := int(r.u2())
+		for i := 0; i < count && r.err == nil; i++ {
+			accessFlags, name, descriptor := r.u2(), utf8Constants[int(r.u2())], utf8Constants[int(r.u2())]
+			if isMethod && name == javaMainMethodName && descriptor == javaMainMethodDescriptor && accessFlags&javaPublicStaticFlags == javaPublicStaticFlags {
+				class.hasMainMethod = true
+			}
+			r.skipAttributes()
+		}
+	}
+	attributesCount := int(r.u2())
+	for i := 0; i < attributesCount && r.err == nil; i++ {
+		name, length := utf8Constants[int(r.u2())], int(r.u4())
+		if name == "SourceFile" && length == 2 {
+			class.sourceFile = utf8Constants[int(r.u2())]
+			continue
+		}
+		r.skip(length)
+	}
+	return class, r.err
+}
+
+// classReader reads big-endian values of the class file, once the end of data is reached keeps the error and returns zeros
+type classReader struct {
+	data []byte
+	err  error
+}
+
+func (r *classReader) bytes(n int) []byte {
+	if r.err != nil || n > len(r.data) {
+		r.err = errors.New("unexpected end of the class file")
+		return nil
+	}
+	b := r.data[:n]
+	r.data = r.data[n:]
+	return b
+}
+
+func (r *classReader) skip(n int) {
+	r.bytes(n)
+}
+
+func (r *classReader) u1() uint8 {
+	if b := r.bytes(1); b != nil {
+		return b[0]
+	}
+	return 0
+}
+
+func (r *classReader) u2() uint16 {
+	if b := r.bytes(2); b != nil {
+		return binary.BigEndian.Uint16(b)
+	}
+	return 0
+}
+
+func (r *classReader) u4() uint32 {
+	if b := r.bytes(4); b != nil {
+		return binary.BigEndian.Uint32(b)
+	}
+	return 0
+}
+
+// skipAttributes skips attributes of the field or the method
+func (r *classReader) skipAttributes() {
+	count := int(r.u2())
+	for i := 0; i < count && r.err == nil; i++ {
+		r.skip(2)
+		r.skip(int(r.u4()))
+	}
+}
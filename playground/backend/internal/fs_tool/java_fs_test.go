@@ -16,8 +16,11 @@
 package fs_tool
 
 import (
+	"bytes"
+	"encoding/binary"
 	"fmt"
 	"github.com/google/uuid"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"reflect"
@@ -59,8 +62,7 @@ func Test_newJavaLifeCycle(t *testing.T) {
 					SourceFileExtension:     javaSourceFileExtension,
 					ExecutableFileExtension: javaCompiledFileExtension,
 				},
-				ExecutableName: executableName,
-				pipelineId:     pipelineId,
+				pipelineId: pipelineId,
 			},
 		},
 	}
@@ -92,8 +94,8 @@ func Test_executableName(t *testing.T) {
 	defer os.RemoveAll(workDir)
 
 	type args struct {
-		pipelineId uuid.UUID
-		workingDir string
+		executableFileFolder string
+		sourceFileName       string
 	}
 	tests := []struct {
 		name    string
@@ -103,7 +105,7 @@ func Test_executableName(t *testing.T) {
 		wantErr bool
 	}{
 		{
-			// Test case with calling sourceFileName method with correct pipelineId and workingDir.
+			// Test case with calling sourceFileName method with existing folder with compiled files.
 			// As a result, want to receive a name that should be executed
 			name: "get executable name",
 			prepare: func() {
@@ -115,20 +117,63 @@ func Test_executableName(t *testing.T) {
 				}
 			},
 			args: args{
-				pipelineId: pipelineId,
-				workingDir: workDir,
+				executableFileFolder: lc.Folder.ExecutableFileFolder,
 			},
 			want:    "temp",
 			wantErr: false,
 		},
 		{
-			// Test case with calling sourceFileName method with correct pipelineId and workingDir.
+			// Test case with calling sourceFileName method with folder with compiled classes which don't declare the main method.
+			// As a result, want to receive an error.
+			name: "no class with main method",
+			prepare: func() {
+				noMainFolder := filepath.Join(workDir, "no_main")
+				if err := os.MkdirAll(noMainFolder, fs.ModePerm); err != nil {
+					panic(err)
+				}
+				helper := javaClassFile("Helper.java", javaMethod{accessFlags: 0x0001, name: "run", descriptor: "()V"})
+				if err := os.WriteFile(filepath.Join(noMainFolder, "Helper.class"), helper, 0600); err != nil {
+					panic(err)
+				}
+			},
+			args: args{
+				executableFileFolder: filepath.Join(workDir, "no_main"),
+			},
+			want:    "",
+			wantErr: true,
+		},
+		{
+			// Test case with calling sourceFileName method with the main class compiled from the file with code and a helper class
+			// which also declares the main method.
+			// As a result, want to receive the name of the class compiled from the file with code.
+			name: "get executable name of the class with main method",
+			prepare: func() {
+				compiled := filepath.Join(workDir, baseFileFolder, pipelineId.String(), compiledFolderName)
+				mainMethod := javaMethod{accessFlags: javaPublicStaticFlags, name: javaMainMethodName, descriptor: javaMainMethodDescriptor}
+				files := map[string][]byte{
+					"Helper.class": javaClassFile("Helper.java", mainMethod),
+					"Main.class":   javaClassFile(pipelineId.String()+javaSourceFileExtension, mainMethod),
+				}
+				for name, content := range files {
+					if err := os.WriteFile(filepath.Join(compiled, name), content, 0600); err != nil {
+						panic(err)
+					}
+				}
+			},
+			args: args{
+				executableFileFolder: lc.Folder.ExecutableFileFolder,
+				sourceFileName:       pipelineId.String() + javaSourceFileExtension,
+			},
+			want:    "Main",
+			wantErr: false,
+		},
+		{
+			// Test case with calling sourceFileName method with folder with compiled files which doesn't exist.
 			// As a result, want to receive an error.
 			name:    "directory doesn't exist",
 			prepare: func() {},
 			args: args{
-				pipelineId: uuid.New(),
-				workingDir: workDir,
+				executableFileFolder: filepath.Join(workDir, baseFileFolder, uuid.New().String(), compiledFolderName),
 			},
 			want:    "",
 			wantErr: true,
@@ -137,7 +182,7 @@ func Test_executableName(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.prepare()
-			got, err := executableName(tt.args.pipelineId, tt.args.workingDir)
+			got, err := executableName(tt.args.executableFileFolder, tt.args.sourceFileName)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("sourceFileName() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -148,3 +193,99 @@ func Test_executableName(t *testing.T) {
 		})
 	}
 }
+
+func Test_parseJavaClass(t *testing.T) {
+	tests := []struct {
+		name    string
+		content []byte
+		want    javaClass
+		wantErr bool
+	}{
+		{
+			// Test case with calling parseJavaClass method with the class which declares public static void main(String[]).
+			// As a result, want to receive the class with the main method and its source file.
+			name:    "class with main method",
+			content: javaClassFile("Main.java", javaMethod{accessFlags: javaPublicStaticFlags, name: "main", descriptor: "([Ljava/lang/String;)V"}),
+			want:    javaClass{hasMainMethod: true, sourceFile: "Main.java"},
+		},
+		{
+			// Test case with calling parseJavaClass method with the class which declares the main method which isn't static.
+			// As a result, want to receive the class without the main method.
+			name:    "class with instance main method",
+			content: javaClassFile("Helper.java", javaMethod{accessFlags: 0x0001, name: "main", descriptor: "([Ljava/lang/String;)V"}),
+			want:    javaClass{sourceFile: "Helper.java"},
+		},
+		{
+			// Test case with calling parseJavaClass method with the class which declares other methods only.
+			// As a result, want to receive the class without the main method.
+			name:    "class without main method",
+			content: javaClassFile("Helper.java", javaMethod{accessFlags: javaPublicStaticFlags, name: "format", descriptor: "(Ljava/lang/String;)Ljava/lang/String;"}),
+			want:    javaClass{sourceFile: "Helper.java"},
+		},
+		{
+			// Test case with calling parseJavaClass method with data which isn't a class file.
+			// As a result, want to receive an error.
+			name:    "not a class file",
+			content: []byte("TEMP_DATA"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseJavaClass(tt.content)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseJavaClass() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("parseJavaClass() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// javaMethod is a method of the class built by javaClassFile
+type javaMethod struct {
+	accessFlags uint16
+	name        string
+	descriptor  string
+}
+
+// javaClassFile returns a minimal class file with methods and the SourceFile attribute as javac produces it
+func javaClassFile(sourceFile string, methods ...javaMethod) []byte {
+	var constants [][]byte
+	utf8 := func(value string) uint16 {
+		constant := append([]byte{1, byte(len(value) >> 8), byte(len(value))}, value...)
+		constants = append(constants, constant)
+		return uint16(len(constants))
+	}
+	classRef := func(name string) uint16 {
+		index := utf8(name)
+		constants = append(constants, []byte{7, byte(index >> 8), byte(index)})
+		return uint16(len(constants))
+	}
+	thisClass := classRef("Main")
+	superClass := classRef("java/lang/Object")
+	sourceFileAttribute := utf8("SourceFile")
+	sourceFileIndex := utf8(sourceFile)
+	methodIndexes := make([][2]uint16, 0, len(methods))
+	for _, method := range methods {
+		methodIndexes = append(methodIndexes, [2]uint16{utf8(method.name), utf8(method.descriptor)})
+	}
+
+	var buf bytes.Buffer
+	write := func(values ...interface{}) {
+		for _, value := range values {
+			_ = binary.Write(&buf, binary.BigEndian, value)
+		}
+	}
+	write(uint32(javaClassMagic), uint16(0), uint16(55), uint16(len(constants)+1))
+	for _, constant := range constants {
+		buf.Write(constant)
+	}
+	write(uint16(0x0021), thisClass, superClass, uint16(0), uint16(0), uint16(len(methods)))
+	for i, method := range methods {
+		write(method.accessFlags, methodIndexes[i][0], methodIndexes[i][1], uint16(0))
+	}
+	write(uint16(1), sourceFileAttribute, uint32(2), sourceFileIndex)
+	return buf.Bytes()
+}
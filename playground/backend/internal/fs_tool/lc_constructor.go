@@ -26,6 +26,14 @@ const (
 	compiledFolderName = "bin"
 )
 
+// FolderName returns the name of the folder in which code of the pipeline is processed.
+type FolderName func(pipelineId uuid.UUID) string
+
+// PipelineIdFolderName is the default FolderName which names the folder by the pipelineId.
+func PipelineIdFolderName(pipelineId uuid.UUID) string {
+	return pipelineId.String()
+}
+
 // newCompilingLifeCycle creates LifeCycle for compiled SDK environment.
 func newCompilingLifeCycle(pipelineId uuid.UUID, workingDir string, sourceFileExtension string, compiledFileExtension string) *LifeCycle {
 	baseFileFolder := filepath.Join(workingDir, baseFileFolder, pipelineId.String())
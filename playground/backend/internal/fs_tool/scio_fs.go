@@ -0,0 +1,94 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fs_tool
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	scioSourceFileExtension       = ".scala"
+	scioCompiledFileExtension     = ".class"
+	scalaObjectEntrypointResolver = "scala_object"
+	scalaModuleClassSuffix        = "$" + scioCompiledFileExtension
+)
+
+// newScioLifeCycle creates LifeCycle with scio SDK environment.
+func newScioLifeCycle(pipelineId uuid.UUID, workingDir string) *LifeCycle {
+	scioLifeCycle := newCompilingLifeCycle(pipelineId, workingDir, scioSourceFileExtension, scioCompiledFileExtension)
+	scioLifeCycle.setEntrypointResolver(scalaObjectName)
+	return scioLifeCycle
+}
+
+// scalaObjectName returns name of the top-level object that should be executed from the folder with compiled files
+// (WordCount for WordCount.class and WordCount$.class for scio SDK).
+// Scala compiles a top-level object into the module class with the "$" suffix and the class with static forwarders
+// which could be executed, so only names having both classes are considered. The object whose forwarder class declares
+// public static void main(String[]) is executed, objects compiled from sourceFileName go first.
+// In case some forwarder class couldn't be parsed and no such object is found the last object is executed.
+// In case all forwarder classes are parsed and none of them declares the main method returns an error.
+func scalaObjectName(executableFileFolder, sourceFileName string) (string, error) {
+	dirEntries, err := os.ReadDir(executableFileFolder)
+	if err != nil {
+		return "", err
+	}
+	classes := make(map[string]bool, len(dirEntries))
+	for _, entry := range dirEntries {
+		classes[entry.Name()] = true
+	}
+	objectName, lastObjectName, objectRank := "", "", 0
+	unparsed := false
+	for _, entry := range dirEntries {
+		name := strings.TrimSuffix(entry.Name(), scalaModuleClassSuffix)
+		if name == entry.Name() || strings.Contains(name, "$") || !classes[name+scioCompiledFileExtension] {
+			continue
+		}
+		lastObjectName = name
+		content, err := os.ReadFile(filepath.Join(executableFileFolder, name+scioCompiledFileExtension))
+		if err != nil {
+			return "", err
+		}
+		class, err := parseJavaClass(content)
+		if err != nil {
+			unparsed = true
+			continue
+		}
+		if !class.hasMainMethod {
+			continue
+		}
+		rank := 1
+		if class.sourceFile == sourceFileName {
+			rank++
+		}
+		if rank > objectRank {
+			objectName, objectRank = name, rank
+		}
+	}
+	if objectName != "" {
+		return objectName, nil
+	}
+	if lastObjectName == "" {
+		return "", errors.New("compiled files should contain at least one top-level object")
+	}
+	if !unparsed {
+		return "", errors.New("no compiled top-level object declares the main method")
+	}
+	return lastObjectName, nil
+}
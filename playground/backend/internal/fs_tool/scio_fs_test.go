@@ -0,0 +1,150 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fs_tool
+
+import (
+	"github.com/google/uuid"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_scalaObjectName(t *testing.T) {
+	pipelineId := uuid.New()
+	workDir := "workingDir"
+
+	lc := newScioLifeCycle(pipelineId, workDir)
+	lc.CreateFolders()
+	defer os.RemoveAll(workDir)
+	compiled := filepath.Join(workDir, baseFileFolder, pipelineId.String(), compiledFolderName)
+	writeClasses := func(names ...string) {
+		for _, name := range names {
+			if err := os.WriteFile(filepath.Join(compiled, name), []byte("TEMP_DATA"), 0600); err != nil {
+				panic(err)
+			}
+		}
+	}
+	// newClassFolder returns the folder with compiled files of a new pipeline which contains files
+	newClassFolder := func(files map[string][]byte) string {
+		lc := newScioLifeCycle(uuid.New(), workDir)
+		if err := lc.CreateFolders(); err != nil {
+			panic(err)
+		}
+		for name, content := range files {
+			if err := os.WriteFile(filepath.Join(lc.Folder.ExecutableFileFolder, name), content, 0600); err != nil {
+				panic(err)
+			}
+		}
+		return lc.Folder.ExecutableFileFolder
+	}
+	sourceFileName := pipelineId.String() + scioSourceFileExtension
+	staticMain := javaMethod{accessFlags: javaPublicStaticFlags, name: javaMainMethodName, descriptor: javaMainMethodDescriptor}
+	instanceMain := javaMethod{accessFlags: 0x0001, name: javaMainMethodName, descriptor: javaMainMethodDescriptor}
+
+	tests := []struct {
+		name                 string
+		prepare              func()
+		executableFileFolder string
+		sourceFileName       string
+		want                 string
+		wantErr              bool
+	}{
+		{
+			// Test case with calling scalaObjectName method with folder with compiled classes only.
+			// As a result, want to receive an error.
+			name:                 "no top-level objects",
+			prepare:              func() { writeClasses("Word.class") },
+			executableFileFolder: lc.Folder.ExecutableFileFolder,
+			want:                 "",
+			wantErr:              true,
+		},
+		{
+			// Test case with calling scalaObjectName method with folder with a compiled class, a compiled object and its anonymous function
+			// which couldn't be parsed.
+			// As a result, want to receive the name of the last object.
+			name:                 "get object name",
+			prepare:              func() { writeClasses("WordCount.class", "WordCount$.class", "WordCount$anonfun$1.class") },
+			executableFileFolder: lc.Folder.ExecutableFileFolder,
+			want:                 "WordCount",
+			wantErr:              false,
+		},
+		{
+			// Test case with calling scalaObjectName method with folder with compiled files which doesn't exist.
+			// As a result, want to receive an error.
+			name:                 "directory doesn't exist",
+			prepare:              func() {},
+			executableFileFolder: filepath.Join(workDir, baseFileFolder, uuid.New().String(), compiledFolderName),
+			want:                 "",
+			wantErr:              true,
+		},
+		{
+			// Test case with calling scalaObjectName method with folder with the object declaring the main method
+			// and the object without it which goes after the first one.
+			// As a result, want to receive the name of the object declaring the main method.
+			name:    "object with main method",
+			prepare: func() {},
+			executableFileFolder: newClassFolder(map[string][]byte{
+				"Main.class":   javaClassFile("Main.scala", staticMain),
+				"Main$.class":  javaClassFile("Main.scala", instanceMain),
+				"Utils.class":  javaClassFile("Utils.scala"),
+				"Utils$.class": javaClassFile("Utils.scala"),
+			}),
+			want:    "Main",
+			wantErr: false,
+		},
+		{
+			// Test case with calling scalaObjectName method with folder with objects declaring the main method
+			// where only the first one is compiled from the file with code.
+			// As a result, want to receive the name of the object compiled from the file with code.
+			name:    "object compiled from file with code",
+			prepare: func() {},
+			executableFileFolder: newClassFolder(map[string][]byte{
+				"App.class":   javaClassFile(sourceFileName, staticMain),
+				"App$.class":  javaClassFile(sourceFileName, instanceMain),
+				"Zeta.class":  javaClassFile("Zeta.scala", staticMain),
+				"Zeta$.class": javaClassFile("Zeta.scala", instanceMain),
+			}),
+			sourceFileName: sourceFileName,
+			want:           "App",
+			wantErr:        false,
+		},
+		{
+			// Test case with calling scalaObjectName method with folder with objects which don't declare the main method.
+			// As a result, want to receive an error.
+			name:    "no object with main method",
+			prepare: func() {},
+			executableFileFolder: newClassFolder(map[string][]byte{
+				"Utils.class":  javaClassFile("Utils.scala"),
+				"Utils$.class": javaClassFile("Utils.scala", instanceMain),
+			}),
+			want:    "",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.prepare()
+			got, err := scalaObjectName(tt.executableFileFolder, tt.sourceFileName)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("scalaObjectName() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("scalaObjectName() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
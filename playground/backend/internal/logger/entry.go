@@ -0,0 +1,125 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logger
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+)
+
+// Fields are structured fields of the message which are written as separate fields in JSON format:
+// - PipelineId: id of code processing the message is about
+// - Phase: phase of code processing, e.g. compile (empty if the message isn't about some phase)
+// - TraceId: id of the request which has started code processing (empty if the request has no trace id)
+type Fields struct {
+	PipelineId string
+	Phase      string
+	TraceId    string
+}
+
+// traceIdKey is the key of the trace id in the context
+type traceIdKey struct{}
+
+// ContextWithTraceId returns a copy of ctx which carries the trace id of the request.
+// In case traceId is empty returns ctx as is.
+func ContextWithTraceId(ctx context.Context, traceId string) context.Context {
+	if traceId == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, traceIdKey{}, traceId)
+}
+
+// TraceIdFromContext returns the trace id carried by ctx (empty if ctx doesn't carry it)
+func TraceIdFromContext(ctx context.Context) string {
+	traceId, _ := ctx.Value(traceIdKey{}).(string)
+	return traceId
+}
+
+// Entry logs messages with the fields.
+// In plain format and for handlers the message is prefixed with the pipeline id and the trace id
+// as "{pipelineId} [{traceId}]: {message}", the trace id is omitted if it is empty.
+type Entry struct {
+	fields Fields
+}
+
+// WithPipelineId returns Entry which logs messages about code processing by pipelineId
+func WithPipelineId(pipelineId uuid.UUID) *Entry {
+	return &Entry{fields: Fields{PipelineId: pipelineId.String()}}
+}
+
+// WithPhase returns a copy of Entry which logs messages about the phase of code processing
+func (e *Entry) WithPhase(phase string) *Entry {
+	fields := e.fields
+	fields.Phase = phase
+	return &Entry{fields: fields}
+}
+
+// WithContext returns a copy of Entry which logs messages with the trace id carried by ctx
+func (e *Entry) WithContext(ctx context.Context) *Entry {
+	fields := e.fields
+	fields.TraceId = TraceIdFromContext(ctx)
+	return &Entry{fields: fields}
+}
+
+// Infof formats according to a format specifier and logs a message with the fields at level Info.
+func (e *Entry) Infof(format string, args ...interface{}) {
+	e.log(INFO, fmt.Sprintf(format, args...))
+}
+
+// Warnf formats according to a format specifier and logs a message with the fields at level Warn.
+func (e *Entry) Warnf(format string, args ...interface{}) {
+	e.log(WARN, fmt.Sprintf(format, args...))
+}
+
+// Errorf formats according to a format specifier and logs a message with the fields at level Error.
+func (e *Entry) Errorf(format string, args ...interface{}) {
+	e.log(ERROR, fmt.Sprintf(format, args...))
+}
+
+// Debugf formats according to a format specifier and logs a message with the fields at level Debug.
+func (e *Entry) Debugf(format string, args ...interface{}) {
+	e.log(DEBUG, fmt.Sprintf(format, args...))
+}
+
+// log forwards the message prefixed with the pipeline id and the trace id to handlers and writes it with the fields to the standard logger
+func (e *Entry) log(severity Severity, message string) {
+	prefix := e.fields.PipelineId
+	if e.fields.TraceId != "" {
+		prefix += " [" + e.fields.TraceId + "]"
+	}
+	prefixed := message
+	if prefix != "" {
+		prefixed = prefix + ": " + message
+	}
+	for _, handler := range handlers {
+		switch severity {
+		case INFO:
+			handler.Info(prefixed)
+		case WARN:
+			handler.Warn(prefixed)
+		case ERROR:
+			handler.Error(prefixed)
+		case DEBUG:
+			handler.Debug(prefixed)
+		}
+	}
+	if jsonFormat {
+		writeMessage(severity, e.fields, message)
+	} else {
+		writeMessage(severity, e.fields, prefixed)
+	}
+}
@@ -16,8 +16,12 @@
 package logger
 
 import (
+	"encoding/json"
 	"fmt"
 	"log"
+	"os"
+	"strings"
+	"time"
 )
 
 type Severity string
@@ -32,11 +36,21 @@ const (
 
 var handlers []Handler
 
+// jsonFormat is whether messages are written to the standard logger as JSON lines instead of plain lines
+var jsonFormat bool
+
 // SetHandlers set a new array of logger handlers
 func SetHandlers(h []Handler) {
 	handlers = h
 }
 
+// SetJsonFormat sets whether messages are written to the standard logger as JSON lines with
+// "ts", "level", "msg" fields and "pipelineId", "phase", "traceId" fields of messages logged via Entry.
+// Handlers receive messages in the same form in both formats.
+func SetJsonFormat(enabled bool) {
+	jsonFormat = enabled
+}
+
 // AddHandler adds a new handler to the array
 func AddHandler(h Handler) {
 	handlers = append(handlers, h)
@@ -102,20 +116,57 @@ func Fatal(args ...interface{}) {
 	for _, handler := range handlers {
 		handler.Fatal(args...)
 	}
-	args = append([]interface{}{FATAL}, args...)
-	log.Fatalln(args...)
+	logMessage(FATAL, args...)
+	os.Exit(1)
 }
 
 func Fatalf(format string, args ...interface{}) {
 	for _, handler := range handlers {
 		handler.Fatalf(format, args...)
 	}
-	args = append([]interface{}{FATAL}, fmt.Sprintf(format, args...))
-	log.Fatalln(args...)
+	logMessage(FATAL, fmt.Sprintf(format, args...))
+	os.Exit(1)
 }
 
 // logMessage logs a message at level severity.
 func logMessage(severity Severity, args ...interface{}) {
-	args = append([]interface{}{severity}, args...)
-	log.Println(args...)
+	writeMessage(severity, Fields{}, args...)
+}
+
+// writeMessage writes a message at level severity with fields to the standard logger.
+// In plain format fields are omitted since they are expected to be in the message already.
+func writeMessage(severity Severity, fields Fields, args ...interface{}) {
+	if !jsonFormat {
+		args = append([]interface{}{severity}, args...)
+		log.Println(args...)
+		return
+	}
+	line, err := json.Marshal(jsonMessage{
+		Ts:         time.Now().UTC().Format(time.RFC3339Nano),
+		Level:      severity.level(),
+		PipelineId: fields.PipelineId,
+		Phase:      fields.Phase,
+		TraceId:    fields.TraceId,
+		Msg:        strings.TrimRight(fmt.Sprintln(args...), "\n"),
+	})
+	if err != nil {
+		log.Println(severity, fmt.Sprint(args...))
+		return
+	}
+	fmt.Fprintln(log.Writer(), string(line))
+}
+
+// jsonMessage is a message written to the standard logger in JSON format
+type jsonMessage struct {
+	Ts         string `json:"ts"`
+	Level      string `json:"level"`
+	PipelineId string `json:"pipelineId,omitempty"`
+	Phase      string `json:"phase,omitempty"`
+	TraceId    string `json:"traceId,omitempty"`
+	Msg        string `json:"msg"`
+}
+
+// level returns the name of the severity without brackets, e.g. INFO
+func (s Severity) level() string {
+	return strings.Trim(string(s), "[]:")
 }
@@ -16,7 +16,11 @@
 package logger
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
+	"github.com/google/uuid"
 	"io/ioutil"
 	"log"
 	"os"
@@ -420,3 +424,84 @@ func TestFatalf(t *testing.T) {
 		})
 	}
 }
+
+func TestEntry_Infof(t *testing.T) {
+	pipelineId := uuid.New()
+	tests := []struct {
+		name        string
+		entry       *Entry
+		jsonFormat  bool
+		wantHandler string
+		wantOutput  string
+		wantFields  map[string]string
+	}{
+		{
+			// Test case with calling Infof method of Entry with the pipeline id in plain format.
+			// As a result, want to receive the message prefixed with the pipeline id by the handler and in the output.
+			name:        "plain format",
+			entry:       WithPipelineId(pipelineId),
+			wantHandler: fmt.Sprint(INFO, pipelineId.String()+": TEST FORMAT TEST_VALUE"),
+			wantOutput:  fmt.Sprintln(INFO, pipelineId.String()+": TEST FORMAT TEST_VALUE"),
+		},
+		{
+			// Test case with calling Infof method of Entry with the pipeline id and the trace id from the context in plain format.
+			// As a result, want to receive the message prefixed with the pipeline id and the trace id by the handler and in the output.
+			name:        "plain format with trace id",
+			entry:       WithPipelineId(pipelineId).WithContext(ContextWithTraceId(context.Background(), "MOCK_TRACE")),
+			wantHandler: fmt.Sprint(INFO, pipelineId.String()+" [MOCK_TRACE]: TEST FORMAT TEST_VALUE"),
+			wantOutput:  fmt.Sprintln(INFO, pipelineId.String()+" [MOCK_TRACE]: TEST FORMAT TEST_VALUE"),
+		},
+		{
+			// Test case with calling Infof method of Entry with the pipeline id, the phase and the trace id in JSON format.
+			// As a result, want to receive the message prefixed with the pipeline id and the trace id by the handler
+			// and the JSON line with the pipeline id, the phase and the trace id as separate fields in the output.
+			name:        "json format",
+			entry:       WithPipelineId(pipelineId).WithPhase("compile").WithContext(ContextWithTraceId(context.Background(), "MOCK_TRACE")),
+			jsonFormat:  true,
+			wantHandler: fmt.Sprint(INFO, pipelineId.String()+" [MOCK_TRACE]: TEST FORMAT TEST_VALUE"),
+			wantFields: map[string]string{
+				"level":      "INFO",
+				"pipelineId": pipelineId.String(),
+				"phase":      "compile",
+				"traceId":    "MOCK_TRACE",
+				"msg":        "TEST FORMAT TEST_VALUE",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var output bytes.Buffer
+			log.SetOutput(&output)
+			log.SetFlags(0)
+			SetJsonFormat(tt.jsonFormat)
+			defer func() {
+				log.SetOutput(os.Stderr)
+				log.SetFlags(log.LstdFlags)
+				SetJsonFormat(false)
+			}()
+
+			tt.entry.Infof("TEST FORMAT %s\n", "TEST_VALUE")
+			if got := preparedHandler.logs[len(preparedHandler.logs)-1]; got != tt.wantHandler+"\n" {
+				t.Errorf("Infof() handler got = %q, want %q", got, tt.wantHandler+"\n")
+			}
+			if !tt.jsonFormat {
+				if got := output.String(); got != tt.wantOutput+"\n" {
+					t.Errorf("Infof() output = %q, want %q", got, tt.wantOutput+"\n")
+				}
+				return
+			}
+			var fields map[string]string
+			if err := json.Unmarshal(output.Bytes(), &fields); err != nil {
+				t.Fatalf("Infof() output isn't a JSON line: %q", output.String())
+			}
+			if fields["ts"] == "" {
+				t.Errorf("Infof() output doesn't contain the timestamp: %q", output.String())
+			}
+			for name, want := range tt.wantFields {
+				if fields[name] != want {
+					t.Errorf("Infof() field %s = %q, want %q", name, fields[name], want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"net/http"
+	"time"
+)
+
+const (
+	phaseDurationName    = "playground_phase_duration_seconds"
+	processingStatusName = "playground_processing_total"
+	sdkLabel             = "sdk"
+	phaseLabel           = "phase"
+	statusLabel          = "status"
+)
+
+// DefaultBuckets are upper bounds of buckets of durations of phases in seconds
+var DefaultBuckets = []float64{0.1, 0.5, 1, 2.5, 5, 10, 30, 60, 120, 300}
+
+// Default is the collector of code processing which is served by the backend
+var Default = NewCollector(DefaultBuckets)
+
+// Collector collects durations of phases of code processing by SDK and phase and counts of pipelines by SDK and final status.
+// It implements prometheus.Collector, so it could be registered in a Prometheus registry and scraped from /metrics.
+type Collector struct {
+	durations *prometheus.HistogramVec
+	statuses  *prometheus.CounterVec
+}
+
+// NewCollector returns Collector with upper bounds of buckets of durations in seconds
+func NewCollector(buckets []float64) *Collector {
+	return &Collector{
+		durations: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    phaseDurationName,
+			Help:    "Duration of phases of code processing.",
+			Buckets: buckets,
+		}, []string{sdkLabel, phaseLabel}),
+		statuses: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: processingStatusName,
+			Help: "Number of pipelines by final status.",
+		}, []string{sdkLabel, statusLabel}),
+	}
+}
+
+// ObservePhaseDuration adds the duration of the phase of code processing for the SDK
+func (c *Collector) ObservePhaseDuration(sdk, phase string, duration time.Duration) {
+	c.durations.WithLabelValues(sdk, phase).Observe(duration.Seconds())
+}
+
+// IncStatus counts the pipeline of the SDK which is finished with the status
+func (c *Collector) IncStatus(sdk, status string) {
+	c.statuses.WithLabelValues(sdk, status).Inc()
+}
+
+// Describe sends descriptors of collected metrics to ch
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	c.durations.Describe(ch)
+	c.statuses.Describe(ch)
+}
+
+// Collect sends collected metrics to ch
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.durations.Collect(ch)
+	c.statuses.Collect(ch)
+}
+
+// Handler returns the handler which serves metrics of the collector in the Prometheus exposition format
+func (c *Collector) Handler() http.Handler {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(c)
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
@@ -0,0 +1,80 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"io/ioutil"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCollector_Handler(t *testing.T) {
+	tests := []struct {
+		name    string
+		observe func(c *Collector)
+		want    []string
+	}{
+		{
+			// Test case with calling Handler method with durations of the phase.
+			// As a result, want to receive cumulative counts of buckets, the sum and the count of durations.
+			name: "phase durations",
+			observe: func(c *Collector) {
+				c.ObservePhaseDuration("SDK_JAVA", "compile", 500*time.Millisecond)
+				c.ObservePhaseDuration("SDK_JAVA", "compile", 2*time.Second)
+			},
+			want: []string{
+				`playground_phase_duration_seconds_bucket{phase="compile",sdk="SDK_JAVA",le="1"} 1`,
+				`playground_phase_duration_seconds_bucket{phase="compile",sdk="SDK_JAVA",le="5"} 2`,
+				`playground_phase_duration_seconds_bucket{phase="compile",sdk="SDK_JAVA",le="+Inf"} 2`,
+				`playground_phase_duration_seconds_sum{phase="compile",sdk="SDK_JAVA"} 2.5`,
+				`playground_phase_duration_seconds_count{phase="compile",sdk="SDK_JAVA"} 2`,
+			},
+		},
+		{
+			// Test case with calling Handler method with canceled and timed out pipelines.
+			// As a result, want to receive distinct counters of their statuses.
+			name: "final statuses",
+			observe: func(c *Collector) {
+				c.IncStatus("SDK_GO", "STATUS_CANCELED")
+				c.IncStatus("SDK_GO", "STATUS_RUN_TIMEOUT")
+				c.IncStatus("SDK_GO", "STATUS_RUN_TIMEOUT")
+			},
+			want: []string{
+				`playground_processing_total{sdk="SDK_GO",status="STATUS_CANCELED"} 1`,
+				`playground_processing_total{sdk="SDK_GO",status="STATUS_RUN_TIMEOUT"} 2`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCollector([]float64{1, 5})
+			tt.observe(c)
+			recorder := httptest.NewRecorder()
+			c.Handler().ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+			body, err := ioutil.ReadAll(recorder.Body)
+			if err != nil {
+				t.Fatalf("Handler() error = %v", err)
+			}
+			for _, line := range tt.want {
+				if !strings.Contains(string(body), line+"\n") {
+					t.Errorf("Handler() body = %s, doesn't contain %s", body, line)
+				}
+			}
+		})
+	}
+}
@@ -15,7 +15,192 @@
 
 package preparators
 
+import (
+	"beam.apache.org/playground/backend/internal/logger"
+	"beam.apache.org/playground/backend/internal/streaming"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+)
+
+// futureImportsPattern matches "from __future__ import" statements which must stay at the beginning of Python code
+const futureImportsPattern = `(?m)^from __future__ import .*$`
+
+// pythonSamplingHookDir is the name of the folder next to the code which contains the sampling hook.
+// The folder is added to PYTHONPATH of the run step, so the hook is imported by Python at startup as the sitecustomize module.
+const pythonSamplingHookDir = "playground_hooks"
+
+// pythonSamplingHookFileName is the name of the module which is imported automatically by Python at startup
+const pythonSamplingHookFileName = "sitecustomize.py"
+
+// pythonSamplingHook is Python code which patches apache_beam.Pipeline.apply so that each PCollection produced
+// by a top-level transform is passed to a ParDo printing its first elements in the intermediate sample format.
+// Named transforms are skipped since Pipeline.apply is called again for the transform they wrap.
+// The only verb of the template is the maximum number of elements printed per PCollection.
+const pythonSamplingHook = `import apache_beam as _playground_beam
+
+
+class _PlaygroundSample(_playground_beam.DoFn):
+    def __init__(self, label, max_samples):
+        self._label = label
+        self._max_samples = max_samples
+        self._count = 0
+
+    def process(self, element):
+        if self._count < self._max_samples:
+            self._count += 1
+            print('` + streaming.IntermediateSamplePrefix + ` %%s\t%%r' %% (self._label, element), flush=True)
+
+
+_playground_apply = _playground_beam.Pipeline.apply
+
+
+def _playground_sampling_apply(self, transform, pvalueish=None, label=None):
+    result = _playground_apply(self, transform, pvalueish, label)
+    if (isinstance(result, _playground_beam.pvalue.PCollection) and len(self.transforms_stack) == 1
+            and not isinstance(transform, _playground_beam.transforms.ptransform._NamedPTransform)
+            and not isinstance(getattr(transform, 'fn', None), _PlaygroundSample)):
+        name = label or transform.label
+        _playground_apply(self, _playground_beam.ParDo(_PlaygroundSample(name, %d)), result, 'PlaygroundSample[%%s]' %% name)
+    return result
+
+
+_playground_beam.Pipeline.apply = _playground_sampling_apply
+
+`
+
+// pythonDependencyPath is Python code which adds the wheel uploaded by the client to the module search path,
+// so its pure Python modules could be imported by the code. The only verb of the template is the path to the wheel.
+const pythonDependencyPath = "import sys as _playground_sys; _playground_sys.path.insert(0, %q)\n"
+
+// pythonCrashReportLines is a number of the last lines of the traceback which are written to the crash report
+const pythonCrashReportLines = 20
+
+// pythonCrashReportWrapper is Python code which runs the original code as the __main__ module. In case the code
+// raises an exception it flushes stdout, writes a tail of the traceback to the crash report and exits with code 1.
+// The original code is compiled with its own file path and its lines are put into linecache,
+// so line numbers and lines of the code in tracebacks are the same as without the wrapper.
+// Verbs of the template are the file path, the crash report file path, the original code encoded in base64
+// and the number of lines of the crash report.
+const pythonCrashReportWrapper = `def _playground_run_main():
+    import base64, io, linecache, sys, tokenize, traceback
+
+    file_path = %q
+    crash_report_path = %q
+    source = base64.b64decode('%s')
+    try:
+        encoding, _ = tokenize.detect_encoding(io.BytesIO(source).readline)
+        linecache.cache[file_path] = (len(source), None, source.decode(encoding).splitlines(True), file_path)
+        code = compile(source, file_path, 'exec')
+        exec(code, sys.modules['__main__'].__dict__)
+    except SystemExit:
+        raise
+    except BaseException as e:
+        sys.stdout.flush()
+        report = ''.join(traceback.format_exception(type(e), e, e.__traceback__.tb_next))
+        with open(crash_report_path, 'w') as crash_report:
+            crash_report.write(''.join(report.splitlines(True)[-%d:]))
+        sys.stderr.write(report)
+        sys.exit(1)
+    finally:
+        sys.stdout.flush()
+
+
+_playground_run_main()
+`
+
 // GetPythonPreparators returns preparation methods that should be applied to Python code
 func GetPythonPreparators(filePath string) *[]Preparator {
 	return &[]Preparator{}
 }
+
+// GetPythonSamplingPreparator returns preparation method which instruments Python code to print
+// up to maxSamples elements of each PCollection produced by a top-level transform of the pipeline.
+// The code itself isn't changed, so line numbers in its tracebacks are kept: the hook is written to the folder
+// by PythonSamplingHookDir which should be added to PYTHONPATH of the run step.
+func GetPythonSamplingPreparator(filePath string, maxSamples int) Preparator {
+	return Preparator{Prepare: writeSamplingHook, Args: []interface{}{filePath, maxSamples}}
+}
+
+// PythonSamplingHookDir returns the path of the folder with the sampling hook of Python code by filePath
+func PythonSamplingHookDir(filePath string) string {
+	return filepath.Join(filepath.Dir(filePath), pythonSamplingHookDir)
+}
+
+// GetPythonDependencyPreparator returns preparation method which adds the wheel by dependencyPath
+// to the module search path of Python code, so modules of the wheel could be imported by the code.
+func GetPythonDependencyPreparator(filePath, dependencyPath string) Preparator {
+	return Preparator{Prepare: injectDependencyPath, Args: []interface{}{filePath, dependencyPath}}
+}
+
+// GetPythonCrashReportPreparator returns preparation method which wraps Python code, so in case the code crashes
+// stdout is flushed and a tail of the traceback is written to the file by crashReportFilePath.
+// Line numbers in tracebacks of the code aren't changed.
+func GetPythonCrashReportPreparator(filePath, crashReportFilePath string) Preparator {
+	return Preparator{Prepare: wrapMain, Args: []interface{}{filePath, crashReportFilePath}}
+}
+
+// writeSamplingHook writes the sampling hook as the sitecustomize module into the folder by PythonSamplingHookDir of filePath
+func writeSamplingHook(args ...interface{}) error {
+	filePath := args[0].(string)
+	maxSamples := args[1].(int)
+
+	hookDir := PythonSamplingHookDir(filePath)
+	if err := os.MkdirAll(hookDir, 0700); err != nil {
+		logger.Errorf("Preparation: Error during create folder: %s, err: %s\n", hookDir, err.Error())
+		return err
+	}
+	hookFilePath := filepath.Join(hookDir, pythonSamplingHookFileName)
+	if err := os.WriteFile(hookFilePath, []byte(fmt.Sprintf(pythonSamplingHook, maxSamples)), 0600); err != nil {
+		logger.Errorf("Preparation: Error during write file: %s, err: %s\n", hookFilePath, err.Error())
+		return err
+	}
+	return nil
+}
+
+// injectDependencyPath inserts the line adding the wheel to the module search path into the file by filePath after "from __future__" imports
+func injectDependencyPath(args ...interface{}) error {
+	filePath := args[0].(string)
+	dependencyPath := args[1].(string)
+
+	code, err := os.ReadFile(filePath)
+	if err != nil {
+		logger.Errorf("Preparation: Error during open file: %s, err: %s\n", filePath, err.Error())
+		return err
+	}
+	line := []byte(fmt.Sprintf(pythonDependencyPath, dependencyPath))
+	position := 0
+	if matches := regexp.MustCompile(futureImportsPattern).FindAllIndex(code, -1); len(matches) > 0 {
+		position = matches[len(matches)-1][1] + 1
+		if position > len(code) {
+			line = append([]byte("\n"), line...)
+			position = len(code)
+		}
+	}
+	prepared := append(append(append([]byte{}, code[:position]...), line...), code[position:]...)
+	if err = os.WriteFile(filePath, prepared, 0600); err != nil {
+		logger.Errorf("Preparation: Error during write file: %s, err: %s\n", filePath, err.Error())
+		return err
+	}
+	return nil
+}
+
+// wrapMain replaces the code in the file by filePath with the crash report wrapper which runs the code
+func wrapMain(args ...interface{}) error {
+	filePath := args[0].(string)
+	crashReportFilePath := args[1].(string)
+
+	code, err := os.ReadFile(filePath)
+	if err != nil {
+		logger.Errorf("Preparation: Error during open file: %s, err: %s\n", filePath, err.Error())
+		return err
+	}
+	wrapper := fmt.Sprintf(pythonCrashReportWrapper, filePath, crashReportFilePath, base64.StdEncoding.EncodeToString(code), pythonCrashReportLines)
+	if err = os.WriteFile(filePath, []byte(wrapper), 0600); err != nil {
+		logger.Errorf("Preparation: Error during write file: %s, err: %s\n", filePath, err.Error())
+		return err
+	}
+	return nil
+}
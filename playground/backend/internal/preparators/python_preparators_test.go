@@ -0,0 +1,237 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package preparators
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func Test_writeSamplingHook(t *testing.T) {
+	code := "import apache_beam as beam\n\nwith beam.Pipeline() as p:\n    p | beam.Create([1, 2, 3])\n"
+	hook := fmt.Sprintf(pythonSamplingHook, 3)
+
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "main.py")
+	if err := os.WriteFile(filePath, []byte(code), 0600); err != nil {
+		panic(err)
+	}
+
+	tests := []struct {
+		name    string
+		args    []interface{}
+		wantErr bool
+	}{
+		{
+			// Test case with calling writeSamplingHook method with the code which folder couldn't contain the folder of the hook.
+			// As a result, want to receive an error.
+			name:    "folder of the hook couldn't be created",
+			args:    []interface{}{filepath.Join(filePath, "main.py"), 3},
+			wantErr: true,
+		},
+		{
+			// Test case with calling writeSamplingHook method with the code.
+			// As a result, want to find the hook as the sitecustomize module in the folder of the hook and the code unchanged.
+			name:    "hook is written",
+			args:    []interface{}{filePath, 3},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := writeSamplingHook(tt.args...); (err != nil) != tt.wantErr {
+				t.Errorf("writeSamplingHook() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr {
+				data, err := os.ReadFile(filepath.Join(PythonSamplingHookDir(filePath), "sitecustomize.py"))
+				if err != nil {
+					t.Errorf("writeSamplingHook() unexpected error = %v", err)
+				}
+				if string(data) != hook {
+					t.Errorf("writeSamplingHook() hook = {%v}, want {%v}", string(data), hook)
+				}
+				if data, _ := os.ReadFile(filePath); string(data) != code {
+					t.Errorf("writeSamplingHook() code = {%v}, want the code unchanged {%v}", string(data), code)
+				}
+			}
+		})
+	}
+}
+
+func Test_injectDependencyPath(t *testing.T) {
+	code := "import transforms\n\nprint(transforms.VERSION)\n"
+	futureImports := "from __future__ import absolute_import\nfrom __future__ import print_function"
+	dependencyPath := "/path/to/transforms.whl"
+	line := fmt.Sprintf(pythonDependencyPath, dependencyPath)
+
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "main.py")
+	filePathWithFutureImports := filepath.Join(dir, "future.py")
+	filePathWithFutureImportsOnly := filepath.Join(dir, "future_only.py")
+	if err := os.WriteFile(filePath, []byte(code), 0600); err != nil {
+		panic(err)
+	}
+	if err := os.WriteFile(filePathWithFutureImports, []byte(futureImports+"\n"+code), 0600); err != nil {
+		panic(err)
+	}
+	if err := os.WriteFile(filePathWithFutureImportsOnly, []byte(futureImports), 0600); err != nil {
+		panic(err)
+	}
+
+	tests := []struct {
+		name     string
+		args     []interface{}
+		wantCode string
+		wantErr  bool
+	}{
+		{
+			// Test case with calling injectDependencyPath method with a file which doesn't exist.
+			// As a result, want to receive an error.
+			name:    "original file doesn't exist",
+			args:    []interface{}{filepath.Join(dir, "someFile.py"), dependencyPath},
+			wantErr: true,
+		},
+		{
+			// Test case with calling injectDependencyPath method with code without "from __future__" imports.
+			// As a result, want to find the line adding the wheel to the module search path at the beginning of the code.
+			name:     "code without future imports",
+			args:     []interface{}{filePath, dependencyPath},
+			wantCode: line + code,
+			wantErr:  false,
+		},
+		{
+			// Test case with calling injectDependencyPath method with code with "from __future__" imports.
+			// As a result, want to find the line adding the wheel to the module search path on the next line after the last of them.
+			name:     "code with future imports",
+			args:     []interface{}{filePathWithFutureImports, dependencyPath},
+			wantCode: futureImports + "\n" + line + code,
+			wantErr:  false,
+		},
+		{
+			// Test case with calling injectDependencyPath method with code which ends with "from __future__" import without a line break.
+			// As a result, want to find the line adding the wheel to the module search path on the next line.
+			name:     "code with future imports only",
+			args:     []interface{}{filePathWithFutureImportsOnly, dependencyPath},
+			wantCode: futureImports + "\n" + line,
+			wantErr:  false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := injectDependencyPath(tt.args...); (err != nil) != tt.wantErr {
+				t.Errorf("injectDependencyPath() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr {
+				data, err := os.ReadFile(tt.args[0].(string))
+				if err != nil {
+					t.Errorf("injectDependencyPath() unexpected error = %v", err)
+				}
+				if string(data) != tt.wantCode {
+					t.Errorf("injectDependencyPath() code = {%v}, wantCode {%v}", string(data), tt.wantCode)
+				}
+			}
+		})
+	}
+}
+
+func Test_wrapMain(t *testing.T) {
+	code := "import apache_beam as beam\n\nwith beam.Pipeline() as p:\n    p | beam.Create([1, 2, 3])\n"
+
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "main.py")
+	crashReportFilePath := filepath.Join(dir, "main.crash")
+	if err := os.WriteFile(filePath, []byte(code), 0600); err != nil {
+		panic(err)
+	}
+
+	tests := []struct {
+		name     string
+		args     []interface{}
+		wantCode string
+		wantErr  bool
+	}{
+		{
+			// Test case with calling wrapMain method with a file which doesn't exist.
+			// As a result, want to receive an error.
+			name:    "original file doesn't exist",
+			args:    []interface{}{filepath.Join(dir, "someFile.py"), crashReportFilePath},
+			wantErr: true,
+		},
+		{
+			// Test case with calling wrapMain method with a file with code.
+			// As a result, want to find the wrapper with the code encoded in base64 instead of the code.
+			name:     "wrap code",
+			args:     []interface{}{filePath, crashReportFilePath},
+			wantCode: fmt.Sprintf(pythonCrashReportWrapper, filePath, crashReportFilePath, base64.StdEncoding.EncodeToString([]byte(code)), pythonCrashReportLines),
+			wantErr:  false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := wrapMain(tt.args...); (err != nil) != tt.wantErr {
+				t.Errorf("wrapMain() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr {
+				data, err := os.ReadFile(tt.args[0].(string))
+				if err != nil {
+					t.Errorf("wrapMain() unexpected error = %v", err)
+				}
+				if string(data) != tt.wantCode {
+					t.Errorf("wrapMain() code = {%v}, wantCode {%v}", string(data), tt.wantCode)
+				}
+			}
+		})
+	}
+}
+
+func Test_wrapMain_crashReport(t *testing.T) {
+	if _, err := exec.LookPath("python3"); err != nil {
+		t.Skip("python3 isn't installed")
+	}
+	code := "from __future__ import print_function\n\nprint('output before the crash')\n\n\ndef fail():\n    raise ValueError('crash')\n\n\nfail()\n"
+
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "main.py")
+	crashReportFilePath := filepath.Join(dir, "main.crash")
+	if err := os.WriteFile(filePath, []byte(code), 0600); err != nil {
+		panic(err)
+	}
+	if err := wrapMain(filePath, crashReportFilePath); err != nil {
+		t.Fatalf("wrapMain() unexpected error = %v", err)
+	}
+
+	output, err := exec.Command("python3", filePath).CombinedOutput()
+	if err == nil {
+		t.Fatalf("wrapped code exited successfully, output: %s", output)
+	}
+	if !strings.HasPrefix(string(output), "output before the crash\n") {
+		t.Errorf("wrapped code output = %s, want the output before the crash first", output)
+	}
+	report, err := os.ReadFile(crashReportFilePath)
+	if err != nil {
+		t.Fatalf("wrapped code didn't write the crash report: %v", err)
+	}
+	for _, want := range []string{fmt.Sprintf("File \"%s\", line 10, in <module>", filePath), fmt.Sprintf("File \"%s\", line 7, in fail", filePath), "ValueError: crash"} {
+		if !strings.Contains(string(report), want) {
+			t.Errorf("crash report = %s, want it contains %s", report, want)
+		}
+	}
+}
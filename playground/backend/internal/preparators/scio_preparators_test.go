@@ -0,0 +1,61 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package preparators
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGetScioPreparators(t *testing.T) {
+	code := "package org.example\n\nimport com.spotify.scio._\n\nobject WordCount {\n  val packageName = \"package\"\n}"
+	wantCode := "\n\nimport com.spotify.scio._\n\nobject WordCount {\n  val packageName = \"package\"\n}"
+	filePath := filepath.Join(t.TempDir(), "WordCount.scala")
+	if err := os.WriteFile(filePath, []byte(code), 0600); err != nil {
+		panic(err)
+	}
+
+	tests := []struct {
+		name     string
+		filePath string
+		wantCode string
+	}{
+		{
+			// Test case with calling GetScioPreparators method and applying its preparators to scio code with the package clause.
+			// As a result, want to receive the code without the package clause.
+			name:     "remove package clause",
+			filePath: filePath,
+			wantCode: wantCode,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, preparator := range *GetScioPreparators(tt.filePath) {
+				if err := preparator.Prepare(preparator.Args...); err != nil {
+					t.Fatalf("GetScioPreparators() preparator error = %v", err)
+				}
+			}
+			data, err := os.ReadFile(tt.filePath)
+			if err != nil {
+				t.Fatalf("GetScioPreparators() unexpected error = %v", err)
+			}
+			if string(data) != tt.wantCode {
+				t.Errorf("GetScioPreparators() code = {%v}, wantCode {%v}", string(data), tt.wantCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcs
+
+import (
+	"beam.apache.org/playground/backend/internal/result_storage"
+	"cloud.google.com/go/storage"
+	"context"
+	"fmt"
+	"google.golang.org/api/option"
+	"io/ioutil"
+)
+
+// Storage keeps objects in the bucket of Google Cloud Storage
+type Storage struct {
+	client *storage.Client
+	bucket string
+}
+
+// New returns Storage which keeps objects in the bucket.
+// In case credentialsFile is empty default credentials of the environment are used.
+func New(ctx context.Context, bucket, credentialsFile string) (*Storage, error) {
+	var options []option.ClientOption
+	if credentialsFile != "" {
+		options = append(options, option.WithCredentialsFile(credentialsFile))
+	}
+	client, err := storage.NewClient(ctx, options...)
+	if err != nil {
+		return nil, err
+	}
+	return &Storage{client: client, bucket: bucket}, nil
+}
+
+// Put writes data to the object with the name and returns the "gs://" url of the object
+func (s *Storage) Put(ctx context.Context, name string, data []byte) (string, error) {
+	writer := s.client.Bucket(s.bucket).Object(name).NewWriter(ctx)
+	if _, err := writer.Write(data); err != nil {
+		writer.Close()
+		return "", err
+	}
+	if err := writer.Close(); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("gs://%s/%s", s.bucket, name), nil
+}
+
+// Get reads data of the object with the name.
+// In case the object doesn't exist returns result_storage.ErrNotFound.
+func (s *Storage) Get(ctx context.Context, name string) ([]byte, error) {
+	reader, err := s.client.Bucket(s.bucket).Object(name).NewReader(ctx)
+	if err == storage.ErrObjectNotExist {
+		return nil, result_storage.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return ioutil.ReadAll(reader)
+}
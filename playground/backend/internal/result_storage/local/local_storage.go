@@ -0,0 +1,59 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local
+
+import (
+	"beam.apache.org/playground/backend/internal/result_storage"
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+const fileMode = 0600
+
+// Storage keeps objects as files in the directory on the local file system
+type Storage struct {
+	dir string
+}
+
+// New returns Storage which keeps objects in the dir.
+// Creates the dir if it doesn't exist.
+func New(dir string) (*Storage, error) {
+	if err := os.MkdirAll(dir, fs.ModePerm); err != nil {
+		return nil, err
+	}
+	return &Storage{dir: dir}, nil
+}
+
+// Put writes data to the file with the name and returns the path to the file as the "file://" url
+func (s *Storage) Put(ctx context.Context, name string, data []byte) (string, error) {
+	path := filepath.Join(s.dir, name)
+	if err := os.WriteFile(path, data, fileMode); err != nil {
+		return "", err
+	}
+	return "file://" + path, nil
+}
+
+// Get reads data of the file with the name.
+// In case the file doesn't exist returns result_storage.ErrNotFound.
+func (s *Storage) Get(ctx context.Context, name string) ([]byte, error) {
+	data, err := os.ReadFile(filepath.Join(s.dir, name))
+	if os.IsNotExist(err) {
+		return nil, result_storage.ErrNotFound
+	}
+	return data, err
+}
@@ -0,0 +1,66 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local
+
+import (
+	"beam.apache.org/playground/backend/internal/result_storage"
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestStorage_PutGet(t *testing.T) {
+	storage, err := New(t.TempDir())
+	if err != nil {
+		t.Fatalf("error during creating the storage: %s", err.Error())
+	}
+	if _, err = storage.Put(context.Background(), "MOCK_OBJECT", []byte("MOCK_DATA")); err != nil {
+		t.Fatalf("error during putting the object: %s", err.Error())
+	}
+	tests := []struct {
+		name    string
+		object  string
+		want    []byte
+		wantErr error
+	}{
+		{
+			// Test case with calling Get method with the name of the put object.
+			// As a result, want to receive data of the object.
+			name:   "object exists",
+			object: "MOCK_OBJECT",
+			want:   []byte("MOCK_DATA"),
+		},
+		{
+			// Test case with calling Get method with the name of the object which isn't put.
+			// As a result, want to receive result_storage.ErrNotFound.
+			name:    "object doesn't exist",
+			object:  "MOCK_MISSING_OBJECT",
+			wantErr: result_storage.ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := storage.Get(context.Background(), tt.object)
+			if err != tt.wantErr {
+				t.Errorf("Get() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Get() got = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
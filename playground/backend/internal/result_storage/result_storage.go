@@ -0,0 +1,72 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package result_storage
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/google/uuid"
+)
+
+// resultExtension is the extension of objects with results of pipelines
+const resultExtension = ".json"
+
+// ErrNotFound is returned by Storage.Get in case the object doesn't exist
+var ErrNotFound = errors.New("object not found")
+
+// Storage is used to persist results of finished pipelines for longer than the cache keeps them
+type Storage interface {
+	// Put saves data as the object with the name and returns the reference to the object.
+	Put(ctx context.Context, name string, data []byte) (string, error)
+
+	// Get returns data of the object with the name.
+	// In case the object doesn't exist returns ErrNotFound.
+	Get(ctx context.Context, name string) ([]byte, error)
+}
+
+// Result contains outputs of the finished pipeline which are persisted to Storage
+type Result struct {
+	Status        pb.Status `json:"status"`
+	CompileOutput string    `json:"compile_output"`
+	RunOutput     string    `json:"run_output"`
+	RunError      string    `json:"run_error"`
+	Source        string    `json:"source"`
+}
+
+// PutResult persists the result of the pipeline to the storage and returns the reference to the object
+func PutResult(ctx context.Context, storage Storage, pipelineId uuid.UUID, result Result) (string, error) {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return "", err
+	}
+	return storage.Put(ctx, pipelineId.String()+resultExtension, data)
+}
+
+// GetResult returns the result of the pipeline persisted by PutResult.
+// In case the result isn't persisted returns ErrNotFound.
+func GetResult(ctx context.Context, storage Storage, pipelineId uuid.UUID) (*Result, error) {
+	data, err := storage.Get(ctx, pipelineId.String()+resultExtension)
+	if err != nil {
+		return nil, err
+	}
+	result := &Result{}
+	if err = json.Unmarshal(data, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,86 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package result_storage
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"context"
+	"github.com/google/uuid"
+	"reflect"
+	"testing"
+)
+
+// mockStorage keeps objects in memory
+type mockStorage map[string][]byte
+
+func (m mockStorage) Put(ctx context.Context, name string, data []byte) (string, error) {
+	m[name] = data
+	return "mock://" + name, nil
+}
+
+func (m mockStorage) Get(ctx context.Context, name string) ([]byte, error) {
+	data, ok := m[name]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return data, nil
+}
+
+func TestGetResult(t *testing.T) {
+	storage := mockStorage{}
+	persistedPipelineId := uuid.New()
+	result := Result{Status: pb.Status_STATUS_FINISHED, CompileOutput: "MOCK_COMPILE_OUTPUT", RunOutput: "MOCK_RUN_OUTPUT"}
+	reference, err := PutResult(context.Background(), storage, persistedPipelineId, result)
+	if err != nil {
+		t.Fatalf("error during putting the result: %s", err.Error())
+	}
+	if wantReference := "mock://" + persistedPipelineId.String() + resultExtension; reference != wantReference {
+		t.Errorf("PutResult() reference = %s, want %s", reference, wantReference)
+	}
+	tests := []struct {
+		name       string
+		pipelineId uuid.UUID
+		want       *Result
+		wantErr    error
+	}{
+		{
+			// Test case with calling GetResult method with pipelineId which result is persisted.
+			// As a result, want to receive the persisted result.
+			name:       "result is persisted",
+			pipelineId: persistedPipelineId,
+			want:       &result,
+		},
+		{
+			// Test case with calling GetResult method with pipelineId which result isn't persisted.
+			// As a result, want to receive ErrNotFound.
+			name:       "result isn't persisted",
+			pipelineId: uuid.New(),
+			wantErr:    ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetResult(context.Background(), storage, tt.pipelineId)
+			if err != tt.wantErr {
+				t.Errorf("GetResult() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetResult() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -19,12 +19,27 @@ import (
 	pb "beam.apache.org/playground/backend/internal/api/v1"
 	"beam.apache.org/playground/backend/internal/environment"
 	"beam.apache.org/playground/backend/internal/executors"
+	"beam.apache.org/playground/backend/internal/preparators"
 	"beam.apache.org/playground/backend/internal/utils"
 	"fmt"
 )
 
-// SetupExecutorBuilder return executor with set args for validator, preparator, compiler and runner
-func SetupExecutorBuilder(srcFilePath, baseFolderPath, execFilePath string, sdkEnv *environment.BeamEnvs) (*executors.ExecutorBuilder, error) {
+// SetupExecutorBuilder return executor with set args for validator, preparator, compiler and runner.
+// In case the SDK supports tests sets args for test runner as well.
+// additionalSrcFilePaths are compiled together with srcFilePath, validators and preparators are applied to srcFilePath only.
+// pipelineArgs are passed to the runner after the executable file name.
+// In case beamVersion isn't empty jars of this version of Apache Beam are used instead of jars of the default version,
+// if the version isn't available returns an error which wraps errors.ErrUnavailableVersion.
+// In case dependencyPath isn't empty the artifact uploaded by the client is added to the classpath of the Java code
+// or to the module search path of the Python code.
+func SetupExecutorBuilder(srcFilePath string, additionalSrcFilePaths []string, baseFolderPath, execFilePath string, pipelineArgs []string, beamVersion, dependencyPath string, sdkEnv *environment.BeamEnvs) (*executors.ExecutorBuilder, error) {
+	sdkEnv, err := sdkEnv.WithBeamVersion(beamVersion)
+	if err != nil {
+		return nil, err
+	}
+	if dependencyPath != "" {
+		sdkEnv = sdkEnv.WithClasspathLibraries([]string{dependencyPath})
+	}
 	sdk := sdkEnv.ApacheBeamSdk
 
 	val, err := utils.GetValidators(sdk, srcFilePath)
@@ -45,14 +60,16 @@ func SetupExecutorBuilder(srcFilePath, baseFolderPath, execFilePath string, sdkE
 		WithCommand(executorConfig.CompileCmd).
 		WithArgs(executorConfig.CompileArgs).
 		WithFileName(srcFilePath).
+		WithAdditionalFileNames(additionalSrcFilePaths).
 		WithWorkingDir(baseFolderPath).
 		WithRunner().
 		WithCommand(executorConfig.RunCmd).
 		WithArgs(executorConfig.RunArgs).
+		WithPipelineArgs(pipelineArgs).
 		WithWorkingDir(baseFolderPath)
 
 	switch sdk {
-	case pb.Sdk_SDK_JAVA: // Executable name for java class will be known after compilation
+	case pb.Sdk_SDK_JAVA, pb.Sdk_SDK_SCIO: // Executable name for java class and scala object will be known after compilation
 	case pb.Sdk_SDK_GO:
 		builder = builder.WithCommand(execFilePath)
 	case pb.Sdk_SDK_PYTHON:
@@ -60,5 +77,82 @@ func SetupExecutorBuilder(srcFilePath, baseFolderPath, execFilePath string, sdkE
 	default:
 		return nil, fmt.Errorf("incorrect sdk: %s", sdkEnv.ApacheBeamSdk)
 	}
-	return &builder.ExecutorBuilder, nil
+	executorBuilder := builder.ExecutorBuilder
+	if testConfig := executorConfig.Test; testConfig != nil {
+		executorBuilder = executorBuilder.
+			WithTestRunner().
+			WithCommand(testConfig.Cmd).
+			WithArgs(testConfig.Args).
+			WithWorkingDir(baseFolderPath).
+			ExecutorBuilder
+	}
+	if dependencyPath != "" && sdk == pb.Sdk_SDK_PYTHON {
+		executorBuilder = executorBuilder.
+			WithPreparator().
+			WithExtraPreparators(&[]preparators.Preparator{preparators.GetPythonDependencyPreparator(srcFilePath, dependencyPath)}).
+			ExecutorBuilder
+	}
+	return &executorBuilder, nil
+}
+
+// SetupCoverage returns executor builder which runs the code under the coverage tool of the SDK.
+// Compile args and the run command are wrapped according to sdkEnv.ExecutorConfig.Coverage, args for the coverage report are set as well.
+// sdkEnv.ExecutorConfig.Coverage should not be nil.
+func SetupCoverage(executorBuilder *executors.ExecutorBuilder, baseFolderPath, execFilePath string, sdkEnv *environment.BeamEnvs) *executors.ExecutorBuilder {
+	executorConfig := sdkEnv.ExecutorConfig
+	coverageConfig := executorConfig.Coverage
+
+	runCmd := executorConfig.RunCmd
+	if sdkEnv.ApacheBeamSdk == pb.Sdk_SDK_GO {
+		runCmd = execFilePath
+	}
+	runArgs := append([]string{}, coverageConfig.RunArgs...)
+	if coverageConfig.RunCmd != "" {
+		runArgs = append(runArgs, runCmd)
+		runCmd = coverageConfig.RunCmd
+	}
+	runArgs = append(runArgs, executorConfig.RunArgs...)
+
+	builder := executorBuilder.
+		WithRunner().
+		WithCommand(runCmd).
+		WithArgs(runArgs).
+		WithCoverage().
+		WithCommand(coverageConfig.ReportCmd).
+		WithArgs(coverageConfig.ReportArgs).
+		WithWorkingDir(baseFolderPath).
+		ExecutorBuilder
+	if len(coverageConfig.CompileArgs) > 0 {
+		builder = builder.WithCompiler().WithArgs(coverageConfig.CompileArgs).ExecutorBuilder
+	}
+	return &builder
+}
+
+// SetupIntermediateSamples returns executor builder which instruments the code during the prepare step
+// to sample up to maxSamples elements of each PCollection of the pipeline.
+// The code itself isn't changed, the folder by preparators.PythonSamplingHookDir should be added to PYTHONPATH of the run step.
+// Only Python code is instrumented, executor builder is returned unchanged for other SDKs.
+func SetupIntermediateSamples(executorBuilder *executors.ExecutorBuilder, srcFilePath string, maxSamples int, sdkEnv *environment.BeamEnvs) *executors.ExecutorBuilder {
+	if sdkEnv.ApacheBeamSdk != pb.Sdk_SDK_PYTHON {
+		return executorBuilder
+	}
+	builder := executorBuilder.
+		WithPreparator().
+		WithExtraPreparators(&[]preparators.Preparator{preparators.GetPythonSamplingPreparator(srcFilePath, maxSamples)}).
+		ExecutorBuilder
+	return &builder
+}
+
+// SetupCrashReport returns executor builder which wraps the code during the prepare step, so in case it crashes
+// a tail of the traceback is written to the file by crashReportFilePath.
+// Only Python code is wrapped, executor builder is returned unchanged for other SDKs.
+func SetupCrashReport(executorBuilder *executors.ExecutorBuilder, srcFilePath, crashReportFilePath string, sdkEnv *environment.BeamEnvs) *executors.ExecutorBuilder {
+	if sdkEnv.ApacheBeamSdk != pb.Sdk_SDK_PYTHON {
+		return executorBuilder
+	}
+	builder := executorBuilder.
+		WithPreparator().
+		WithExtraPreparators(&[]preparators.Preparator{preparators.GetPythonCrashReportPreparator(srcFilePath, crashReportFilePath)}).
+		ExecutorBuilder
+	return &builder
 }
@@ -20,6 +20,7 @@ import (
 	"beam.apache.org/playground/backend/internal/environment"
 	"beam.apache.org/playground/backend/internal/executors"
 	"beam.apache.org/playground/backend/internal/fs_tool"
+	"beam.apache.org/playground/backend/internal/preparators"
 	"beam.apache.org/playground/backend/internal/utils"
 	"fmt"
 	"github.com/google/uuid"
@@ -67,6 +68,7 @@ func TestSetupExecutor(t *testing.T) {
 		baseFolderPath string
 		execFilePath   string
 		sdkEnv         *environment.BeamEnvs
+		beamVersion    string
 	}
 	tests := []struct {
 		name    string
@@ -78,7 +80,7 @@ func TestSetupExecutor(t *testing.T) {
 			// Test case with calling Setup with incorrect SDK.
 			// As a result, want to receive an error.
 			name:    "incorrect sdk",
-			args:    args{lc.GetAbsoluteSourceFilePath(), lc.GetAbsoluteBaseFolderPath(), lc.GetAbsoluteExecutableFilePath(), environment.NewBeamEnvs(pb.Sdk_SDK_UNSPECIFIED, executorConfig, "")},
+			args:    args{lc.GetAbsoluteSourceFilePath(), lc.GetAbsoluteBaseFolderPath(), lc.GetAbsoluteExecutableFilePath(), environment.NewBeamEnvs(pb.Sdk_SDK_UNSPECIFIED, executorConfig, ""), ""},
 			want:    nil,
 			wantErr: true,
 		},
@@ -86,14 +88,22 @@ func TestSetupExecutor(t *testing.T) {
 			// Test case with calling Setup with correct SDK.
 			// As a result, want to receive an expected builder.
 			name:    "correct sdk",
-			args:    args{lc.GetAbsoluteSourceFilePath(), lc.GetAbsoluteBaseFolderPath(), lc.GetAbsoluteExecutableFilePath(), sdkEnv},
+			args:    args{lc.GetAbsoluteSourceFilePath(), lc.GetAbsoluteBaseFolderPath(), lc.GetAbsoluteExecutableFilePath(), sdkEnv, ""},
 			want:    wantExecutor,
 			wantErr: false,
 		},
+		{
+			// Test case with calling Setup with the version of Apache Beam which isn't available.
+			// As a result, want to receive an error.
+			name:    "unavailable beam version",
+			args:    args{lc.GetAbsoluteSourceFilePath(), lc.GetAbsoluteBaseFolderPath(), lc.GetAbsoluteExecutableFilePath(), sdkEnv, "2.0.0"},
+			want:    nil,
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := SetupExecutorBuilder(tt.args.srcFilePath, tt.args.baseFolderPath, tt.args.execFilePath, tt.args.sdkEnv)
+			got, err := SetupExecutorBuilder(tt.args.srcFilePath, nil, tt.args.baseFolderPath, tt.args.execFilePath, nil, tt.args.beamVersion, "", tt.args.sdkEnv)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("SetupExecutorBuilder() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -104,3 +114,138 @@ func TestSetupExecutor(t *testing.T) {
 		})
 	}
 }
+
+func TestSetupCoverage(t *testing.T) {
+	pipelineId := uuid.New()
+	lc, err := fs_tool.NewLifeCycle(pb.Sdk_SDK_GO, pipelineId, "")
+	if err != nil {
+		panic(err)
+	}
+	baseFolderPath := lc.GetAbsoluteBaseFolderPath()
+	execFilePath := lc.GetAbsoluteExecutableFilePath()
+	executorConfig := &environment.ExecutorConfig{
+		CompileCmd:  "MOCK_COMPILE_CMD",
+		RunCmd:      "MOCK_RUN_CMD",
+		CompileArgs: []string{"MOCK_COMPILE_ARG"},
+		RunArgs:     []string{"MOCK_RUN_ARG"},
+		Coverage: &environment.CoverageConfig{
+			RunArgs:    []string{"MOCK_COVERAGE_RUN_ARG"},
+			ReportCmd:  "MOCK_REPORT_CMD",
+			ReportArgs: []string{"MOCK_REPORT_ARG"},
+		},
+	}
+	wrappingConfig := &environment.ExecutorConfig{
+		CompileCmd:  "MOCK_COMPILE_CMD",
+		CompileArgs: []string{"MOCK_COMPILE_ARG"},
+		Coverage: &environment.CoverageConfig{
+			CompileArgs: []string{"MOCK_COVERAGE_COMPILE_ARG"},
+			RunCmd:      "MOCK_COVERAGE_RUN_CMD",
+			RunArgs:     []string{"MOCK_COVERAGE_RUN_ARG"},
+			ReportCmd:   "MOCK_REPORT_CMD",
+			ReportArgs:  []string{"MOCK_REPORT_ARG"},
+		},
+	}
+
+	type args struct {
+		executorBuilder *executors.ExecutorBuilder
+		sdkEnv          *environment.BeamEnvs
+	}
+	tests := []struct {
+		name string
+		args args
+		want *executors.ExecutorBuilder
+	}{
+		{
+			// Test case with calling SetupCoverage with the coverage config which adds args to the run command.
+			// As a result, want to receive the builder with coverage args before run args.
+			name: "coverage run args",
+			args: args{
+				executorBuilder: executors.NewExecutorBuilder(),
+				sdkEnv:          environment.NewBeamEnvs(pb.Sdk_SDK_PYTHON, executorConfig, ""),
+			},
+			want: &executors.NewExecutorBuilder().
+				WithRunner().
+				WithCommand("MOCK_RUN_CMD").
+				WithArgs([]string{"MOCK_COVERAGE_RUN_ARG", "MOCK_RUN_ARG"}).
+				WithCoverage().
+				WithCommand("MOCK_REPORT_CMD").
+				WithArgs([]string{"MOCK_REPORT_ARG"}).
+				WithWorkingDir(baseFolderPath).
+				ExecutorBuilder,
+		},
+		{
+			// Test case with calling SetupCoverage with the coverage config which wraps the go executable and changes compile args.
+			// As a result, want to receive the builder which runs the executable by the coverage run command.
+			name: "coverage run command",
+			args: args{
+				executorBuilder: executors.NewExecutorBuilder(),
+				sdkEnv:          environment.NewBeamEnvs(pb.Sdk_SDK_GO, wrappingConfig, ""),
+			},
+			want: &executors.NewExecutorBuilder().
+				WithRunner().
+				WithCommand("MOCK_COVERAGE_RUN_CMD").
+				WithArgs([]string{"MOCK_COVERAGE_RUN_ARG", execFilePath}).
+				WithCoverage().
+				WithCommand("MOCK_REPORT_CMD").
+				WithArgs([]string{"MOCK_REPORT_ARG"}).
+				WithWorkingDir(baseFolderPath).
+				WithCompiler().
+				WithArgs([]string{"MOCK_COVERAGE_COMPILE_ARG"}).
+				ExecutorBuilder,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SetupCoverage(tt.args.executorBuilder, baseFolderPath, execFilePath, tt.args.sdkEnv)
+			if fmt.Sprint(got.Build()) != fmt.Sprint(tt.want.Build()) {
+				t.Errorf("SetupCoverage() got = %v, want %v", got.Build(), tt.want.Build())
+			}
+		})
+	}
+}
+
+func TestSetupIntermediateSamples(t *testing.T) {
+	srcFilePath := "MOCK_SRC_FILE_PATH"
+	executorConfig := &environment.ExecutorConfig{
+		RunCmd:  "MOCK_RUN_CMD",
+		RunArgs: []string{"MOCK_RUN_ARG"},
+	}
+	newExecutorBuilder := func() *executors.ExecutorBuilder {
+		return &executors.NewExecutorBuilder().
+			WithRunner().
+			WithCommand("MOCK_RUN_CMD").
+			ExecutorBuilder
+	}
+
+	tests := []struct {
+		name   string
+		sdkEnv *environment.BeamEnvs
+		want   *executors.ExecutorBuilder
+	}{
+		{
+			// Test case with calling SetupIntermediateSamples for Python code.
+			// As a result, want to receive the builder with the sampling preparator.
+			name:   "python code",
+			sdkEnv: environment.NewBeamEnvs(pb.Sdk_SDK_PYTHON, executorConfig, ""),
+			want: &newExecutorBuilder().
+				WithPreparator().
+				WithExtraPreparators(&[]preparators.Preparator{preparators.GetPythonSamplingPreparator(srcFilePath, 3)}).
+				ExecutorBuilder,
+		},
+		{
+			// Test case with calling SetupIntermediateSamples for Java code which isn't instrumented.
+			// As a result, want to receive the builder unchanged.
+			name:   "java code",
+			sdkEnv: environment.NewBeamEnvs(pb.Sdk_SDK_JAVA, executorConfig, ""),
+			want:   newExecutorBuilder(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SetupIntermediateSamples(newExecutorBuilder(), srcFilePath, 3, tt.sdkEnv)
+			if fmt.Sprint(got.Build()) != fmt.Sprint(tt.want.Build()) {
+				t.Errorf("SetupIntermediateSamples() got = %v, want %v", got.Build(), tt.want.Build())
+			}
+		})
+	}
+}
@@ -17,25 +17,27 @@ package life_cycle
 
 import (
 	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"beam.apache.org/playground/backend/internal/environment"
 	"beam.apache.org/playground/backend/internal/fs_tool"
 	"beam.apache.org/playground/backend/internal/logger"
 	"github.com/google/uuid"
 )
 
 // Setup returns fs_tool.LifeCycle.
-// Also, prepares files and folders needed to code processing according to sdk
-func Setup(sdk pb.Sdk, code string, pipelineId uuid.UUID, workingDir string, preparedModDir string) (*fs_tool.LifeCycle, error) {
+// Also, prepares files and folders needed to code processing according to sdk.
+// In case lifeCycleConfig is nil, default conventions of files for the sdk are used.
+func Setup(sdk pb.Sdk, code string, pipelineId uuid.UUID, workingDir string, preparedModDir string, lifeCycleConfig *environment.LifeCycleConfig) (*fs_tool.LifeCycle, error) {
 	// create file system service
-	lc, err := fs_tool.NewLifeCycle(sdk, pipelineId, workingDir)
+	lc, err := fs_tool.NewLifeCycleWithConfig(sdk, lifeCycleConfig, pipelineId, workingDir)
 	if err != nil {
-		logger.Errorf("%s: RunCode(): NewLifeCycle(): %s\n", pipelineId, err.Error())
+		logger.WithPipelineId(pipelineId).Errorf("RunCode(): NewLifeCycle(): %s\n", err.Error())
 		return nil, err
 	}
 
 	// create folders
 	err = lc.CreateFolders()
 	if err != nil {
-		logger.Errorf("%s: RunCode(): CreateFolders(): %s\n", pipelineId, err.Error())
+		logger.WithPipelineId(pipelineId).Errorf("RunCode(): CreateFolders(): %s\n", err.Error())
 		return nil, err
 	}
 
@@ -43,7 +45,7 @@ func Setup(sdk pb.Sdk, code string, pipelineId uuid.UUID, workingDir string, pre
 	if sdk == pb.Sdk_SDK_GO {
 		err = lc.CopyFiles(workingDir, preparedModDir)
 		if err != nil {
-			logger.Errorf("%s: RunCode(): CopyFiles(): %s\n", pipelineId, err.Error())
+			logger.WithPipelineId(pipelineId).Errorf("RunCode(): CopyFiles(): %s\n", err.Error())
 			lc.DeleteFolders()
 			return nil, err
 		}
@@ -52,7 +54,7 @@ func Setup(sdk pb.Sdk, code string, pipelineId uuid.UUID, workingDir string, pre
 	// create file with code
 	_, err = lc.CreateSourceCodeFile(code)
 	if err != nil {
-		logger.Errorf("%s: RunCode(): CreateSourceCodeFile(): %s\n", pipelineId, err.Error())
+		logger.WithPipelineId(pipelineId).Errorf("RunCode(): CreateSourceCodeFile(): %s\n", err.Error())
 		lc.DeleteFolders()
 		return nil, err
 	}
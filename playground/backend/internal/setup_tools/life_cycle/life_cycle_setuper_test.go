@@ -17,6 +17,7 @@ package life_cycle
 
 import (
 	playground "beam.apache.org/playground/backend/internal/api/v1"
+	"beam.apache.org/playground/backend/internal/environment"
 	"beam.apache.org/playground/backend/internal/fs_tool"
 	"github.com/google/uuid"
 	"os"
@@ -32,11 +33,12 @@ func TestSetup(t *testing.T) {
 		panic(err)
 	}
 	type args struct {
-		sdk            playground.Sdk
-		code           string
-		pipelineId     uuid.UUID
-		workingDir     string
-		preparedModDir string
+		sdk             playground.Sdk
+		code            string
+		pipelineId      uuid.UUID
+		workingDir      string
+		preparedModDir  string
+		lifeCycleConfig *environment.LifeCycleConfig
 	}
 	tests := []struct {
 		name    string
@@ -96,7 +98,7 @@ func TestSetup(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := Setup(tt.args.sdk, tt.args.code, tt.args.pipelineId, tt.args.workingDir, tt.args.preparedModDir)
+			got, err := Setup(tt.args.sdk, tt.args.code, tt.args.pipelineId, tt.args.workingDir, tt.args.preparedModDir, tt.args.lifeCycleConfig)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Setup() error = %v, wantErr %v", err, tt.wantErr)
 				return
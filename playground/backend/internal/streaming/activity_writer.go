@@ -0,0 +1,52 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package streaming
+
+import (
+	"sync"
+	"time"
+)
+
+// ActivityWriter is used to track when the command has written its output for the last time.
+// It doesn't keep the output, so it should be combined with other writers (e.g. by io.MultiWriter).
+// It could be used by several writers of the command (e.g. stdout and stderr) concurrently.
+type ActivityWriter struct {
+	mu        sync.Mutex
+	lastWrite time.Time
+}
+
+// NewActivityWriter returns ActivityWriter which considers start as the time of the last write until something is written
+func NewActivityWriter(start time.Time) *ActivityWriter {
+	return &ActivityWriter{lastWrite: start}
+}
+
+// Write saves the current time as the time of the last write if p isn't empty.
+// Always returns (len(p), nil).
+func (aw *ActivityWriter) Write(p []byte) (int, error) {
+	if len(p) > 0 {
+		aw.mu.Lock()
+		aw.lastWrite = time.Now()
+		aw.mu.Unlock()
+	}
+	return len(p), nil
+}
+
+// IdleTime returns the duration from the last write until now
+func (aw *ActivityWriter) IdleTime(now time.Time) time.Duration {
+	aw.mu.Lock()
+	defer aw.mu.Unlock()
+	return now.Sub(aw.lastWrite)
+}
@@ -0,0 +1,74 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package streaming
+
+import (
+	"testing"
+	"time"
+)
+
+func TestActivityWriter_IdleTime(t *testing.T) {
+	start := time.Now().Add(-time.Hour)
+	tests := []struct {
+		name    string
+		writes  []string
+		wantMin time.Duration
+		wantMax time.Duration
+	}{
+		{
+			// Test case with calling IdleTime method without writes.
+			// As a result, want to receive the duration from the start.
+			name:    "nothing is written",
+			writes:  nil,
+			wantMin: time.Hour,
+			wantMax: time.Hour + time.Minute,
+		},
+		{
+			// Test case with calling IdleTime method after empty writes.
+			// As a result, want to receive the duration from the start.
+			name:    "empty output is written",
+			writes:  []string{"", ""},
+			wantMin: time.Hour,
+			wantMax: time.Hour + time.Minute,
+		},
+		{
+			// Test case with calling IdleTime method after the output is written.
+			// As a result, want to receive the duration from the last write.
+			name:    "output is written",
+			writes:  []string{"MOCK_OUTPUT"},
+			wantMin: 0,
+			wantMax: time.Minute,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			aw := NewActivityWriter(start)
+			for _, data := range tt.writes {
+				got, err := aw.Write([]byte(data))
+				if err != nil {
+					t.Errorf("Write() error = %v", err)
+					return
+				}
+				if got != len(data) {
+					t.Errorf("Write() got = %v, want %v", got, len(data))
+				}
+			}
+			if got := aw.IdleTime(time.Now()); got < tt.wantMin || got > tt.wantMax {
+				t.Errorf("IdleTime() = %v, want between %v and %v", got, tt.wantMin, tt.wantMax)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package streaming
+
+import (
+	"beam.apache.org/playground/backend/internal/cache"
+	"beam.apache.org/playground/backend/internal/errors"
+	"beam.apache.org/playground/backend/internal/logger"
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"sync"
+	"unicode/utf8"
+)
+
+// CompileOutputWriter is used to write the compile step's output to cache as a stream.
+// Bytes of a multibyte UTF-8 character split between writes are kept until the rest of the character is written,
+// so cache always contains whole characters.
+// Mu guards cache.CompileOutput when several writers of the same pipeline are used concurrently (e.g. for stdout and stderr),
+// it could be nil otherwise.
+type CompileOutputWriter struct {
+	Ctx          context.Context
+	CacheService cache.Cache
+	PipelineId   uuid.UUID
+	Mu           *sync.Mutex
+
+	pending []byte
+}
+
+// Write writes len(p) bytes from p to cache with cache.CompileOutput subKey.
+// The incomplete UTF-8 character at the end of p is written with the next call of Write or Flush.
+// The streamed output is replaced by the whole output once the compile step is finished, so in case it couldn't be
+// written to cache the error is logged and the compiler isn't affected by it.
+// Returns (len(p), nil).
+func (cow *CompileOutputWriter) Write(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	data := append(cow.pending, p...)
+	complete := completeRunesLength(data)
+	cow.appendOutput(data[:complete])
+	cow.pending = append([]byte(nil), data[complete:]...)
+	return len(p), nil
+}
+
+// Flush writes the kept bytes of the incomplete UTF-8 character to cache as they are.
+// It should be called once the compile step is finished.
+// In case the output couldn't be written to cache the error is logged the same way as by Write.
+func (cow *CompileOutputWriter) Flush() error {
+	cow.appendOutput(cow.pending)
+	cow.pending = nil
+	return nil
+}
+
+// appendOutput adds data to the compile output from cache.
+// In case some error occurs logs it.
+func (cow *CompileOutputWriter) appendOutput(data []byte) {
+	if len(data) == 0 {
+		return
+	}
+	if cow.Mu != nil {
+		cow.Mu.Lock()
+		defer cow.Mu.Unlock()
+	}
+
+	if err := cow.setOutput(data); err != nil {
+		logger.WithPipelineId(cow.PipelineId).WithContext(cow.Ctx).Errorf("CompileOutputWriter: error during streaming the compile output: %s\n", err.Error())
+	}
+}
+
+// setOutput saves the compile output from cache followed by data to cache
+func (cow *CompileOutputWriter) setOutput(data []byte) error {
+	prevOutput, err := cow.CacheService.GetValue(cow.Ctx, cow.PipelineId, cache.CompileOutput)
+	if err != nil {
+		return err
+	}
+	output, converted := prevOutput.(string)
+	if !converted {
+		return errors.TypeMismatchError(fmt.Errorf("value of %T isn't string", prevOutput))
+	}
+	return cow.CacheService.SetValue(cow.Ctx, cow.PipelineId, cache.CompileOutput, output+string(data))
+}
+
+// completeRunesLength returns the length of the prefix of data without the incomplete UTF-8 character at the end.
+// Invalid bytes are considered as complete characters.
+func completeRunesLength(data []byte) int {
+	for i := len(data) - 1; i >= 0 && i >= len(data)-utf8.UTFMax; i-- {
+		if utf8.RuneStart(data[i]) {
+			if !utf8.FullRune(data[i:]) {
+				return i
+			}
+			break
+		}
+	}
+	return len(data)
+}
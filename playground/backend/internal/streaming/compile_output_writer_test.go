@@ -0,0 +1,120 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package streaming
+
+import (
+	"beam.apache.org/playground/backend/internal/cache"
+	"beam.apache.org/playground/backend/internal/cache/local"
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestCompileOutputWriter_Write(t *testing.T) {
+	cacheService := local.New(context.Background())
+	euro := []byte("€")
+
+	tests := []struct {
+		name string
+		// output is the compile output in cache before writes, "" is used in case it is nil
+		output     interface{}
+		noOutput   bool
+		writes     [][]byte
+		wantOutput interface{}
+		wantFlush  interface{}
+	}{
+		{
+			// Test case with calling Write method with pipelineId which doesn't contain compile output yet.
+			// As a result, want the output to be accepted without the compile output in cache.
+			name:       "output doesn't exist",
+			noOutput:   true,
+			writes:     [][]byte{[]byte("MOCK_OUTPUT")},
+			wantOutput: nil,
+			wantFlush:  nil,
+		},
+		{
+			// Test case with calling Write method with pipelineId which contains compile output of the wrong type.
+			// As a result, want the output to be accepted and the compile output in cache not to be changed.
+			name:       "output of the wrong type",
+			output:     1,
+			writes:     [][]byte{[]byte("MOCK_OUTPUT")},
+			wantOutput: 1,
+			wantFlush:  1,
+		},
+		{
+			// Test case with calling Write method several times with ASCII output.
+			// As a result, want to find the concatenated output in cache.
+			name:       "ascii output",
+			writes:     [][]byte{[]byte("MOCK_OUTPUT "), []byte("NEW_MOCK_OUTPUT")},
+			wantOutput: "MOCK_OUTPUT NEW_MOCK_OUTPUT",
+			wantFlush:  "MOCK_OUTPUT NEW_MOCK_OUTPUT",
+		},
+		{
+			// Test case with calling Write method with a multibyte character split between writes.
+			// As a result, want to find only whole characters in cache after each write.
+			name:       "split character",
+			writes:     [][]byte{append([]byte("price: "), euro[:1]...), euro[1:2], append(euro[2:], '5')},
+			wantOutput: "price: €5",
+			wantFlush:  "price: €5",
+		},
+		{
+			// Test case with calling Write method with output which ends with an incomplete character.
+			// As a result, want to find the incomplete character in cache only after Flush.
+			name:       "incomplete character",
+			writes:     [][]byte{append([]byte("price: "), euro[:2]...)},
+			wantOutput: "price: ",
+			wantFlush:  "price: " + string(euro[:2]),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pipelineId := uuid.New()
+			if !tt.noOutput {
+				output := tt.output
+				if output == nil {
+					output = ""
+				}
+				if err := cacheService.SetValue(context.Background(), pipelineId, cache.CompileOutput, output); err != nil {
+					panic(err)
+				}
+			}
+			cow := &CompileOutputWriter{Ctx: context.Background(), CacheService: cacheService, PipelineId: pipelineId}
+			for _, data := range tt.writes {
+				got, err := cow.Write(data)
+				if err != nil {
+					t.Fatalf("Write() error = %v", err)
+				}
+				if got != len(data) {
+					t.Errorf("Write() got = %v, want %v", got, len(data))
+				}
+				if output, _ := cacheService.GetValue(context.Background(), pipelineId, cache.CompileOutput); output != nil && !utf8.ValidString(fmt.Sprint(output)) {
+					t.Errorf("Write() writes incomplete character to cache: %q", output)
+				}
+			}
+			if output, _ := cacheService.GetValue(context.Background(), pipelineId, cache.CompileOutput); output != tt.wantOutput {
+				t.Errorf("Write() writes %q, want %q", output, tt.wantOutput)
+			}
+			if err := cow.Flush(); err != nil {
+				t.Fatalf("Flush() error = %v", err)
+			}
+			if output, _ := cacheService.GetValue(context.Background(), pipelineId, cache.CompileOutput); output != tt.wantFlush {
+				t.Errorf("Flush() writes %q, want %q", output, tt.wantFlush)
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package streaming
+
+import (
+	"beam.apache.org/playground/backend/internal/cache"
+	"beam.apache.org/playground/backend/internal/errors"
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"io"
+)
+
+// IntermediateSamplePrefix is a prefix of the run output line which reports an element of the pipeline's PCollection.
+// The name of the PCollection is separated from the element by a tab.
+// Example of the line: "PLAYGROUND_SAMPLE: Split words\t'hello'"
+const IntermediateSamplePrefix = "PLAYGROUND_SAMPLE:"
+
+// IntermediateSamplesWriter is used to parse PCollection element reports from the run step's output and write them to cache.
+// Not more than MaxSamples elements are stored per PCollection, the rest of the reports are skipped.
+// Lines with element reports are dropped from the output, the rest of the output is written to Writer as is.
+// The line which could be a report is kept until it is completed or Flush is called, other lines are written at once.
+type IntermediateSamplesWriter struct {
+	Writer       io.Writer
+	Ctx          context.Context
+	CacheService cache.Cache
+	PipelineId   uuid.UUID
+	MaxSamples   int
+
+	// pending is the beginning of the current line which could be an element report
+	pending []byte
+	// passing is true if the beginning of the current line isn't a report, so the rest of it is written at once
+	passing bool
+}
+
+// Write parses PCollection element reports from completed lines of p and adds them to cache with cache.IntermediateSamples subKey.
+// Other output is written to Writer.
+// In case some error occurs - returns (0, error).
+// In case finished with no error - returns (len(p), nil) even if the incomplete line is kept.
+func (sw *IntermediateSamplesWriter) Write(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	data := p
+	for len(data) > 0 {
+		end := bytes.IndexByte(data, '\n')
+		if sw.passing {
+			if end < 0 {
+				if _, err := sw.Writer.Write(data); err != nil {
+					return 0, err
+				}
+				break
+			}
+			if _, err := sw.Writer.Write(data[:end+1]); err != nil {
+				return 0, err
+			}
+			sw.passing = false
+			data = data[end+1:]
+			continue
+		}
+		if end < 0 {
+			sw.pending = append(sw.pending, data...)
+			if !isIntermediateSampleStart(sw.pending) {
+				if err := sw.writePending(); err != nil {
+					return 0, err
+				}
+				sw.passing = true
+			}
+			break
+		}
+		line := append(sw.pending, data[:end+1]...)
+		sw.pending = nil
+		data = data[end+1:]
+		if err := sw.processLine(line); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// Flush processes the incomplete line kept by the writer: adds the element report to cache or writes other output to Writer
+func (sw *IntermediateSamplesWriter) Flush() error {
+	if len(sw.pending) == 0 {
+		return nil
+	}
+	line := sw.pending
+	sw.pending = nil
+	return sw.processLine(line)
+}
+
+// processLine adds the element report from the completed line to cache, the line which isn't a report is written to Writer
+func (sw *IntermediateSamplesWriter) processLine(line []byte) error {
+	if !bytes.HasPrefix(bytes.TrimSpace(line), []byte(IntermediateSamplePrefix)) {
+		_, err := sw.Writer.Write(line)
+		return err
+	}
+	pCollection, element, ok := parseIntermediateSample(line)
+	if !ok {
+		return nil
+	}
+	return sw.addSample(pCollection, element)
+}
+
+// writePending writes the beginning of the current line kept by the writer to Writer
+func (sw *IntermediateSamplesWriter) writePending() error {
+	pending := sw.pending
+	sw.pending = nil
+	_, err := sw.Writer.Write(pending)
+	return err
+}
+
+// addSample adds the element to the samples of the PCollection from cache if there are less than MaxSamples of them
+func (sw *IntermediateSamplesWriter) addSample(pCollection, element string) error {
+	value, err := sw.CacheService.GetValue(sw.Ctx, sw.PipelineId, cache.IntermediateSamples)
+	if err != nil {
+		return err
+	}
+	samples, converted := value.([]cache.PCollectionSamples)
+	if !converted {
+		return errors.TypeMismatchError(fmt.Errorf("value of %T isn't []cache.PCollectionSamples", value))
+	}
+	i := 0
+	for i < len(samples) && samples[i].PCollection != pCollection {
+		i++
+	}
+	if i == len(samples) {
+		samples = append(samples, cache.PCollectionSamples{PCollection: pCollection})
+	}
+	if len(samples[i].Elements) >= sw.MaxSamples {
+		return nil
+	}
+	samples[i].Elements = append(samples[i].Elements, element)
+	return sw.CacheService.SetValue(sw.Ctx, sw.PipelineId, cache.IntermediateSamples, samples)
+}
+
+// isIntermediateSampleStart returns true if the beginning of the line could be the beginning of the element report
+func isIntermediateSampleStart(line []byte) bool {
+	line = bytes.TrimLeft(line, " \t\r")
+	prefix := []byte(IntermediateSamplePrefix)
+	if len(line) < len(prefix) {
+		return bytes.HasPrefix(prefix, line)
+	}
+	return bytes.HasPrefix(line, prefix)
+}
+
+// parseIntermediateSample returns the name of the PCollection and the element from the element report line
+func parseIntermediateSample(line []byte) (string, string, bool) {
+	line = bytes.TrimSpace(line)
+	if !bytes.HasPrefix(line, []byte(IntermediateSamplePrefix)) {
+		return "", "", false
+	}
+	parts := bytes.SplitN(bytes.TrimSpace(line[len(IntermediateSamplePrefix):]), []byte("\t"), 2)
+	if len(parts) != 2 || len(parts[0]) == 0 {
+		return "", "", false
+	}
+	return string(parts[0]), string(parts[1]), true
+}
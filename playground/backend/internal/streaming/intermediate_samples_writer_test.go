@@ -0,0 +1,198 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package streaming
+
+import (
+	"beam.apache.org/playground/backend/internal/cache"
+	"beam.apache.org/playground/backend/internal/cache/local"
+	"beam.apache.org/playground/backend/internal/errors"
+	"bytes"
+	"context"
+	stderrors "errors"
+	"github.com/google/uuid"
+	"io"
+	"reflect"
+	"testing"
+)
+
+func TestIntermediateSamplesWriter_Write(t *testing.T) {
+	pipelineId := uuid.New()
+	cacheService := local.New(context.Background())
+	err := cacheService.SetValue(context.Background(), pipelineId, cache.IntermediateSamples, []cache.PCollectionSamples{})
+	if err != nil {
+		panic(err)
+	}
+
+	tests := []struct {
+		name        string
+		pipelineId  uuid.UUID
+		writes      []string
+		wantSamples []cache.PCollectionSamples
+		wantOutput  string
+		wantErr     bool
+	}{
+		{
+			// Test case with calling Write method with pipelineId which doesn't contain samples yet.
+			// As a result, want to receive an error.
+			name:       "samples don't exist",
+			pipelineId: uuid.New(),
+			writes:     []string{IntermediateSamplePrefix + " Create\t1\n"},
+			wantErr:    true,
+		},
+		{
+			// Test case with calling Write method with output which contains element reports of two PCollections.
+			// Reports are split between several writes and mixed with other output, one report has no element separator.
+			// As a result, want to find reported elements grouped by PCollections in cache and only other output in the output.
+			name:       "elements are reported",
+			pipelineId: pipelineId,
+			writes: []string{
+				"MOCK_OUTPUT\n" + IntermediateSamplePrefix + " Create\t'a'\n" + IntermediateSamplePrefix,
+				" Upper\t'A'\n" + IntermediateSamplePrefix + " Create\n" + IntermediateSamplePrefix + " Create\t'b c'\nMOCK_OUTPUT\n",
+			},
+			wantSamples: []cache.PCollectionSamples{
+				{PCollection: "Create", Elements: []string{"'a'", "'b c'"}},
+				{PCollection: "Upper", Elements: []string{"'A'"}},
+			},
+			wantOutput: "MOCK_OUTPUT\nMOCK_OUTPUT\n",
+			wantErr:    false,
+		},
+		{
+			// Test case with calling Write method with more element reports than MaxSamples.
+			// As a result, want to find only the first MaxSamples elements of each PCollection in cache.
+			name:       "elements are limited",
+			pipelineId: pipelineId,
+			writes:     []string{IntermediateSamplePrefix + " Create\t'd'\n" + IntermediateSamplePrefix + " Upper\t'B'\n"},
+			wantSamples: []cache.PCollectionSamples{
+				{PCollection: "Create", Elements: []string{"'a'", "'b c'"}},
+				{PCollection: "Upper", Elements: []string{"'A'", "'B'"}},
+			},
+			wantErr: false,
+		},
+		{
+			// Test case with calling Write method with other output which is split between several writes.
+			// As a result, want the incomplete lines which aren't reports to be written at once without waiting for the rest of them.
+			name:       "other output isn't kept",
+			pipelineId: pipelineId,
+			writes:     []string{"MOCK", "_OUTPUT\n  PLAYGROUND", "_OTHER\n"},
+			wantSamples: []cache.PCollectionSamples{
+				{PCollection: "Create", Elements: []string{"'a'", "'b c'"}},
+				{PCollection: "Upper", Elements: []string{"'A'", "'B'"}},
+			},
+			wantOutput: "MOCK_OUTPUT\n  PLAYGROUND_OTHER\n",
+			wantErr:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var output bytes.Buffer
+			sw := &IntermediateSamplesWriter{
+				Writer:       &output,
+				Ctx:          context.Background(),
+				CacheService: cacheService,
+				PipelineId:   tt.pipelineId,
+				MaxSamples:   2,
+			}
+			for _, data := range tt.writes {
+				got, err := sw.Write([]byte(data))
+				if (err != nil) != tt.wantErr {
+					t.Errorf("Write() error = %v, wantErr %v", err, tt.wantErr)
+					return
+				}
+				if err == nil && got != len(data) {
+					t.Errorf("Write() got = %v, want %v", got, len(data))
+				}
+			}
+			if tt.wantErr {
+				return
+			}
+			value, err := cacheService.GetValue(context.Background(), tt.pipelineId, cache.IntermediateSamples)
+			if err != nil {
+				t.Fatalf("Write() doesn't write samples to cache: %s", err.Error())
+			}
+			if !reflect.DeepEqual(value, tt.wantSamples) {
+				t.Errorf("Write() writes %v, want %v", value, tt.wantSamples)
+			}
+			if output.String() != tt.wantOutput {
+				t.Errorf("Write() output = %q, want %q", output.String(), tt.wantOutput)
+			}
+		})
+	}
+}
+
+func TestIntermediateSamplesWriter_typeMismatch(t *testing.T) {
+	pipelineId := uuid.New()
+	cacheService := local.New(context.Background())
+	if err := cacheService.SetValue(context.Background(), pipelineId, cache.IntermediateSamples, "MOCK_VALUE"); err != nil {
+		panic(err)
+	}
+	sw := &IntermediateSamplesWriter{Writer: io.Discard, Ctx: context.Background(), CacheService: cacheService, PipelineId: pipelineId, MaxSamples: 2}
+	if _, err := sw.Write([]byte(IntermediateSamplePrefix + " Create\t1\n")); !stderrors.Is(err, errors.ErrTypeMismatch) {
+		t.Errorf("Write() error = %v, want %v", err, errors.ErrTypeMismatch)
+	}
+}
+
+func TestIntermediateSamplesWriter_Flush(t *testing.T) {
+	pipelineId := uuid.New()
+	cacheService := local.New(context.Background())
+	if err := cacheService.SetValue(context.Background(), pipelineId, cache.IntermediateSamples, []cache.PCollectionSamples{}); err != nil {
+		panic(err)
+	}
+	tests := []struct {
+		name        string
+		write       string
+		wantSamples []cache.PCollectionSamples
+		wantOutput  string
+	}{
+		{
+			// Test case with calling Flush method after the incomplete element report.
+			// As a result, want the element to be added to cache without writing it to the output.
+			name:        "incomplete report",
+			write:       IntermediateSamplePrefix + " Create\t1",
+			wantSamples: []cache.PCollectionSamples{{PCollection: "Create", Elements: []string{"1"}}},
+			wantOutput:  "",
+		},
+		{
+			// Test case with calling Flush method after the incomplete line which could be the beginning of the report.
+			// As a result, want the line to be written to the output.
+			name:        "incomplete beginning of report",
+			write:       "PLAYGROUND",
+			wantSamples: []cache.PCollectionSamples{{PCollection: "Create", Elements: []string{"1"}}},
+			wantOutput:  "PLAYGROUND",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var output bytes.Buffer
+			sw := &IntermediateSamplesWriter{Writer: &output, Ctx: context.Background(), CacheService: cacheService, PipelineId: pipelineId, MaxSamples: 2}
+			if _, err := sw.Write([]byte(tt.write)); err != nil {
+				t.Fatalf("Write() error = %v", err)
+			}
+			if output.Len() != 0 {
+				t.Errorf("Write() output = %q, want the line to be kept", output.String())
+			}
+			if err := sw.Flush(); err != nil {
+				t.Fatalf("Flush() error = %v", err)
+			}
+			value, _ := cacheService.GetValue(context.Background(), pipelineId, cache.IntermediateSamples)
+			if !reflect.DeepEqual(value, tt.wantSamples) {
+				t.Errorf("Flush() writes %v, want %v", value, tt.wantSamples)
+			}
+			if output.String() != tt.wantOutput {
+				t.Errorf("Flush() output = %q, want %q", output.String(), tt.wantOutput)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+
+package streaming
+
+import (
+	"beam.apache.org/playground/backend/internal/cache"
+	"beam.apache.org/playground/backend/internal/errors"
+	"beam.apache.org/playground/backend/internal/logger"
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"strconv"
+	"time"
+)
+
+// ElementCountPrefix is a prefix of the run output line which reports the number of elements processed by the pipeline.
+// Example of the line: "PLAYGROUND_ELEMENT_COUNT: 1024"
+const ElementCountPrefix = "PLAYGROUND_ELEMENT_COUNT:"
+
+// MetricsWriter is used to parse element count reports from the run step's output and write them to cache as time-series points.
+// A new point is stored not more often than once per SamplingInterval, reports received in between are skipped.
+type MetricsWriter struct {
+	Ctx              context.Context
+	CacheService     cache.Cache
+	PipelineId       uuid.UUID
+	SamplingInterval time.Duration
+
+	lastSample time.Time
+	tail       []byte
+}
+
+// Write parses element count reports from completed lines of p and adds them to cache with cache.Metrics subKey.
+// The last incomplete line is kept until the rest of it is written.
+// Metrics are optional, so in case the point couldn't be added to cache the error is logged
+// and the output is still accepted, the run step isn't affected by it.
+// Returns (len(p), nil).
+func (mw *MetricsWriter) Write(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	lines := bytes.Split(append(mw.tail, p...), []byte("\n"))
+	mw.tail = append([]byte(nil), lines[len(lines)-1]...)
+	for _, line := range lines[:len(lines)-1] {
+		count, ok := parseElementCount(line)
+		if !ok || time.Since(mw.lastSample) < mw.SamplingInterval {
+			continue
+		}
+		if err := mw.addPoint(cache.MetricPoint{Timestamp: time.Now(), ElementCount: count}); err != nil {
+			logger.WithPipelineId(mw.PipelineId).WithContext(mw.Ctx).Errorf("MetricsWriter: error during adding the metric point: %s\n", err.Error())
+		}
+	}
+	return len(p), nil
+}
+
+// addPoint adds new metric point to the points from cache
+func (mw *MetricsWriter) addPoint(point cache.MetricPoint) error {
+	prevPoints, err := mw.CacheService.GetValue(mw.Ctx, mw.PipelineId, cache.Metrics)
+	if err != nil {
+		return err
+	}
+	points, converted := prevPoints.([]cache.MetricPoint)
+	if !converted {
+		return errors.TypeMismatchError(fmt.Errorf("value of %T isn't []cache.MetricPoint", prevPoints))
+	}
+	points = append(points, point)
+	if err = mw.CacheService.SetValue(mw.Ctx, mw.PipelineId, cache.Metrics, points); err != nil {
+		return err
+	}
+	mw.lastSample = point.Timestamp
+	return nil
+}
+
+// parseElementCount returns the number of elements from the element count report line
+func parseElementCount(line []byte) (int64, bool) {
+	line = bytes.TrimSpace(line)
+	if !bytes.HasPrefix(line, []byte(ElementCountPrefix)) {
+		return 0, false
+	}
+	count, err := strconv.ParseInt(string(bytes.TrimSpace(line[len(ElementCountPrefix):])), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return count, true
+}
@@ -0,0 +1,142 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+
+package streaming
+
+import (
+	"beam.apache.org/playground/backend/internal/cache"
+	"beam.apache.org/playground/backend/internal/cache/local"
+	"context"
+	"github.com/google/uuid"
+	"testing"
+	"time"
+)
+
+func TestMetricsWriter_Write(t *testing.T) {
+	pipelineId := uuid.New()
+	cacheService := local.New(context.Background())
+	err := cacheService.SetValue(context.Background(), pipelineId, cache.Metrics, []cache.MetricPoint{})
+	if err != nil {
+		panic(err)
+	}
+	typeMismatchPipelineId := uuid.New()
+	err = cacheService.SetValue(context.Background(), typeMismatchPipelineId, cache.Metrics, "MOCK_METRICS")
+	if err != nil {
+		panic(err)
+	}
+
+	type fields struct {
+		PipelineId       uuid.UUID
+		SamplingInterval time.Duration
+	}
+	tests := []struct {
+		name       string
+		fields     fields
+		writes     []string
+		wantCounts []int64
+		wantErr    bool
+		// wantMetrics is false if metrics shouldn't be written to cache
+		wantMetrics bool
+	}{
+		{
+			// Test case with calling Write method with pipelineId which doesn't contain metrics yet.
+			// As a result, want the output to be accepted without metrics in cache.
+			name: "metrics don't exist",
+			fields: fields{
+				PipelineId:       uuid.New(),
+				SamplingInterval: time.Nanosecond,
+			},
+			writes:      []string{ElementCountPrefix + " 10\n"},
+			wantErr:     false,
+			wantMetrics: false,
+		},
+		{
+			// Test case with calling Write method with pipelineId which contains metrics of the wrong type.
+			// As a result, want the output to be accepted and metrics in cache not to be changed.
+			name: "metrics of the wrong type",
+			fields: fields{
+				PipelineId:       typeMismatchPipelineId,
+				SamplingInterval: time.Nanosecond,
+			},
+			writes:      []string{ElementCountPrefix + " 10\n"},
+			wantErr:     false,
+			wantMetrics: false,
+		},
+		{
+			// Test case with calling Write method with output which contains element count reports.
+			// Reports are split between several writes and mixed with other output.
+			// As a result, want to find all reported element counts in cache.
+			name: "element counts are reported",
+			fields: fields{
+				PipelineId:       pipelineId,
+				SamplingInterval: time.Nanosecond,
+			},
+			writes:      []string{"MOCK_OUTPUT\n" + ElementCountPrefix + " 10\n" + ElementCountPrefix, " 20\nMOCK_OUTPUT\n"},
+			wantCounts:  []int64{10, 20},
+			wantErr:     false,
+			wantMetrics: true,
+		},
+		{
+			// Test case with calling Write method with several element count reports within the sampling interval.
+			// As a result, want to find only the first report in cache.
+			name: "element counts are sampled",
+			fields: fields{
+				PipelineId:       pipelineId,
+				SamplingInterval: time.Hour,
+			},
+			writes:      []string{ElementCountPrefix + " 30\n" + ElementCountPrefix + " 40\n"},
+			wantCounts:  []int64{10, 20, 30},
+			wantErr:     false,
+			wantMetrics: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mw := &MetricsWriter{
+				Ctx:              context.Background(),
+				CacheService:     cacheService,
+				PipelineId:       tt.fields.PipelineId,
+				SamplingInterval: tt.fields.SamplingInterval,
+			}
+			for _, data := range tt.writes {
+				got, err := mw.Write([]byte(data))
+				if (err != nil) != tt.wantErr {
+					t.Errorf("Write() error = %v, wantErr %v", err, tt.wantErr)
+					return
+				}
+				if err == nil && got != len(data) {
+					t.Errorf("Write() got = %v, want %v", got, len(data))
+				}
+			}
+			if tt.wantErr || !tt.wantMetrics {
+				return
+			}
+			value, err := cacheService.GetValue(context.Background(), tt.fields.PipelineId, cache.Metrics)
+			if err != nil {
+				t.Fatalf("Write() doesn't write metrics to cache: %s", err.Error())
+			}
+			points := value.([]cache.MetricPoint)
+			if len(points) != len(tt.wantCounts) {
+				t.Fatalf("Write() writes %d points, want %d", len(points), len(tt.wantCounts))
+			}
+			for i, point := range points {
+				if point.ElementCount != tt.wantCounts[i] {
+					t.Errorf("Write() writes element count %d, want %d", point.ElementCount, tt.wantCounts[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package streaming
+
+import (
+	"bytes"
+)
+
+// escapeByte starts ANSI escape sequences
+const escapeByte = 0x1b
+
+// maxPendingEscapeLength is a maximum length of the incomplete escape sequence which is kept until it is completed.
+// Longer incomplete sequences aren't escape sequences of runners, so they are written as is.
+const maxPendingEscapeLength = 256
+
+// OutputTransformer transforms the run output before it is saved.
+// Transform could keep the end of p (e.g. an incomplete escape sequence) until the next call,
+// so data split between several writes is transformed as a whole. Flush returns the kept data at the end of the output.
+type OutputTransformer interface {
+	Transform(p []byte) []byte
+	Flush() []byte
+}
+
+// transformOutput applies transformers to p one after another
+func transformOutput(transformers []OutputTransformer, p []byte) []byte {
+	for _, transformer := range transformers {
+		p = transformer.Transform(p)
+	}
+	return p
+}
+
+// flushTransformers returns data kept by transformers, data kept by each transformer is transformed by the next ones
+func flushTransformers(transformers []OutputTransformer) []byte {
+	var data []byte
+	for _, transformer := range transformers {
+		if len(data) > 0 {
+			data = transformer.Transform(data)
+		}
+		data = append(data, transformer.Flush()...)
+	}
+	return data
+}
+
+// AnsiStripper is OutputTransformer which removes ANSI escape sequences (e.g. colors and cursor movements) from the output.
+// CSI ("ESC [ ... final"), OSC ("ESC ] ... BEL" or "ESC ] ... ESC \") and two-byte sequences are removed.
+// The incomplete sequence at the end of the data is kept until it is completed or Flush is called.
+type AnsiStripper struct {
+	pending []byte
+}
+
+// Transform returns p without ANSI escape sequences
+func (as *AnsiStripper) Transform(p []byte) []byte {
+	data := p
+	if len(as.pending) > 0 {
+		data = append(as.pending, p...)
+		as.pending = nil
+	}
+	result := make([]byte, 0, len(data))
+	for len(data) > 0 {
+		start := bytes.IndexByte(data, escapeByte)
+		if start < 0 {
+			result = append(result, data...)
+			break
+		}
+		result = append(result, data[:start]...)
+		data = data[start:]
+		length, complete := escapeSequenceLength(data)
+		if !complete {
+			if len(data) < maxPendingEscapeLength {
+				as.pending = append([]byte(nil), data...)
+				break
+			}
+			// the sequence is too long to be an escape sequence, so the escape byte is written as is
+			length = 1
+			result = append(result, escapeByte)
+		}
+		data = data[length:]
+	}
+	return result
+}
+
+// Flush returns the incomplete escape sequence kept by the stripper as is
+func (as *AnsiStripper) Flush() []byte {
+	pending := as.pending
+	as.pending = nil
+	return pending
+}
+
+// escapeSequenceLength returns the length of the escape sequence at the start of data
+// and false if data ends before the sequence is completed
+func escapeSequenceLength(data []byte) (int, bool) {
+	if len(data) < 2 {
+		return 0, false
+	}
+	switch data[1] {
+	case '[':
+		// CSI: parameter and intermediate bytes are followed by the final byte in 0x40-0x7E
+		for i := 2; i < len(data); i++ {
+			if data[i] >= 0x40 && data[i] <= 0x7e {
+				return i + 1, true
+			}
+		}
+		return 0, false
+	case ']':
+		// OSC: terminated by BEL or ST ("ESC \")
+		for i := 2; i < len(data); i++ {
+			if data[i] == 0x07 {
+				return i + 1, true
+			}
+			if data[i] == escapeByte {
+				if i+1 == len(data) {
+					return 0, false
+				}
+				if data[i+1] == '\\' {
+					return i + 2, true
+				}
+			}
+		}
+		return 0, false
+	default:
+		return 2, true
+	}
+}
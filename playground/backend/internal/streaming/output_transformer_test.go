@@ -0,0 +1,85 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package streaming
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAnsiStripper_Transform(t *testing.T) {
+	tests := []struct {
+		name   string
+		writes []string
+		want   string
+	}{
+		{
+			// Test case with calling Transform method with the output without escape sequences.
+			// As a result, want to receive the output as is.
+			name:   "output without escape sequences",
+			writes: []string{"Hello", " world\n"},
+			want:   "Hello world\n",
+		},
+		{
+			// Test case with calling Transform method with colors and cursor movements in the output.
+			// As a result, want to receive the output without escape sequences.
+			name:   "color and cursor sequences",
+			writes: []string{"\x1b[1;31mERROR\x1b[0m: MOCK_ERROR\n\x1b[2K\x1b[1Gdone\x1b=\n"},
+			want:   "ERROR: MOCK_ERROR\ndone\n",
+		},
+		{
+			// Test case with calling Transform method with OSC sequences terminated by BEL and by ST.
+			// As a result, want to receive the output without escape sequences.
+			name:   "osc sequences",
+			writes: []string{"\x1b]0;MOCK_TITLE\x07Hello \x1b]8;;http://mock\x1b\\link\x1b]8;;\x1b\\\n"},
+			want:   "Hello link\n",
+		},
+		{
+			// Test case with calling Transform method with escape sequences split between several writes.
+			// As a result, want to receive the output without escape sequences.
+			name:   "sequences split between writes",
+			writes: []string{"Hello \x1b", "[3", "2mworld\x1b[0", "m\n\x1b]0;MOCK", "_TITLE\x1b", "\\done\n"},
+			want:   "Hello world\ndone\n",
+		},
+		{
+			// Test case with calling Transform method with the incomplete escape sequence at the end of the output.
+			// As a result, want to receive the incomplete sequence as is once it is flushed.
+			name:   "incomplete sequence is flushed",
+			writes: []string{"Hello\n\x1b[3"},
+			want:   "Hello\n\x1b[3",
+		},
+		{
+			// Test case with calling Transform method with the escape byte followed by the long line without the final byte.
+			// As a result, want to receive the line as is without keeping it until the end of the output.
+			name:   "too long incomplete sequence",
+			writes: []string{"\x1b[" + strings.Repeat("1", maxPendingEscapeLength), "\n"},
+			want:   "\x1b[" + strings.Repeat("1", maxPendingEscapeLength) + "\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stripper := &AnsiStripper{}
+			var got []byte
+			for _, write := range tt.writes {
+				got = append(got, stripper.Transform([]byte(write))...)
+			}
+			got = append(got, stripper.Flush()...)
+			if string(got) != tt.want {
+				t.Errorf("Transform() got = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
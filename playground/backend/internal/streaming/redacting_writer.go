@@ -0,0 +1,83 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package streaming
+
+import (
+	"bytes"
+	"io"
+	"regexp"
+)
+
+// RedactedSecret is written instead of secrets found in the output
+const RedactedSecret = "***"
+
+// maxPendingLineLength is a maximum length of the incomplete line which is kept until it is completed.
+// Longer incomplete lines are redacted and written as is, so the output isn't kept in memory without limit.
+const maxPendingLineLength = 64 * 1024
+
+// RedactingWriter replaces secrets matching Patterns in the output with RedactedSecret before writing it to Writer.
+// Output is redacted line by line, so secrets split between several writes are redacted as well.
+// The incomplete line is kept until it is completed or Flush is called.
+type RedactingWriter struct {
+	Writer   io.Writer
+	Patterns []*regexp.Regexp
+
+	pending []byte
+}
+
+// Write writes completed lines of p to Writer with secrets replaced by RedactedSecret.
+// In case some error occurs - returns (0, error).
+// In case finished with no error - returns (len(p), nil) even if the incomplete line is kept.
+func (rw *RedactingWriter) Write(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	rw.pending = append(rw.pending, p...)
+	end := bytes.LastIndexByte(rw.pending, '\n')
+	if end < 0 && len(rw.pending) < maxPendingLineLength {
+		return len(p), nil
+	}
+	completed := rw.pending
+	rw.pending = nil
+	if end >= 0 && end+1 < len(completed) {
+		rw.pending = append(rw.pending, completed[end+1:]...)
+		completed = completed[:end+1]
+	}
+	if _, err := rw.Writer.Write(rw.redact(completed)); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Flush writes the incomplete line kept by the writer to Writer with secrets replaced by RedactedSecret
+func (rw *RedactingWriter) Flush() error {
+	if len(rw.pending) == 0 {
+		return nil
+	}
+	pending := rw.pending
+	rw.pending = nil
+	_, err := rw.Writer.Write(rw.redact(pending))
+	return err
+}
+
+// redact replaces all matches of Patterns in data with RedactedSecret
+func (rw *RedactingWriter) redact(data []byte) []byte {
+	for _, pattern := range rw.Patterns {
+		data = pattern.ReplaceAllLiteral(data, []byte(RedactedSecret))
+	}
+	return data
+}
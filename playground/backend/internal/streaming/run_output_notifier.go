@@ -0,0 +1,60 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package streaming
+
+import (
+	"github.com/google/uuid"
+	"sync"
+)
+
+// runOutputSubscribers contains channels of subscribers to the run output by pipelines
+var runOutputSubscribers = struct {
+	sync.Mutex
+	channels map[uuid.UUID]map[chan struct{}]struct{}
+}{channels: make(map[uuid.UUID]map[chan struct{}]struct{})}
+
+// SubscribeRunOutput returns the channel which receives a value after the run output of the pipeline is written
+// or the pipeline is finished, and the function which unsubscribes the channel.
+// Notifications which aren't received yet are merged into one, so the subscriber should read the whole new output after each of them.
+func SubscribeRunOutput(pipelineId uuid.UUID) (<-chan struct{}, func()) {
+	channel := make(chan struct{}, 1)
+	runOutputSubscribers.Lock()
+	defer runOutputSubscribers.Unlock()
+	if _, found := runOutputSubscribers.channels[pipelineId]; !found {
+		runOutputSubscribers.channels[pipelineId] = make(map[chan struct{}]struct{})
+	}
+	runOutputSubscribers.channels[pipelineId][channel] = struct{}{}
+	return channel, func() {
+		runOutputSubscribers.Lock()
+		defer runOutputSubscribers.Unlock()
+		delete(runOutputSubscribers.channels[pipelineId], channel)
+		if len(runOutputSubscribers.channels[pipelineId]) == 0 {
+			delete(runOutputSubscribers.channels, pipelineId)
+		}
+	}
+}
+
+// NotifyRunOutput notifies subscribers to the run output of the pipeline without blocking
+func NotifyRunOutput(pipelineId uuid.UUID) {
+	runOutputSubscribers.Lock()
+	defer runOutputSubscribers.Unlock()
+	for channel := range runOutputSubscribers.channels[pipelineId] {
+		select {
+		case channel <- struct{}{}:
+		default:
+		}
+	}
+}
@@ -0,0 +1,94 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package streaming
+
+import (
+	"github.com/google/uuid"
+	"testing"
+)
+
+func TestSubscribeRunOutput(t *testing.T) {
+	pipelineId := uuid.New()
+	tests := []struct {
+		name string
+		// notify notifies subscribers of pipelines
+		notify      func()
+		unsubscribe bool
+		want        bool
+	}{
+		{
+			// Test case with calling SubscribeRunOutput method without notifications.
+			// As a result, want the channel to be empty.
+			name:   "no notifications",
+			notify: func() {},
+			want:   false,
+		},
+		{
+			// Test case with calling SubscribeRunOutput method with several notifications of the pipeline.
+			// As a result, want the channel to receive one merged notification.
+			name: "pipeline is notified",
+			notify: func() {
+				NotifyRunOutput(pipelineId)
+				NotifyRunOutput(pipelineId)
+			},
+			want: true,
+		},
+		{
+			// Test case with calling SubscribeRunOutput method with the notification of another pipeline.
+			// As a result, want the channel to be empty.
+			name: "another pipeline is notified",
+			notify: func() {
+				NotifyRunOutput(uuid.New())
+			},
+			want: false,
+		},
+		{
+			// Test case with calling SubscribeRunOutput method with the notification after the channel is unsubscribed.
+			// As a result, want the channel to be empty.
+			name: "channel is unsubscribed",
+			notify: func() {
+				NotifyRunOutput(pipelineId)
+			},
+			unsubscribe: true,
+			want:        false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			channel, unsubscribe := SubscribeRunOutput(pipelineId)
+			if tt.unsubscribe {
+				unsubscribe()
+			} else {
+				defer unsubscribe()
+			}
+			tt.notify()
+			got := false
+			select {
+			case <-channel:
+				got = true
+			default:
+			}
+			if got != tt.want {
+				t.Errorf("SubscribeRunOutput() notified = %v, want %v", got, tt.want)
+			}
+			select {
+			case <-channel:
+				t.Errorf("SubscribeRunOutput() received more than one notification")
+			default:
+			}
+		})
+	}
+}
@@ -17,16 +17,62 @@ package streaming
 
 import (
 	"beam.apache.org/playground/backend/internal/cache"
+	"beam.apache.org/playground/backend/internal/errors"
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"github.com/google/uuid"
+	"regexp"
+	"unicode/utf8"
 )
 
+// OutputTruncatedMarker is written once to the run output after it exceeds the limit of size
+const OutputTruncatedMarker = "\n... output truncated ...\n"
+
+// JsonLinesFormat is the format of the run output with one JSON object per line
+const JsonLinesFormat = "jsonl"
+
+// runRecordsBatchSize is the number of new records which are kept by RunOutputWriter before they are saved to cache
+const runRecordsBatchSize = 100
+
+// IsSupportedOutputFormat returns true if the run output in the format could be parsed by RunOutputWriter.
+// Empty format means that the run output isn't parsed.
+func IsSupportedOutputFormat(format string) bool {
+	return format == "" || format == JsonLinesFormat
+}
+
 // RunOutputWriter is used to write the run step's output to cache as a stream.
+// Subscribers to the run output of the pipeline are notified after each write of cache.RunOutput.
+// In case MaxBytes is positive the run output is truncated after MaxBytes bytes:
+// the rest of the output is skipped, OutputTruncatedMarker is written, cache.OutputTruncated is set,
+// and LimitChannel (if it is set) receives a value, so the run step could be stopped.
+// In case Format is JsonLinesFormat each completed line of the written output is also added to cache.RunRecords,
+// lines which aren't JSON objects are added as malformed ones. Records are kept in memory and saved to cache
+// by runRecordsBatchSize new records, the rest of them and the last incomplete line are saved by Flush.
+// In case LogFilter is set completed lines matching it (e.g. INFO logs of Beam) are dropped from cache.RunOutput,
+// the unfiltered output is written to cache.RawRunOutput. The last incomplete line is filtered by Flush.
+// In case Transformers are set the output is transformed by them one after another (e.g. ANSI escape sequences are stripped)
+// before it is written to cache, data kept by transformers is written by Flush.
 type RunOutputWriter struct {
 	Ctx          context.Context
 	CacheService cache.Cache
 	PipelineId   uuid.UUID
+	MaxBytes     int
+	LimitChannel chan bool
+	Format       string
+	LogFilter    *regexp.Regexp
+	Transformers []OutputTransformer
+
+	truncated    bool
+	rawTruncated bool
+	tail         []byte
+	filterTail   []byte
+	lines        int
+	// records are loaded from cache once, unsavedRecords of them aren't saved to cache yet
+	records        []cache.RunRecord
+	recordsLoaded  bool
+	unsavedRecords int
 }
 
 // Write writes len(p) bytes from p to cache with cache.RunOutput subKey.
@@ -42,19 +88,216 @@ func (row *RunOutputWriter) Write(p []byte) (int, error) {
 	if len(p) == 0 {
 		return 0, nil
 	}
+	if row.truncated {
+		return len(p), nil
+	}
+	if err := row.write(transformOutput(row.Transformers, p)); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// write writes the transformed output to cache.RunOutput and, in case LogFilter is set, to cache.RawRunOutput
+func (row *RunOutputWriter) write(p []byte) error {
+	if len(p) == 0 {
+		return nil
+	}
+	if row.LogFilter != nil {
+		if err := row.writeRawOutput(p); err != nil {
+			return err
+		}
+		return row.writeOutput(row.filterLines(p))
+	}
+	return row.writeOutput(p)
+}
 
-	prevOutput, err := row.CacheService.GetValue(row.Ctx, row.PipelineId, cache.RunOutput)
+// writeOutput appends p to cache.RunOutput truncating it after MaxBytes bytes and adds its lines to cache.RunRecords
+func (row *RunOutputWriter) writeOutput(p []byte) error {
+	if len(p) == 0 || row.truncated {
+		return nil
+	}
+
+	prevOutput, err := row.getOutput(cache.RunOutput)
 	if err != nil {
-		return 0, err
+		return err
+	}
+
+	data := p
+	exceeded := row.MaxBytes > 0 && len(prevOutput)+len(p) > row.MaxBytes
+	if exceeded {
+		data = truncateOutput(p, row.MaxBytes-len(prevOutput))
 	}
 
 	// concat prevValue and new value
-	str := fmt.Sprintf("%s%s", prevOutput.(string), string(p))
+	str := prevOutput + string(data)
+	if exceeded {
+		str += OutputTruncatedMarker
+	}
 
 	// set new cache value
 	err = row.CacheService.SetValue(row.Ctx, row.PipelineId, cache.RunOutput, str)
 	if err != nil {
-		return 0, err
+		return err
 	}
-	return len(p), nil
+	NotifyRunOutput(row.PipelineId)
+	if row.Format == JsonLinesFormat {
+		lines := bytes.Split(append(row.tail, data...), []byte("\n"))
+		row.tail = append([]byte(nil), lines[len(lines)-1]...)
+		if err = row.addRecords(lines[:len(lines)-1]); err != nil {
+			return err
+		}
+	}
+	if exceeded {
+		row.truncated = true
+		if err = row.CacheService.SetValue(row.Ctx, row.PipelineId, cache.OutputTruncated, true); err != nil {
+			return err
+		}
+		if row.LimitChannel != nil {
+			select {
+			case row.LimitChannel <- true:
+			default:
+			}
+		}
+	}
+	return nil
+}
+
+// writeRawOutput appends p to cache.RawRunOutput truncating it after MaxBytes bytes
+func (row *RunOutputWriter) writeRawOutput(p []byte) error {
+	if row.rawTruncated {
+		return nil
+	}
+	prevOutput, err := row.getOutput(cache.RawRunOutput)
+	if err != nil {
+		return err
+	}
+	data := p
+	exceeded := row.MaxBytes > 0 && len(prevOutput)+len(p) > row.MaxBytes
+	if exceeded {
+		data = truncateOutput(p, row.MaxBytes-len(prevOutput))
+		row.rawTruncated = true
+	}
+	str := prevOutput + string(data)
+	if exceeded {
+		str += OutputTruncatedMarker
+	}
+	return row.CacheService.SetValue(row.Ctx, row.PipelineId, cache.RawRunOutput, str)
+}
+
+// getOutput returns the output from cache by subKey
+func (row *RunOutputWriter) getOutput(subKey cache.SubKey) (string, error) {
+	value, err := row.CacheService.GetValue(row.Ctx, row.PipelineId, subKey)
+	if err != nil {
+		return "", err
+	}
+	output, converted := value.(string)
+	if !converted {
+		return "", errors.TypeMismatchError(fmt.Errorf("value of %T isn't string", value))
+	}
+	return output, nil
+}
+
+// filterLines returns completed lines of the output which don't match LogFilter.
+// The last incomplete line is kept until the next write or Flush.
+func (row *RunOutputWriter) filterLines(p []byte) []byte {
+	lines := bytes.SplitAfter(append(row.filterTail, p...), []byte("\n"))
+	last := lines[len(lines)-1]
+	row.filterTail = append([]byte(nil), last...)
+	var kept []byte
+	for _, line := range lines[:len(lines)-1] {
+		if !row.LogFilter.Match(bytes.TrimRight(line, "\r\n")) {
+			kept = append(kept, line...)
+		}
+	}
+	return kept
+}
+
+// Flush writes data kept by Transformers, then writes the last incomplete line of the output to cache.RunOutput
+// unless it matches LogFilter and, in case Format is JsonLinesFormat, saves records which aren't saved yet
+// with the last incomplete line to cache.RunRecords
+func (row *RunOutputWriter) Flush() error {
+	if len(row.Transformers) > 0 && !row.truncated {
+		if err := row.write(flushTransformers(row.Transformers)); err != nil {
+			return err
+		}
+	}
+	if len(row.filterTail) > 0 {
+		tail := row.filterTail
+		row.filterTail = nil
+		if !row.LogFilter.Match(tail) {
+			if err := row.writeOutput(tail); err != nil {
+				return err
+			}
+		}
+	}
+	if len(row.tail) > 0 {
+		tail := row.tail
+		row.tail = nil
+		if err := row.addRecords([][]byte{tail}); err != nil {
+			return err
+		}
+	}
+	return row.saveRecords()
+}
+
+// addRecords adds lines to the records which are loaded from cache on the first call.
+// Empty lines are counted but aren't added. Records are saved to cache once there are runRecordsBatchSize unsaved ones.
+func (row *RunOutputWriter) addRecords(lines [][]byte) error {
+	if len(lines) == 0 {
+		return nil
+	}
+	if !row.recordsLoaded {
+		value, err := row.CacheService.GetValue(row.Ctx, row.PipelineId, cache.RunRecords)
+		if err != nil {
+			return err
+		}
+		records, converted := value.([]cache.RunRecord)
+		if !converted {
+			return errors.TypeMismatchError(fmt.Errorf("value of %T isn't []cache.RunRecord", value))
+		}
+		row.records = records
+		row.recordsLoaded = true
+	}
+	for _, line := range lines {
+		row.lines++
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+		row.records = append(row.records, cache.RunRecord{Line: row.lines, Json: string(line), Malformed: !isJsonObject(line)})
+		row.unsavedRecords++
+	}
+	if row.unsavedRecords < runRecordsBatchSize {
+		return nil
+	}
+	return row.saveRecords()
+}
+
+// saveRecords saves the records to cache.RunRecords in case some of them aren't saved yet
+func (row *RunOutputWriter) saveRecords() error {
+	if row.unsavedRecords == 0 {
+		return nil
+	}
+	if err := row.CacheService.SetValue(row.Ctx, row.PipelineId, cache.RunRecords, row.records); err != nil {
+		return err
+	}
+	row.unsavedRecords = 0
+	return nil
+}
+
+// isJsonObject returns true if line is a valid JSON object
+func isJsonObject(line []byte) bool {
+	return bytes.HasPrefix(line, []byte("{")) && json.Valid(line)
+}
+
+// truncateOutput returns the first size bytes of p. The end is moved to the start of a UTF-8 character,
+// so the last character isn't broken.
+func truncateOutput(p []byte, size int) []byte {
+	if size <= 0 {
+		return nil
+	}
+	for size > 0 && !utf8.RuneStart(p[size]) {
+		size--
+	}
+	return p[:size]
 }
@@ -20,6 +20,9 @@ import (
 	"beam.apache.org/playground/backend/internal/cache/local"
 	"context"
 	"github.com/google/uuid"
+	"reflect"
+	"regexp"
+	"strings"
 	"testing"
 )
 
@@ -121,3 +124,341 @@ func TestRunOutputWriter_Write(t *testing.T) {
 		})
 	}
 }
+
+func TestRunOutputWriter_Write_MaxBytes(t *testing.T) {
+	tests := []struct {
+		name          string
+		maxBytes      int
+		writes        []string
+		want          string
+		wantTruncated bool
+	}{
+		{
+			// Test case with calling Write method with the output which doesn't exceed MaxBytes.
+			// As a result, want to receive the whole output without the marker.
+			name:          "output within the limit",
+			maxBytes:      10,
+			writes:        []string{"MOCK", "_OUT"},
+			want:          "MOCK_OUT",
+			wantTruncated: false,
+		},
+		{
+			// Test case with calling Write method with the output which exceeds MaxBytes.
+			// As a result, want to receive the first MaxBytes bytes with the marker once and cache.OutputTruncated set.
+			name:          "output exceeds the limit",
+			maxBytes:      6,
+			writes:        []string{"MOCK", "_OUTPUT", "_MORE"},
+			want:          "MOCK_O" + OutputTruncatedMarker,
+			wantTruncated: true,
+		},
+		{
+			// Test case with calling Write method with the output which exceeds MaxBytes in the middle of a UTF-8 character.
+			// As a result, want to receive the output without the broken character.
+			name:          "output exceeds the limit in the middle of a character",
+			maxBytes:      6,
+			writes:        []string{"MOCK_\u00e9"},
+			want:          "MOCK_" + OutputTruncatedMarker,
+			wantTruncated: true,
+		},
+		{
+			// Test case with calling Write method without MaxBytes.
+			// As a result, want to receive the whole output.
+			name:          "no limit",
+			writes:        []string{strings.Repeat("MOCK", 100)},
+			want:          strings.Repeat("MOCK", 100),
+			wantTruncated: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pipelineId := uuid.New()
+			cacheService := local.New(context.Background())
+			if err := cacheService.SetValue(context.Background(), pipelineId, cache.RunOutput, ""); err != nil {
+				panic(err)
+			}
+			limitChannel := make(chan bool, 1)
+			row := &RunOutputWriter{
+				Ctx:          context.Background(),
+				CacheService: cacheService,
+				PipelineId:   pipelineId,
+				MaxBytes:     tt.maxBytes,
+				LimitChannel: limitChannel,
+			}
+			for _, write := range tt.writes {
+				if got, err := row.Write([]byte(write)); err != nil || got != len(write) {
+					t.Fatalf("Write() got = %v, err = %v, want %v", got, err, len(write))
+				}
+			}
+			if got, _ := cacheService.GetValue(context.Background(), pipelineId, cache.RunOutput); got != tt.want {
+				t.Errorf("Write() output = %q, want %q", got, tt.want)
+			}
+			truncated, err := cacheService.GetValue(context.Background(), pipelineId, cache.OutputTruncated)
+			if tt.wantTruncated && (err != nil || truncated != true) {
+				t.Errorf("Write() cache.OutputTruncated = %v, err = %v, want true", truncated, err)
+			}
+			if !tt.wantTruncated && err == nil {
+				t.Errorf("Write() cache.OutputTruncated = %v, want it isn't set", truncated)
+			}
+			if got := len(limitChannel); (got > 0) != tt.wantTruncated {
+				t.Errorf("Write() LimitChannel receives %d values, want truncated %v", got, tt.wantTruncated)
+			}
+		})
+	}
+}
+
+func TestRunOutputWriter_Write_JsonLines(t *testing.T) {
+	batch := strings.Repeat("{}\n", runRecordsBatchSize)
+	batchRecords := make([]cache.RunRecord, 0, runRecordsBatchSize+1)
+	for line := 1; line <= runRecordsBatchSize+1; line++ {
+		batchRecords = append(batchRecords, cache.RunRecord{Line: line, Json: "{}"})
+	}
+	tests := []struct {
+		name   string
+		format string
+		// records are the records in cache before writes, an empty slice is used in case it is nil
+		records     interface{}
+		writes      []string
+		wantOutput  string
+		wantRecords []cache.RunRecord
+		// wantSaved is the number of records which should be saved to cache before Flush
+		wantSaved int
+		wantErr   bool
+	}{
+		{
+			// Test case with calling Write method with JSON lines split between writes and the last line without a line break.
+			// As a result, want to receive all lines as records once they are completed or flushed and the raw output as is.
+			name:       "json lines",
+			format:     JsonLinesFormat,
+			writes:     []string{"{\"word\":\"a\",", "\"count\":1}\n\n{\"word\":\"b\"}"},
+			wantOutput: "{\"word\":\"a\",\"count\":1}\n\n{\"word\":\"b\"}",
+			wantRecords: []cache.RunRecord{
+				{Line: 1, Json: "{\"word\":\"a\",\"count\":1}"},
+				{Line: 3, Json: "{\"word\":\"b\"}"},
+			},
+		},
+		{
+			// Test case with calling Write method with lines which aren't JSON objects.
+			// As a result, want to receive them as malformed records and in the raw output.
+			name:       "malformed lines",
+			format:     JsonLinesFormat,
+			writes:     []string{"MOCK_LINE\n[1, 2]\n{\"word\":\n"},
+			wantOutput: "MOCK_LINE\n[1, 2]\n{\"word\":\n",
+			wantRecords: []cache.RunRecord{
+				{Line: 1, Json: "MOCK_LINE", Malformed: true},
+				{Line: 2, Json: "[1, 2]", Malformed: true},
+				{Line: 3, Json: "{\"word\":", Malformed: true},
+			},
+		},
+		{
+			// Test case with calling Write method without the output format.
+			// As a result, want to receive the raw output without records.
+			name:        "no format",
+			format:      "",
+			writes:      []string{"{\"word\":\"a\"}\n"},
+			wantOutput:  "{\"word\":\"a\"}\n",
+			wantRecords: nil,
+		},
+		{
+			// Test case with calling Write method with more lines than the batch of records.
+			// As a result, want to receive the batch of records in cache before Flush and all records after it.
+			name:        "batch of records",
+			format:      JsonLinesFormat,
+			writes:      []string{batch, "{}\n"},
+			wantOutput:  batch + "{}\n",
+			wantRecords: batchRecords,
+			wantSaved:   runRecordsBatchSize,
+		},
+		{
+			// Test case with calling Write method when records in cache have the wrong type.
+			// As a result, want to receive an error.
+			name:    "records of the wrong type",
+			format:  JsonLinesFormat,
+			records: "MOCK_RECORDS",
+			writes:  []string{"{}\n"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pipelineId := uuid.New()
+			cacheService := local.New(context.Background())
+			if err := cacheService.SetValue(context.Background(), pipelineId, cache.RunOutput, ""); err != nil {
+				panic(err)
+			}
+			if tt.format != "" {
+				records := tt.records
+				if records == nil {
+					records = []cache.RunRecord{}
+				}
+				if err := cacheService.SetValue(context.Background(), pipelineId, cache.RunRecords, records); err != nil {
+					panic(err)
+				}
+			}
+			row := &RunOutputWriter{
+				Ctx:          context.Background(),
+				CacheService: cacheService,
+				PipelineId:   pipelineId,
+				Format:       tt.format,
+			}
+			for _, write := range tt.writes {
+				got, err := row.Write([]byte(write))
+				if (err != nil) != tt.wantErr {
+					t.Fatalf("Write() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				if err != nil {
+					return
+				}
+				if got != len(write) {
+					t.Fatalf("Write() got = %v, want %v", got, len(write))
+				}
+			}
+			if tt.wantSaved > 0 {
+				saved, _ := cacheService.GetValue(context.Background(), pipelineId, cache.RunRecords)
+				if records, _ := saved.([]cache.RunRecord); len(records) != tt.wantSaved {
+					t.Errorf("Write() saves %d records before Flush(), want %d", len(records), tt.wantSaved)
+				}
+			}
+			if err := row.Flush(); err != nil {
+				t.Fatalf("Flush() error = %v", err)
+			}
+			if got, _ := cacheService.GetValue(context.Background(), pipelineId, cache.RunOutput); got != tt.wantOutput {
+				t.Errorf("Write() output = %q, want %q", got, tt.wantOutput)
+			}
+			records, err := cacheService.GetValue(context.Background(), pipelineId, cache.RunRecords)
+			if tt.wantRecords == nil {
+				if err == nil {
+					t.Errorf("Write() records = %v, want they aren't set", records)
+				}
+				return
+			}
+			if !reflect.DeepEqual(records, tt.wantRecords) {
+				t.Errorf("Write() records = %v, want %v", records, tt.wantRecords)
+			}
+		})
+	}
+}
+
+func TestRunOutputWriter_Write_LogFilter(t *testing.T) {
+	logFilter := regexp.MustCompile(`^\S+ \S+ (INFO|DEBUG)`)
+	tests := []struct {
+		name          string
+		writes        []string
+		maxBytes      int
+		wantOutput    string
+		wantRawOutput string
+	}{
+		{
+			// Test case with calling Write method with log lines mixed with the output of the code and split between writes.
+			// As a result, want to receive the output without log lines and the raw output as is.
+			name:          "log lines are dropped",
+			writes:        []string{"2022-01-02 03:04:05 INFO Pipeline started\nHello", " world\n2022-01-02 03:04:06 DEBUG", " done\nWARNING: MOCK_WARNING\n"},
+			wantOutput:    "Hello world\nWARNING: MOCK_WARNING\n",
+			wantRawOutput: "2022-01-02 03:04:05 INFO Pipeline started\nHello world\n2022-01-02 03:04:06 DEBUG done\nWARNING: MOCK_WARNING\n",
+		},
+		{
+			// Test case with calling Write method with the last line without a line break.
+			// As a result, want to receive the last line once it is flushed unless it is a log line.
+			name:          "last line is flushed",
+			writes:        []string{"Hello\n", "2022-01-02 03:04:05 INFO Pipeline finished\nBye"},
+			wantOutput:    "Hello\nBye",
+			wantRawOutput: "Hello\n2022-01-02 03:04:05 INFO Pipeline finished\nBye",
+		},
+		{
+			// Test case with calling Write method with the raw output longer than the limit of size.
+			// As a result, want to receive the raw output truncated with the marker and the filtered output which fits the limit.
+			name:          "raw output is truncated",
+			writes:        []string{"2022-01-02 03:04:05 INFO Pipeline started\n", "Hello\n"},
+			maxBytes:      20,
+			wantOutput:    "Hello\n",
+			wantRawOutput: "2022-01-02 03:04:05 " + OutputTruncatedMarker,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pipelineId := uuid.New()
+			cacheService := local.New(context.Background())
+			if err := cacheService.SetValue(context.Background(), pipelineId, cache.RunOutput, ""); err != nil {
+				panic(err)
+			}
+			if err := cacheService.SetValue(context.Background(), pipelineId, cache.RawRunOutput, ""); err != nil {
+				panic(err)
+			}
+			row := &RunOutputWriter{
+				Ctx:          context.Background(),
+				CacheService: cacheService,
+				PipelineId:   pipelineId,
+				MaxBytes:     tt.maxBytes,
+				LogFilter:    logFilter,
+			}
+			for _, write := range tt.writes {
+				if got, err := row.Write([]byte(write)); err != nil || got != len(write) {
+					t.Fatalf("Write() got = %v, err = %v, want %v", got, err, len(write))
+				}
+			}
+			if err := row.Flush(); err != nil {
+				t.Fatalf("Flush() error = %v", err)
+			}
+			if got, _ := cacheService.GetValue(context.Background(), pipelineId, cache.RunOutput); got != tt.wantOutput {
+				t.Errorf("Write() output = %q, want %q", got, tt.wantOutput)
+			}
+			if got, _ := cacheService.GetValue(context.Background(), pipelineId, cache.RawRunOutput); got != tt.wantRawOutput {
+				t.Errorf("Write() raw output = %q, want %q", got, tt.wantRawOutput)
+			}
+		})
+	}
+}
+
+func TestRunOutputWriter_Write_Transformers(t *testing.T) {
+	tests := []struct {
+		name       string
+		writes     []string
+		logFilter  *regexp.Regexp
+		wantOutput string
+	}{
+		{
+			// Test case with calling Write method with colored output split between writes.
+			// As a result, want to receive the output without escape sequences.
+			name:       "escape sequences are stripped",
+			writes:     []string{"\x1b[32mHello\x1b", "[0m world\n"},
+			wantOutput: "Hello world\n",
+		},
+		{
+			// Test case with calling Write method with colored log lines and the log filter.
+			// As a result, want to receive the output without escape sequences and log lines.
+			name:       "escape sequences are stripped before filtering",
+			writes:     []string{"\x1b[34m2022-01-02 03:04:05 INFO\x1b[0m Pipeline started\n", "Hello\n\x1b[1mBye\x1b["},
+			logFilter:  regexp.MustCompile(`^\S+ \S+ INFO`),
+			wantOutput: "Hello\nBye\x1b[",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pipelineId := uuid.New()
+			cacheService := local.New(context.Background())
+			if err := cacheService.SetValue(context.Background(), pipelineId, cache.RunOutput, ""); err != nil {
+				panic(err)
+			}
+			if err := cacheService.SetValue(context.Background(), pipelineId, cache.RawRunOutput, ""); err != nil {
+				panic(err)
+			}
+			row := &RunOutputWriter{
+				Ctx:          context.Background(),
+				CacheService: cacheService,
+				PipelineId:   pipelineId,
+				LogFilter:    tt.logFilter,
+				Transformers: []OutputTransformer{&AnsiStripper{}},
+			}
+			for _, write := range tt.writes {
+				if got, err := row.Write([]byte(write)); err != nil || got != len(write) {
+					t.Fatalf("Write() got = %v, err = %v, want %v", got, err, len(write))
+				}
+			}
+			if err := row.Flush(); err != nil {
+				t.Fatalf("Flush() error = %v", err)
+			}
+			if got, _ := cacheService.GetValue(context.Background(), pipelineId, cache.RunOutput); got != tt.wantOutput {
+				t.Errorf("Write() output = %q, want %q", got, tt.wantOutput)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+
+package streaming
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+)
+
+// SamplingMarkerFormat is a format of the line which is written once sampling of the output is activated
+const SamplingMarkerFormat = "[Output is too long, sampling is active: only 1 of every %d lines is shown]\n"
+
+// SamplingWriter is used to sample the output of very high-throughput pipelines.
+// The first Threshold lines are written to Writer as is. After that only 1 of every Rate lines is written,
+// and the line with the marker of sampling is written once before the sampled lines.
+type SamplingWriter struct {
+	Writer    io.Writer
+	Threshold int
+	Rate      int
+
+	lines   int
+	partial bool
+	marked  bool
+}
+
+// Write writes the sampled lines of p to Writer.
+// In case some error occurs - returns (0, error).
+// In case finished with no error - returns (len(p), nil) even if some lines are skipped.
+func (sw *SamplingWriter) Write(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	var sampled []byte
+	for rest := p; len(rest) > 0; {
+		segment := rest
+		end := bytes.IndexByte(rest, '\n')
+		if end >= 0 {
+			segment = rest[:end+1]
+		}
+		rest = rest[len(segment):]
+
+		if sw.lines == sw.Threshold && !sw.partial && !sw.marked {
+			sampled = append(sampled, fmt.Sprintf(SamplingMarkerFormat, sw.Rate)...)
+			sw.marked = true
+		}
+		if sw.keepLine() {
+			sampled = append(sampled, segment...)
+		}
+		sw.partial = end < 0
+		if !sw.partial {
+			sw.lines++
+		}
+	}
+
+	if len(sampled) > 0 {
+		if _, err := sw.Writer.Write(sampled); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// keepLine returns true if the current line should be written
+func (sw *SamplingWriter) keepLine() bool {
+	if sw.lines < sw.Threshold || sw.Rate <= 1 {
+		return true
+	}
+	return (sw.lines-sw.Threshold)%sw.Rate == 0
+}
@@ -0,0 +1,85 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+
+package streaming
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+func TestSamplingWriter_Write(t *testing.T) {
+	marker := fmt.Sprintf(SamplingMarkerFormat, 2)
+	type fields struct {
+		Threshold int
+		Rate      int
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		writes []string
+		want   string
+	}{
+		{
+			// Test case with calling Write method with output shorter than the threshold.
+			// As a result, want to receive the whole output.
+			name:   "output isn't sampled",
+			fields: fields{Threshold: 3, Rate: 2},
+			writes: []string{"1\n2\n", "3\n"},
+			want:   "1\n2\n3\n",
+		},
+		{
+			// Test case with calling Write method with output longer than the threshold.
+			// As a result, want to receive the first lines, the marker and 1 of every 2 next lines.
+			name:   "output is sampled",
+			fields: fields{Threshold: 2, Rate: 2},
+			writes: []string{"1\n2\n3\n", "4\n5\n6\n"},
+			want:   "1\n2\n" + marker + "3\n5\n",
+		},
+		{
+			// Test case with calling Write method with lines which are split between several writes.
+			// As a result, want to receive the sampled lines without broken lines.
+			name:   "lines are split",
+			fields: fields{Threshold: 1, Rate: 2},
+			writes: []string{"1", "1\n2", "2\n3", "3\n4"},
+			want:   "11\n" + marker + "22\n4",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var output bytes.Buffer
+			sw := &SamplingWriter{
+				Writer:    &output,
+				Threshold: tt.fields.Threshold,
+				Rate:      tt.fields.Rate,
+			}
+			for _, data := range tt.writes {
+				got, err := sw.Write([]byte(data))
+				if err != nil {
+					t.Errorf("Write() error = %v", err)
+					return
+				}
+				if got != len(data) {
+					t.Errorf("Write() got = %v, want %v", got, len(data))
+				}
+			}
+			if output.String() != tt.want {
+				t.Errorf("Write() writes %q, want %q", output.String(), tt.want)
+			}
+		})
+	}
+}
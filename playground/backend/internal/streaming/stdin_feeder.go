@@ -0,0 +1,47 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package streaming
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// StdinChunk is a part of the scripted stdin of the command which is fed after Delay
+type StdinChunk struct {
+	Data  []byte
+	Delay time.Duration
+}
+
+// FeedStdin writes chunks to the stdin of the command one by one waiting for the delay of each chunk before writing it.
+// Stops feeding as soon as ctx is done or the command doesn't read its stdin anymore.
+// Closes stdin at the end, so the command receives EOF after the last chunk.
+func FeedStdin(ctx context.Context, stdin io.WriteCloser, chunks []StdinChunk) {
+	defer stdin.Close()
+	for _, chunk := range chunks {
+		timer := time.NewTimer(chunk.Delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		if _, err := stdin.Write(chunk.Data); err != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,115 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package streaming
+
+import (
+	"bytes"
+	"context"
+	"os/exec"
+	"testing"
+	"time"
+)
+
+// bufferCloser is a buffer which keeps whether it has been closed
+type bufferCloser struct {
+	bytes.Buffer
+	closed bool
+}
+
+func (b *bufferCloser) Close() error {
+	b.closed = true
+	return nil
+}
+
+func TestFeedStdin(t *testing.T) {
+	tests := []struct {
+		name     string
+		chunks   []StdinChunk
+		timeout  time.Duration
+		want     string
+		wantTime time.Duration
+	}{
+		{
+			// Test case with calling FeedStdin method with chunks without delays.
+			// As a result, want to receive all chunks written to stdin.
+			name:    "chunks without delays",
+			chunks:  []StdinChunk{{Data: []byte("MOCK_")}, {Data: []byte("INPUT\n")}},
+			timeout: time.Second,
+			want:    "MOCK_INPUT\n",
+		},
+		{
+			// Test case with calling FeedStdin method with the chunk after the delay.
+			// As a result, want to receive all chunks written to stdin not earlier than after the delay.
+			name:     "chunk with delay",
+			chunks:   []StdinChunk{{Data: []byte("FIRST\n")}, {Data: []byte("SECOND\n"), Delay: 100 * time.Millisecond}},
+			timeout:  time.Second,
+			want:     "FIRST\nSECOND\n",
+			wantTime: 100 * time.Millisecond,
+		},
+		{
+			// Test case with calling FeedStdin method with the chunk which delay is longer than the timeout.
+			// As a result, want to receive only chunks fed before the timeout.
+			name:     "delay exceeds timeout",
+			chunks:   []StdinChunk{{Data: []byte("FIRST\n")}, {Data: []byte("SECOND\n"), Delay: time.Hour}},
+			timeout:  100 * time.Millisecond,
+			want:     "FIRST\n",
+			wantTime: 100 * time.Millisecond,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), tt.timeout)
+			defer cancel()
+			stdin := &bufferCloser{}
+			start := time.Now()
+			FeedStdin(ctx, stdin, tt.chunks)
+			if elapsed := time.Since(start); elapsed < tt.wantTime {
+				t.Errorf("FeedStdin() finished after %s, want at least %s", elapsed, tt.wantTime)
+			}
+			if got := stdin.String(); got != tt.want {
+				t.Errorf("FeedStdin() written = %q, want %q", got, tt.want)
+			}
+			if !stdin.closed {
+				t.Error("FeedStdin() doesn't close stdin")
+			}
+		})
+	}
+}
+
+func TestFeedStdin_CommandExitsEarly(t *testing.T) {
+	// The command exits without reading stdin while there is more data than the pipe could buffer.
+	// As a result, want FeedStdin to finish instead of blocking on writing to stdin.
+	cmd := exec.Command("true")
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		t.Fatalf("error during getting stdin: %s", err.Error())
+	}
+	if err = cmd.Start(); err != nil {
+		t.Fatalf("error during starting the command: %s", err.Error())
+	}
+	chunks := []StdinChunk{{Data: bytes.Repeat([]byte("MOCK_INPUT\n"), 1<<16)}, {Data: []byte("MOCK_INPUT\n")}}
+	done := make(chan struct{})
+	go func() {
+		FeedStdin(context.Background(), stdin, chunks)
+		close(done)
+	}()
+	_ = cmd.Wait()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("FeedStdin() is blocked after the command is finished")
+	}
+}
@@ -0,0 +1,95 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package streaming
+
+import (
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/encoding/korean"
+	"golang.org/x/text/encoding/simplifiedchinese"
+	"golang.org/x/text/encoding/traditionalchinese"
+	"golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/transform"
+	"io"
+	"strings"
+)
+
+// utf8Charset is the name of the charset which output is written as is
+const utf8Charset = "UTF-8"
+
+// charsets contains encodings of supported charsets of the output by their names in upper case
+var charsets = map[string]encoding.Encoding{
+	utf8Charset:    unicode.UTF8,
+	"UTF-16BE":     unicode.UTF16(unicode.BigEndian, unicode.IgnoreBOM),
+	"UTF-16LE":     unicode.UTF16(unicode.LittleEndian, unicode.IgnoreBOM),
+	"ISO-8859-1":   charmap.ISO8859_1,
+	"ISO-8859-2":   charmap.ISO8859_2,
+	"ISO-8859-5":   charmap.ISO8859_5,
+	"ISO-8859-15":  charmap.ISO8859_15,
+	"WINDOWS-1250": charmap.Windows1250,
+	"WINDOWS-1251": charmap.Windows1251,
+	"WINDOWS-1252": charmap.Windows1252,
+	"KOI8-R":       charmap.KOI8R,
+	"SHIFT_JIS":    japanese.ShiftJIS,
+	"EUC-JP":       japanese.EUCJP,
+	"EUC-KR":       korean.EUCKR,
+	"GBK":          simplifiedchinese.GBK,
+	"GB18030":      simplifiedchinese.GB18030,
+	"BIG5":         traditionalchinese.Big5,
+}
+
+// IsSupportedCharset returns true if the output in the charset could be transcoded to UTF-8.
+// Names of charsets are case-insensitive, empty charset means UTF-8.
+func IsSupportedCharset(charset string) bool {
+	if charset == "" {
+		return true
+	}
+	_, ok := charsets[strings.ToUpper(charset)]
+	return ok
+}
+
+// TranscodingWriter transcodes the output in the charset to UTF-8 before writing it to the underlying writer.
+// Bytes of the character which isn't completed by the last write are kept until Flush.
+type TranscodingWriter struct {
+	writer *transform.Writer
+}
+
+// NewTranscodingWriter returns writer which transcodes the output in the charset to UTF-8 before writing it to writer.
+// Multi-byte characters split between several writes are transcoded once the rest of them is written.
+// In case charset is empty, UTF-8 or isn't supported returns writer as is, otherwise returns *TranscodingWriter.
+func NewTranscodingWriter(writer io.Writer, charset string) io.Writer {
+	charset = strings.ToUpper(charset)
+	if charset == "" || charset == utf8Charset {
+		return writer
+	}
+	charsetEncoding, ok := charsets[charset]
+	if !ok {
+		return writer
+	}
+	return &TranscodingWriter{writer: transform.NewWriter(writer, charsetEncoding.NewDecoder())}
+}
+
+// Write transcodes p and writes it to the underlying writer
+func (tw *TranscodingWriter) Write(p []byte) (int, error) {
+	return tw.writer.Write(p)
+}
+
+// Flush transcodes kept bytes of the incomplete character (they are replaced with the replacement character)
+// and writes them to the underlying writer. The writer shouldn't be written after it is flushed.
+func (tw *TranscodingWriter) Flush() error {
+	return tw.writer.Close()
+}
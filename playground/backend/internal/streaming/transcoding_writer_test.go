@@ -0,0 +1,128 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package streaming
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestIsSupportedCharset(t *testing.T) {
+	tests := []struct {
+		name    string
+		charset string
+		want    bool
+	}{
+		{
+			// Test case with calling IsSupportedCharset method with empty charset.
+			// As a result, want to receive true because UTF-8 is used.
+			name:    "empty charset",
+			charset: "",
+			want:    true,
+		},
+		{
+			// Test case with calling IsSupportedCharset method with the supported charset in lower case.
+			// As a result, want to receive true.
+			name:    "supported charset",
+			charset: "iso-8859-1",
+			want:    true,
+		},
+		{
+			// Test case with calling IsSupportedCharset method with the unknown charset.
+			// As a result, want to receive false.
+			name:    "unsupported charset",
+			charset: "MOCK_CHARSET",
+			want:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsSupportedCharset(tt.charset); got != tt.want {
+				t.Errorf("IsSupportedCharset() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewTranscodingWriter(t *testing.T) {
+	tests := []struct {
+		name    string
+		charset string
+		writes  [][]byte
+		// flush is true if the writer is flushed after writes
+		flush bool
+		want  string
+	}{
+		{
+			// Test case with calling Write method of the writer for UTF-8 output.
+			// As a result, want to receive the output as is.
+			name:    "utf-8 output",
+			charset: "UTF-8",
+			writes:  [][]byte{[]byte("Grüße\n")},
+			want:    "Grüße\n",
+		},
+		{
+			// Test case with calling Write method of the writer for ISO-8859-1 output.
+			// As a result, want to receive the output transcoded to UTF-8.
+			name:    "iso-8859-1 output",
+			charset: "ISO-8859-1",
+			writes:  [][]byte{{'G', 'r', 0xfc, 0xdf, 'e', '\n'}},
+			want:    "Grüße\n",
+		},
+		{
+			// Test case with calling Write method of the writer for Shift_JIS output with the character split between writes.
+			// As a result, want to receive the output transcoded to UTF-8 without broken characters.
+			name:    "shift_jis output split between writes",
+			charset: "shift_jis",
+			writes:  [][]byte{{0x82}, {0xa0, '\n'}},
+			want:    "あ\n",
+		},
+		{
+			// Test case with calling Flush method of the writer for Shift_JIS output which ends with the incomplete character.
+			// As a result, want to receive the replacement character instead of the kept bytes.
+			name:    "shift_jis output with incomplete character is flushed",
+			charset: "shift_jis",
+			writes:  [][]byte{{0x82, 0xa0, 0x82}},
+			flush:   true,
+			want:    "あ\ufffd",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var output bytes.Buffer
+			writer := NewTranscodingWriter(&output, tt.charset)
+			for _, data := range tt.writes {
+				got, err := writer.Write(data)
+				if err != nil {
+					t.Errorf("Write() error = %v", err)
+					return
+				}
+				if got != len(data) {
+					t.Errorf("Write() got = %v, want %v", got, len(data))
+				}
+			}
+			if tt.flush {
+				if err := writer.(*TranscodingWriter).Flush(); err != nil {
+					t.Errorf("Flush() error = %v", err)
+					return
+				}
+			}
+			if output.String() != tt.want {
+				t.Errorf("Write() writes %q, want %q", output.String(), tt.want)
+			}
+		})
+	}
+}
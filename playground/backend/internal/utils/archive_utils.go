@@ -0,0 +1,123 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// ErrInvalidArchive is returned in case the file uploaded by the client isn't a valid archive or it is too large
+var ErrInvalidArchive = errors.New("invalid archive")
+
+// ErrArchiveTooLarge is returned by ZipFolder in case the archive exceeds the maximum size
+var ErrArchiveTooLarge = errors.New("archive is too large")
+
+// CheckArchive returns ErrInvalidArchive in case the file by filePath is larger than maxSize bytes (if it is positive)
+// or it isn't a valid zip archive with at least one file, e.g. a jar or a wheel.
+func CheckArchive(filePath string, maxSize int) error {
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return err
+	}
+	name := filepath.Base(filePath)
+	if maxSize > 0 && info.Size() > int64(maxSize) {
+		return fmt.Errorf("%w: %s is %d bytes, the limit is %d bytes", ErrInvalidArchive, name, info.Size(), maxSize)
+	}
+	archive, err := zip.OpenReader(filePath)
+	if err != nil {
+		return fmt.Errorf("%w: %s: %s", ErrInvalidArchive, name, err.Error())
+	}
+	defer archive.Close()
+	if len(archive.File) == 0 {
+		return fmt.Errorf("%w: %s is empty", ErrInvalidArchive, name)
+	}
+	return nil
+}
+
+// ZipFolder returns the zip archive with all files from the folder and extraFiles.
+// Names of files from the folder are relative to the folder, extraFiles contain content of files by their names.
+// In case maxSize is positive the archive is written through the writer limited by maxSize bytes,
+// so packing is stopped with ErrArchiveTooLarge as soon as the archive exceeds the limit.
+func ZipFolder(folderPath string, extraFiles map[string]string, maxSize int) ([]byte, error) {
+	var buffer bytes.Buffer
+	archive := zip.NewWriter(&limitedWriter{writer: &buffer, limit: maxSize})
+	names := make([]string, 0, len(extraFiles))
+	for name := range extraFiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		writer, err := archive.Create(name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err = writer.Write([]byte(extraFiles[name])); err != nil {
+			return nil, err
+		}
+	}
+	err := filepath.WalkDir(folderPath, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		name, err := filepath.Rel(folderPath, path)
+		if err != nil {
+			return err
+		}
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		writer, err := archive.Create(filepath.ToSlash(name))
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(writer, file)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err = archive.Close(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// limitedWriter writes to writer until more than limit bytes are written, then returns ErrArchiveTooLarge.
+// Not positive limit means that the size isn't limited.
+type limitedWriter struct {
+	writer  io.Writer
+	limit   int
+	written int
+}
+
+func (lw *limitedWriter) Write(p []byte) (int, error) {
+	if lw.limit > 0 && lw.written+len(p) > lw.limit {
+		return 0, fmt.Errorf("%w: the limit is %d bytes", ErrArchiveTooLarge, lw.limit)
+	}
+	n, err := lw.writer.Write(p)
+	lw.written += n
+	return n, err
+}
@@ -0,0 +1,171 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"archive/zip"
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestZipFolder(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "org", "example"), 0700); err != nil {
+		t.Fatalf("error during prepare folders: %s", err.Error())
+	}
+	if err := os.WriteFile(filepath.Join(dir, "org", "example", "Main.class"), []byte("MOCK_CLASS"), 0600); err != nil {
+		t.Fatalf("error during prepare files: %s", err.Error())
+	}
+	type args struct {
+		folderPath string
+		extraFiles map[string]string
+		maxSize    int
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			// Test case with calling ZipFolder method with the folder which doesn't exist.
+			// As a result, want to receive an error.
+			name:    "folder doesn't exist",
+			args:    args{folderPath: filepath.Join(dir, "absent")},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			// Test case with calling ZipFolder method with the folder and extra files.
+			// As a result, want to receive the archive with files from the folder and extra files.
+			name:    "folder with extra files",
+			args:    args{folderPath: dir, extraFiles: map[string]string{"META-INF/MANIFEST.MF": "MOCK_MANIFEST"}},
+			want:    map[string]string{"META-INF/MANIFEST.MF": "MOCK_MANIFEST", "org/example/Main.class": "MOCK_CLASS"},
+			wantErr: false,
+		},
+		{
+			// Test case with calling ZipFolder method with the maximum size which the archive doesn't exceed.
+			// As a result, want to receive the archive with files from the folder.
+			name:    "archive is within the maximum size",
+			args:    args{folderPath: dir, maxSize: 1024},
+			want:    map[string]string{"org/example/Main.class": "MOCK_CLASS"},
+			wantErr: false,
+		},
+		{
+			// Test case with calling ZipFolder method with the maximum size which the archive exceeds.
+			// As a result, want to receive an error.
+			name:    "archive exceeds the maximum size",
+			args:    args{folderPath: dir, maxSize: 16},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ZipFolder(tt.args.folderPath, tt.args.extraFiles, tt.args.maxSize)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ZipFolder() err = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			archive, err := zip.NewReader(bytes.NewReader(got), int64(len(got)))
+			if err != nil {
+				t.Fatalf("ZipFolder() returns incorrect archive: %s", err.Error())
+			}
+			files := make(map[string]string)
+			for _, file := range archive.File {
+				reader, err := file.Open()
+				if err != nil {
+					t.Fatalf("ZipFolder() returns incorrect archive: %s", err.Error())
+				}
+				content, _ := ioutil.ReadAll(reader)
+				reader.Close()
+				files[file.Name] = string(content)
+			}
+			if !reflect.DeepEqual(files, tt.want) {
+				t.Errorf("ZipFolder() got = %v, want %v", files, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckArchive(t *testing.T) {
+	dir := t.TempDir()
+	archive, err := ZipFolder(dir, map[string]string{"transforms/__init__.py": "VERSION = 1"}, 0)
+	if err != nil {
+		t.Fatalf("error during prepare the archive: %s", err.Error())
+	}
+	archivePath := filepath.Join(dir, "transforms.whl")
+	if err = os.WriteFile(archivePath, archive, 0600); err != nil {
+		t.Fatalf("error during prepare files: %s", err.Error())
+	}
+	notArchivePath := filepath.Join(dir, "transforms.jar")
+	if err = os.WriteFile(notArchivePath, []byte("MOCK_JAR"), 0600); err != nil {
+		t.Fatalf("error during prepare files: %s", err.Error())
+	}
+	type args struct {
+		filePath string
+		maxSize  int
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			// Test case with calling CheckArchive method with the file which doesn't exist.
+			// As a result, want to receive an error.
+			name:    "file doesn't exist",
+			args:    args{filePath: filepath.Join(dir, "absent.whl")},
+			wantErr: true,
+		},
+		{
+			// Test case with calling CheckArchive method with the valid archive.
+			// As a result, want to receive no error.
+			name:    "valid archive",
+			args:    args{filePath: archivePath, maxSize: len(archive)},
+			wantErr: false,
+		},
+		{
+			// Test case with calling CheckArchive method with the archive larger than the limit.
+			// As a result, want to receive an error.
+			name:    "archive is too large",
+			args:    args{filePath: archivePath, maxSize: len(archive) - 1},
+			wantErr: true,
+		},
+		{
+			// Test case with calling CheckArchive method with the file which isn't an archive.
+			// As a result, want to receive an error.
+			name:    "file isn't archive",
+			args:    args{filePath: notArchivePath},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckArchive(tt.args.filePath, tt.args.maxSize)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CheckArchive() err = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -27,7 +27,7 @@ import (
 func SetToCache(ctx context.Context, cacheService cache.Cache, key uuid.UUID, subKey cache.SubKey, value interface{}) error {
 	err := cacheService.SetValue(ctx, key, subKey, value)
 	if err != nil {
-		logger.Errorf("%s: cache.SetValue: %s\n", key, err.Error())
+		logger.WithPipelineId(key).Errorf("cache.SetValue: %s\n", err.Error())
 	}
 	return err
 }
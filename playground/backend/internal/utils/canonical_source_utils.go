@@ -0,0 +1,156 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"strings"
+)
+
+// CanonicalizeSource returns the canonical form of the code according to sdk.
+// The canonical form doesn't contain comments and formatting differences,
+// so trivially different code has the same canonical form.
+func CanonicalizeSource(sdk pb.Sdk, code string) (string, error) {
+	switch sdk {
+	case pb.Sdk_SDK_JAVA, pb.Sdk_SDK_SCIO:
+		// Scala has the same syntax of comments and string literals as Java
+		return canonicalizeJavaSource(code)
+	case pb.Sdk_SDK_GO:
+		return canonicalizeGoSource(code)
+	case pb.Sdk_SDK_PYTHON:
+		return canonicalizePythonSource(code)
+	default:
+		return "", fmt.Errorf("incorrect sdk: %s", sdk)
+	}
+}
+
+// canonicalizeGoSource returns the go code without comments formatted by gofmt
+func canonicalizeGoSource(code string) (string, error) {
+	fileSet := token.NewFileSet()
+	file, err := parser.ParseFile(fileSet, "", code, 0)
+	if err != nil {
+		return "", err
+	}
+	var buffer bytes.Buffer
+	if err = format.Node(&buffer, fileSet, file); err != nil {
+		return "", err
+	}
+	return removeEmptyLines(buffer.String(), false), nil
+}
+
+// canonicalizeJavaSource returns the java code without comments, indentation and empty lines
+func canonicalizeJavaSource(code string) (string, error) {
+	var result strings.Builder
+	for i := 0; i < len(code); i++ {
+		switch {
+		case code[i] == '"' || code[i] == '\'':
+			end, err := skipQuoted(code, i, code[i:i+1])
+			if err != nil {
+				return "", err
+			}
+			result.WriteString(code[i:end])
+			i = end - 1
+		case strings.HasPrefix(code[i:], "//"):
+			end := strings.IndexByte(code[i:], '\n')
+			if end == -1 {
+				i = len(code)
+			} else {
+				i += end - 1
+			}
+		case strings.HasPrefix(code[i:], "/*"):
+			end := strings.Index(code[i+2:], "*/")
+			if end == -1 {
+				return "", errors.New("unterminated comment")
+			}
+			result.WriteByte(' ')
+			i += end + 3
+		default:
+			result.WriteByte(code[i])
+		}
+	}
+	return removeEmptyLines(result.String(), true), nil
+}
+
+// canonicalizePythonSource returns the python code without comments, trailing spaces and empty lines.
+// Indentation is kept because it is significant in python.
+func canonicalizePythonSource(code string) (string, error) {
+	var result strings.Builder
+	for i := 0; i < len(code); i++ {
+		switch {
+		case strings.HasPrefix(code[i:], `"""`) || strings.HasPrefix(code[i:], `'''`):
+			end, err := skipQuoted(code, i, code[i:i+3])
+			if err != nil {
+				return "", err
+			}
+			result.WriteString(code[i:end])
+			i = end - 1
+		case code[i] == '"' || code[i] == '\'':
+			end, err := skipQuoted(code, i, code[i:i+1])
+			if err != nil {
+				return "", err
+			}
+			result.WriteString(code[i:end])
+			i = end - 1
+		case code[i] == '#':
+			end := strings.IndexByte(code[i:], '\n')
+			if end == -1 {
+				i = len(code)
+			} else {
+				i += end - 1
+			}
+		default:
+			result.WriteByte(code[i])
+		}
+	}
+	return removeEmptyLines(result.String(), false), nil
+}
+
+// skipQuoted returns the index following the literal which starts at the start index with the quote
+func skipQuoted(code string, start int, quote string) (int, error) {
+	for i := start + len(quote); i < len(code); i++ {
+		switch {
+		case code[i] == '\\':
+			i++
+		case strings.HasPrefix(code[i:], quote):
+			return i + len(quote), nil
+		case code[i] == '\n' && len(quote) == 1:
+			return 0, errors.New("unterminated string literal")
+		}
+	}
+	return 0, errors.New("unterminated string literal")
+}
+
+// removeEmptyLines returns the code without empty lines and trailing spaces.
+// If trimIndentation is true, leading spaces of lines are also removed.
+func removeEmptyLines(code string, trimIndentation bool) string {
+	lines := make([]string, 0)
+	for _, line := range strings.Split(code, "\n") {
+		line = strings.TrimRight(line, " \t\r")
+		if trimIndentation {
+			line = strings.TrimLeft(line, " \t")
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,103 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	playground "beam.apache.org/playground/backend/internal/api/v1"
+	"testing"
+)
+
+func TestCanonicalizeSource(t *testing.T) {
+	type args struct {
+		sdk  playground.Sdk
+		code string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			// Test case with calling CanonicalizeSource method with incorrect SDK.
+			// As a result, want to receive an error.
+			name:    "incorrect sdk",
+			args:    args{sdk: playground.Sdk_SDK_UNSPECIFIED, code: "MOCK_CODE"},
+			want:    "",
+			wantErr: true,
+		},
+		{
+			// Test case with calling CanonicalizeSource method with java code with comments and indentation.
+			// As a result, want to receive the code without comments, indentation and empty lines.
+			name:    "java code",
+			args:    args{sdk: playground.Sdk_SDK_JAVA, code: "// header\nclass Main {\n\n    /* doc */\n    String s = \"// not a comment\";  \n}\n"},
+			want:    "class Main {\nString s = \"// not a comment\";\n}",
+			wantErr: false,
+		},
+		{
+			// Test case with calling CanonicalizeSource method with java code with the unterminated comment.
+			// As a result, want to receive an error.
+			name:    "java code with unterminated comment",
+			args:    args{sdk: playground.Sdk_SDK_JAVA, code: "class Main {} /* comment"},
+			want:    "",
+			wantErr: true,
+		},
+		{
+			// Test case with calling CanonicalizeSource method with go code with comments and broken formatting.
+			// As a result, want to receive the code without comments formatted by gofmt.
+			name:    "go code",
+			args:    args{sdk: playground.Sdk_SDK_GO, code: "// Package main\npackage main\nimport \"fmt\"\n\n\nfunc main()  {   fmt.Println(\"# not a comment\") // print\n}"},
+			want:    "package main\nimport \"fmt\"\nfunc main() {\n\tfmt.Println(\"# not a comment\")\n}",
+			wantErr: false,
+		},
+		{
+			// Test case with calling CanonicalizeSource method with go code which couldn't be parsed.
+			// As a result, want to receive an error.
+			name:    "broken go code",
+			args:    args{sdk: playground.Sdk_SDK_GO, code: "package main\n\nfunc main() {"},
+			want:    "",
+			wantErr: true,
+		},
+		{
+			// Test case with calling CanonicalizeSource method with python code with comments and empty lines.
+			// As a result, want to receive the code without comments and empty lines with kept indentation.
+			name:    "python code",
+			args:    args{sdk: playground.Sdk_SDK_PYTHON, code: "# header\ndef main():\n\n    '''# docstring'''\n    print('# not a comment')  # print\n"},
+			want:    "def main():\n    '''# docstring'''\n    print('# not a comment')",
+			wantErr: false,
+		},
+		{
+			// Test case with calling CanonicalizeSource method with python code with the unterminated string literal.
+			// As a result, want to receive an error.
+			name:    "python code with unterminated string literal",
+			args:    args{sdk: playground.Sdk_SDK_PYTHON, code: "print('text)\n"},
+			want:    "",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CanonicalizeSource(tt.args.sdk, tt.args.code)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CanonicalizeSource() err = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("CanonicalizeSource() got = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
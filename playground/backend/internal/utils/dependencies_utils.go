@@ -0,0 +1,165 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"errors"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	javaImportRegexp       = regexp.MustCompile(`(?m)^\s*import\s+(?:static\s+)?([\w.]+?)(?:\.\*)?\s*;`)
+	pythonImportRegexp     = regexp.MustCompile(`(?m)^\s*import\s+([\w.]+(?:\s+as\s+\w+)?(?:\s*,\s*[\w.]+(?:\s+as\s+\w+)?)*)`)
+	pythonFromImportRegexp = regexp.MustCompile(`(?m)^\s*from\s+([\w.]+)\s+import\s`)
+	scioImportRegexp       = regexp.MustCompile(`(?m)^\s*import\s+([\w.]+?)(?:\._|\.\{([^}]*)\})?\s*$`)
+)
+
+// ErrDisallowedImports is returned in case the code imports packages or modules which aren't available on the server
+var ErrDisallowedImports = errors.New("code imports packages which aren't available on the server")
+
+// GetDependencies returns sorted imports of the code from the file according to sdk
+func GetDependencies(sdk pb.Sdk, filePath string) ([]string, error) {
+	code, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	var dependencies []string
+	switch sdk {
+	case pb.Sdk_SDK_JAVA:
+		dependencies = getJavaDependencies(code)
+	case pb.Sdk_SDK_GO:
+		dependencies, err = getGoDependencies(code)
+	case pb.Sdk_SDK_PYTHON:
+		dependencies = getPythonDependencies(code)
+	case pb.Sdk_SDK_SCIO:
+		dependencies = getScioDependencies(code)
+	default:
+		return nil, fmt.Errorf("incorrect sdk: %s", sdk)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return uniqueSorted(dependencies), nil
+}
+
+// CheckImports returns ErrDisallowedImports listing imports of the code from filePaths according to sdk which aren't allowed by isAllowed.
+// Python modules of files from filePaths and relative imports of Python code are always allowed.
+func CheckImports(sdk pb.Sdk, filePaths []string, isAllowed func(string) bool) error {
+	localModules := make(map[string]bool)
+	if sdk == pb.Sdk_SDK_PYTHON {
+		for _, filePath := range filePaths {
+			localModules[strings.TrimSuffix(filepath.Base(filePath), filepath.Ext(filePath))] = true
+		}
+	}
+	var disallowed []string
+	for _, filePath := range filePaths {
+		dependencies, err := GetDependencies(sdk, filePath)
+		if err != nil {
+			return err
+		}
+		for _, dependency := range dependencies {
+			if strings.HasPrefix(dependency, ".") || localModules[strings.SplitN(dependency, ".", 2)[0]] || isAllowed(dependency) {
+				continue
+			}
+			disallowed = append(disallowed, dependency)
+		}
+	}
+	if len(disallowed) > 0 {
+		return fmt.Errorf("%w: %s. Only packages pre-installed on the server could be imported", ErrDisallowedImports, strings.Join(uniqueSorted(disallowed), ", "))
+	}
+	return nil
+}
+
+// getJavaDependencies returns classes and packages imported by the java code
+func getJavaDependencies(code []byte) []string {
+	dependencies := make([]string, 0)
+	for _, match := range javaImportRegexp.FindAllSubmatch(code, -1) {
+		dependencies = append(dependencies, string(match[1]))
+	}
+	return dependencies
+}
+
+// getGoDependencies returns packages imported by the go code
+func getGoDependencies(code []byte) ([]string, error) {
+	file, err := parser.ParseFile(token.NewFileSet(), "", code, parser.ImportsOnly)
+	if err != nil {
+		return nil, err
+	}
+	dependencies := make([]string, 0, len(file.Imports))
+	for _, importSpec := range file.Imports {
+		path, err := strconv.Unquote(importSpec.Path.Value)
+		if err != nil {
+			return nil, err
+		}
+		dependencies = append(dependencies, path)
+	}
+	return dependencies, nil
+}
+
+// getPythonDependencies returns modules imported by the python code
+func getPythonDependencies(code []byte) []string {
+	dependencies := make([]string, 0)
+	for _, match := range pythonImportRegexp.FindAllSubmatch(code, -1) {
+		for _, module := range strings.Split(string(match[1]), ",") {
+			dependencies = append(dependencies, strings.Fields(module)[0])
+		}
+	}
+	for _, match := range pythonFromImportRegexp.FindAllSubmatch(code, -1) {
+		dependencies = append(dependencies, string(match[1]))
+	}
+	return dependencies
+}
+
+// getScioDependencies returns classes and packages imported by the scio code.
+// Selectors in braces are returned as separate classes, renamed classes are returned by their original names.
+func getScioDependencies(code []byte) []string {
+	dependencies := make([]string, 0)
+	for _, match := range scioImportRegexp.FindAllSubmatch(code, -1) {
+		if match[2] == nil {
+			dependencies = append(dependencies, string(match[1]))
+			continue
+		}
+		for _, selector := range strings.Split(string(match[2]), ",") {
+			fields := strings.Fields(selector)
+			if len(fields) == 0 || fields[0] == "_" {
+				continue
+			}
+			dependencies = append(dependencies, string(match[1])+"."+fields[0])
+		}
+	}
+	return dependencies
+}
+
+// uniqueSorted returns sorted values without duplicates
+func uniqueSorted(values []string) []string {
+	unique := make([]string, 0, len(values))
+	sort.Strings(values)
+	for i, value := range values {
+		if i == 0 || value != values[i-1] {
+			unique = append(unique, value)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,196 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	playground "beam.apache.org/playground/backend/internal/api/v1"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGetDependencies(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"Main.java":  "package org.example;\n\nimport java.util.List;\nimport static java.util.Arrays.asList;\nimport org.apache.beam.sdk.transforms.*;\nimport java.util.List;\n\nclass Main {}\n",
+		"main.go":    "package main\n\nimport (\n\t\"fmt\"\n\tbeam \"github.com/apache/beam/sdks/go/pkg/beam\"\n)\n\nfunc main() { fmt.Println(beam.Scope{}) }\n",
+		"broken.go":  "package main\n\nimport (\n",
+		"main.py":    "import apache_beam as beam, os\nfrom apache_beam.options.pipeline_options import PipelineOptions\nimport re\n",
+		"Main.scala": "package org.example\n\nimport com.spotify.scio._\nimport com.spotify.scio.values.{SCollection, SideInput => Side}\nimport org.apache.beam.sdk.io.TextIO\n\nobject Main {}\n",
+	}
+	for name, code := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(code), 0600); err != nil {
+			t.Fatalf("error during prepare files: %s", err.Error())
+		}
+	}
+	type args struct {
+		sdk      playground.Sdk
+		filePath string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []string
+		wantErr bool
+	}{
+		{
+			// Test case with calling GetDependencies method with incorrect SDK.
+			// As a result, want to receive an error.
+			name:    "incorrect sdk",
+			args:    args{sdk: playground.Sdk_SDK_UNSPECIFIED, filePath: filepath.Join(dir, "main.go")},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			// Test case with calling GetDependencies method with the file which doesn't exist.
+			// As a result, want to receive an error.
+			name:    "file doesn't exist",
+			args:    args{sdk: playground.Sdk_SDK_GO, filePath: filepath.Join(dir, "absent.go")},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			// Test case with calling GetDependencies method with java code.
+			// As a result, want to receive imported classes and packages without duplicates.
+			name:    "java code",
+			args:    args{sdk: playground.Sdk_SDK_JAVA, filePath: filepath.Join(dir, "Main.java")},
+			want:    []string{"java.util.Arrays.asList", "java.util.List", "org.apache.beam.sdk.transforms"},
+			wantErr: false,
+		},
+		{
+			// Test case with calling GetDependencies method with go code.
+			// As a result, want to receive imported packages.
+			name:    "go code",
+			args:    args{sdk: playground.Sdk_SDK_GO, filePath: filepath.Join(dir, "main.go")},
+			want:    []string{"fmt", "github.com/apache/beam/sdks/go/pkg/beam"},
+			wantErr: false,
+		},
+		{
+			// Test case with calling GetDependencies method with go code which couldn't be parsed.
+			// As a result, want to receive an error.
+			name:    "broken go code",
+			args:    args{sdk: playground.Sdk_SDK_GO, filePath: filepath.Join(dir, "broken.go")},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			// Test case with calling GetDependencies method with python code.
+			// As a result, want to receive imported modules.
+			name:    "python code",
+			args:    args{sdk: playground.Sdk_SDK_PYTHON, filePath: filepath.Join(dir, "main.py")},
+			want:    []string{"apache_beam", "apache_beam.options.pipeline_options", "os", "re"},
+			wantErr: false,
+		},
+		{
+			// Test case with calling GetDependencies method with scio code.
+			// As a result, want to receive imported packages and classes selected in braces by their original names.
+			name:    "scio code",
+			args:    args{sdk: playground.Sdk_SDK_SCIO, filePath: filepath.Join(dir, "Main.scala")},
+			want:    []string{"com.spotify.scio", "com.spotify.scio.values.SCollection", "com.spotify.scio.values.SideInput", "org.apache.beam.sdk.io.TextIO"},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetDependencies(tt.args.sdk, tt.args.filePath)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetDependencies() err = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetDependencies() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckImports(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"Main.java": "package org.example;\n\nimport java.util.List;\nimport com.example.Secret;\n\nclass Main {}\n",
+		"main.go":   "package main\n\nimport (\n\t\"fmt\"\n\t\"os/exec\"\n)\n\nfunc main() { fmt.Println(exec.Command) }\n",
+		"main.py":   "import apache_beam as beam\nimport helper\nfrom . import sibling\nfrom requests import get\n",
+		"helper.py": "import os\n",
+	}
+	for name, code := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(code), 0600); err != nil {
+			t.Fatalf("error during prepare files: %s", err.Error())
+		}
+	}
+	isAllowed := func(name string) bool {
+		for _, allowed := range []string{"java.util", "fmt", "apache_beam", "os"} {
+			if name == allowed || strings.HasPrefix(name, allowed+".") {
+				return true
+			}
+		}
+		return false
+	}
+	type args struct {
+		sdk       playground.Sdk
+		filePaths []string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr string
+	}{
+		{
+			// Test case with calling CheckImports method with java code importing the class which isn't allowed.
+			// As a result, want to receive ErrDisallowedImports with the class.
+			name:    "disallowed java import",
+			args:    args{sdk: playground.Sdk_SDK_JAVA, filePaths: []string{filepath.Join(dir, "Main.java")}},
+			wantErr: "com.example.Secret",
+		},
+		{
+			// Test case with calling CheckImports method with go code importing the package which isn't allowed.
+			// As a result, want to receive ErrDisallowedImports with the package.
+			name:    "disallowed go import",
+			args:    args{sdk: playground.Sdk_SDK_GO, filePaths: []string{filepath.Join(dir, "main.go")}},
+			wantErr: "os/exec",
+		},
+		{
+			// Test case with calling CheckImports method with python code importing the additional file, the relative module and the module which isn't allowed.
+			// As a result, want to receive ErrDisallowedImports with the module which isn't allowed only.
+			name:    "disallowed python import",
+			args:    args{sdk: playground.Sdk_SDK_PYTHON, filePaths: []string{filepath.Join(dir, "main.py"), filepath.Join(dir, "helper.py")}},
+			wantErr: ": requests.",
+		},
+		{
+			// Test case with calling CheckImports method with python code importing allowed modules only.
+			// As a result, want to receive no error.
+			name:    "allowed python imports",
+			args:    args{sdk: playground.Sdk_SDK_PYTHON, filePaths: []string{filepath.Join(dir, "helper.py")}},
+			wantErr: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckImports(tt.args.sdk, tt.args.filePaths, isAllowed)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("CheckImports() error = %v, want no error", err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrDisallowedImports) || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("CheckImports() error = %v, want ErrDisallowedImports with %s", err, tt.wantErr)
+			}
+		})
+	}
+}
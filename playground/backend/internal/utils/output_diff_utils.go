@@ -0,0 +1,57 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"sort"
+	"strings"
+)
+
+// NormalizeOutput returns lines of the output without trailing whitespaces and empty lines sorted in lexicographic order.
+// Lines are sorted because the order of elements processed by the pipeline isn't deterministic.
+func NormalizeOutput(output string) []string {
+	lines := make([]string, 0)
+	for _, line := range strings.Split(output, "\n") {
+		if line = strings.TrimRight(line, " \t\r"); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	sort.Strings(lines)
+	return lines
+}
+
+// DiffOutputs returns the diff of normalized outputs.
+// Lines which are only in the first output are prefixed with "- ", lines which are only in the second output are prefixed with "+ ".
+// Returns empty string if normalized outputs are the same.
+func DiffOutputs(first, second string) string {
+	firstLines, secondLines := NormalizeOutput(first), NormalizeOutput(second)
+	var diff strings.Builder
+	i, j := 0, 0
+	for i < len(firstLines) || j < len(secondLines) {
+		switch {
+		case j == len(secondLines) || (i < len(firstLines) && firstLines[i] < secondLines[j]):
+			diff.WriteString("- " + firstLines[i] + "\n")
+			i++
+		case i == len(firstLines) || firstLines[i] > secondLines[j]:
+			diff.WriteString("+ " + secondLines[j] + "\n")
+			j++
+		default:
+			i++
+			j++
+		}
+	}
+	return diff.String()
+}
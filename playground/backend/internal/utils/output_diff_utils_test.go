@@ -0,0 +1,85 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNormalizeOutput(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   []string
+	}{
+		{
+			// Test case with calling NormalizeOutput method with unordered lines, trailing whitespaces and empty lines.
+			// As a result, want to receive sorted lines without trailing whitespaces and empty lines.
+			name:   "unordered output",
+			output: "b: 2  \r\n\na: 1\t\n",
+			want:   []string{"a: 1", "b: 2"},
+		},
+		{
+			// Test case with calling NormalizeOutput method with empty output.
+			// As a result, want to receive empty slice.
+			name:   "empty output",
+			output: "",
+			want:   []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeOutput(tt.output); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NormalizeOutput() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffOutputs(t *testing.T) {
+	type args struct {
+		first  string
+		second string
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			// Test case with calling DiffOutputs method with outputs which differ only in the order of lines.
+			// As a result, want to receive empty diff.
+			name: "same outputs",
+			args: args{first: "a: 1\nb: 2\n", second: "b: 2\na: 1"},
+			want: "",
+		},
+		{
+			// Test case with calling DiffOutputs method with different outputs.
+			// As a result, want to receive lines which are only in one of outputs.
+			name: "different outputs",
+			args: args{first: "a: 1\nb: 2\nb: 2\n", second: "a: 1\nb: 2\nc: 3\n"},
+			want: "- b: 2\n+ c: 3\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DiffOutputs(tt.args.first, tt.args.second); got != tt.want {
+				t.Errorf("DiffOutputs() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
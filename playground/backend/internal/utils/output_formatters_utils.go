@@ -0,0 +1,58 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"sync"
+)
+
+// OutputFormatter transforms the run output of the code to be displayed, e.g. formats PCollection dumps into tables
+type OutputFormatter func([]byte) ([]byte, error)
+
+var (
+	outputFormattersMu sync.RWMutex
+	outputFormatters   = make(map[pb.Sdk]OutputFormatter)
+)
+
+// RegisterOutputFormatter registers the formatter of the run output of the code of the sdk.
+// The formatter replaces the one which has been registered for the sdk before, nil formatter removes it.
+func RegisterOutputFormatter(sdk pb.Sdk, formatter OutputFormatter) {
+	outputFormattersMu.Lock()
+	defer outputFormattersMu.Unlock()
+	if formatter == nil {
+		delete(outputFormatters, sdk)
+		return
+	}
+	outputFormatters[sdk] = formatter
+}
+
+// FormatOutput returns the run output formatted by the formatter registered for the sdk.
+// In case no formatter is registered returns the output as is.
+// In case the formatter fails returns the output as is and the error of the formatter.
+func FormatOutput(sdk pb.Sdk, output []byte) ([]byte, error) {
+	outputFormattersMu.RLock()
+	formatter, ok := outputFormatters[sdk]
+	outputFormattersMu.RUnlock()
+	if !ok {
+		return output, nil
+	}
+	formatted, err := formatter(output)
+	if err != nil {
+		return output, err
+	}
+	return formatted, nil
+}
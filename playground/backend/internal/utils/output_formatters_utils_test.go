@@ -0,0 +1,84 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	playground "beam.apache.org/playground/backend/internal/api/v1"
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestFormatOutput(t *testing.T) {
+	upperFormatter := func(output []byte) ([]byte, error) {
+		return bytes.ToUpper(output), nil
+	}
+	failedFormatter := func(output []byte) ([]byte, error) {
+		return nil, errors.New("MOCK_ERROR")
+	}
+	type args struct {
+		sdk    playground.Sdk
+		output []byte
+	}
+	tests := []struct {
+		name      string
+		formatter OutputFormatter
+		args      args
+		want      string
+		wantErr   bool
+	}{
+		{
+			// Test case with calling FormatOutput method without the registered formatter.
+			// As a result, want to receive the output as is.
+			name:      "formatter isn't registered",
+			formatter: nil,
+			args:      args{sdk: playground.Sdk_SDK_JAVA, output: []byte("mock_output")},
+			want:      "mock_output",
+			wantErr:   false,
+		},
+		{
+			// Test case with calling FormatOutput method with the registered formatter.
+			// As a result, want to receive the formatted output.
+			name:      "formatter is registered",
+			formatter: upperFormatter,
+			args:      args{sdk: playground.Sdk_SDK_JAVA, output: []byte("mock_output")},
+			want:      "MOCK_OUTPUT",
+			wantErr:   false,
+		},
+		{
+			// Test case with calling FormatOutput method with the registered formatter which fails.
+			// As a result, want to receive the output as is and an error.
+			name:      "formatter fails",
+			formatter: failedFormatter,
+			args:      args{sdk: playground.Sdk_SDK_JAVA, output: []byte("mock_output")},
+			want:      "mock_output",
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			RegisterOutputFormatter(tt.args.sdk, tt.formatter)
+			defer RegisterOutputFormatter(tt.args.sdk, nil)
+			got, err := FormatOutput(tt.args.sdk, tt.args.output)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FormatOutput() err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if string(got) != tt.want {
+				t.Errorf("FormatOutput() got = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
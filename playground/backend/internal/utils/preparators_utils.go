@@ -31,6 +31,8 @@ func GetPreparators(sdk pb.Sdk, filepath string) (*[]preparators.Preparator, err
 		prep = preparators.GetGoPreparators(filepath)
 	case pb.Sdk_SDK_PYTHON:
 		prep = preparators.GetPythonPreparators(filepath)
+	case pb.Sdk_SDK_SCIO:
+		prep = preparators.GetScioPreparators(filepath)
 	default:
 		return nil, fmt.Errorf("incorrect sdk: %s", sdk)
 	}
@@ -16,14 +16,79 @@
 package utils
 
 import (
+	"fmt"
+	"io/fs"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"reflect"
 	"runtime"
+	"strconv"
 	"strings"
+	"time"
 )
 
+// clockTicksPerSecond is the number of clock ticks per second used by /proc/{pid}/stat (USER_HZ)
+const clockTicksPerSecond = 100
+
 // GetFuncName returns the name of the received func
 func GetFuncName(i interface{}) string {
 	fullName := runtime.FuncForPC(reflect.ValueOf(i).Pointer()).Name()
 	splitName := strings.Split(fullName, ".")
 	return splitName[len(splitName)-1]
 }
+
+// GetProcessCpuTime returns CPU time (user and system) spent by the running process with the pid.
+// It reads /proc/{pid}/stat, so it works only on Linux.
+func GetProcessCpuTime(pid int) (time.Duration, error) {
+	stat, err := ioutil.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if err != nil {
+		return 0, err
+	}
+	// the name of the process could contain spaces, so fields are counted after its closing bracket
+	nameEnd := strings.LastIndexByte(string(stat), ')')
+	if nameEnd == -1 {
+		return 0, fmt.Errorf("incorrect stat of the process: %d", pid)
+	}
+	fields := strings.Fields(string(stat[nameEnd+1:]))
+	// utime and stime are 14th and 15th fields of the stat, fields start from the 3rd one
+	if len(fields) < 13 {
+		return 0, fmt.Errorf("incorrect stat of the process: %d", pid)
+	}
+	var ticks int64
+	for _, field := range fields[11:13] {
+		value, err := strconv.ParseInt(field, 10, 64)
+		if err != nil {
+			return 0, err
+		}
+		ticks += value
+	}
+	return time.Duration(ticks) * time.Second / clockTicksPerSecond, nil
+}
+
+// GetDirSize returns the total size in bytes of regular files in the folder and its subfolders.
+// Files which are removed while the folder is walked are skipped, so the size of the folder which is being changed could be got.
+func GetDirSize(dirPath string) (int64, error) {
+	var size int64
+	err := filepath.WalkDir(dirPath, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		size += info.Size()
+		return nil
+	})
+	return size, err
+}
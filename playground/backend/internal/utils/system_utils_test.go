@@ -15,7 +15,11 @@
 
 package utils
 
-import "testing"
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
 
 func TestGetFuncName(t *testing.T) {
 	type args struct {
@@ -40,3 +44,92 @@ func TestGetFuncName(t *testing.T) {
 		})
 	}
 }
+
+func TestGetProcessCpuTime(t *testing.T) {
+	type args struct {
+		pid int
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			// Test case with calling GetProcessCpuTime method for the running process.
+			// As a result, want to receive CPU time without an error.
+			name:    "running process",
+			args:    args{pid: os.Getpid()},
+			wantErr: false,
+		},
+		{
+			// Test case with calling GetProcessCpuTime method for the process which doesn't exist.
+			// As a result, want to receive an error.
+			name:    "process doesn't exist",
+			args:    args{pid: -1},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetProcessCpuTime(tt.args.pid)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetProcessCpuTime() err = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got < 0 {
+				t.Errorf("GetProcessCpuTime() = %v, want non-negative duration", got)
+			}
+		})
+	}
+}
+
+func TestGetDirSize(t *testing.T) {
+	dirPath := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dirPath, "nested"), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dirPath, "file.txt"), make([]byte, 100), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dirPath, "nested", "file.txt"), make([]byte, 50), 0600); err != nil {
+		t.Fatal(err)
+	}
+	type args struct {
+		dirPath string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    int64
+		wantErr bool
+	}{
+		{
+			// Test case with calling GetDirSize method for the folder with files in it and in its subfolder.
+			// As a result, want to receive the total size of the files.
+			name:    "folder with files",
+			args:    args{dirPath: dirPath},
+			want:    150,
+			wantErr: false,
+		},
+		{
+			// Test case with calling GetDirSize method for the folder which doesn't exist.
+			// As a result, want to receive zero size without an error.
+			name:    "folder doesn't exist",
+			args:    args{dirPath: filepath.Join(dirPath, "MOCK_MISSING_DIR")},
+			want:    0,
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetDirSize(tt.args.dirPath)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetDirSize() err = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("GetDirSize() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
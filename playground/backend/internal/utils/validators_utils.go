@@ -28,9 +28,11 @@ func GetValidators(sdk pb.Sdk, filepath string) (*[]validators.Validator, error)
 	case pb.Sdk_SDK_JAVA:
 		val = validators.GetJavaValidators(filepath)
 	case pb.Sdk_SDK_GO:
-		val = validators.GetGoValidators()
+		val = validators.GetGoValidators(filepath)
 	case pb.Sdk_SDK_PYTHON:
-		val = validators.GetPythonValidators()
+		val = validators.GetPythonValidators(filepath)
+	case pb.Sdk_SDK_SCIO:
+		val = validators.GetScioValidators()
 	default:
 		return nil, fmt.Errorf("incorrect sdk: %s", sdk)
 	}
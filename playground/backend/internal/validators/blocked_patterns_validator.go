@@ -0,0 +1,58 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validators
+
+import (
+	"beam.apache.org/playground/backend/internal/logger"
+	"errors"
+	"os"
+	"regexp"
+)
+
+// ErrBlockedPattern is returned if the code matches a known-malicious pattern.
+// It doesn't reveal the pattern to users, the pattern is only logged.
+var ErrBlockedPattern = errors.New("code contains forbidden constructions")
+
+// GetBlockedPatternsValidator returns validator which rejects code of files matching one of patterns
+func GetBlockedPatternsValidator(filePaths []string, patterns []*regexp.Regexp) Validator {
+	validatorArgs := make([]interface{}, 2)
+	validatorArgs[0] = filePaths
+	validatorArgs[1] = patterns
+	return Validator{
+		Validator: checkBlockedPatterns,
+		Args:      validatorArgs,
+	}
+}
+
+// checkBlockedPatterns checks that code of files doesn't match any of patterns.
+// args[0] is a list of paths to files with code, args[1] is a list of patterns.
+func checkBlockedPatterns(args ...interface{}) error {
+	filePaths := args[0].([]string)
+	patterns := args[1].([]*regexp.Regexp)
+	for _, filePath := range filePaths {
+		code, err := os.ReadFile(filePath)
+		if err != nil {
+			return err
+		}
+		for _, pattern := range patterns {
+			if pattern.Match(code) {
+				logger.Errorf("%s: code matches blocked pattern %s\n", filePath, pattern.String())
+				return ErrBlockedPattern
+			}
+		}
+	}
+	return nil
+}
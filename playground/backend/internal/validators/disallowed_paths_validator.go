@@ -0,0 +1,96 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validators
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"beam.apache.org/playground/backend/internal/logger"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// ErrDisallowedPath is returned if the code refers to an absolute path matching one of disallowed path patterns
+var ErrDisallowedPath = errors.New("code refers to a disallowed filesystem path")
+
+// stringLiteralRegexps contains patterns of string literals for each SDK, the first group is the content of the literal
+var stringLiteralRegexps = map[pb.Sdk][]*regexp.Regexp{
+	pb.Sdk_SDK_JAVA: {
+		regexp.MustCompile(`"((?:[^"\\\n]|\\.)*)"`),
+	},
+	pb.Sdk_SDK_GO: {
+		regexp.MustCompile(`"((?:[^"\\\n]|\\.)*)"`),
+		regexp.MustCompile("`([^`]*)`"),
+	},
+	pb.Sdk_SDK_PYTHON: {
+		regexp.MustCompile(`"((?:[^"\\\n]|\\.)*)"`),
+		regexp.MustCompile(`'((?:[^'\\\n]|\\.)*)'`),
+	},
+	pb.Sdk_SDK_SCIO: {
+		regexp.MustCompile(`"((?:[^"\\\n]|\\.)*)"`),
+	},
+}
+
+// GetDisallowedPathsValidator returns validator which rejects code of files containing string literals
+// with absolute paths matching one of patterns. It's a heuristic, so it doesn't catch paths built at runtime.
+func GetDisallowedPathsValidator(sdk pb.Sdk, filePaths []string, patterns []*regexp.Regexp) Validator {
+	validatorArgs := make([]interface{}, 3)
+	validatorArgs[0] = sdk
+	validatorArgs[1] = filePaths
+	validatorArgs[2] = patterns
+	return Validator{
+		Validator: checkDisallowedPaths,
+		Args:      validatorArgs,
+	}
+}
+
+// checkDisallowedPaths checks that string literals of code of files don't contain absolute paths matching any of patterns.
+// args[0] is the SDK of the code, args[1] is a list of paths to files with code, args[2] is a list of patterns.
+func checkDisallowedPaths(args ...interface{}) error {
+	sdk := args[0].(pb.Sdk)
+	filePaths := args[1].([]string)
+	patterns := args[2].([]*regexp.Regexp)
+	for _, filePath := range filePaths {
+		code, err := os.ReadFile(filePath)
+		if err != nil {
+			return err
+		}
+		for _, path := range findAbsolutePaths(sdk, string(code)) {
+			for _, pattern := range patterns {
+				if pattern.MatchString(path) {
+					logger.Errorf("%s: code refers to path %s matching disallowed path pattern %s\n", filePath, path, pattern.String())
+					return fmt.Errorf("%w: %s", ErrDisallowedPath, path)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// findAbsolutePaths returns contents of string literals of the code which are absolute paths
+func findAbsolutePaths(sdk pb.Sdk, code string) []string {
+	var paths []string
+	for _, literalRegexp := range stringLiteralRegexps[sdk] {
+		for _, match := range literalRegexp.FindAllStringSubmatch(code, -1) {
+			if strings.HasPrefix(match[1], "/") {
+				paths = append(paths, match[1])
+			}
+		}
+	}
+	return paths
+}
@@ -0,0 +1,80 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validators
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"errors"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+)
+
+func Test_checkDisallowedPaths(t *testing.T) {
+	patterns := []*regexp.Regexp{regexp.MustCompile(`^/etc(/|$)`), regexp.MustCompile(`^/proc/`)}
+	tests := []struct {
+		name    string
+		sdk     pb.Sdk
+		code    string
+		wantErr bool
+	}{
+		{
+			// Test case with calling checkDisallowedPaths method with java code reading a disallowed path.
+			// As a result, want to receive ErrDisallowedPath.
+			name:    "java code with disallowed path",
+			sdk:     pb.Sdk_SDK_JAVA,
+			code:    `Files.readAllLines(Paths.get("/etc/passwd"));`,
+			wantErr: true,
+		},
+		{
+			// Test case with calling checkDisallowedPaths method with go code reading a disallowed path in a raw string.
+			// As a result, want to receive ErrDisallowedPath.
+			name:    "go code with disallowed path in raw string",
+			sdk:     pb.Sdk_SDK_GO,
+			code:    "os.ReadFile(`/proc/self/environ`)",
+			wantErr: true,
+		},
+		{
+			// Test case with calling checkDisallowedPaths method with python code reading a disallowed path in a single-quoted string.
+			// As a result, want to receive ErrDisallowedPath.
+			name:    "python code with disallowed path",
+			sdk:     pb.Sdk_SDK_PYTHON,
+			code:    `open('/etc', 'r')`,
+			wantErr: true,
+		},
+		{
+			// Test case with calling checkDisallowedPaths method with code referring to allowed and relative paths.
+			// As a result, want to receive no error.
+			name:    "code with allowed paths",
+			sdk:     pb.Sdk_SDK_JAVA,
+			code:    `readFile("/tmp/etc/data.txt"); readFile("etc/passwd"); log("see /etc/passwd");`,
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filePath := filepath.Join(t.TempDir(), "MOCK_FILE")
+			if err := os.WriteFile(filePath, []byte(tt.code), 0600); err != nil {
+				t.Fatalf("error during prepare the file: %s", err.Error())
+			}
+			err := checkDisallowedPaths(tt.sdk, []string{filePath}, patterns)
+			if errors.Is(err, ErrDisallowedPath) != tt.wantErr {
+				t.Errorf("checkDisallowedPaths() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
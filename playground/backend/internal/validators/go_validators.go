@@ -15,7 +15,38 @@
 
 package validators
 
+import (
+	"fmt"
+	"go/parser"
+	"go/scanner"
+	"go/token"
+	"strings"
+)
+
 // GetGoValidators return validators methods that should be applied to Go code
-func GetGoValidators() *[]Validator {
-	return &[]Validator{}
+func GetGoValidators(filePath string) *[]Validator {
+	validatorArgs := make([]interface{}, 1)
+	validatorArgs[0] = filePath
+	syntaxValidator := Validator{
+		Validator: checkGoSyntax,
+		Args:      validatorArgs,
+	}
+	return &[]Validator{syntaxValidator}
+}
+
+// checkGoSyntax checks that the Go code of the file is parsed without syntax errors.
+// Syntax errors are reported in the "{file}:{line}:{column}: {message}" format one per line.
+// args[0] is the path to the file with the code.
+func checkGoSyntax(args ...interface{}) error {
+	filePath := args[0].(string)
+	_, err := parser.ParseFile(token.NewFileSet(), filePath, nil, parser.AllErrors)
+	errorList, ok := err.(scanner.ErrorList)
+	if !ok {
+		return err
+	}
+	messages := make([]string, 0, len(errorList))
+	for _, syntaxError := range errorList {
+		messages = append(messages, syntaxError.Error())
+	}
+	return fmt.Errorf("%w:\n%s", ErrSyntax, strings.Join(messages, "\n"))
 }
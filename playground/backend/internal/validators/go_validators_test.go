@@ -0,0 +1,65 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validators
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func Test_checkGoSyntax(t *testing.T) {
+	tests := []struct {
+		name         string
+		code         string
+		wantErr      bool
+		wantPosition string
+	}{
+		{
+			// Test case with calling checkGoSyntax method with code which contains a syntax error.
+			// As a result, want to receive ErrSyntax with the position of the error.
+			name:         "code with syntax error",
+			code:         "package main\n\nfunc main() {\n\tfmt.Println(\"MOCK\"\n}\n",
+			wantErr:      true,
+			wantPosition: ":4:20: ",
+		},
+		{
+			// Test case with calling checkGoSyntax method with code which is parsed without errors.
+			// As a result, want to receive no error.
+			name:    "correct code",
+			code:    "package main\n\nfunc main() {\n\tfmt.Println(\"MOCK\")\n}\n",
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filePath := filepath.Join(t.TempDir(), "main.go")
+			if err := os.WriteFile(filePath, []byte(tt.code), 0600); err != nil {
+				t.Fatalf("error during prepare the file: %s", err.Error())
+			}
+			err := checkGoSyntax(filePath)
+			if errors.Is(err, ErrSyntax) != tt.wantErr {
+				t.Errorf("checkGoSyntax() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr && !strings.Contains(err.Error(), filePath+tt.wantPosition) {
+				t.Errorf("checkGoSyntax() error = %v, want the position %s", err, tt.wantPosition)
+			}
+		})
+	}
+}
@@ -15,8 +15,53 @@
 
 package validators
 
-// GetPythonValidators return validators methods that should be applied to Go code
-func GetPythonValidators() *[]Validator {
-	//TODO: Will be added in task [BEAM-13292]
-	return &[]Validator{}
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+)
+
+const (
+	pythonCmd = "python3"
+
+	// pythonSyntaxErrorExitCode is the exit code of pythonSyntaxCheck in case the code contains a syntax error
+	pythonSyntaxErrorExitCode = 3
+
+	// pythonSyntaxCheck parses the file from the first argument by the ast module
+	// and prints a syntax error in the "{file}:{line}:{column}: {message}" format of pyflakes
+	pythonSyntaxCheck = `import ast, sys
+source = open(sys.argv[1]).read()
+try:
+    ast.parse(source, sys.argv[1])
+except SyntaxError as e:
+    print("%s:%d:%d: %s" % (e.filename, e.lineno or 0, e.offset or 0, e.msg))
+    sys.exit(3)
+`
+)
+
+// GetPythonValidators return validators methods that should be applied to Python code
+func GetPythonValidators(filePath string) *[]Validator {
+	validatorArgs := make([]interface{}, 1)
+	validatorArgs[0] = filePath
+	syntaxValidator := Validator{
+		Validator: checkPythonSyntax,
+		Args:      validatorArgs,
+	}
+	return &[]Validator{syntaxValidator}
+}
+
+// checkPythonSyntax checks that the Python code of the file is parsed by the ast module without syntax errors.
+// In case the interpreter couldn't be started the code isn't checked, so it fails later at the run step.
+// args[0] is the path to the file with the code.
+func checkPythonSyntax(args ...interface{}) error {
+	filePath := args[0].(string)
+	output, err := exec.Command(pythonCmd, "-c", pythonSyntaxCheck, filePath).CombinedOutput()
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		return nil
+	}
+	if exitErr.ExitCode() == pythonSyntaxErrorExitCode {
+		return fmt.Errorf("%w:\n%s", ErrSyntax, output)
+	}
+	return fmt.Errorf("error during checking the syntax: %s", output)
 }
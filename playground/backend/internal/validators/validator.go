@@ -15,6 +15,11 @@
 
 package validators
 
+import "errors"
+
+// ErrSyntax is returned if the code couldn't be parsed, the error contains messages of the parser with positions of syntax errors
+var ErrSyntax = errors.New("code contains syntax errors")
+
 type Validator struct {
 	Validator func(args ...interface{}) error
 	Args      []interface{}